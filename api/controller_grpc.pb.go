@@ -19,39 +19,206 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ControllerService_StreamInput_FullMethodName     = "/api.ControllerService/StreamInput"
-	ControllerService_GetFrame_FullMethodName        = "/api.ControllerService/GetFrame"
-	ControllerService_ReadMemory_FullMethodName      = "/api.ControllerService/ReadMemory"
-	ControllerService_LoadState_FullMethodName       = "/api.ControllerService/LoadState"
-	ControllerService_ResetSystem_FullMethodName     = "/api.ControllerService/ResetSystem"
-	ControllerService_Pause_FullMethodName           = "/api.ControllerService/Pause"
-	ControllerService_Resume_FullMethodName          = "/api.ControllerService/Resume"
-	ControllerService_Step_FullMethodName            = "/api.ControllerService/Step"
-	ControllerService_GetCPUState_FullMethodName     = "/api.ControllerService/GetCPUState"
-	ControllerService_ReadMemoryBlock_FullMethodName = "/api.ControllerService/ReadMemoryBlock"
+	ControllerService_StreamInput_FullMethodName          = "/api.ControllerService/StreamInput"
+	ControllerService_GetFrame_FullMethodName             = "/api.ControllerService/GetFrame"
+	ControllerService_GetFrameHash_FullMethodName         = "/api.ControllerService/GetFrameHash"
+	ControllerService_StreamFrames_FullMethodName         = "/api.ControllerService/StreamFrames"
+	ControllerService_Spectate_FullMethodName             = "/api.ControllerService/Spectate"
+	ControllerService_ReadMemory_FullMethodName           = "/api.ControllerService/ReadMemory"
+	ControllerService_LoadState_FullMethodName            = "/api.ControllerService/LoadState"
+	ControllerService_SaveState_FullMethodName            = "/api.ControllerService/SaveState"
+	ControllerService_ResetSystem_FullMethodName          = "/api.ControllerService/ResetSystem"
+	ControllerService_ResetEpisode_FullMethodName         = "/api.ControllerService/ResetEpisode"
+	ControllerService_StepFrame_FullMethodName            = "/api.ControllerService/StepFrame"
+	ControllerService_SetObservationSpec_FullMethodName   = "/api.ControllerService/SetObservationSpec"
+	ControllerService_StartRecording_FullMethodName       = "/api.ControllerService/StartRecording"
+	ControllerService_StopRecording_FullMethodName        = "/api.ControllerService/StopRecording"
+	ControllerService_PlayMovie_FullMethodName            = "/api.ControllerService/PlayMovie"
+	ControllerService_EditMovie_FullMethodName            = "/api.ControllerService/EditMovie"
+	ControllerService_SeekMovie_FullMethodName            = "/api.ControllerService/SeekMovie"
+	ControllerService_TruncateMovie_FullMethodName        = "/api.ControllerService/TruncateMovie"
+	ControllerService_InsertMovieInput_FullMethodName     = "/api.ControllerService/InsertMovieInput"
+	ControllerService_SaveMovie_FullMethodName            = "/api.ControllerService/SaveMovie"
+	ControllerService_MovieStatus_FullMethodName          = "/api.ControllerService/MovieStatus"
+	ControllerService_LoadROM_FullMethodName              = "/api.ControllerService/LoadROM"
+	ControllerService_CreateSession_FullMethodName        = "/api.ControllerService/CreateSession"
+	ControllerService_DestroySession_FullMethodName       = "/api.ControllerService/DestroySession"
+	ControllerService_Pause_FullMethodName                = "/api.ControllerService/Pause"
+	ControllerService_Resume_FullMethodName               = "/api.ControllerService/Resume"
+	ControllerService_Step_FullMethodName                 = "/api.ControllerService/Step"
+	ControllerService_AdvanceFrame_FullMethodName         = "/api.ControllerService/AdvanceFrame"
+	ControllerService_RunUntil_FullMethodName             = "/api.ControllerService/RunUntil"
+	ControllerService_GetCPUState_FullMethodName          = "/api.ControllerService/GetCPUState"
+	ControllerService_ReadMemoryBlock_FullMethodName      = "/api.ControllerService/ReadMemoryBlock"
+	ControllerService_WriteMemoryBlock_FullMethodName     = "/api.ControllerService/WriteMemoryBlock"
+	ControllerService_AddWatchpoint_FullMethodName        = "/api.ControllerService/AddWatchpoint"
+	ControllerService_RemoveWatchpoint_FullMethodName     = "/api.ControllerService/RemoveWatchpoint"
+	ControllerService_ListWatchpoints_FullMethodName      = "/api.ControllerService/ListWatchpoints"
+	ControllerService_GetWatchHit_FullMethodName          = "/api.ControllerService/GetWatchHit"
+	ControllerService_AddBreakpoint_FullMethodName        = "/api.ControllerService/AddBreakpoint"
+	ControllerService_RemoveBreakpoint_FullMethodName     = "/api.ControllerService/RemoveBreakpoint"
+	ControllerService_ListBreakpoints_FullMethodName      = "/api.ControllerService/ListBreakpoints"
+	ControllerService_GetBreakpointHit_FullMethodName     = "/api.ControllerService/GetBreakpointHit"
+	ControllerService_AddCheat_FullMethodName             = "/api.ControllerService/AddCheat"
+	ControllerService_ListCheats_FullMethodName           = "/api.ControllerService/ListCheats"
+	ControllerService_SetCheatEnabled_FullMethodName      = "/api.ControllerService/SetCheatEnabled"
+	ControllerService_Evaluate_FullMethodName             = "/api.ControllerService/Evaluate"
+	ControllerService_StartProfile_FullMethodName         = "/api.ControllerService/StartProfile"
+	ControllerService_StopProfile_FullMethodName          = "/api.ControllerService/StopProfile"
+	ControllerService_GetProfile_FullMethodName           = "/api.ControllerService/GetProfile"
+	ControllerService_StartCDL_FullMethodName             = "/api.ControllerService/StartCDL"
+	ControllerService_StopCDL_FullMethodName              = "/api.ControllerService/StopCDL"
+	ControllerService_GetCDL_FullMethodName               = "/api.ControllerService/GetCDL"
+	ControllerService_Disassemble_FullMethodName          = "/api.ControllerService/Disassemble"
+	ControllerService_GetMemoryMap_FullMethodName         = "/api.ControllerService/GetMemoryMap"
+	ControllerService_ReadNametables_FullMethodName       = "/api.ControllerService/ReadNametables"
+	ControllerService_GetPPUState_FullMethodName          = "/api.ControllerService/GetPPUState"
+	ControllerService_GetAPUState_FullMethodName          = "/api.ControllerService/GetAPUState"
+	ControllerService_ReadOAM_FullMethodName              = "/api.ControllerService/ReadOAM"
+	ControllerService_ReadPalette_FullMethodName          = "/api.ControllerService/ReadPalette"
+	ControllerService_GetPatternTableImage_FullMethodName = "/api.ControllerService/GetPatternTableImage"
+	ControllerService_GetNametableImage_FullMethodName    = "/api.ControllerService/GetNametableImage"
+	ControllerService_GetLogLevels_FullMethodName         = "/api.ControllerService/GetLogLevels"
+	ControllerService_SetLogLevels_FullMethodName         = "/api.ControllerService/SetLogLevels"
+	ControllerService_GetPacingStats_FullMethodName       = "/api.ControllerService/GetPacingStats"
 )
 
 // ControllerServiceClient is the client API for ControllerService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ControllerService serves every RPC of the focused vibemulator.v1 services (see
+// api/v1/services.proto) under one name, for clients written before the split. It and
+// its messages are frozen: new RPCs and fields go into vibemulator.v1 only, and the
+// server answers these calls with the v1 handlers, so the messages here must keep the
+// same fields as their v1 copies.
 type ControllerServiceClient interface {
 	// Client streams button states to the emulator
 	StreamInput(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InputState, Empty], error)
 	// RL Endpoints
 	// Requests the current frame buffer (pixels) from the PPU
-	GetFrame(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FrameResponse, error)
+	GetFrame(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error)
+	// FNV-64a hash of the raw RGBA frame, to verify output without transferring pixels
+	GetFrameHash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FrameHashResponse, error)
+	// Pushes every newly completed frame with its number and hash
+	StreamFrames(ctx context.Context, in *StreamFramesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FrameResponse], error)
+	// Read-only spectator feed: the input latched at every frame (and optionally the video),
+	// delivered a fixed number of frames behind the live game
+	Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectatorUpdate], error)
 	// Reads a byte from the NES system bus (used to calculate RL rewards/done state)
 	ReadMemory(ctx context.Context, in *MemoryRequest, opts ...grpc.CallOption) (*MemoryResponse, error)
-	// Loads an emulator save state from a file, bypassing the title screen
+	// Loads an emulator save state from a file or inline bytes, bypassing the title screen
 	LoadState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Snapshots the emulator, with what is needed to anchor a recording to the snapshot
+	SaveState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateResponse, error)
 	// Triggers a hardware reset of the NES (returns game to title screen)
 	ResetSystem(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Power-cycles the NES into a known state (optionally from a ROM or state blob), pauses it,
+	// and returns the initial observation. Identical inputs then yield bit-identical trajectories.
+	ResetEpisode(ctx context.Context, in *EpisodeRequest, opts ...grpc.CallOption) (*Observation, error)
+	// Holds the given inputs for a number of whole frames and returns the resulting observation
+	StepFrame(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*Observation, error)
+	// Registers named memory features that every ResetEpisode/StepFrame observation will carry,
+	// saving clients a ReadMemory round trip per value per frame. Replaces any previous spec.
+	SetObservationSpec(ctx context.Context, in *ObservationSpec, opts ...grpc.CallOption) (*Empty, error)
+	// Movie recording and playback. Input is logged per frame (not wall-clock), so PlayMovie
+	// replays deterministically from the recorded starting state and reports the final frame hash.
+	StartRecording(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	StopRecording(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	PlayMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	// Movie editing, on one movie per instance. EditMovie loads a movie and seeks to its
+	// first frame, and SeekMovie replays it to the start of a frame. TruncateMovie drops the
+	// input after the current frame, and InsertMovieInput inserts input at it. SaveMovie
+	// returns the edited movie, optionally writing it to a file, and MovieStatus reports
+	// where the emulator is in it without returning it. Resuming plays the rest.
+	EditMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	SeekMovie(ctx context.Context, in *MovieSeekRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	TruncateMovie(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error)
+	InsertMovieInput(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	SaveMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	MovieStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error)
+	// Inserts a ROM from raw iNES bytes and power-cycles the NES, so headless instances
+	// do not need the ROM provisioned on disk
+	LoadROM(ctx context.Context, in *ROMRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Multi-session support. CreateSession starts an independent headless emulator; every
+	// other RPC targets it when the "session-id" metadata key carries the returned ID, and
+	// the default emulator otherwise.
+	CreateSession(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SessionResponse, error)
+	DestroySession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Empty, error)
 	// --- VDB (Vibemulator Debugger) Endpoints ---
+	// Pause returns once the emulator has stopped on an instruction boundary. Step and
+	// AdvanceFrame run the emulator one instruction, or to the end of the frame, and
+	// return once it has paused again.
 	Pause(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	Step(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	AdvanceFrame(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Resumes the emulator and blocks until the condition is met, a watchpoint or Pause
+	// stops it first, or the call is cancelled (which pauses the emulator)
+	RunUntil(ctx context.Context, in *RunUntilRequest, opts ...grpc.CallOption) (*RunUntilResponse, error)
 	GetCPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CPUStateResponse, error)
 	ReadMemoryBlock(ctx context.Context, in *MemoryBlockRequest, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// Writes through the CPU address space (RAM, PPU/APU registers, mapper registers, PRG RAM)
+	WriteMemoryBlock(ctx context.Context, in *MemoryWriteRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Watchpoints pause the emulator when the CPU reads or writes an address range
+	AddWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Watchpoint, error)
+	RemoveWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Empty, error)
+	ListWatchpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchpointList, error)
+	// Returns and clears the last watchpoint hit, if any
+	GetWatchHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchHit, error)
+	// Breakpoints pause the emulator before the instruction at an address executes,
+	// optionally only when a condition holds (evaluated inside the emulator)
+	AddBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Breakpoint, error)
+	RemoveBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Empty, error)
+	ListBreakpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointList, error)
+	// Returns and clears the last breakpoint hit, if any
+	GetBreakpointHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointHit, error)
+	// Cheat codes (Game Genie or raw "AAAA:VV" / "AAAA?CC:VV") patch what the CPU reads
+	AddCheat(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error)
+	ListCheats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheatList, error)
+	// Turns the cheat with the given id on or off
+	SetCheatEnabled(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error)
+	// Evaluates expressions in the breakpoint condition language against the current state
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// Instruction profiler: counts the instructions executed at each address and JSR calls
+	// to each subroutine between StartProfile and StopProfile
+	StartProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	StopProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error)
+	// Returns the running or most recent profile
+	GetProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error)
+	// Code/data logger: marks each PRG ROM byte executed as code or read as data, through
+	// whichever bank it is mapped in, for export as an FCEUX .cdl file. StartCDL continues
+	// from the given log, or starts an empty one.
+	StartCDL(ctx context.Context, in *CDLRequest, opts ...grpc.CallOption) (*Empty, error)
+	StopCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error)
+	// Returns the running or most recent log
+	GetCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error)
+	// Decodes instructions at an address (the PC by default)
+	Disassemble(ctx context.Context, in *DisassembleRequest, opts ...grpc.CallOption) (*DisassembleResponse, error)
+	// Describes the CPU address space, including where each PRG window currently points
+	GetMemoryMap(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryMap, error)
+	// --- PPU viewers (read without side effects such as MMC3 IRQ clocking) ---
+	// Logical nametables $2000-$2FFF (4KB) as currently mirrored
+	ReadNametables(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// Internal registers and raster position
+	GetPPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PPUStateResponse, error)
+	// Channel enables, periods, length counters and IRQ flags
+	GetAPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*APUStateResponse, error)
+	// 256 bytes of sprite OAM
+	ReadOAM(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// 32 bytes of palette RAM ($3F00-$3F1F)
+	ReadPalette(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// 128x128 rendering of a pattern table
+	GetPatternTableImage(ctx context.Context, in *PatternTableRequest, opts ...grpc.CallOption) (*FrameResponse, error)
+	// 512x480 rendering of all four nametables
+	GetNametableImage(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error)
+	// --- Logging: one level per subsystem (bus, cartridge, cpu, ppu), shared by every session ---
+	GetLogLevels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogLevels, error)
+	// Sets the levels given (trace, debug, info, warn or error; "all" sets every
+	// subsystem) and returns the levels of all subsystems
+	SetLogLevels(ctx context.Context, in *LogLevels, opts ...grpc.CallOption) (*LogLevels, error)
+	// --- Pacing: frame delivery and audio sync over the last ten seconds of play ---
+	GetPacingStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PacingStats, error)
 }
 
 type controllerServiceClient struct {
@@ -75,7 +242,7 @@ func (c *controllerServiceClient) StreamInput(ctx context.Context, opts ...grpc.
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ControllerService_StreamInputClient = grpc.BidiStreamingClient[InputState, Empty]
 
-func (c *controllerServiceClient) GetFrame(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FrameResponse, error) {
+func (c *controllerServiceClient) GetFrame(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FrameResponse)
 	err := c.cc.Invoke(ctx, ControllerService_GetFrame_FullMethodName, in, out, cOpts...)
@@ -85,6 +252,54 @@ func (c *controllerServiceClient) GetFrame(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *controllerServiceClient) GetFrameHash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FrameHashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameHashResponse)
+	err := c.cc.Invoke(ctx, ControllerService_GetFrameHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StreamFrames(ctx context.Context, in *StreamFramesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FrameResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControllerService_ServiceDesc.Streams[1], ControllerService_StreamFrames_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamFramesRequest, FrameResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControllerService_StreamFramesClient = grpc.ServerStreamingClient[FrameResponse]
+
+func (c *controllerServiceClient) Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectatorUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControllerService_ServiceDesc.Streams[2], ControllerService_Spectate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SpectateRequest, SpectatorUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControllerService_SpectateClient = grpc.ServerStreamingClient[SpectatorUpdate]
+
 func (c *controllerServiceClient) ReadMemory(ctx context.Context, in *MemoryRequest, opts ...grpc.CallOption) (*MemoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MemoryResponse)
@@ -105,6 +320,16 @@ func (c *controllerServiceClient) LoadState(ctx context.Context, in *StateReques
 	return out, nil
 }
 
+func (c *controllerServiceClient) SaveState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateResponse)
+	err := c.cc.Invoke(ctx, ControllerService_SaveState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controllerServiceClient) ResetSystem(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -115,6 +340,156 @@ func (c *controllerServiceClient) ResetSystem(ctx context.Context, in *Empty, op
 	return out, nil
 }
 
+func (c *controllerServiceClient) ResetEpisode(ctx context.Context, in *EpisodeRequest, opts ...grpc.CallOption) (*Observation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Observation)
+	err := c.cc.Invoke(ctx, ControllerService_ResetEpisode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StepFrame(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*Observation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Observation)
+	err := c.cc.Invoke(ctx, ControllerService_StepFrame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) SetObservationSpec(ctx context.Context, in *ObservationSpec, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_SetObservationSpec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StartRecording(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_StartRecording_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StopRecording(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_StopRecording_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) PlayMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_PlayMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) EditMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_EditMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) SeekMovie(ctx context.Context, in *MovieSeekRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_SeekMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) TruncateMovie(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_TruncateMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) InsertMovieInput(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_InsertMovieInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) SaveMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_SaveMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) MovieStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, ControllerService_MovieStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) LoadROM(ctx context.Context, in *ROMRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_LoadROM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) CreateSession(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionResponse)
+	err := c.cc.Invoke(ctx, ControllerService_CreateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) DestroySession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_DestroySession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controllerServiceClient) Pause(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -145,6 +520,26 @@ func (c *controllerServiceClient) Step(ctx context.Context, in *Empty, opts ...g
 	return out, nil
 }
 
+func (c *controllerServiceClient) AdvanceFrame(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_AdvanceFrame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) RunUntil(ctx context.Context, in *RunUntilRequest, opts ...grpc.CallOption) (*RunUntilResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunUntilResponse)
+	err := c.cc.Invoke(ctx, ControllerService_RunUntil_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controllerServiceClient) GetCPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CPUStateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CPUStateResponse)
@@ -165,253 +560,1756 @@ func (c *controllerServiceClient) ReadMemoryBlock(ctx context.Context, in *Memor
 	return out, nil
 }
 
-// ControllerServiceServer is the server API for ControllerService service.
-// All implementations must embed UnimplementedControllerServiceServer
-// for forward compatibility.
-type ControllerServiceServer interface {
-	// Client streams button states to the emulator
-	StreamInput(grpc.BidiStreamingServer[InputState, Empty]) error
-	// RL Endpoints
-	// Requests the current frame buffer (pixels) from the PPU
-	GetFrame(context.Context, *Empty) (*FrameResponse, error)
-	// Reads a byte from the NES system bus (used to calculate RL rewards/done state)
-	ReadMemory(context.Context, *MemoryRequest) (*MemoryResponse, error)
-	// Loads an emulator save state from a file, bypassing the title screen
-	LoadState(context.Context, *StateRequest) (*Empty, error)
-	// Triggers a hardware reset of the NES (returns game to title screen)
-	ResetSystem(context.Context, *Empty) (*Empty, error)
-	// --- VDB (Vibemulator Debugger) Endpoints ---
-	Pause(context.Context, *Empty) (*Empty, error)
-	Resume(context.Context, *Empty) (*Empty, error)
-	Step(context.Context, *Empty) (*Empty, error)
-	GetCPUState(context.Context, *Empty) (*CPUStateResponse, error)
-	ReadMemoryBlock(context.Context, *MemoryBlockRequest) (*MemoryBlockResponse, error)
-	mustEmbedUnimplementedControllerServiceServer()
+func (c *controllerServiceClient) WriteMemoryBlock(ctx context.Context, in *MemoryWriteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_WriteMemoryBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedControllerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedControllerServiceServer struct{}
-
-func (UnimplementedControllerServiceServer) StreamInput(grpc.BidiStreamingServer[InputState, Empty]) error {
-	return status.Error(codes.Unimplemented, "method StreamInput not implemented")
+func (c *controllerServiceClient) AddWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Watchpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchpoint)
+	err := c.cc.Invoke(ctx, ControllerService_AddWatchpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) GetFrame(context.Context, *Empty) (*FrameResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetFrame not implemented")
+
+func (c *controllerServiceClient) RemoveWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_RemoveWatchpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) ReadMemory(context.Context, *MemoryRequest) (*MemoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ReadMemory not implemented")
+
+func (c *controllerServiceClient) ListWatchpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchpointList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchpointList)
+	err := c.cc.Invoke(ctx, ControllerService_ListWatchpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) LoadState(context.Context, *StateRequest) (*Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method LoadState not implemented")
+
+func (c *controllerServiceClient) GetWatchHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchHit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchHit)
+	err := c.cc.Invoke(ctx, ControllerService_GetWatchHit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) ResetSystem(context.Context, *Empty) (*Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method ResetSystem not implemented")
+
+func (c *controllerServiceClient) AddBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Breakpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Breakpoint)
+	err := c.cc.Invoke(ctx, ControllerService_AddBreakpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) Pause(context.Context, *Empty) (*Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method Pause not implemented")
+
+func (c *controllerServiceClient) RemoveBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_RemoveBreakpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) Resume(context.Context, *Empty) (*Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method Resume not implemented")
+
+func (c *controllerServiceClient) ListBreakpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BreakpointList)
+	err := c.cc.Invoke(ctx, ControllerService_ListBreakpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedControllerServiceServer) Step(context.Context, *Empty) (*Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method Step not implemented")
+
+func (c *controllerServiceClient) GetBreakpointHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointHit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BreakpointHit)
+	err := c.cc.Invoke(ctx, ControllerService_GetBreakpointHit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) AddCheat(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cheat)
+	err := c.cc.Invoke(ctx, ControllerService_AddCheat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) ListCheats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheatList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheatList)
+	err := c.cc.Invoke(ctx, ControllerService_ListCheats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) SetCheatEnabled(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cheat)
+	err := c.cc.Invoke(ctx, ControllerService_SetCheatEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, ControllerService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StartProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_StartProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StopProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfileReport)
+	err := c.cc.Invoke(ctx, ControllerService_StopProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfileReport)
+	err := c.cc.Invoke(ctx, ControllerService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StartCDL(ctx context.Context, in *CDLRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ControllerService_StartCDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) StopCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CDLReport)
+	err := c.cc.Invoke(ctx, ControllerService_StopCDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CDLReport)
+	err := c.cc.Invoke(ctx, ControllerService_GetCDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Disassemble(ctx context.Context, in *DisassembleRequest, opts ...grpc.CallOption) (*DisassembleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisassembleResponse)
+	err := c.cc.Invoke(ctx, ControllerService_Disassemble_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetMemoryMap(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryMap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryMap)
+	err := c.cc.Invoke(ctx, ControllerService_GetMemoryMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) ReadNametables(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, ControllerService_ReadNametables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetPPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PPUStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PPUStateResponse)
+	err := c.cc.Invoke(ctx, ControllerService_GetPPUState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetAPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*APUStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APUStateResponse)
+	err := c.cc.Invoke(ctx, ControllerService_GetAPUState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) ReadOAM(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, ControllerService_ReadOAM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) ReadPalette(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, ControllerService_ReadPalette_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetPatternTableImage(ctx context.Context, in *PatternTableRequest, opts ...grpc.CallOption) (*FrameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameResponse)
+	err := c.cc.Invoke(ctx, ControllerService_GetPatternTableImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetNametableImage(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameResponse)
+	err := c.cc.Invoke(ctx, ControllerService_GetNametableImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetLogLevels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, ControllerService_GetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) SetLogLevels(ctx context.Context, in *LogLevels, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, ControllerService_SetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) GetPacingStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PacingStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PacingStats)
+	err := c.cc.Invoke(ctx, ControllerService_GetPacingStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControllerServiceServer is the server API for ControllerService service.
+// All implementations must embed UnimplementedControllerServiceServer
+// for forward compatibility.
+//
+// ControllerService serves every RPC of the focused vibemulator.v1 services (see
+// api/v1/services.proto) under one name, for clients written before the split. It and
+// its messages are frozen: new RPCs and fields go into vibemulator.v1 only, and the
+// server answers these calls with the v1 handlers, so the messages here must keep the
+// same fields as their v1 copies.
+type ControllerServiceServer interface {
+	// Client streams button states to the emulator
+	StreamInput(grpc.BidiStreamingServer[InputState, Empty]) error
+	// RL Endpoints
+	// Requests the current frame buffer (pixels) from the PPU
+	GetFrame(context.Context, *FrameRequest) (*FrameResponse, error)
+	// FNV-64a hash of the raw RGBA frame, to verify output without transferring pixels
+	GetFrameHash(context.Context, *Empty) (*FrameHashResponse, error)
+	// Pushes every newly completed frame with its number and hash
+	StreamFrames(*StreamFramesRequest, grpc.ServerStreamingServer[FrameResponse]) error
+	// Read-only spectator feed: the input latched at every frame (and optionally the video),
+	// delivered a fixed number of frames behind the live game
+	Spectate(*SpectateRequest, grpc.ServerStreamingServer[SpectatorUpdate]) error
+	// Reads a byte from the NES system bus (used to calculate RL rewards/done state)
+	ReadMemory(context.Context, *MemoryRequest) (*MemoryResponse, error)
+	// Loads an emulator save state from a file or inline bytes, bypassing the title screen
+	LoadState(context.Context, *StateRequest) (*Empty, error)
+	// Snapshots the emulator, with what is needed to anchor a recording to the snapshot
+	SaveState(context.Context, *Empty) (*StateResponse, error)
+	// Triggers a hardware reset of the NES (returns game to title screen)
+	ResetSystem(context.Context, *Empty) (*Empty, error)
+	// Power-cycles the NES into a known state (optionally from a ROM or state blob), pauses it,
+	// and returns the initial observation. Identical inputs then yield bit-identical trajectories.
+	ResetEpisode(context.Context, *EpisodeRequest) (*Observation, error)
+	// Holds the given inputs for a number of whole frames and returns the resulting observation
+	StepFrame(context.Context, *StepRequest) (*Observation, error)
+	// Registers named memory features that every ResetEpisode/StepFrame observation will carry,
+	// saving clients a ReadMemory round trip per value per frame. Replaces any previous spec.
+	SetObservationSpec(context.Context, *ObservationSpec) (*Empty, error)
+	// Movie recording and playback. Input is logged per frame (not wall-clock), so PlayMovie
+	// replays deterministically from the recorded starting state and reports the final frame hash.
+	StartRecording(context.Context, *Empty) (*Empty, error)
+	StopRecording(context.Context, *MovieRequest) (*MovieResponse, error)
+	PlayMovie(context.Context, *MovieRequest) (*MovieResponse, error)
+	// Movie editing, on one movie per instance. EditMovie loads a movie and seeks to its
+	// first frame, and SeekMovie replays it to the start of a frame. TruncateMovie drops the
+	// input after the current frame, and InsertMovieInput inserts input at it. SaveMovie
+	// returns the edited movie, optionally writing it to a file, and MovieStatus reports
+	// where the emulator is in it without returning it. Resuming plays the rest.
+	EditMovie(context.Context, *MovieRequest) (*MovieResponse, error)
+	SeekMovie(context.Context, *MovieSeekRequest) (*MovieResponse, error)
+	TruncateMovie(context.Context, *Empty) (*MovieResponse, error)
+	InsertMovieInput(context.Context, *StepRequest) (*MovieResponse, error)
+	SaveMovie(context.Context, *MovieRequest) (*MovieResponse, error)
+	MovieStatus(context.Context, *Empty) (*MovieResponse, error)
+	// Inserts a ROM from raw iNES bytes and power-cycles the NES, so headless instances
+	// do not need the ROM provisioned on disk
+	LoadROM(context.Context, *ROMRequest) (*Empty, error)
+	// Multi-session support. CreateSession starts an independent headless emulator; every
+	// other RPC targets it when the "session-id" metadata key carries the returned ID, and
+	// the default emulator otherwise.
+	CreateSession(context.Context, *Empty) (*SessionResponse, error)
+	DestroySession(context.Context, *SessionRequest) (*Empty, error)
+	// --- VDB (Vibemulator Debugger) Endpoints ---
+	// Pause returns once the emulator has stopped on an instruction boundary. Step and
+	// AdvanceFrame run the emulator one instruction, or to the end of the frame, and
+	// return once it has paused again.
+	Pause(context.Context, *Empty) (*Empty, error)
+	Resume(context.Context, *Empty) (*Empty, error)
+	Step(context.Context, *Empty) (*Empty, error)
+	AdvanceFrame(context.Context, *Empty) (*Empty, error)
+	// Resumes the emulator and blocks until the condition is met, a watchpoint or Pause
+	// stops it first, or the call is cancelled (which pauses the emulator)
+	RunUntil(context.Context, *RunUntilRequest) (*RunUntilResponse, error)
+	GetCPUState(context.Context, *Empty) (*CPUStateResponse, error)
+	ReadMemoryBlock(context.Context, *MemoryBlockRequest) (*MemoryBlockResponse, error)
+	// Writes through the CPU address space (RAM, PPU/APU registers, mapper registers, PRG RAM)
+	WriteMemoryBlock(context.Context, *MemoryWriteRequest) (*Empty, error)
+	// Watchpoints pause the emulator when the CPU reads or writes an address range
+	AddWatchpoint(context.Context, *Watchpoint) (*Watchpoint, error)
+	RemoveWatchpoint(context.Context, *Watchpoint) (*Empty, error)
+	ListWatchpoints(context.Context, *Empty) (*WatchpointList, error)
+	// Returns and clears the last watchpoint hit, if any
+	GetWatchHit(context.Context, *Empty) (*WatchHit, error)
+	// Breakpoints pause the emulator before the instruction at an address executes,
+	// optionally only when a condition holds (evaluated inside the emulator)
+	AddBreakpoint(context.Context, *Breakpoint) (*Breakpoint, error)
+	RemoveBreakpoint(context.Context, *Breakpoint) (*Empty, error)
+	ListBreakpoints(context.Context, *Empty) (*BreakpointList, error)
+	// Returns and clears the last breakpoint hit, if any
+	GetBreakpointHit(context.Context, *Empty) (*BreakpointHit, error)
+	// Cheat codes (Game Genie or raw "AAAA:VV" / "AAAA?CC:VV") patch what the CPU reads
+	AddCheat(context.Context, *Cheat) (*Cheat, error)
+	ListCheats(context.Context, *Empty) (*CheatList, error)
+	// Turns the cheat with the given id on or off
+	SetCheatEnabled(context.Context, *Cheat) (*Cheat, error)
+	// Evaluates expressions in the breakpoint condition language against the current state
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Instruction profiler: counts the instructions executed at each address and JSR calls
+	// to each subroutine between StartProfile and StopProfile
+	StartProfile(context.Context, *Empty) (*Empty, error)
+	StopProfile(context.Context, *Empty) (*ProfileReport, error)
+	// Returns the running or most recent profile
+	GetProfile(context.Context, *Empty) (*ProfileReport, error)
+	// Code/data logger: marks each PRG ROM byte executed as code or read as data, through
+	// whichever bank it is mapped in, for export as an FCEUX .cdl file. StartCDL continues
+	// from the given log, or starts an empty one.
+	StartCDL(context.Context, *CDLRequest) (*Empty, error)
+	StopCDL(context.Context, *Empty) (*CDLReport, error)
+	// Returns the running or most recent log
+	GetCDL(context.Context, *Empty) (*CDLReport, error)
+	// Decodes instructions at an address (the PC by default)
+	Disassemble(context.Context, *DisassembleRequest) (*DisassembleResponse, error)
+	// Describes the CPU address space, including where each PRG window currently points
+	GetMemoryMap(context.Context, *Empty) (*MemoryMap, error)
+	// --- PPU viewers (read without side effects such as MMC3 IRQ clocking) ---
+	// Logical nametables $2000-$2FFF (4KB) as currently mirrored
+	ReadNametables(context.Context, *Empty) (*MemoryBlockResponse, error)
+	// Internal registers and raster position
+	GetPPUState(context.Context, *Empty) (*PPUStateResponse, error)
+	// Channel enables, periods, length counters and IRQ flags
+	GetAPUState(context.Context, *Empty) (*APUStateResponse, error)
+	// 256 bytes of sprite OAM
+	ReadOAM(context.Context, *Empty) (*MemoryBlockResponse, error)
+	// 32 bytes of palette RAM ($3F00-$3F1F)
+	ReadPalette(context.Context, *Empty) (*MemoryBlockResponse, error)
+	// 128x128 rendering of a pattern table
+	GetPatternTableImage(context.Context, *PatternTableRequest) (*FrameResponse, error)
+	// 512x480 rendering of all four nametables
+	GetNametableImage(context.Context, *FrameRequest) (*FrameResponse, error)
+	// --- Logging: one level per subsystem (bus, cartridge, cpu, ppu), shared by every session ---
+	GetLogLevels(context.Context, *Empty) (*LogLevels, error)
+	// Sets the levels given (trace, debug, info, warn or error; "all" sets every
+	// subsystem) and returns the levels of all subsystems
+	SetLogLevels(context.Context, *LogLevels) (*LogLevels, error)
+	// --- Pacing: frame delivery and audio sync over the last ten seconds of play ---
+	GetPacingStats(context.Context, *Empty) (*PacingStats, error)
+	mustEmbedUnimplementedControllerServiceServer()
+}
+
+// UnimplementedControllerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedControllerServiceServer struct{}
+
+func (UnimplementedControllerServiceServer) StreamInput(grpc.BidiStreamingServer[InputState, Empty]) error {
+	return status.Error(codes.Unimplemented, "method StreamInput not implemented")
+}
+func (UnimplementedControllerServiceServer) GetFrame(context.Context, *FrameRequest) (*FrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFrame not implemented")
+}
+func (UnimplementedControllerServiceServer) GetFrameHash(context.Context, *Empty) (*FrameHashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFrameHash not implemented")
+}
+func (UnimplementedControllerServiceServer) StreamFrames(*StreamFramesRequest, grpc.ServerStreamingServer[FrameResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamFrames not implemented")
+}
+func (UnimplementedControllerServiceServer) Spectate(*SpectateRequest, grpc.ServerStreamingServer[SpectatorUpdate]) error {
+	return status.Error(codes.Unimplemented, "method Spectate not implemented")
+}
+func (UnimplementedControllerServiceServer) ReadMemory(context.Context, *MemoryRequest) (*MemoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadMemory not implemented")
+}
+func (UnimplementedControllerServiceServer) LoadState(context.Context, *StateRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoadState not implemented")
+}
+func (UnimplementedControllerServiceServer) SaveState(context.Context, *Empty) (*StateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveState not implemented")
+}
+func (UnimplementedControllerServiceServer) ResetSystem(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetSystem not implemented")
+}
+func (UnimplementedControllerServiceServer) ResetEpisode(context.Context, *EpisodeRequest) (*Observation, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetEpisode not implemented")
+}
+func (UnimplementedControllerServiceServer) StepFrame(context.Context, *StepRequest) (*Observation, error) {
+	return nil, status.Error(codes.Unimplemented, "method StepFrame not implemented")
+}
+func (UnimplementedControllerServiceServer) SetObservationSpec(context.Context, *ObservationSpec) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetObservationSpec not implemented")
+}
+func (UnimplementedControllerServiceServer) StartRecording(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartRecording not implemented")
+}
+func (UnimplementedControllerServiceServer) StopRecording(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopRecording not implemented")
+}
+func (UnimplementedControllerServiceServer) PlayMovie(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlayMovie not implemented")
+}
+func (UnimplementedControllerServiceServer) EditMovie(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EditMovie not implemented")
+}
+func (UnimplementedControllerServiceServer) SeekMovie(context.Context, *MovieSeekRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SeekMovie not implemented")
+}
+func (UnimplementedControllerServiceServer) TruncateMovie(context.Context, *Empty) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TruncateMovie not implemented")
+}
+func (UnimplementedControllerServiceServer) InsertMovieInput(context.Context, *StepRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InsertMovieInput not implemented")
+}
+func (UnimplementedControllerServiceServer) SaveMovie(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveMovie not implemented")
+}
+func (UnimplementedControllerServiceServer) MovieStatus(context.Context, *Empty) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MovieStatus not implemented")
+}
+func (UnimplementedControllerServiceServer) LoadROM(context.Context, *ROMRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoadROM not implemented")
+}
+func (UnimplementedControllerServiceServer) CreateSession(context.Context, *Empty) (*SessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedControllerServiceServer) DestroySession(context.Context, *SessionRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DestroySession not implemented")
+}
+func (UnimplementedControllerServiceServer) Pause(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedControllerServiceServer) Resume(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedControllerServiceServer) Step(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Step not implemented")
+}
+func (UnimplementedControllerServiceServer) AdvanceFrame(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdvanceFrame not implemented")
+}
+func (UnimplementedControllerServiceServer) RunUntil(context.Context, *RunUntilRequest) (*RunUntilResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunUntil not implemented")
 }
 func (UnimplementedControllerServiceServer) GetCPUState(context.Context, *Empty) (*CPUStateResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCPUState not implemented")
 }
-func (UnimplementedControllerServiceServer) ReadMemoryBlock(context.Context, *MemoryBlockRequest) (*MemoryBlockResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ReadMemoryBlock not implemented")
+func (UnimplementedControllerServiceServer) ReadMemoryBlock(context.Context, *MemoryBlockRequest) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadMemoryBlock not implemented")
+}
+func (UnimplementedControllerServiceServer) WriteMemoryBlock(context.Context, *MemoryWriteRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method WriteMemoryBlock not implemented")
+}
+func (UnimplementedControllerServiceServer) AddWatchpoint(context.Context, *Watchpoint) (*Watchpoint, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddWatchpoint not implemented")
+}
+func (UnimplementedControllerServiceServer) RemoveWatchpoint(context.Context, *Watchpoint) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveWatchpoint not implemented")
+}
+func (UnimplementedControllerServiceServer) ListWatchpoints(context.Context, *Empty) (*WatchpointList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWatchpoints not implemented")
+}
+func (UnimplementedControllerServiceServer) GetWatchHit(context.Context, *Empty) (*WatchHit, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWatchHit not implemented")
+}
+func (UnimplementedControllerServiceServer) AddBreakpoint(context.Context, *Breakpoint) (*Breakpoint, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddBreakpoint not implemented")
+}
+func (UnimplementedControllerServiceServer) RemoveBreakpoint(context.Context, *Breakpoint) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveBreakpoint not implemented")
+}
+func (UnimplementedControllerServiceServer) ListBreakpoints(context.Context, *Empty) (*BreakpointList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBreakpoints not implemented")
+}
+func (UnimplementedControllerServiceServer) GetBreakpointHit(context.Context, *Empty) (*BreakpointHit, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBreakpointHit not implemented")
+}
+func (UnimplementedControllerServiceServer) AddCheat(context.Context, *Cheat) (*Cheat, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddCheat not implemented")
+}
+func (UnimplementedControllerServiceServer) ListCheats(context.Context, *Empty) (*CheatList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCheats not implemented")
+}
+func (UnimplementedControllerServiceServer) SetCheatEnabled(context.Context, *Cheat) (*Cheat, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCheatEnabled not implemented")
+}
+func (UnimplementedControllerServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedControllerServiceServer) StartProfile(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartProfile not implemented")
+}
+func (UnimplementedControllerServiceServer) StopProfile(context.Context, *Empty) (*ProfileReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopProfile not implemented")
+}
+func (UnimplementedControllerServiceServer) GetProfile(context.Context, *Empty) (*ProfileReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedControllerServiceServer) StartCDL(context.Context, *CDLRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartCDL not implemented")
+}
+func (UnimplementedControllerServiceServer) StopCDL(context.Context, *Empty) (*CDLReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopCDL not implemented")
+}
+func (UnimplementedControllerServiceServer) GetCDL(context.Context, *Empty) (*CDLReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCDL not implemented")
+}
+func (UnimplementedControllerServiceServer) Disassemble(context.Context, *DisassembleRequest) (*DisassembleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Disassemble not implemented")
+}
+func (UnimplementedControllerServiceServer) GetMemoryMap(context.Context, *Empty) (*MemoryMap, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMemoryMap not implemented")
+}
+func (UnimplementedControllerServiceServer) ReadNametables(context.Context, *Empty) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadNametables not implemented")
+}
+func (UnimplementedControllerServiceServer) GetPPUState(context.Context, *Empty) (*PPUStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPPUState not implemented")
+}
+func (UnimplementedControllerServiceServer) GetAPUState(context.Context, *Empty) (*APUStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAPUState not implemented")
+}
+func (UnimplementedControllerServiceServer) ReadOAM(context.Context, *Empty) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadOAM not implemented")
+}
+func (UnimplementedControllerServiceServer) ReadPalette(context.Context, *Empty) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadPalette not implemented")
+}
+func (UnimplementedControllerServiceServer) GetPatternTableImage(context.Context, *PatternTableRequest) (*FrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPatternTableImage not implemented")
+}
+func (UnimplementedControllerServiceServer) GetNametableImage(context.Context, *FrameRequest) (*FrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNametableImage not implemented")
+}
+func (UnimplementedControllerServiceServer) GetLogLevels(context.Context, *Empty) (*LogLevels, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (UnimplementedControllerServiceServer) SetLogLevels(context.Context, *LogLevels) (*LogLevels, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLogLevels not implemented")
+}
+func (UnimplementedControllerServiceServer) GetPacingStats(context.Context, *Empty) (*PacingStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPacingStats not implemented")
+}
+func (UnimplementedControllerServiceServer) mustEmbedUnimplementedControllerServiceServer() {}
+func (UnimplementedControllerServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeControllerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControllerServiceServer will
+// result in compilation errors.
+type UnsafeControllerServiceServer interface {
+	mustEmbedUnimplementedControllerServiceServer()
+}
+
+func RegisterControllerServiceServer(s grpc.ServiceRegistrar, srv ControllerServiceServer) {
+	// If the following call panics, it indicates UnimplementedControllerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ControllerService_ServiceDesc, srv)
+}
+
+func _ControllerService_StreamInput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControllerServiceServer).StreamInput(&grpc.GenericServerStream[InputState, Empty]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControllerService_StreamInputServer = grpc.BidiStreamingServer[InputState, Empty]
+
+func _ControllerService_GetFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FrameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetFrame(ctx, req.(*FrameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetFrameHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetFrameHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetFrameHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetFrameHash(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_StreamFrames_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFramesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServiceServer).StreamFrames(m, &grpc.GenericServerStream[StreamFramesRequest, FrameResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControllerService_StreamFramesServer = grpc.ServerStreamingServer[FrameResponse]
+
+func _ControllerService_Spectate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpectateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServiceServer).Spectate(m, &grpc.GenericServerStream[SpectateRequest, SpectatorUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControllerService_SpectateServer = grpc.ServerStreamingServer[SpectatorUpdate]
+
+func _ControllerService_ReadMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ReadMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ReadMemory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ReadMemory(ctx, req.(*MemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_LoadState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).LoadState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_LoadState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).LoadState(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_SaveState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).SaveState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_SaveState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).SaveState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_ResetSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ResetSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ResetSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ResetSystem(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_ResetEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ResetEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ResetEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ResetEpisode(ctx, req.(*EpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_StepFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StepFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StepFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StepFrame(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_SetObservationSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObservationSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).SetObservationSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_SetObservationSpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).SetObservationSpec(ctx, req.(*ObservationSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_StartRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StartRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StartRecording_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StartRecording(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_StopRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StopRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StopRecording_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StopRecording(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_PlayMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).PlayMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_PlayMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).PlayMovie(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_EditMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).EditMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_EditMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).EditMovie(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_SeekMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieSeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).SeekMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_SeekMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).SeekMovie(ctx, req.(*MovieSeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_TruncateMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).TruncateMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_TruncateMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).TruncateMovie(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_InsertMovieInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).InsertMovieInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_InsertMovieInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).InsertMovieInput(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_SaveMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).SaveMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_SaveMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).SaveMovie(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_MovieStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).MovieStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_MovieStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).MovieStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_LoadROM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ROMRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).LoadROM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_LoadROM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).LoadROM(ctx, req.(*ROMRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_CreateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).CreateSession(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_DestroySession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).DestroySession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_DestroySession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).DestroySession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Pause(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Resume(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_Step_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Step(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_AdvanceFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).AdvanceFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_AdvanceFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).AdvanceFrame(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_RunUntil_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunUntilRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).RunUntil(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_RunUntil_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).RunUntil(ctx, req.(*RunUntilRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetCPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetCPUState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetCPUState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetCPUState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_ReadMemoryBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ReadMemoryBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ReadMemoryBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ReadMemoryBlock(ctx, req.(*MemoryBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_WriteMemoryBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryWriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).WriteMemoryBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_WriteMemoryBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).WriteMemoryBlock(ctx, req.(*MemoryWriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_AddWatchpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Watchpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).AddWatchpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_AddWatchpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).AddWatchpoint(ctx, req.(*Watchpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_RemoveWatchpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Watchpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).RemoveWatchpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_RemoveWatchpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).RemoveWatchpoint(ctx, req.(*Watchpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_ListWatchpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ListWatchpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ListWatchpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ListWatchpoints(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetWatchHit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetWatchHit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetWatchHit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetWatchHit(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_AddBreakpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Breakpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).AddBreakpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_AddBreakpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).AddBreakpoint(ctx, req.(*Breakpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_RemoveBreakpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Breakpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).RemoveBreakpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_RemoveBreakpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).RemoveBreakpoint(ctx, req.(*Breakpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_ListBreakpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ListBreakpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ListBreakpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ListBreakpoints(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetBreakpointHit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetBreakpointHit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetBreakpointHit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetBreakpointHit(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_AddCheat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Cheat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).AddCheat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_AddCheat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).AddCheat(ctx, req.(*Cheat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_ListCheats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ListCheats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ListCheats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ListCheats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_SetCheatEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Cheat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).SetCheatEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_SetCheatEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).SetCheatEnabled(ctx, req.(*Cheat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_StartProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StartProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StartProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StartProfile(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_StopProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StopProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StopProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StopProfile(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetProfile(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedControllerServiceServer) mustEmbedUnimplementedControllerServiceServer() {}
-func (UnimplementedControllerServiceServer) testEmbeddedByValue()                           {}
 
-// UnsafeControllerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ControllerServiceServer will
-// result in compilation errors.
-type UnsafeControllerServiceServer interface {
-	mustEmbedUnimplementedControllerServiceServer()
+func _ControllerService_StartCDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CDLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StartCDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StartCDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StartCDL(ctx, req.(*CDLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterControllerServiceServer(s grpc.ServiceRegistrar, srv ControllerServiceServer) {
-	// If the following call panics, it indicates UnimplementedControllerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ControllerService_StopCDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ControllerService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).StopCDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_StopCDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).StopCDL(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_StreamInput_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ControllerServiceServer).StreamInput(&grpc.GenericServerStream[InputState, Empty]{ServerStream: stream})
+func _ControllerService_GetCDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetCDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetCDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetCDL(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ControllerService_StreamInputServer = grpc.BidiStreamingServer[InputState, Empty]
+func _ControllerService_Disassemble_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisassembleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Disassemble(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_Disassemble_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Disassemble(ctx, req.(*DisassembleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func _ControllerService_GetFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ControllerService_GetMemoryMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).GetFrame(ctx, in)
+		return srv.(ControllerServiceServer).GetMemoryMap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_GetFrame_FullMethodName,
+		FullMethod: ControllerService_GetMemoryMap_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).GetFrame(ctx, req.(*Empty))
+		return srv.(ControllerServiceServer).GetMemoryMap(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_ReadMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MemoryRequest)
+func _ControllerService_ReadNametables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).ReadMemory(ctx, in)
+		return srv.(ControllerServiceServer).ReadNametables(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_ReadMemory_FullMethodName,
+		FullMethod: ControllerService_ReadNametables_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).ReadMemory(ctx, req.(*MemoryRequest))
+		return srv.(ControllerServiceServer).ReadNametables(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_LoadState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StateRequest)
+func _ControllerService_GetPPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).LoadState(ctx, in)
+		return srv.(ControllerServiceServer).GetPPUState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_LoadState_FullMethodName,
+		FullMethod: ControllerService_GetPPUState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).LoadState(ctx, req.(*StateRequest))
+		return srv.(ControllerServiceServer).GetPPUState(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_ResetSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ControllerService_GetAPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).ResetSystem(ctx, in)
+		return srv.(ControllerServiceServer).GetAPUState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_ResetSystem_FullMethodName,
+		FullMethod: ControllerService_GetAPUState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).ResetSystem(ctx, req.(*Empty))
+		return srv.(ControllerServiceServer).GetAPUState(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ControllerService_ReadOAM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).Pause(ctx, in)
+		return srv.(ControllerServiceServer).ReadOAM(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_Pause_FullMethodName,
+		FullMethod: ControllerService_ReadOAM_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).Pause(ctx, req.(*Empty))
+		return srv.(ControllerServiceServer).ReadOAM(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ControllerService_ReadPalette_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).Resume(ctx, in)
+		return srv.(ControllerServiceServer).ReadPalette(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_Resume_FullMethodName,
+		FullMethod: ControllerService_ReadPalette_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).Resume(ctx, req.(*Empty))
+		return srv.(ControllerServiceServer).ReadPalette(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _ControllerService_GetPatternTableImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternTableRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).Step(ctx, in)
+		return srv.(ControllerServiceServer).GetPatternTableImage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_Step_FullMethodName,
+		FullMethod: ControllerService_GetPatternTableImage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).Step(ctx, req.(*Empty))
+		return srv.(ControllerServiceServer).GetPatternTableImage(ctx, req.(*PatternTableRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_GetCPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ControllerService_GetNametableImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FrameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetNametableImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetNametableImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetNametableImage(ctx, req.(*FrameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).GetCPUState(ctx, in)
+		return srv.(ControllerServiceServer).GetLogLevels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_GetCPUState_FullMethodName,
+		FullMethod: ControllerService_GetLogLevels_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).GetCPUState(ctx, req.(*Empty))
+		return srv.(ControllerServiceServer).GetLogLevels(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControllerService_ReadMemoryBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MemoryBlockRequest)
+func _ControllerService_SetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevels)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControllerServiceServer).ReadMemoryBlock(ctx, in)
+		return srv.(ControllerServiceServer).SetLogLevels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ControllerService_ReadMemoryBlock_FullMethodName,
+		FullMethod: ControllerService_SetLogLevels_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServiceServer).ReadMemoryBlock(ctx, req.(*MemoryBlockRequest))
+		return srv.(ControllerServiceServer).SetLogLevels(ctx, req.(*LogLevels))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_GetPacingStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).GetPacingStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_GetPacingStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).GetPacingStats(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -427,6 +2325,10 @@ var ControllerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFrame",
 			Handler:    _ControllerService_GetFrame_Handler,
 		},
+		{
+			MethodName: "GetFrameHash",
+			Handler:    _ControllerService_GetFrameHash_Handler,
+		},
 		{
 			MethodName: "ReadMemory",
 			Handler:    _ControllerService_ReadMemory_Handler,
@@ -435,10 +2337,74 @@ var ControllerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoadState",
 			Handler:    _ControllerService_LoadState_Handler,
 		},
+		{
+			MethodName: "SaveState",
+			Handler:    _ControllerService_SaveState_Handler,
+		},
 		{
 			MethodName: "ResetSystem",
 			Handler:    _ControllerService_ResetSystem_Handler,
 		},
+		{
+			MethodName: "ResetEpisode",
+			Handler:    _ControllerService_ResetEpisode_Handler,
+		},
+		{
+			MethodName: "StepFrame",
+			Handler:    _ControllerService_StepFrame_Handler,
+		},
+		{
+			MethodName: "SetObservationSpec",
+			Handler:    _ControllerService_SetObservationSpec_Handler,
+		},
+		{
+			MethodName: "StartRecording",
+			Handler:    _ControllerService_StartRecording_Handler,
+		},
+		{
+			MethodName: "StopRecording",
+			Handler:    _ControllerService_StopRecording_Handler,
+		},
+		{
+			MethodName: "PlayMovie",
+			Handler:    _ControllerService_PlayMovie_Handler,
+		},
+		{
+			MethodName: "EditMovie",
+			Handler:    _ControllerService_EditMovie_Handler,
+		},
+		{
+			MethodName: "SeekMovie",
+			Handler:    _ControllerService_SeekMovie_Handler,
+		},
+		{
+			MethodName: "TruncateMovie",
+			Handler:    _ControllerService_TruncateMovie_Handler,
+		},
+		{
+			MethodName: "InsertMovieInput",
+			Handler:    _ControllerService_InsertMovieInput_Handler,
+		},
+		{
+			MethodName: "SaveMovie",
+			Handler:    _ControllerService_SaveMovie_Handler,
+		},
+		{
+			MethodName: "MovieStatus",
+			Handler:    _ControllerService_MovieStatus_Handler,
+		},
+		{
+			MethodName: "LoadROM",
+			Handler:    _ControllerService_LoadROM_Handler,
+		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _ControllerService_CreateSession_Handler,
+		},
+		{
+			MethodName: "DestroySession",
+			Handler:    _ControllerService_DestroySession_Handler,
+		},
 		{
 			MethodName: "Pause",
 			Handler:    _ControllerService_Pause_Handler,
@@ -451,6 +2417,14 @@ var ControllerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Step",
 			Handler:    _ControllerService_Step_Handler,
 		},
+		{
+			MethodName: "AdvanceFrame",
+			Handler:    _ControllerService_AdvanceFrame_Handler,
+		},
+		{
+			MethodName: "RunUntil",
+			Handler:    _ControllerService_RunUntil_Handler,
+		},
 		{
 			MethodName: "GetCPUState",
 			Handler:    _ControllerService_GetCPUState_Handler,
@@ -459,6 +2433,130 @@ var ControllerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadMemoryBlock",
 			Handler:    _ControllerService_ReadMemoryBlock_Handler,
 		},
+		{
+			MethodName: "WriteMemoryBlock",
+			Handler:    _ControllerService_WriteMemoryBlock_Handler,
+		},
+		{
+			MethodName: "AddWatchpoint",
+			Handler:    _ControllerService_AddWatchpoint_Handler,
+		},
+		{
+			MethodName: "RemoveWatchpoint",
+			Handler:    _ControllerService_RemoveWatchpoint_Handler,
+		},
+		{
+			MethodName: "ListWatchpoints",
+			Handler:    _ControllerService_ListWatchpoints_Handler,
+		},
+		{
+			MethodName: "GetWatchHit",
+			Handler:    _ControllerService_GetWatchHit_Handler,
+		},
+		{
+			MethodName: "AddBreakpoint",
+			Handler:    _ControllerService_AddBreakpoint_Handler,
+		},
+		{
+			MethodName: "RemoveBreakpoint",
+			Handler:    _ControllerService_RemoveBreakpoint_Handler,
+		},
+		{
+			MethodName: "ListBreakpoints",
+			Handler:    _ControllerService_ListBreakpoints_Handler,
+		},
+		{
+			MethodName: "GetBreakpointHit",
+			Handler:    _ControllerService_GetBreakpointHit_Handler,
+		},
+		{
+			MethodName: "AddCheat",
+			Handler:    _ControllerService_AddCheat_Handler,
+		},
+		{
+			MethodName: "ListCheats",
+			Handler:    _ControllerService_ListCheats_Handler,
+		},
+		{
+			MethodName: "SetCheatEnabled",
+			Handler:    _ControllerService_SetCheatEnabled_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _ControllerService_Evaluate_Handler,
+		},
+		{
+			MethodName: "StartProfile",
+			Handler:    _ControllerService_StartProfile_Handler,
+		},
+		{
+			MethodName: "StopProfile",
+			Handler:    _ControllerService_StopProfile_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _ControllerService_GetProfile_Handler,
+		},
+		{
+			MethodName: "StartCDL",
+			Handler:    _ControllerService_StartCDL_Handler,
+		},
+		{
+			MethodName: "StopCDL",
+			Handler:    _ControllerService_StopCDL_Handler,
+		},
+		{
+			MethodName: "GetCDL",
+			Handler:    _ControllerService_GetCDL_Handler,
+		},
+		{
+			MethodName: "Disassemble",
+			Handler:    _ControllerService_Disassemble_Handler,
+		},
+		{
+			MethodName: "GetMemoryMap",
+			Handler:    _ControllerService_GetMemoryMap_Handler,
+		},
+		{
+			MethodName: "ReadNametables",
+			Handler:    _ControllerService_ReadNametables_Handler,
+		},
+		{
+			MethodName: "GetPPUState",
+			Handler:    _ControllerService_GetPPUState_Handler,
+		},
+		{
+			MethodName: "GetAPUState",
+			Handler:    _ControllerService_GetAPUState_Handler,
+		},
+		{
+			MethodName: "ReadOAM",
+			Handler:    _ControllerService_ReadOAM_Handler,
+		},
+		{
+			MethodName: "ReadPalette",
+			Handler:    _ControllerService_ReadPalette_Handler,
+		},
+		{
+			MethodName: "GetPatternTableImage",
+			Handler:    _ControllerService_GetPatternTableImage_Handler,
+		},
+		{
+			MethodName: "GetNametableImage",
+			Handler:    _ControllerService_GetNametableImage_Handler,
+		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _ControllerService_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevels",
+			Handler:    _ControllerService_SetLogLevels_Handler,
+		},
+		{
+			MethodName: "GetPacingStats",
+			Handler:    _ControllerService_GetPacingStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -467,6 +2565,16 @@ var ControllerService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamFrames",
+			Handler:       _ControllerService_StreamFrames_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Spectate",
+			Handler:       _ControllerService_Spectate_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/controller.proto",
 }
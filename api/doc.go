@@ -0,0 +1,8 @@
+// Package api holds ControllerService, which serves every RPC of the emulator's gRPC
+// API under one name, and its messages. It is frozen for existing clients: new clients
+// should use the focused services in api/v1, which have their own copies of the
+// messages and get every new RPC and field.
+//
+// The package is its own module, so tools can import the generated clients without
+// the emulator's dependencies.
+package api
@@ -0,0 +1,3 @@
+// Package apiv1 holds the emulator's gRPC services split by use: InputService,
+// VideoService, StateService and DebugService, and the messages they take and return.
+package apiv1
@@ -0,0 +1,4210 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: api/v1/messages.proto
+
+// The messages of the vibemulator.v1 services. They started as copies of the messages in
+// api/controller.proto and keep the same fields, so api.ControllerService can be served
+// by the same handlers, but they change only with the v1 services from now on.
+
+package apiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StopCondition int32
+
+const (
+	StopCondition_STOP_AT_ADDRESS  StopCondition = 0 // Before the instruction at address executes
+	StopCondition_STOP_AT_SCANLINE StopCondition = 1 // Start of scanline (-1 to 260)
+	StopCondition_STOP_AT_VBLANK   StopCondition = 2 // Start of vertical blank (scanline 241, dot 1)
+	StopCondition_STOP_AT_FRAME    StopCondition = 3 // Start of frame (the frame counter reaching frame)
+)
+
+// Enum value maps for StopCondition.
+var (
+	StopCondition_name = map[int32]string{
+		0: "STOP_AT_ADDRESS",
+		1: "STOP_AT_SCANLINE",
+		2: "STOP_AT_VBLANK",
+		3: "STOP_AT_FRAME",
+	}
+	StopCondition_value = map[string]int32{
+		"STOP_AT_ADDRESS":  0,
+		"STOP_AT_SCANLINE": 1,
+		"STOP_AT_VBLANK":   2,
+		"STOP_AT_FRAME":    3,
+	}
+)
+
+func (x StopCondition) Enum() *StopCondition {
+	p := new(StopCondition)
+	*p = x
+	return p
+}
+
+func (x StopCondition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StopCondition) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (StopCondition) Type() protoreflect.EnumType {
+	return &file_api_v1_messages_proto_enumTypes[0]
+}
+
+func (x StopCondition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StopCondition.Descriptor instead.
+func (StopCondition) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type FrameEncoding int32
+
+const (
+	FrameEncoding_FRAME_ENCODING_RGBA      FrameEncoding = 0 // 4 bytes per pixel
+	FrameEncoding_FRAME_ENCODING_RGB       FrameEncoding = 1 // 3 bytes per pixel
+	FrameEncoding_FRAME_ENCODING_GRAYSCALE FrameEncoding = 2 // 1 byte of luma per pixel
+	FrameEncoding_FRAME_ENCODING_PNG       FrameEncoding = 3 // PNG-compressed RGBA image
+)
+
+// Enum value maps for FrameEncoding.
+var (
+	FrameEncoding_name = map[int32]string{
+		0: "FRAME_ENCODING_RGBA",
+		1: "FRAME_ENCODING_RGB",
+		2: "FRAME_ENCODING_GRAYSCALE",
+		3: "FRAME_ENCODING_PNG",
+	}
+	FrameEncoding_value = map[string]int32{
+		"FRAME_ENCODING_RGBA":      0,
+		"FRAME_ENCODING_RGB":       1,
+		"FRAME_ENCODING_GRAYSCALE": 2,
+		"FRAME_ENCODING_PNG":       3,
+	}
+)
+
+func (x FrameEncoding) Enum() *FrameEncoding {
+	p := new(FrameEncoding)
+	*p = x
+	return p
+}
+
+func (x FrameEncoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FrameEncoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (FrameEncoding) Type() protoreflect.EnumType {
+	return &file_api_v1_messages_proto_enumTypes[1]
+}
+
+func (x FrameEncoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FrameEncoding.Descriptor instead.
+func (FrameEncoding) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type MemoryRegion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         uint32                 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           uint32                 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`       // Inclusive
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`      // ram, ppu, io, prg-ram, prg-rom, cart or open
+	Mirror        uint32                 `protobuf:"varint,4,opt,name=mirror,proto3" json:"mirror,omitempty"` // Size of the block repeated through the region, or 0
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"` // Where start falls in PRG ROM or RAM, or -1
+	Bank          int32                  `protobuf:"varint,6,opt,name=bank,proto3" json:"bank,omitempty"`     // offset in units of the region's size, or -1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryRegion) Reset() {
+	*x = MemoryRegion{}
+	mi := &file_api_v1_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryRegion) ProtoMessage() {}
+
+func (x *MemoryRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryRegion.ProtoReflect.Descriptor instead.
+func (*MemoryRegion) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MemoryRegion) GetStart() uint32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *MemoryRegion) GetEnd() uint32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *MemoryRegion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *MemoryRegion) GetMirror() uint32 {
+	if x != nil {
+		return x.Mirror
+	}
+	return 0
+}
+
+func (x *MemoryRegion) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *MemoryRegion) GetBank() int32 {
+	if x != nil {
+		return x.Bank
+	}
+	return 0
+}
+
+type MemoryMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Regions       []*MemoryRegion        `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryMap) Reset() {
+	*x = MemoryMap{}
+	mi := &file_api_v1_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryMap) ProtoMessage() {}
+
+func (x *MemoryMap) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryMap.ProtoReflect.Descriptor instead.
+func (*MemoryMap) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MemoryMap) GetRegions() []*MemoryRegion {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type PacingStats struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Frames uint32                 `protobuf:"varint,1,opt,name=frames,proto3" json:"frames,omitempty"` // Emulated frames in the window
+	// Wall-clock time between frames, in microseconds
+	MeanIntervalUs int64  `protobuf:"varint,2,opt,name=mean_interval_us,json=meanIntervalUs,proto3" json:"mean_interval_us,omitempty"`
+	JitterUs       int64  `protobuf:"varint,3,opt,name=jitter_us,json=jitterUs,proto3" json:"jitter_us,omitempty"` // Standard deviation of the interval
+	MaxIntervalUs  int64  `protobuf:"varint,4,opt,name=max_interval_us,json=maxIntervalUs,proto3" json:"max_interval_us,omitempty"`
+	Dropped        uint64 `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`       // Frames that missed their display deadline
+	Duplicated     uint64 `protobuf:"varint,6,opt,name=duplicated,proto3" json:"duplicated,omitempty"` // Displays that repeated the previous frame
+	// Audio buffered ahead of the speaker, and its change over the window
+	AudioQueuedUs  int64  `protobuf:"varint,7,opt,name=audio_queued_us,json=audioQueuedUs,proto3" json:"audio_queued_us,omitempty"`
+	AudioDriftUs   int64  `protobuf:"varint,8,opt,name=audio_drift_us,json=audioDriftUs,proto3" json:"audio_drift_us,omitempty"`
+	AudioUnderruns uint64 `protobuf:"varint,9,opt,name=audio_underruns,json=audioUnderruns,proto3" json:"audio_underruns,omitempty"` // Reads the APU could not fill
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PacingStats) Reset() {
+	*x = PacingStats{}
+	mi := &file_api_v1_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PacingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PacingStats) ProtoMessage() {}
+
+func (x *PacingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PacingStats.ProtoReflect.Descriptor instead.
+func (*PacingStats) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PacingStats) GetFrames() uint32 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+func (x *PacingStats) GetMeanIntervalUs() int64 {
+	if x != nil {
+		return x.MeanIntervalUs
+	}
+	return 0
+}
+
+func (x *PacingStats) GetJitterUs() int64 {
+	if x != nil {
+		return x.JitterUs
+	}
+	return 0
+}
+
+func (x *PacingStats) GetMaxIntervalUs() int64 {
+	if x != nil {
+		return x.MaxIntervalUs
+	}
+	return 0
+}
+
+func (x *PacingStats) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *PacingStats) GetDuplicated() uint64 {
+	if x != nil {
+		return x.Duplicated
+	}
+	return 0
+}
+
+func (x *PacingStats) GetAudioQueuedUs() int64 {
+	if x != nil {
+		return x.AudioQueuedUs
+	}
+	return 0
+}
+
+func (x *PacingStats) GetAudioDriftUs() int64 {
+	if x != nil {
+		return x.AudioDriftUs
+	}
+	return 0
+}
+
+func (x *PacingStats) GetAudioUnderruns() uint64 {
+	if x != nil {
+		return x.AudioUnderruns
+	}
+	return 0
+}
+
+type LogLevels struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Levels        map[string]string      `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Subsystem to level name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLevels) Reset() {
+	*x = LogLevels{}
+	mi := &file_api_v1_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLevels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevels) ProtoMessage() {}
+
+func (x *LogLevels) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevels.ProtoReflect.Descriptor instead.
+func (*LogLevels) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LogLevels) GetLevels() map[string]string {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type CPUStateResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Pc     uint32                 `protobuf:"varint,1,opt,name=pc,proto3" json:"pc,omitempty"`
+	Sp     uint32                 `protobuf:"varint,2,opt,name=sp,proto3" json:"sp,omitempty"`
+	A      uint32                 `protobuf:"varint,3,opt,name=a,proto3" json:"a,omitempty"`
+	X      uint32                 `protobuf:"varint,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y      uint32                 `protobuf:"varint,5,opt,name=y,proto3" json:"y,omitempty"`
+	Status uint32                 `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+	// Cycles left in the instruction in progress
+	Cycles uint32 `protobuf:"varint,7,opt,name=cycles,proto3" json:"cycles,omitempty"`
+	// Where in the frame the emulator is: the PPU frame counter, scanline (-1 for the
+	// pre-render line) and dot
+	Frame    uint64 `protobuf:"varint,8,opt,name=frame,proto3" json:"frame,omitempty"`
+	Scanline int32  `protobuf:"varint,9,opt,name=scanline,proto3" json:"scanline,omitempty"`
+	Dot      uint32 `protobuf:"varint,10,opt,name=dot,proto3" json:"dot,omitempty"`
+	// PPU dots and CPU cycles run since the emulator started or the last ResetEpisode.
+	// Savestates carry them.
+	PpuCycles uint64 `protobuf:"varint,11,opt,name=ppu_cycles,json=ppuCycles,proto3" json:"ppu_cycles,omitempty"`
+	CpuCycles uint64 `protobuf:"varint,12,opt,name=cpu_cycles,json=cpuCycles,proto3" json:"cpu_cycles,omitempty"`
+	// The CPU executed a JAM (KIL) opcode and is locked up until reset, with pc on it
+	Jammed        bool `protobuf:"varint,13,opt,name=jammed,proto3" json:"jammed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CPUStateResponse) Reset() {
+	*x = CPUStateResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CPUStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUStateResponse) ProtoMessage() {}
+
+func (x *CPUStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUStateResponse.ProtoReflect.Descriptor instead.
+func (*CPUStateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CPUStateResponse) GetPc() uint32 {
+	if x != nil {
+		return x.Pc
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetSp() uint32 {
+	if x != nil {
+		return x.Sp
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetA() uint32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetX() uint32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetY() uint32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetCycles() uint32 {
+	if x != nil {
+		return x.Cycles
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetScanline() int32 {
+	if x != nil {
+		return x.Scanline
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetDot() uint32 {
+	if x != nil {
+		return x.Dot
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetPpuCycles() uint64 {
+	if x != nil {
+		return x.PpuCycles
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetCpuCycles() uint64 {
+	if x != nil {
+		return x.CpuCycles
+	}
+	return 0
+}
+
+func (x *CPUStateResponse) GetJammed() bool {
+	if x != nil {
+		return x.Jammed
+	}
+	return false
+}
+
+type PPUStateResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Ctrl    uint32                 `protobuf:"varint,1,opt,name=ctrl,proto3" json:"ctrl,omitempty"`
+	Mask    uint32                 `protobuf:"varint,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	Status  uint32                 `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	OamAddr uint32                 `protobuf:"varint,4,opt,name=oam_addr,json=oamAddr,proto3" json:"oam_addr,omitempty"`
+	// Current and temporary VRAM addresses ("loopy" v and t), fine X scroll and the
+	// write toggle shared by $2005 and $2006
+	V        uint32 `protobuf:"varint,5,opt,name=v,proto3" json:"v,omitempty"`
+	T        uint32 `protobuf:"varint,6,opt,name=t,proto3" json:"t,omitempty"`
+	FineX    uint32 `protobuf:"varint,7,opt,name=fine_x,json=fineX,proto3" json:"fine_x,omitempty"`
+	W        bool   `protobuf:"varint,8,opt,name=w,proto3" json:"w,omitempty"`
+	Scanline int32  `protobuf:"varint,9,opt,name=scanline,proto3" json:"scanline,omitempty"`
+	Dot      uint32 `protobuf:"varint,10,opt,name=dot,proto3" json:"dot,omitempty"`
+	Frame    uint64 `protobuf:"varint,11,opt,name=frame,proto3" json:"frame,omitempty"`
+	// NMI raised but not yet taken by the CPU
+	NmiPending    bool `protobuf:"varint,12,opt,name=nmi_pending,json=nmiPending,proto3" json:"nmi_pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PPUStateResponse) Reset() {
+	*x = PPUStateResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PPUStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PPUStateResponse) ProtoMessage() {}
+
+func (x *PPUStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PPUStateResponse.ProtoReflect.Descriptor instead.
+func (*PPUStateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PPUStateResponse) GetCtrl() uint32 {
+	if x != nil {
+		return x.Ctrl
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetMask() uint32 {
+	if x != nil {
+		return x.Mask
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetOamAddr() uint32 {
+	if x != nil {
+		return x.OamAddr
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetV() uint32 {
+	if x != nil {
+		return x.V
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetT() uint32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetFineX() uint32 {
+	if x != nil {
+		return x.FineX
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetW() bool {
+	if x != nil {
+		return x.W
+	}
+	return false
+}
+
+func (x *PPUStateResponse) GetScanline() int32 {
+	if x != nil {
+		return x.Scanline
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetDot() uint32 {
+	if x != nil {
+		return x.Dot
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *PPUStateResponse) GetNmiPending() bool {
+	if x != nil {
+		return x.NmiPending
+	}
+	return false
+}
+
+type APUChannel struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Timer period in timer clocks
+	Period        uint32 `protobuf:"varint,2,opt,name=period,proto3" json:"period,omitempty"`
+	LengthCounter uint32 `protobuf:"varint,3,opt,name=length_counter,json=lengthCounter,proto3" json:"length_counter,omitempty"`
+	// Length counter halt (envelope loop)
+	Halt bool `protobuf:"varint,4,opt,name=halt,proto3" json:"halt,omitempty"`
+	// Envelope or constant volume, the triangle's linear counter, or the DMC output level
+	Volume        uint32 `protobuf:"varint,5,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APUChannel) Reset() {
+	*x = APUChannel{}
+	mi := &file_api_v1_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APUChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APUChannel) ProtoMessage() {}
+
+func (x *APUChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APUChannel.ProtoReflect.Descriptor instead.
+func (*APUChannel) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *APUChannel) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *APUChannel) GetPeriod() uint32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *APUChannel) GetLengthCounter() uint32 {
+	if x != nil {
+		return x.LengthCounter
+	}
+	return 0
+}
+
+func (x *APUChannel) GetHalt() bool {
+	if x != nil {
+		return x.Halt
+	}
+	return false
+}
+
+func (x *APUChannel) GetVolume() uint32 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type APUStateResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Pulse1            *APUChannel            `protobuf:"bytes,1,opt,name=pulse1,proto3" json:"pulse1,omitempty"`
+	Pulse2            *APUChannel            `protobuf:"bytes,2,opt,name=pulse2,proto3" json:"pulse2,omitempty"`
+	Triangle          *APUChannel            `protobuf:"bytes,3,opt,name=triangle,proto3" json:"triangle,omitempty"`
+	Noise             *APUChannel            `protobuf:"bytes,4,opt,name=noise,proto3" json:"noise,omitempty"`
+	Dmc               *APUChannel            `protobuf:"bytes,5,opt,name=dmc,proto3" json:"dmc,omitempty"`
+	DmcAddress        uint32                 `protobuf:"varint,6,opt,name=dmc_address,json=dmcAddress,proto3" json:"dmc_address,omitempty"`
+	DmcBytesRemaining uint32                 `protobuf:"varint,7,opt,name=dmc_bytes_remaining,json=dmcBytesRemaining,proto3" json:"dmc_bytes_remaining,omitempty"`
+	// Frame counter mode and flags
+	FiveStep      bool `protobuf:"varint,8,opt,name=five_step,json=fiveStep,proto3" json:"five_step,omitempty"`
+	IrqInhibit    bool `protobuf:"varint,9,opt,name=irq_inhibit,json=irqInhibit,proto3" json:"irq_inhibit,omitempty"`
+	FrameIrq      bool `protobuf:"varint,10,opt,name=frame_irq,json=frameIrq,proto3" json:"frame_irq,omitempty"`
+	DmcIrq        bool `protobuf:"varint,11,opt,name=dmc_irq,json=dmcIrq,proto3" json:"dmc_irq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APUStateResponse) Reset() {
+	*x = APUStateResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APUStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APUStateResponse) ProtoMessage() {}
+
+func (x *APUStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APUStateResponse.ProtoReflect.Descriptor instead.
+func (*APUStateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *APUStateResponse) GetPulse1() *APUChannel {
+	if x != nil {
+		return x.Pulse1
+	}
+	return nil
+}
+
+func (x *APUStateResponse) GetPulse2() *APUChannel {
+	if x != nil {
+		return x.Pulse2
+	}
+	return nil
+}
+
+func (x *APUStateResponse) GetTriangle() *APUChannel {
+	if x != nil {
+		return x.Triangle
+	}
+	return nil
+}
+
+func (x *APUStateResponse) GetNoise() *APUChannel {
+	if x != nil {
+		return x.Noise
+	}
+	return nil
+}
+
+func (x *APUStateResponse) GetDmc() *APUChannel {
+	if x != nil {
+		return x.Dmc
+	}
+	return nil
+}
+
+func (x *APUStateResponse) GetDmcAddress() uint32 {
+	if x != nil {
+		return x.DmcAddress
+	}
+	return 0
+}
+
+func (x *APUStateResponse) GetDmcBytesRemaining() uint32 {
+	if x != nil {
+		return x.DmcBytesRemaining
+	}
+	return 0
+}
+
+func (x *APUStateResponse) GetFiveStep() bool {
+	if x != nil {
+		return x.FiveStep
+	}
+	return false
+}
+
+func (x *APUStateResponse) GetIrqInhibit() bool {
+	if x != nil {
+		return x.IrqInhibit
+	}
+	return false
+}
+
+func (x *APUStateResponse) GetFrameIrq() bool {
+	if x != nil {
+		return x.FrameIrq
+	}
+	return false
+}
+
+func (x *APUStateResponse) GetDmcIrq() bool {
+	if x != nil {
+		return x.DmcIrq
+	}
+	return false
+}
+
+type MemoryBlockRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address uint32                 `protobuf:"varint,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Up to the whole 64KB address space; the block may not run past $FFFF
+	Size uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Read as the CPU would, with side effects such as clearing the vblank flag on
+	// $2002 or shifting the controllers. By default registers are peeked.
+	SideEffects   bool `protobuf:"varint,3,opt,name=side_effects,json=sideEffects,proto3" json:"side_effects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryBlockRequest) Reset() {
+	*x = MemoryBlockRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryBlockRequest) ProtoMessage() {}
+
+func (x *MemoryBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryBlockRequest.ProtoReflect.Descriptor instead.
+func (*MemoryBlockRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MemoryBlockRequest) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *MemoryBlockRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *MemoryBlockRequest) GetSideEffects() bool {
+	if x != nil {
+		return x.SideEffects
+	}
+	return false
+}
+
+type MemoryWriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       uint32                 `protobuf:"varint,1,opt,name=address,proto3" json:"address,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryWriteRequest) Reset() {
+	*x = MemoryWriteRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryWriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryWriteRequest) ProtoMessage() {}
+
+func (x *MemoryWriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryWriteRequest.ProtoReflect.Descriptor instead.
+func (*MemoryWriteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MemoryWriteRequest) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *MemoryWriteRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type MovieRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// File in the server's movie folder to save the movie to (StopRecording, SaveMovie) or
+	// load it from (PlayMovie, EditMovie). It must be a relative path inside the folder.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Movie blob to play or edit when no filename is given
+	Movie         []byte `protobuf:"bytes,2,opt,name=movie,proto3" json:"movie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieRequest) Reset() {
+	*x = MovieRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieRequest) ProtoMessage() {}
+
+func (x *MovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieRequest.ProtoReflect.Descriptor instead.
+func (*MovieRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MovieRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *MovieRequest) GetMovie() []byte {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+type MovieSeekRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frame         uint32                 `protobuf:"varint,1,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieSeekRequest) Reset() {
+	*x = MovieSeekRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieSeekRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieSeekRequest) ProtoMessage() {}
+
+func (x *MovieSeekRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieSeekRequest.ProtoReflect.Descriptor instead.
+func (*MovieSeekRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MovieSeekRequest) GetFrame() uint32 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type MovieResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The recorded or edited movie (StopRecording and SaveMovie only)
+	Movie []byte `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	// Number of frame boundaries the movie spans
+	Frames uint32 `protobuf:"varint,2,opt,name=frames,proto3" json:"frames,omitempty"`
+	// FNV-64a hash of the final frame after recording or playback
+	FrameHash uint64 `protobuf:"varint,3,opt,name=frame_hash,json=frameHash,proto3" json:"frame_hash,omitempty"`
+	// Final frame hash stored in the movie when it was recorded. While editing, SaveMovie
+	// brings it up to date with the edits; other editing calls report it as last saved.
+	RecordedHash uint64 `protobuf:"varint,4,opt,name=recorded_hash,json=recordedHash,proto3" json:"recorded_hash,omitempty"`
+	// Movie frame the emulator is at (movie editing only)
+	Position      uint32 `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieResponse) Reset() {
+	*x = MovieResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieResponse) ProtoMessage() {}
+
+func (x *MovieResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieResponse.ProtoReflect.Descriptor instead.
+func (*MovieResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MovieResponse) GetMovie() []byte {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+func (x *MovieResponse) GetFrames() uint32 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+func (x *MovieResponse) GetFrameHash() uint64 {
+	if x != nil {
+		return x.FrameHash
+	}
+	return 0
+}
+
+func (x *MovieResponse) GetRecordedHash() uint64 {
+	if x != nil {
+		return x.RecordedHash
+	}
+	return 0
+}
+
+func (x *MovieResponse) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type PatternTableRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pattern table 0 ($0000) or 1 ($1000)
+	Table uint32 `protobuf:"varint,1,opt,name=table,proto3" json:"table,omitempty"`
+	// Palette to colour tiles with (0-3 background, 4-7 sprite)
+	Palette uint32 `protobuf:"varint,2,opt,name=palette,proto3" json:"palette,omitempty"`
+	// Encoding and sizing of the returned image (crop_overscan is ignored)
+	Format        *FrameRequest `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatternTableRequest) Reset() {
+	*x = PatternTableRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatternTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternTableRequest) ProtoMessage() {}
+
+func (x *PatternTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternTableRequest.ProtoReflect.Descriptor instead.
+func (*PatternTableRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PatternTableRequest) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *PatternTableRequest) GetPalette() uint32 {
+	if x != nil {
+		return x.Palette
+	}
+	return 0
+}
+
+func (x *PatternTableRequest) GetFormat() *FrameRequest {
+	if x != nil {
+		return x.Format
+	}
+	return nil
+}
+
+type Watchpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Assigned by AddWatchpoint; the only field RemoveWatchpoint needs
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Inclusive address range (end defaults to start)
+	Start uint32 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint32 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Watch writes when set, reads otherwise
+	Write         bool `protobuf:"varint,4,opt,name=write,proto3" json:"write,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Watchpoint) Reset() {
+	*x = Watchpoint{}
+	mi := &file_api_v1_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Watchpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Watchpoint) ProtoMessage() {}
+
+func (x *Watchpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Watchpoint.ProtoReflect.Descriptor instead.
+func (*Watchpoint) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Watchpoint) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Watchpoint) GetStart() uint32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Watchpoint) GetEnd() uint32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Watchpoint) GetWrite() bool {
+	if x != nil {
+		return x.Write
+	}
+	return false
+}
+
+type DisassembleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to the current PC
+	Address *uint32 `protobuf:"varint,1,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	// Instructions to decode from address (default 10)
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Instructions to include before address, found by resyncing backwards
+	Before        uint32 `protobuf:"varint,3,opt,name=before,proto3" json:"before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisassembleRequest) Reset() {
+	*x = DisassembleRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisassembleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisassembleRequest) ProtoMessage() {}
+
+func (x *DisassembleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisassembleRequest.ProtoReflect.Descriptor instead.
+func (*DisassembleRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DisassembleRequest) GetAddress() uint32 {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return 0
+}
+
+func (x *DisassembleRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DisassembleRequest) GetBefore() uint32 {
+	if x != nil {
+		return x.Before
+	}
+	return 0
+}
+
+type Instruction struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address uint32                 `protobuf:"varint,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Opcode followed by its operand bytes
+	Bytes    []byte `protobuf:"bytes,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Mnemonic string `protobuf:"bytes,3,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	// Addressing mode name from the CPU's opcode table (imp, imm, zp0, abs, rel, ...)
+	Mode          string `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Instruction) Reset() {
+	*x = Instruction{}
+	mi := &file_api_v1_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instruction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instruction) ProtoMessage() {}
+
+func (x *Instruction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instruction.ProtoReflect.Descriptor instead.
+func (*Instruction) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Instruction) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *Instruction) GetBytes() []byte {
+	if x != nil {
+		return x.Bytes
+	}
+	return nil
+}
+
+func (x *Instruction) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
+func (x *Instruction) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type DisassembleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instructions  []*Instruction         `protobuf:"bytes,1,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	Pc            uint32                 `protobuf:"varint,2,opt,name=pc,proto3" json:"pc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisassembleResponse) Reset() {
+	*x = DisassembleResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisassembleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisassembleResponse) ProtoMessage() {}
+
+func (x *DisassembleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisassembleResponse.ProtoReflect.Descriptor instead.
+func (*DisassembleResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DisassembleResponse) GetInstructions() []*Instruction {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
+}
+
+func (x *DisassembleResponse) GetPc() uint32 {
+	if x != nil {
+		return x.Pc
+	}
+	return 0
+}
+
+type Breakpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Assigned by AddBreakpoint; the only field RemoveBreakpoint needs
+	Id      uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address uint32 `protobuf:"varint,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Optional expression over registers (A X Y SP P PC), flags (N V D I Z C), the PPU
+	// position (SCANLINE DOT FRAME) and memory ([addr]), e.g. "A == 0x40 && [$00D0] > 3"
+	Condition string `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	// When set, a pausepoint that pauses as this frame starts (after its input is latched)
+	// instead of at address
+	Frame         uint64 `protobuf:"varint,4,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Breakpoint) Reset() {
+	*x = Breakpoint{}
+	mi := &file_api_v1_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Breakpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breakpoint) ProtoMessage() {}
+
+func (x *Breakpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breakpoint.ProtoReflect.Descriptor instead.
+func (*Breakpoint) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Breakpoint) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Breakpoint) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *Breakpoint) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *Breakpoint) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type BreakpointList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Breakpoints   []*Breakpoint          `protobuf:"bytes,1,rep,name=breakpoints,proto3" json:"breakpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreakpointList) Reset() {
+	*x = BreakpointList{}
+	mi := &file_api_v1_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreakpointList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakpointList) ProtoMessage() {}
+
+func (x *BreakpointList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakpointList.ProtoReflect.Descriptor instead.
+func (*BreakpointList) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BreakpointList) GetBreakpoints() []*Breakpoint {
+	if x != nil {
+		return x.Breakpoints
+	}
+	return nil
+}
+
+type BreakpointHit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when no breakpoint has been hit since the last call
+	Hit           bool        `protobuf:"varint,1,opt,name=hit,proto3" json:"hit,omitempty"`
+	Breakpoint    *Breakpoint `protobuf:"bytes,2,opt,name=breakpoint,proto3" json:"breakpoint,omitempty"`
+	Frame         uint64      `protobuf:"varint,3,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreakpointHit) Reset() {
+	*x = BreakpointHit{}
+	mi := &file_api_v1_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreakpointHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakpointHit) ProtoMessage() {}
+
+func (x *BreakpointHit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakpointHit.ProtoReflect.Descriptor instead.
+func (*BreakpointHit) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BreakpointHit) GetHit() bool {
+	if x != nil {
+		return x.Hit
+	}
+	return false
+}
+
+func (x *BreakpointHit) GetBreakpoint() *Breakpoint {
+	if x != nil {
+		return x.Breakpoint
+	}
+	return nil
+}
+
+func (x *BreakpointHit) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type EvaluateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expressions   []string               `protobuf:"bytes,1,rep,name=expressions,proto3" json:"expressions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EvaluateRequest) GetExpressions() []string {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per expression, in order
+	Results       []*EvaluateResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EvaluateResponse) GetResults() []*EvaluateResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type EvaluateResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Set when the expression could not be parsed
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResult) Reset() {
+	*x = EvaluateResult{}
+	mi := &file_api_v1_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResult) ProtoMessage() {}
+
+func (x *EvaluateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResult.ProtoReflect.Descriptor instead.
+func (*EvaluateResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EvaluateResult) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *EvaluateResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Cheat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Assigned by AddCheat; SetCheatEnabled needs only id and enabled
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The code as entered; AddCheat decodes it into the fields below
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Address       uint32 `protobuf:"varint,3,opt,name=address,proto3" json:"address,omitempty"`
+	Value         uint32 `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	HasCompare    bool   `protobuf:"varint,5,opt,name=has_compare,json=hasCompare,proto3" json:"has_compare,omitempty"`
+	Compare       uint32 `protobuf:"varint,6,opt,name=compare,proto3" json:"compare,omitempty"`
+	Enabled       bool   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cheat) Reset() {
+	*x = Cheat{}
+	mi := &file_api_v1_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cheat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cheat) ProtoMessage() {}
+
+func (x *Cheat) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cheat.ProtoReflect.Descriptor instead.
+func (*Cheat) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Cheat) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cheat) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Cheat) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *Cheat) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Cheat) GetHasCompare() bool {
+	if x != nil {
+		return x.HasCompare
+	}
+	return false
+}
+
+func (x *Cheat) GetCompare() uint32 {
+	if x != nil {
+		return x.Compare
+	}
+	return 0
+}
+
+func (x *Cheat) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type CheatList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cheats        []*Cheat               `protobuf:"bytes,1,rep,name=cheats,proto3" json:"cheats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheatList) Reset() {
+	*x = CheatList{}
+	mi := &file_api_v1_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheatList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheatList) ProtoMessage() {}
+
+func (x *CheatList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheatList.ProtoReflect.Descriptor instead.
+func (*CheatList) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CheatList) GetCheats() []*Cheat {
+	if x != nil {
+		return x.Cheats
+	}
+	return nil
+}
+
+type CDLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // An FCEUX .cdl file for the loaded ROM, or empty to start afresh
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CDLRequest) Reset() {
+	*x = CDLRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CDLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CDLRequest) ProtoMessage() {}
+
+func (x *CDLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CDLRequest.ProtoReflect.Descriptor instead.
+func (*CDLRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CDLRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CDLReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Running       bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // FCEUX .cdl: a flag byte per PRG ROM byte, then per CHR ROM byte
+	PrgSize       uint32                 `protobuf:"varint,3,opt,name=prg_size,json=prgSize,proto3" json:"prg_size,omitempty"`
+	CodeBytes     uint32                 `protobuf:"varint,4,opt,name=code_bytes,json=codeBytes,proto3" json:"code_bytes,omitempty"` // PRG ROM bytes logged as code
+	DataBytes     uint32                 `protobuf:"varint,5,opt,name=data_bytes,json=dataBytes,proto3" json:"data_bytes,omitempty"` // PRG ROM bytes logged as data, including DMC samples
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CDLReport) Reset() {
+	*x = CDLReport{}
+	mi := &file_api_v1_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CDLReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CDLReport) ProtoMessage() {}
+
+func (x *CDLReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CDLReport.ProtoReflect.Descriptor instead.
+func (*CDLReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CDLReport) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *CDLReport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CDLReport) GetPrgSize() uint32 {
+	if x != nil {
+		return x.PrgSize
+	}
+	return 0
+}
+
+func (x *CDLReport) GetCodeBytes() uint32 {
+	if x != nil {
+		return x.CodeBytes
+	}
+	return 0
+}
+
+func (x *CDLReport) GetDataBytes() uint32 {
+	if x != nil {
+		return x.DataBytes
+	}
+	return 0
+}
+
+type ProfileEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       uint32                 `protobuf:"varint,1,opt,name=address,proto3" json:"address,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileEntry) Reset() {
+	*x = ProfileEntry{}
+	mi := &file_api_v1_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileEntry) ProtoMessage() {}
+
+func (x *ProfileEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileEntry.ProtoReflect.Descriptor instead.
+func (*ProfileEntry) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ProfileEntry) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *ProfileEntry) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ProfileReport struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Running      bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Instructions uint64                 `protobuf:"varint,2,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	Frames       uint64                 `protobuf:"varint,3,opt,name=frames,proto3" json:"frames,omitempty"`
+	// Instructions executed at each address, busiest first
+	Pcs []*ProfileEntry `protobuf:"bytes,4,rep,name=pcs,proto3" json:"pcs,omitempty"`
+	// JSR calls to each subroutine, busiest first
+	Subroutines   []*ProfileEntry `protobuf:"bytes,5,rep,name=subroutines,proto3" json:"subroutines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileReport) Reset() {
+	*x = ProfileReport{}
+	mi := &file_api_v1_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileReport) ProtoMessage() {}
+
+func (x *ProfileReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileReport.ProtoReflect.Descriptor instead.
+func (*ProfileReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ProfileReport) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *ProfileReport) GetInstructions() uint64 {
+	if x != nil {
+		return x.Instructions
+	}
+	return 0
+}
+
+func (x *ProfileReport) GetFrames() uint64 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+func (x *ProfileReport) GetPcs() []*ProfileEntry {
+	if x != nil {
+		return x.Pcs
+	}
+	return nil
+}
+
+func (x *ProfileReport) GetSubroutines() []*ProfileEntry {
+	if x != nil {
+		return x.Subroutines
+	}
+	return nil
+}
+
+type WatchpointList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watchpoints   []*Watchpoint          `protobuf:"bytes,1,rep,name=watchpoints,proto3" json:"watchpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchpointList) Reset() {
+	*x = WatchpointList{}
+	mi := &file_api_v1_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchpointList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchpointList) ProtoMessage() {}
+
+func (x *WatchpointList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchpointList.ProtoReflect.Descriptor instead.
+func (*WatchpointList) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WatchpointList) GetWatchpoints() []*Watchpoint {
+	if x != nil {
+		return x.Watchpoints
+	}
+	return nil
+}
+
+type WatchHit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when no watchpoint has been hit since the last call
+	Hit        bool        `protobuf:"varint,1,opt,name=hit,proto3" json:"hit,omitempty"`
+	Watchpoint *Watchpoint `protobuf:"bytes,2,opt,name=watchpoint,proto3" json:"watchpoint,omitempty"`
+	Address    uint32      `protobuf:"varint,3,opt,name=address,proto3" json:"address,omitempty"`
+	Write      bool        `protobuf:"varint,4,opt,name=write,proto3" json:"write,omitempty"`
+	// Value before a write (only when has_old is set; registers cannot be read safely)
+	OldValue uint32 `protobuf:"varint,5,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	HasOld   bool   `protobuf:"varint,6,opt,name=has_old,json=hasOld,proto3" json:"has_old,omitempty"`
+	// Value written or read
+	NewValue uint32 `protobuf:"varint,7,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	// Instruction that made the access and the frame it happened in
+	Pc            uint32 `protobuf:"varint,8,opt,name=pc,proto3" json:"pc,omitempty"`
+	Frame         uint64 `protobuf:"varint,9,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchHit) Reset() {
+	*x = WatchHit{}
+	mi := &file_api_v1_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchHit) ProtoMessage() {}
+
+func (x *WatchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchHit.ProtoReflect.Descriptor instead.
+func (*WatchHit) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WatchHit) GetHit() bool {
+	if x != nil {
+		return x.Hit
+	}
+	return false
+}
+
+func (x *WatchHit) GetWatchpoint() *Watchpoint {
+	if x != nil {
+		return x.Watchpoint
+	}
+	return nil
+}
+
+func (x *WatchHit) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *WatchHit) GetWrite() bool {
+	if x != nil {
+		return x.Write
+	}
+	return false
+}
+
+func (x *WatchHit) GetOldValue() uint32 {
+	if x != nil {
+		return x.OldValue
+	}
+	return 0
+}
+
+func (x *WatchHit) GetHasOld() bool {
+	if x != nil {
+		return x.HasOld
+	}
+	return false
+}
+
+func (x *WatchHit) GetNewValue() uint32 {
+	if x != nil {
+		return x.NewValue
+	}
+	return 0
+}
+
+func (x *WatchHit) GetPc() uint32 {
+	if x != nil {
+		return x.Pc
+	}
+	return 0
+}
+
+func (x *WatchHit) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type MemoryBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryBlockResponse) Reset() {
+	*x = MemoryBlockResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryBlockResponse) ProtoMessage() {}
+
+func (x *MemoryBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryBlockResponse.ProtoReflect.Descriptor instead.
+func (*MemoryBlockResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MemoryBlockResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// EpisodeRequest re-inserts the current cartridge in its power-on state. Push a different
+// ROM with LoadROM first.
+type EpisodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional savestate blob (gob-encoded, as written by SaveState) to start the episode from
+	State         []byte `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EpisodeRequest) Reset() {
+	*x = EpisodeRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EpisodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EpisodeRequest) ProtoMessage() {}
+
+func (x *EpisodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EpisodeRequest.ProtoReflect.Descriptor instead.
+func (*EpisodeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *EpisodeRequest) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type ROMRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw .nes file contents (at most 2 MiB)
+	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ROMRequest) Reset() {
+	*x = ROMRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ROMRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ROMRequest) ProtoMessage() {}
+
+func (x *ROMRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ROMRequest.ProtoReflect.Descriptor instead.
+func (*ROMRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ROMRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type SessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionResponse) Reset() {
+	*x = SessionResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionResponse) ProtoMessage() {}
+
+func (x *SessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionResponse.ProtoReflect.Descriptor instead.
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type StepRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	P1    *InputState            `protobuf:"bytes,1,opt,name=p1,proto3" json:"p1,omitempty"`
+	P2    *InputState            `protobuf:"bytes,2,opt,name=p2,proto3" json:"p2,omitempty"`
+	// Number of frames to hold the inputs for (defaults to 1, at most 3600)
+	Frames        uint32 `protobuf:"varint,3,opt,name=frames,proto3" json:"frames,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepRequest) Reset() {
+	*x = StepRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepRequest) ProtoMessage() {}
+
+func (x *StepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepRequest.ProtoReflect.Descriptor instead.
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StepRequest) GetP1() *InputState {
+	if x != nil {
+		return x.P1
+	}
+	return nil
+}
+
+func (x *StepRequest) GetP2() *InputState {
+	if x != nil {
+		return x.P2
+	}
+	return nil
+}
+
+func (x *StepRequest) GetFrames() uint32 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+type Observation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw RGBA pixel data of the last completed frame
+	Pixels []byte `protobuf:"bytes,1,opt,name=pixels,proto3" json:"pixels,omitempty"`
+	// PPU frame counter at the time of the observation
+	Frame uint64 `protobuf:"varint,2,opt,name=frame,proto3" json:"frame,omitempty"`
+	// Values of the features registered with SetObservationSpec, keyed by name
+	Features      map[string]uint64 `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Observation) Reset() {
+	*x = Observation{}
+	mi := &file_api_v1_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Observation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Observation) ProtoMessage() {}
+
+func (x *Observation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Observation.ProtoReflect.Descriptor instead.
+func (*Observation) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Observation) GetPixels() []byte {
+	if x != nil {
+		return x.Pixels
+	}
+	return nil
+}
+
+func (x *Observation) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *Observation) GetFeatures() map[string]uint64 {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type ObservationFeature struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key the value is reported under, e.g. "mario_x"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// CPU bus address of the first byte, e.g. 0x006D
+	Address uint32 `protobuf:"varint,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Number of bytes (1-8, default 1), decoded as a little-endian unsigned integer
+	Length        uint32 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObservationFeature) Reset() {
+	*x = ObservationFeature{}
+	mi := &file_api_v1_messages_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObservationFeature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObservationFeature) ProtoMessage() {}
+
+func (x *ObservationFeature) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObservationFeature.ProtoReflect.Descriptor instead.
+func (*ObservationFeature) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ObservationFeature) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ObservationFeature) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *ObservationFeature) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type ObservationSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Features      []*ObservationFeature  `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObservationSpec) Reset() {
+	*x = ObservationSpec{}
+	mi := &file_api_v1_messages_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObservationSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObservationSpec) ProtoMessage() {}
+
+func (x *ObservationSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObservationSpec.ProtoReflect.Descriptor instead.
+func (*ObservationSpec) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ObservationSpec) GetFeatures() []*ObservationFeature {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type StateRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Filename string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Savestate bytes (from SaveState), used instead of filename when set
+	State         []byte `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateRequest) Reset() {
+	*x = StateRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateRequest) ProtoMessage() {}
+
+func (x *StateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateRequest.ProtoReflect.Descriptor instead.
+func (*StateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StateRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *StateRequest) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type StateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// Frame in progress when the snapshot was taken
+	Frame uint64 `protobuf:"varint,2,opt,name=frame,proto3" json:"frame,omitempty"`
+	// Hex SHA-1 of the loaded ROM image and the emulation core version
+	RomSha1       string `protobuf:"bytes,3,opt,name=rom_sha1,json=romSha1,proto3" json:"rom_sha1,omitempty"`
+	Emulator      string `protobuf:"bytes,4,opt,name=emulator,proto3" json:"emulator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateResponse) Reset() {
+	*x = StateResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateResponse) ProtoMessage() {}
+
+func (x *StateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateResponse.ProtoReflect.Descriptor instead.
+func (*StateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *StateResponse) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *StateResponse) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *StateResponse) GetRomSha1() string {
+	if x != nil {
+		return x.RomSha1
+	}
+	return ""
+}
+
+func (x *StateResponse) GetEmulator() string {
+	if x != nil {
+		return x.Emulator
+	}
+	return ""
+}
+
+type InputState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Player 1 or 2
+	PlayerIndex int32 `protobuf:"varint,1,opt,name=player_index,json=playerIndex,proto3" json:"player_index,omitempty"`
+	// NES Controller Buttons
+	A      bool `protobuf:"varint,2,opt,name=a,proto3" json:"a,omitempty"`
+	B      bool `protobuf:"varint,3,opt,name=b,proto3" json:"b,omitempty"`
+	Select bool `protobuf:"varint,4,opt,name=select,proto3" json:"select,omitempty"`
+	Start  bool `protobuf:"varint,5,opt,name=start,proto3" json:"start,omitempty"`
+	Up     bool `protobuf:"varint,6,opt,name=up,proto3" json:"up,omitempty"`
+	Down   bool `protobuf:"varint,7,opt,name=down,proto3" json:"down,omitempty"`
+	Left   bool `protobuf:"varint,8,opt,name=left,proto3" json:"left,omitempty"`
+	Right  bool `protobuf:"varint,9,opt,name=right,proto3" json:"right,omitempty"`
+	// When set, the state is buffered and latched at the start of this PPU frame (see
+	// GetFrameHash for the current frame) instead of applying on arrival, so remote input
+	// is immune to network jitter. States for frames already past apply at the next frame.
+	TargetFrame   *uint64 `protobuf:"varint,10,opt,name=target_frame,json=targetFrame,proto3,oneof" json:"target_frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputState) Reset() {
+	*x = InputState{}
+	mi := &file_api_v1_messages_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputState) ProtoMessage() {}
+
+func (x *InputState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputState.ProtoReflect.Descriptor instead.
+func (*InputState) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *InputState) GetPlayerIndex() int32 {
+	if x != nil {
+		return x.PlayerIndex
+	}
+	return 0
+}
+
+func (x *InputState) GetA() bool {
+	if x != nil {
+		return x.A
+	}
+	return false
+}
+
+func (x *InputState) GetB() bool {
+	if x != nil {
+		return x.B
+	}
+	return false
+}
+
+func (x *InputState) GetSelect() bool {
+	if x != nil {
+		return x.Select
+	}
+	return false
+}
+
+func (x *InputState) GetStart() bool {
+	if x != nil {
+		return x.Start
+	}
+	return false
+}
+
+func (x *InputState) GetUp() bool {
+	if x != nil {
+		return x.Up
+	}
+	return false
+}
+
+func (x *InputState) GetDown() bool {
+	if x != nil {
+		return x.Down
+	}
+	return false
+}
+
+func (x *InputState) GetLeft() bool {
+	if x != nil {
+		return x.Left
+	}
+	return false
+}
+
+func (x *InputState) GetRight() bool {
+	if x != nil {
+		return x.Right
+	}
+	return false
+}
+
+func (x *InputState) GetTargetFrame() uint64 {
+	if x != nil && x.TargetFrame != nil {
+		return *x.TargetFrame
+	}
+	return 0
+}
+
+type RunUntilRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Condition     StopCondition          `protobuf:"varint,1,opt,name=condition,proto3,enum=vibemulator.v1.StopCondition" json:"condition,omitempty"`
+	Address       uint32                 `protobuf:"varint,2,opt,name=address,proto3" json:"address,omitempty"`
+	Scanline      int32                  `protobuf:"varint,3,opt,name=scanline,proto3" json:"scanline,omitempty"`
+	Frame         uint64                 `protobuf:"varint,4,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunUntilRequest) Reset() {
+	*x = RunUntilRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunUntilRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunUntilRequest) ProtoMessage() {}
+
+func (x *RunUntilRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunUntilRequest.ProtoReflect.Descriptor instead.
+func (*RunUntilRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RunUntilRequest) GetCondition() StopCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return StopCondition_STOP_AT_ADDRESS
+}
+
+func (x *RunUntilRequest) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *RunUntilRequest) GetScanline() int32 {
+	if x != nil {
+		return x.Scanline
+	}
+	return 0
+}
+
+func (x *RunUntilRequest) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type RunUntilResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when a watchpoint or Pause stopped the emulator first
+	Reached bool `protobuf:"varint,1,opt,name=reached,proto3" json:"reached,omitempty"`
+	// Where the emulator stopped. Raster conditions stop on the first instruction
+	// boundary at or after the target, so dot may be a few cycles past it.
+	Pc            uint32 `protobuf:"varint,2,opt,name=pc,proto3" json:"pc,omitempty"`
+	Scanline      int32  `protobuf:"varint,3,opt,name=scanline,proto3" json:"scanline,omitempty"`
+	Dot           uint32 `protobuf:"varint,4,opt,name=dot,proto3" json:"dot,omitempty"`
+	Frame         uint64 `protobuf:"varint,5,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunUntilResponse) Reset() {
+	*x = RunUntilResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunUntilResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunUntilResponse) ProtoMessage() {}
+
+func (x *RunUntilResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunUntilResponse.ProtoReflect.Descriptor instead.
+func (*RunUntilResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RunUntilResponse) GetReached() bool {
+	if x != nil {
+		return x.Reached
+	}
+	return false
+}
+
+func (x *RunUntilResponse) GetPc() uint32 {
+	if x != nil {
+		return x.Pc
+	}
+	return 0
+}
+
+func (x *RunUntilResponse) GetScanline() int32 {
+	if x != nil {
+		return x.Scanline
+	}
+	return 0
+}
+
+func (x *RunUntilResponse) GetDot() uint32 {
+	if x != nil {
+		return x.Dot
+	}
+	return 0
+}
+
+func (x *RunUntilResponse) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type FrameRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Encoding FrameEncoding          `protobuf:"varint,1,opt,name=encoding,proto3,enum=vibemulator.v1.FrameEncoding" json:"encoding,omitempty"`
+	// Integer factor to shrink the frame by (0 or 1 keeps full size). Pixels are box-averaged.
+	Downscale uint32 `protobuf:"varint,2,opt,name=downscale,proto3" json:"downscale,omitempty"`
+	// Drops the top and bottom 8 scanlines most TVs hide, giving a 256x224 frame
+	CropOverscan bool `protobuf:"varint,3,opt,name=crop_overscan,json=cropOverscan,proto3" json:"crop_overscan,omitempty"`
+	// Explicit output size (e.g. 84x84). Overrides downscale when both are set.
+	Width         uint32 `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        uint32 `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameRequest) Reset() {
+	*x = FrameRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameRequest) ProtoMessage() {}
+
+func (x *FrameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameRequest.ProtoReflect.Descriptor instead.
+func (*FrameRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *FrameRequest) GetEncoding() FrameEncoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return FrameEncoding_FRAME_ENCODING_RGBA
+}
+
+func (x *FrameRequest) GetDownscale() uint32 {
+	if x != nil {
+		return x.Downscale
+	}
+	return 0
+}
+
+func (x *FrameRequest) GetCropOverscan() bool {
+	if x != nil {
+		return x.CropOverscan
+	}
+	return false
+}
+
+func (x *FrameRequest) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *FrameRequest) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type FrameResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pixel data in the requested encoding, row-major
+	Pixels []byte `protobuf:"bytes,1,opt,name=pixels,proto3" json:"pixels,omitempty"`
+	// Dimensions of the returned image
+	Width    uint32        `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height   uint32        `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Encoding FrameEncoding `protobuf:"varint,4,opt,name=encoding,proto3,enum=vibemulator.v1.FrameEncoding" json:"encoding,omitempty"`
+	// Frame number and raw-frame hash (StreamFrames only)
+	Frame         uint64 `protobuf:"varint,5,opt,name=frame,proto3" json:"frame,omitempty"`
+	Hash          uint64 `protobuf:"varint,6,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameResponse) Reset() {
+	*x = FrameResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameResponse) ProtoMessage() {}
+
+func (x *FrameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameResponse.ProtoReflect.Descriptor instead.
+func (*FrameResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *FrameResponse) GetPixels() []byte {
+	if x != nil {
+		return x.Pixels
+	}
+	return nil
+}
+
+func (x *FrameResponse) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *FrameResponse) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *FrameResponse) GetEncoding() FrameEncoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return FrameEncoding_FRAME_ENCODING_RGBA
+}
+
+func (x *FrameResponse) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *FrameResponse) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+type SpectateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How many frames behind the live game updates are released (0 sends immediately)
+	DelayFrames uint32 `protobuf:"varint,1,opt,name=delay_frames,json=delayFrames,proto3" json:"delay_frames,omitempty"`
+	// Also send each frame's image, encoded as described by format
+	Video         bool          `protobuf:"varint,2,opt,name=video,proto3" json:"video,omitempty"`
+	Format        *FrameRequest `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateRequest) Reset() {
+	*x = SpectateRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateRequest) ProtoMessage() {}
+
+func (x *SpectateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateRequest.ProtoReflect.Descriptor instead.
+func (*SpectateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SpectateRequest) GetDelayFrames() uint32 {
+	if x != nil {
+		return x.DelayFrames
+	}
+	return 0
+}
+
+func (x *SpectateRequest) GetVideo() bool {
+	if x != nil {
+		return x.Video
+	}
+	return false
+}
+
+func (x *SpectateRequest) GetFormat() *FrameRequest {
+	if x != nil {
+		return x.Format
+	}
+	return nil
+}
+
+type SpectatorUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Frame uint64                 `protobuf:"varint,1,opt,name=frame,proto3" json:"frame,omitempty"`
+	// Controller states latched at the start of the frame
+	P1 *InputState `protobuf:"bytes,2,opt,name=p1,proto3" json:"p1,omitempty"`
+	P2 *InputState `protobuf:"bytes,3,opt,name=p2,proto3" json:"p2,omitempty"`
+	// Hash of the last completed frame at that point, for desync checks
+	Hash uint64 `protobuf:"varint,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Frame image when video was requested
+	Video         *FrameResponse `protobuf:"bytes,5,opt,name=video,proto3" json:"video,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectatorUpdate) Reset() {
+	*x = SpectatorUpdate{}
+	mi := &file_api_v1_messages_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectatorUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectatorUpdate) ProtoMessage() {}
+
+func (x *SpectatorUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectatorUpdate.ProtoReflect.Descriptor instead.
+func (*SpectatorUpdate) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SpectatorUpdate) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *SpectatorUpdate) GetP1() *InputState {
+	if x != nil {
+		return x.P1
+	}
+	return nil
+}
+
+func (x *SpectatorUpdate) GetP2() *InputState {
+	if x != nil {
+		return x.P2
+	}
+	return nil
+}
+
+func (x *SpectatorUpdate) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *SpectatorUpdate) GetVideo() *FrameResponse {
+	if x != nil {
+		return x.Video
+	}
+	return nil
+}
+
+type FrameHashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          uint64                 `protobuf:"varint,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Frame         uint64                 `protobuf:"varint,2,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameHashResponse) Reset() {
+	*x = FrameHashResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameHashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameHashResponse) ProtoMessage() {}
+
+func (x *FrameHashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameHashResponse.ProtoReflect.Descriptor instead.
+func (*FrameHashResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FrameHashResponse) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *FrameHashResponse) GetFrame() uint64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+type StreamFramesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Encoding and sizing of each streamed frame
+	Format *FrameRequest `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// Send only the frame number and hash, leaving pixels empty
+	HashesOnly    bool `protobuf:"varint,2,opt,name=hashes_only,json=hashesOnly,proto3" json:"hashes_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamFramesRequest) Reset() {
+	*x = StreamFramesRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFramesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFramesRequest) ProtoMessage() {}
+
+func (x *StreamFramesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFramesRequest.ProtoReflect.Descriptor instead.
+func (*StreamFramesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *StreamFramesRequest) GetFormat() *FrameRequest {
+	if x != nil {
+		return x.Format
+	}
+	return nil
+}
+
+func (x *StreamFramesRequest) GetHashesOnly() bool {
+	if x != nil {
+		return x.HashesOnly
+	}
+	return false
+}
+
+type MemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       uint32                 `protobuf:"varint,1,opt,name=address,proto3" json:"address,omitempty"`
+	SideEffects   bool                   `protobuf:"varint,2,opt,name=side_effects,json=sideEffects,proto3" json:"side_effects,omitempty"` // As in MemoryBlockRequest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryRequest) Reset() {
+	*x = MemoryRequest{}
+	mi := &file_api_v1_messages_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryRequest) ProtoMessage() {}
+
+func (x *MemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryRequest.ProtoReflect.Descriptor instead.
+func (*MemoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MemoryRequest) GetAddress() uint32 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *MemoryRequest) GetSideEffects() bool {
+	if x != nil {
+		return x.SideEffects
+	}
+	return false
+}
+
+type MemoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          uint32                 `protobuf:"varint,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryResponse) Reset() {
+	*x = MemoryResponse{}
+	mi := &file_api_v1_messages_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryResponse) ProtoMessage() {}
+
+func (x *MemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryResponse.ProtoReflect.Descriptor instead.
+func (*MemoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *MemoryResponse) GetData() uint32 {
+	if x != nil {
+		return x.Data
+	}
+	return 0
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_api_v1_messages_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_messages_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_api_v1_messages_proto_rawDescGZIP(), []int{54}
+}
+
+var File_api_v1_messages_proto protoreflect.FileDescriptor
+
+const file_api_v1_messages_proto_rawDesc = "" +
+	"\n" +
+	"\x15api/v1/messages.proto\x12\x0evibemulator.v1\"\x8e\x01\n" +
+	"\fMemoryRegion\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\rR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\rR\x03end\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06mirror\x18\x04 \x01(\rR\x06mirror\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04bank\x18\x06 \x01(\x05R\x04bank\"C\n" +
+	"\tMemoryMap\x126\n" +
+	"\aregions\x18\x01 \x03(\v2\x1c.vibemulator.v1.MemoryRegionR\aregions\"\xc5\x02\n" +
+	"\vPacingStats\x12\x16\n" +
+	"\x06frames\x18\x01 \x01(\rR\x06frames\x12(\n" +
+	"\x10mean_interval_us\x18\x02 \x01(\x03R\x0emeanIntervalUs\x12\x1b\n" +
+	"\tjitter_us\x18\x03 \x01(\x03R\bjitterUs\x12&\n" +
+	"\x0fmax_interval_us\x18\x04 \x01(\x03R\rmaxIntervalUs\x12\x18\n" +
+	"\adropped\x18\x05 \x01(\x04R\adropped\x12\x1e\n" +
+	"\n" +
+	"duplicated\x18\x06 \x01(\x04R\n" +
+	"duplicated\x12&\n" +
+	"\x0faudio_queued_us\x18\a \x01(\x03R\raudioQueuedUs\x12$\n" +
+	"\x0eaudio_drift_us\x18\b \x01(\x03R\faudioDriftUs\x12'\n" +
+	"\x0faudio_underruns\x18\t \x01(\x04R\x0eaudioUnderruns\"\x85\x01\n" +
+	"\tLogLevels\x12=\n" +
+	"\x06levels\x18\x01 \x03(\v2%.vibemulator.v1.LogLevels.LevelsEntryR\x06levels\x1a9\n" +
+	"\vLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x02\n" +
+	"\x10CPUStateResponse\x12\x0e\n" +
+	"\x02pc\x18\x01 \x01(\rR\x02pc\x12\x0e\n" +
+	"\x02sp\x18\x02 \x01(\rR\x02sp\x12\f\n" +
+	"\x01a\x18\x03 \x01(\rR\x01a\x12\f\n" +
+	"\x01x\x18\x04 \x01(\rR\x01x\x12\f\n" +
+	"\x01y\x18\x05 \x01(\rR\x01y\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\rR\x06status\x12\x16\n" +
+	"\x06cycles\x18\a \x01(\rR\x06cycles\x12\x14\n" +
+	"\x05frame\x18\b \x01(\x04R\x05frame\x12\x1a\n" +
+	"\bscanline\x18\t \x01(\x05R\bscanline\x12\x10\n" +
+	"\x03dot\x18\n" +
+	" \x01(\rR\x03dot\x12\x1d\n" +
+	"\n" +
+	"ppu_cycles\x18\v \x01(\x04R\tppuCycles\x12\x1d\n" +
+	"\n" +
+	"cpu_cycles\x18\f \x01(\x04R\tcpuCycles\x12\x16\n" +
+	"\x06jammed\x18\r \x01(\bR\x06jammed\"\x93\x02\n" +
+	"\x10PPUStateResponse\x12\x12\n" +
+	"\x04ctrl\x18\x01 \x01(\rR\x04ctrl\x12\x12\n" +
+	"\x04mask\x18\x02 \x01(\rR\x04mask\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\rR\x06status\x12\x19\n" +
+	"\boam_addr\x18\x04 \x01(\rR\aoamAddr\x12\f\n" +
+	"\x01v\x18\x05 \x01(\rR\x01v\x12\f\n" +
+	"\x01t\x18\x06 \x01(\rR\x01t\x12\x15\n" +
+	"\x06fine_x\x18\a \x01(\rR\x05fineX\x12\f\n" +
+	"\x01w\x18\b \x01(\bR\x01w\x12\x1a\n" +
+	"\bscanline\x18\t \x01(\x05R\bscanline\x12\x10\n" +
+	"\x03dot\x18\n" +
+	" \x01(\rR\x03dot\x12\x14\n" +
+	"\x05frame\x18\v \x01(\x04R\x05frame\x12\x1f\n" +
+	"\vnmi_pending\x18\f \x01(\bR\n" +
+	"nmiPending\"\x91\x01\n" +
+	"\n" +
+	"APUChannel\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\rR\x06period\x12%\n" +
+	"\x0elength_counter\x18\x03 \x01(\rR\rlengthCounter\x12\x12\n" +
+	"\x04halt\x18\x04 \x01(\bR\x04halt\x12\x16\n" +
+	"\x06volume\x18\x05 \x01(\rR\x06volume\"\xd7\x03\n" +
+	"\x10APUStateResponse\x122\n" +
+	"\x06pulse1\x18\x01 \x01(\v2\x1a.vibemulator.v1.APUChannelR\x06pulse1\x122\n" +
+	"\x06pulse2\x18\x02 \x01(\v2\x1a.vibemulator.v1.APUChannelR\x06pulse2\x126\n" +
+	"\btriangle\x18\x03 \x01(\v2\x1a.vibemulator.v1.APUChannelR\btriangle\x120\n" +
+	"\x05noise\x18\x04 \x01(\v2\x1a.vibemulator.v1.APUChannelR\x05noise\x12,\n" +
+	"\x03dmc\x18\x05 \x01(\v2\x1a.vibemulator.v1.APUChannelR\x03dmc\x12\x1f\n" +
+	"\vdmc_address\x18\x06 \x01(\rR\n" +
+	"dmcAddress\x12.\n" +
+	"\x13dmc_bytes_remaining\x18\a \x01(\rR\x11dmcBytesRemaining\x12\x1b\n" +
+	"\tfive_step\x18\b \x01(\bR\bfiveStep\x12\x1f\n" +
+	"\virq_inhibit\x18\t \x01(\bR\n" +
+	"irqInhibit\x12\x1b\n" +
+	"\tframe_irq\x18\n" +
+	" \x01(\bR\bframeIrq\x12\x17\n" +
+	"\admc_irq\x18\v \x01(\bR\x06dmcIrq\"e\n" +
+	"\x12MemoryBlockRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\rR\aaddress\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\rR\x04size\x12!\n" +
+	"\fside_effects\x18\x03 \x01(\bR\vsideEffects\"B\n" +
+	"\x12MemoryWriteRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\rR\aaddress\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"@\n" +
+	"\fMovieRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x14\n" +
+	"\x05movie\x18\x02 \x01(\fR\x05movie\"(\n" +
+	"\x10MovieSeekRequest\x12\x14\n" +
+	"\x05frame\x18\x01 \x01(\rR\x05frame\"\x9d\x01\n" +
+	"\rMovieResponse\x12\x14\n" +
+	"\x05movie\x18\x01 \x01(\fR\x05movie\x12\x16\n" +
+	"\x06frames\x18\x02 \x01(\rR\x06frames\x12\x1d\n" +
+	"\n" +
+	"frame_hash\x18\x03 \x01(\x04R\tframeHash\x12#\n" +
+	"\rrecorded_hash\x18\x04 \x01(\x04R\frecordedHash\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\rR\bposition\"{\n" +
+	"\x13PatternTableRequest\x12\x14\n" +
+	"\x05table\x18\x01 \x01(\rR\x05table\x12\x18\n" +
+	"\apalette\x18\x02 \x01(\rR\apalette\x124\n" +
+	"\x06format\x18\x03 \x01(\v2\x1c.vibemulator.v1.FrameRequestR\x06format\"Z\n" +
+	"\n" +
+	"Watchpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\rR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\rR\x03end\x12\x14\n" +
+	"\x05write\x18\x04 \x01(\bR\x05write\"m\n" +
+	"\x12DisassembleRequest\x12\x1d\n" +
+	"\aaddress\x18\x01 \x01(\rH\x00R\aaddress\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12\x16\n" +
+	"\x06before\x18\x03 \x01(\rR\x06beforeB\n" +
+	"\n" +
+	"\b_address\"m\n" +
+	"\vInstruction\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\rR\aaddress\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\fR\x05bytes\x12\x1a\n" +
+	"\bmnemonic\x18\x03 \x01(\tR\bmnemonic\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\tR\x04mode\"f\n" +
+	"\x13DisassembleResponse\x12?\n" +
+	"\finstructions\x18\x01 \x03(\v2\x1b.vibemulator.v1.InstructionR\finstructions\x12\x0e\n" +
+	"\x02pc\x18\x02 \x01(\rR\x02pc\"j\n" +
+	"\n" +
+	"Breakpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\rR\aaddress\x12\x1c\n" +
+	"\tcondition\x18\x03 \x01(\tR\tcondition\x12\x14\n" +
+	"\x05frame\x18\x04 \x01(\x04R\x05frame\"N\n" +
+	"\x0eBreakpointList\x12<\n" +
+	"\vbreakpoints\x18\x01 \x03(\v2\x1a.vibemulator.v1.BreakpointR\vbreakpoints\"s\n" +
+	"\rBreakpointHit\x12\x10\n" +
+	"\x03hit\x18\x01 \x01(\bR\x03hit\x12:\n" +
+	"\n" +
+	"breakpoint\x18\x02 \x01(\v2\x1a.vibemulator.v1.BreakpointR\n" +
+	"breakpoint\x12\x14\n" +
+	"\x05frame\x18\x03 \x01(\x04R\x05frame\"3\n" +
+	"\x0fEvaluateRequest\x12 \n" +
+	"\vexpressions\x18\x01 \x03(\tR\vexpressions\"L\n" +
+	"\x10EvaluateResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.vibemulator.v1.EvaluateResultR\aresults\"<\n" +
+	"\x0eEvaluateResult\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xb0\x01\n" +
+	"\x05Cheat\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\rR\aaddress\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\rR\x05value\x12\x1f\n" +
+	"\vhas_compare\x18\x05 \x01(\bR\n" +
+	"hasCompare\x12\x18\n" +
+	"\acompare\x18\x06 \x01(\rR\acompare\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\":\n" +
+	"\tCheatList\x12-\n" +
+	"\x06cheats\x18\x01 \x03(\v2\x15.vibemulator.v1.CheatR\x06cheats\" \n" +
+	"\n" +
+	"CDLRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x92\x01\n" +
+	"\tCDLReport\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x19\n" +
+	"\bprg_size\x18\x03 \x01(\rR\aprgSize\x12\x1d\n" +
+	"\n" +
+	"code_bytes\x18\x04 \x01(\rR\tcodeBytes\x12\x1d\n" +
+	"\n" +
+	"data_bytes\x18\x05 \x01(\rR\tdataBytes\">\n" +
+	"\fProfileEntry\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\rR\aaddress\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xd5\x01\n" +
+	"\rProfileReport\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12\"\n" +
+	"\finstructions\x18\x02 \x01(\x04R\finstructions\x12\x16\n" +
+	"\x06frames\x18\x03 \x01(\x04R\x06frames\x12.\n" +
+	"\x03pcs\x18\x04 \x03(\v2\x1c.vibemulator.v1.ProfileEntryR\x03pcs\x12>\n" +
+	"\vsubroutines\x18\x05 \x03(\v2\x1c.vibemulator.v1.ProfileEntryR\vsubroutines\"N\n" +
+	"\x0eWatchpointList\x12<\n" +
+	"\vwatchpoints\x18\x01 \x03(\v2\x1a.vibemulator.v1.WatchpointR\vwatchpoints\"\x81\x02\n" +
+	"\bWatchHit\x12\x10\n" +
+	"\x03hit\x18\x01 \x01(\bR\x03hit\x12:\n" +
+	"\n" +
+	"watchpoint\x18\x02 \x01(\v2\x1a.vibemulator.v1.WatchpointR\n" +
+	"watchpoint\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\rR\aaddress\x12\x14\n" +
+	"\x05write\x18\x04 \x01(\bR\x05write\x12\x1b\n" +
+	"\told_value\x18\x05 \x01(\rR\boldValue\x12\x17\n" +
+	"\ahas_old\x18\x06 \x01(\bR\x06hasOld\x12\x1b\n" +
+	"\tnew_value\x18\a \x01(\rR\bnewValue\x12\x0e\n" +
+	"\x02pc\x18\b \x01(\rR\x02pc\x12\x14\n" +
+	"\x05frame\x18\t \x01(\x04R\x05frame\")\n" +
+	"\x13MemoryBlockResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"6\n" +
+	"\x0eEpisodeRequest\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\fR\x05stateJ\x04\b\x01\x10\x02R\brom_path\" \n" +
+	"\n" +
+	"ROMRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"/\n" +
+	"\x0eSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"0\n" +
+	"\x0fSessionResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"}\n" +
+	"\vStepRequest\x12*\n" +
+	"\x02p1\x18\x01 \x01(\v2\x1a.vibemulator.v1.InputStateR\x02p1\x12*\n" +
+	"\x02p2\x18\x02 \x01(\v2\x1a.vibemulator.v1.InputStateR\x02p2\x12\x16\n" +
+	"\x06frames\x18\x03 \x01(\rR\x06frames\"\xbf\x01\n" +
+	"\vObservation\x12\x16\n" +
+	"\x06pixels\x18\x01 \x01(\fR\x06pixels\x12\x14\n" +
+	"\x05frame\x18\x02 \x01(\x04R\x05frame\x12E\n" +
+	"\bfeatures\x18\x03 \x03(\v2).vibemulator.v1.Observation.FeaturesEntryR\bfeatures\x1a;\n" +
+	"\rFeaturesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"Z\n" +
+	"\x12ObservationFeature\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\rR\aaddress\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\rR\x06length\"Q\n" +
+	"\x0fObservationSpec\x12>\n" +
+	"\bfeatures\x18\x01 \x03(\v2\".vibemulator.v1.ObservationFeatureR\bfeatures\"@\n" +
+	"\fStateRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\fR\x05state\"r\n" +
+	"\rStateResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\x12\x14\n" +
+	"\x05frame\x18\x02 \x01(\x04R\x05frame\x12\x19\n" +
+	"\brom_sha1\x18\x03 \x01(\tR\aromSha1\x12\x1a\n" +
+	"\bemulator\x18\x04 \x01(\tR\bemulator\"\x80\x02\n" +
+	"\n" +
+	"InputState\x12!\n" +
+	"\fplayer_index\x18\x01 \x01(\x05R\vplayerIndex\x12\f\n" +
+	"\x01a\x18\x02 \x01(\bR\x01a\x12\f\n" +
+	"\x01b\x18\x03 \x01(\bR\x01b\x12\x16\n" +
+	"\x06select\x18\x04 \x01(\bR\x06select\x12\x14\n" +
+	"\x05start\x18\x05 \x01(\bR\x05start\x12\x0e\n" +
+	"\x02up\x18\x06 \x01(\bR\x02up\x12\x12\n" +
+	"\x04down\x18\a \x01(\bR\x04down\x12\x12\n" +
+	"\x04left\x18\b \x01(\bR\x04left\x12\x14\n" +
+	"\x05right\x18\t \x01(\bR\x05right\x12&\n" +
+	"\ftarget_frame\x18\n" +
+	" \x01(\x04H\x00R\vtargetFrame\x88\x01\x01B\x0f\n" +
+	"\r_target_frame\"\x9a\x01\n" +
+	"\x0fRunUntilRequest\x12;\n" +
+	"\tcondition\x18\x01 \x01(\x0e2\x1d.vibemulator.v1.StopConditionR\tcondition\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\rR\aaddress\x12\x1a\n" +
+	"\bscanline\x18\x03 \x01(\x05R\bscanline\x12\x14\n" +
+	"\x05frame\x18\x04 \x01(\x04R\x05frame\"\x80\x01\n" +
+	"\x10RunUntilResponse\x12\x18\n" +
+	"\areached\x18\x01 \x01(\bR\areached\x12\x0e\n" +
+	"\x02pc\x18\x02 \x01(\rR\x02pc\x12\x1a\n" +
+	"\bscanline\x18\x03 \x01(\x05R\bscanline\x12\x10\n" +
+	"\x03dot\x18\x04 \x01(\rR\x03dot\x12\x14\n" +
+	"\x05frame\x18\x05 \x01(\x04R\x05frame\"\xba\x01\n" +
+	"\fFrameRequest\x129\n" +
+	"\bencoding\x18\x01 \x01(\x0e2\x1d.vibemulator.v1.FrameEncodingR\bencoding\x12\x1c\n" +
+	"\tdownscale\x18\x02 \x01(\rR\tdownscale\x12#\n" +
+	"\rcrop_overscan\x18\x03 \x01(\bR\fcropOverscan\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\rR\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\rR\x06height\"\xba\x01\n" +
+	"\rFrameResponse\x12\x16\n" +
+	"\x06pixels\x18\x01 \x01(\fR\x06pixels\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\rR\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\rR\x06height\x129\n" +
+	"\bencoding\x18\x04 \x01(\x0e2\x1d.vibemulator.v1.FrameEncodingR\bencoding\x12\x14\n" +
+	"\x05frame\x18\x05 \x01(\x04R\x05frame\x12\x12\n" +
+	"\x04hash\x18\x06 \x01(\x04R\x04hash\"\x80\x01\n" +
+	"\x0fSpectateRequest\x12!\n" +
+	"\fdelay_frames\x18\x01 \x01(\rR\vdelayFrames\x12\x14\n" +
+	"\x05video\x18\x02 \x01(\bR\x05video\x124\n" +
+	"\x06format\x18\x03 \x01(\v2\x1c.vibemulator.v1.FrameRequestR\x06format\"\xc8\x01\n" +
+	"\x0fSpectatorUpdate\x12\x14\n" +
+	"\x05frame\x18\x01 \x01(\x04R\x05frame\x12*\n" +
+	"\x02p1\x18\x02 \x01(\v2\x1a.vibemulator.v1.InputStateR\x02p1\x12*\n" +
+	"\x02p2\x18\x03 \x01(\v2\x1a.vibemulator.v1.InputStateR\x02p2\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\x04R\x04hash\x123\n" +
+	"\x05video\x18\x05 \x01(\v2\x1d.vibemulator.v1.FrameResponseR\x05video\"=\n" +
+	"\x11FrameHashResponse\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\x04R\x04hash\x12\x14\n" +
+	"\x05frame\x18\x02 \x01(\x04R\x05frame\"l\n" +
+	"\x13StreamFramesRequest\x124\n" +
+	"\x06format\x18\x01 \x01(\v2\x1c.vibemulator.v1.FrameRequestR\x06format\x12\x1f\n" +
+	"\vhashes_only\x18\x02 \x01(\bR\n" +
+	"hashesOnly\"L\n" +
+	"\rMemoryRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\rR\aaddress\x12!\n" +
+	"\fside_effects\x18\x02 \x01(\bR\vsideEffects\"$\n" +
+	"\x0eMemoryResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\rR\x04data\"\a\n" +
+	"\x05Empty*a\n" +
+	"\rStopCondition\x12\x13\n" +
+	"\x0fSTOP_AT_ADDRESS\x10\x00\x12\x14\n" +
+	"\x10STOP_AT_SCANLINE\x10\x01\x12\x12\n" +
+	"\x0eSTOP_AT_VBLANK\x10\x02\x12\x11\n" +
+	"\rSTOP_AT_FRAME\x10\x03*v\n" +
+	"\rFrameEncoding\x12\x17\n" +
+	"\x13FRAME_ENCODING_RGBA\x10\x00\x12\x16\n" +
+	"\x12FRAME_ENCODING_RGB\x10\x01\x12\x1c\n" +
+	"\x18FRAME_ENCODING_GRAYSCALE\x10\x02\x12\x16\n" +
+	"\x12FRAME_ENCODING_PNG\x10\x03B-Z+github.com/meadori/vibemulator/api/v1;apiv1b\x06proto3"
+
+var (
+	file_api_v1_messages_proto_rawDescOnce sync.Once
+	file_api_v1_messages_proto_rawDescData []byte
+)
+
+func file_api_v1_messages_proto_rawDescGZIP() []byte {
+	file_api_v1_messages_proto_rawDescOnce.Do(func() {
+		file_api_v1_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_messages_proto_rawDesc), len(file_api_v1_messages_proto_rawDesc)))
+	})
+	return file_api_v1_messages_proto_rawDescData
+}
+
+var file_api_v1_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
+var file_api_v1_messages_proto_goTypes = []any{
+	(StopCondition)(0),          // 0: vibemulator.v1.StopCondition
+	(FrameEncoding)(0),          // 1: vibemulator.v1.FrameEncoding
+	(*MemoryRegion)(nil),        // 2: vibemulator.v1.MemoryRegion
+	(*MemoryMap)(nil),           // 3: vibemulator.v1.MemoryMap
+	(*PacingStats)(nil),         // 4: vibemulator.v1.PacingStats
+	(*LogLevels)(nil),           // 5: vibemulator.v1.LogLevels
+	(*CPUStateResponse)(nil),    // 6: vibemulator.v1.CPUStateResponse
+	(*PPUStateResponse)(nil),    // 7: vibemulator.v1.PPUStateResponse
+	(*APUChannel)(nil),          // 8: vibemulator.v1.APUChannel
+	(*APUStateResponse)(nil),    // 9: vibemulator.v1.APUStateResponse
+	(*MemoryBlockRequest)(nil),  // 10: vibemulator.v1.MemoryBlockRequest
+	(*MemoryWriteRequest)(nil),  // 11: vibemulator.v1.MemoryWriteRequest
+	(*MovieRequest)(nil),        // 12: vibemulator.v1.MovieRequest
+	(*MovieSeekRequest)(nil),    // 13: vibemulator.v1.MovieSeekRequest
+	(*MovieResponse)(nil),       // 14: vibemulator.v1.MovieResponse
+	(*PatternTableRequest)(nil), // 15: vibemulator.v1.PatternTableRequest
+	(*Watchpoint)(nil),          // 16: vibemulator.v1.Watchpoint
+	(*DisassembleRequest)(nil),  // 17: vibemulator.v1.DisassembleRequest
+	(*Instruction)(nil),         // 18: vibemulator.v1.Instruction
+	(*DisassembleResponse)(nil), // 19: vibemulator.v1.DisassembleResponse
+	(*Breakpoint)(nil),          // 20: vibemulator.v1.Breakpoint
+	(*BreakpointList)(nil),      // 21: vibemulator.v1.BreakpointList
+	(*BreakpointHit)(nil),       // 22: vibemulator.v1.BreakpointHit
+	(*EvaluateRequest)(nil),     // 23: vibemulator.v1.EvaluateRequest
+	(*EvaluateResponse)(nil),    // 24: vibemulator.v1.EvaluateResponse
+	(*EvaluateResult)(nil),      // 25: vibemulator.v1.EvaluateResult
+	(*Cheat)(nil),               // 26: vibemulator.v1.Cheat
+	(*CheatList)(nil),           // 27: vibemulator.v1.CheatList
+	(*CDLRequest)(nil),          // 28: vibemulator.v1.CDLRequest
+	(*CDLReport)(nil),           // 29: vibemulator.v1.CDLReport
+	(*ProfileEntry)(nil),        // 30: vibemulator.v1.ProfileEntry
+	(*ProfileReport)(nil),       // 31: vibemulator.v1.ProfileReport
+	(*WatchpointList)(nil),      // 32: vibemulator.v1.WatchpointList
+	(*WatchHit)(nil),            // 33: vibemulator.v1.WatchHit
+	(*MemoryBlockResponse)(nil), // 34: vibemulator.v1.MemoryBlockResponse
+	(*EpisodeRequest)(nil),      // 35: vibemulator.v1.EpisodeRequest
+	(*ROMRequest)(nil),          // 36: vibemulator.v1.ROMRequest
+	(*SessionRequest)(nil),      // 37: vibemulator.v1.SessionRequest
+	(*SessionResponse)(nil),     // 38: vibemulator.v1.SessionResponse
+	(*StepRequest)(nil),         // 39: vibemulator.v1.StepRequest
+	(*Observation)(nil),         // 40: vibemulator.v1.Observation
+	(*ObservationFeature)(nil),  // 41: vibemulator.v1.ObservationFeature
+	(*ObservationSpec)(nil),     // 42: vibemulator.v1.ObservationSpec
+	(*StateRequest)(nil),        // 43: vibemulator.v1.StateRequest
+	(*StateResponse)(nil),       // 44: vibemulator.v1.StateResponse
+	(*InputState)(nil),          // 45: vibemulator.v1.InputState
+	(*RunUntilRequest)(nil),     // 46: vibemulator.v1.RunUntilRequest
+	(*RunUntilResponse)(nil),    // 47: vibemulator.v1.RunUntilResponse
+	(*FrameRequest)(nil),        // 48: vibemulator.v1.FrameRequest
+	(*FrameResponse)(nil),       // 49: vibemulator.v1.FrameResponse
+	(*SpectateRequest)(nil),     // 50: vibemulator.v1.SpectateRequest
+	(*SpectatorUpdate)(nil),     // 51: vibemulator.v1.SpectatorUpdate
+	(*FrameHashResponse)(nil),   // 52: vibemulator.v1.FrameHashResponse
+	(*StreamFramesRequest)(nil), // 53: vibemulator.v1.StreamFramesRequest
+	(*MemoryRequest)(nil),       // 54: vibemulator.v1.MemoryRequest
+	(*MemoryResponse)(nil),      // 55: vibemulator.v1.MemoryResponse
+	(*Empty)(nil),               // 56: vibemulator.v1.Empty
+	nil,                         // 57: vibemulator.v1.LogLevels.LevelsEntry
+	nil,                         // 58: vibemulator.v1.Observation.FeaturesEntry
+}
+var file_api_v1_messages_proto_depIdxs = []int32{
+	2,  // 0: vibemulator.v1.MemoryMap.regions:type_name -> vibemulator.v1.MemoryRegion
+	57, // 1: vibemulator.v1.LogLevels.levels:type_name -> vibemulator.v1.LogLevels.LevelsEntry
+	8,  // 2: vibemulator.v1.APUStateResponse.pulse1:type_name -> vibemulator.v1.APUChannel
+	8,  // 3: vibemulator.v1.APUStateResponse.pulse2:type_name -> vibemulator.v1.APUChannel
+	8,  // 4: vibemulator.v1.APUStateResponse.triangle:type_name -> vibemulator.v1.APUChannel
+	8,  // 5: vibemulator.v1.APUStateResponse.noise:type_name -> vibemulator.v1.APUChannel
+	8,  // 6: vibemulator.v1.APUStateResponse.dmc:type_name -> vibemulator.v1.APUChannel
+	48, // 7: vibemulator.v1.PatternTableRequest.format:type_name -> vibemulator.v1.FrameRequest
+	18, // 8: vibemulator.v1.DisassembleResponse.instructions:type_name -> vibemulator.v1.Instruction
+	20, // 9: vibemulator.v1.BreakpointList.breakpoints:type_name -> vibemulator.v1.Breakpoint
+	20, // 10: vibemulator.v1.BreakpointHit.breakpoint:type_name -> vibemulator.v1.Breakpoint
+	25, // 11: vibemulator.v1.EvaluateResponse.results:type_name -> vibemulator.v1.EvaluateResult
+	26, // 12: vibemulator.v1.CheatList.cheats:type_name -> vibemulator.v1.Cheat
+	30, // 13: vibemulator.v1.ProfileReport.pcs:type_name -> vibemulator.v1.ProfileEntry
+	30, // 14: vibemulator.v1.ProfileReport.subroutines:type_name -> vibemulator.v1.ProfileEntry
+	16, // 15: vibemulator.v1.WatchpointList.watchpoints:type_name -> vibemulator.v1.Watchpoint
+	16, // 16: vibemulator.v1.WatchHit.watchpoint:type_name -> vibemulator.v1.Watchpoint
+	45, // 17: vibemulator.v1.StepRequest.p1:type_name -> vibemulator.v1.InputState
+	45, // 18: vibemulator.v1.StepRequest.p2:type_name -> vibemulator.v1.InputState
+	58, // 19: vibemulator.v1.Observation.features:type_name -> vibemulator.v1.Observation.FeaturesEntry
+	41, // 20: vibemulator.v1.ObservationSpec.features:type_name -> vibemulator.v1.ObservationFeature
+	0,  // 21: vibemulator.v1.RunUntilRequest.condition:type_name -> vibemulator.v1.StopCondition
+	1,  // 22: vibemulator.v1.FrameRequest.encoding:type_name -> vibemulator.v1.FrameEncoding
+	1,  // 23: vibemulator.v1.FrameResponse.encoding:type_name -> vibemulator.v1.FrameEncoding
+	48, // 24: vibemulator.v1.SpectateRequest.format:type_name -> vibemulator.v1.FrameRequest
+	45, // 25: vibemulator.v1.SpectatorUpdate.p1:type_name -> vibemulator.v1.InputState
+	45, // 26: vibemulator.v1.SpectatorUpdate.p2:type_name -> vibemulator.v1.InputState
+	49, // 27: vibemulator.v1.SpectatorUpdate.video:type_name -> vibemulator.v1.FrameResponse
+	48, // 28: vibemulator.v1.StreamFramesRequest.format:type_name -> vibemulator.v1.FrameRequest
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_messages_proto_init() }
+func file_api_v1_messages_proto_init() {
+	if File_api_v1_messages_proto != nil {
+		return
+	}
+	file_api_v1_messages_proto_msgTypes[15].OneofWrappers = []any{}
+	file_api_v1_messages_proto_msgTypes[43].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_messages_proto_rawDesc), len(file_api_v1_messages_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   57,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_v1_messages_proto_goTypes,
+		DependencyIndexes: file_api_v1_messages_proto_depIdxs,
+		EnumInfos:         file_api_v1_messages_proto_enumTypes,
+		MessageInfos:      file_api_v1_messages_proto_msgTypes,
+	}.Build()
+	File_api_v1_messages_proto = out.File
+	file_api_v1_messages_proto_goTypes = nil
+	file_api_v1_messages_proto_depIdxs = nil
+}
@@ -0,0 +1,307 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: api/v1/services.proto
+
+// The emulator's RPCs split by what clients use them for. Their messages are in
+// messages.proto, in this package, so the whole of v1 is versioned together. The server
+// still serves ControllerService for existing clients.
+
+package apiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_api_v1_services_proto protoreflect.FileDescriptor
+
+const file_api_v1_services_proto_rawDesc = "" +
+	"\n" +
+	"\x15api/v1/services.proto\x12\x0evibemulator.v1\x1a\x15api/v1/messages.proto2\xfe\x05\n" +
+	"\fInputService\x12F\n" +
+	"\vStreamInput\x12\x1a.vibemulator.v1.InputState\x1a\x15.vibemulator.v1.Empty\"\x00(\x010\x01\x12@\n" +
+	"\x0eStartRecording\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x12N\n" +
+	"\rStopRecording\x12\x1c.vibemulator.v1.MovieRequest\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12J\n" +
+	"\tPlayMovie\x12\x1c.vibemulator.v1.MovieRequest\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12J\n" +
+	"\tEditMovie\x12\x1c.vibemulator.v1.MovieRequest\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12N\n" +
+	"\tSeekMovie\x12 .vibemulator.v1.MovieSeekRequest\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12G\n" +
+	"\rTruncateMovie\x12\x15.vibemulator.v1.Empty\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12P\n" +
+	"\x10InsertMovieInput\x12\x1b.vibemulator.v1.StepRequest\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12J\n" +
+	"\tSaveMovie\x12\x1c.vibemulator.v1.MovieRequest\x1a\x1d.vibemulator.v1.MovieResponse\"\x00\x12E\n" +
+	"\vMovieStatus\x12\x15.vibemulator.v1.Empty\x1a\x1d.vibemulator.v1.MovieResponse\"\x002\x97\x03\n" +
+	"\fVideoService\x12I\n" +
+	"\bGetFrame\x12\x1c.vibemulator.v1.FrameRequest\x1a\x1d.vibemulator.v1.FrameResponse\"\x00\x12J\n" +
+	"\fGetFrameHash\x12\x15.vibemulator.v1.Empty\x1a!.vibemulator.v1.FrameHashResponse\"\x00\x12V\n" +
+	"\fStreamFrames\x12#.vibemulator.v1.StreamFramesRequest\x1a\x1d.vibemulator.v1.FrameResponse\"\x000\x01\x12P\n" +
+	"\bSpectate\x12\x1f.vibemulator.v1.SpectateRequest\x1a\x1f.vibemulator.v1.SpectatorUpdate\"\x000\x01\x12F\n" +
+	"\x0eGetPacingStats\x12\x15.vibemulator.v1.Empty\x1a\x1b.vibemulator.v1.PacingStats\"\x002\xe3\x05\n" +
+	"\fStateService\x12M\n" +
+	"\n" +
+	"ReadMemory\x12\x1d.vibemulator.v1.MemoryRequest\x1a\x1e.vibemulator.v1.MemoryResponse\"\x00\x12B\n" +
+	"\tLoadState\x12\x1c.vibemulator.v1.StateRequest\x1a\x15.vibemulator.v1.Empty\"\x00\x12C\n" +
+	"\tSaveState\x12\x15.vibemulator.v1.Empty\x1a\x1d.vibemulator.v1.StateResponse\"\x00\x12=\n" +
+	"\vResetSystem\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x12M\n" +
+	"\fResetEpisode\x12\x1e.vibemulator.v1.EpisodeRequest\x1a\x1b.vibemulator.v1.Observation\"\x00\x12G\n" +
+	"\tStepFrame\x12\x1b.vibemulator.v1.StepRequest\x1a\x1b.vibemulator.v1.Observation\"\x00\x12N\n" +
+	"\x12SetObservationSpec\x12\x1f.vibemulator.v1.ObservationSpec\x1a\x15.vibemulator.v1.Empty\"\x00\x12>\n" +
+	"\aLoadROM\x12\x1a.vibemulator.v1.ROMRequest\x1a\x15.vibemulator.v1.Empty\"\x00\x12I\n" +
+	"\rCreateSession\x12\x15.vibemulator.v1.Empty\x1a\x1f.vibemulator.v1.SessionResponse\"\x00\x12I\n" +
+	"\x0eDestroySession\x12\x1e.vibemulator.v1.SessionRequest\x1a\x15.vibemulator.v1.Empty\"\x002\x84\x15\n" +
+	"\fDebugService\x127\n" +
+	"\x05Pause\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x128\n" +
+	"\x06Resume\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x126\n" +
+	"\x04Step\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x12>\n" +
+	"\fAdvanceFrame\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x12O\n" +
+	"\bRunUntil\x12\x1f.vibemulator.v1.RunUntilRequest\x1a .vibemulator.v1.RunUntilResponse\"\x00\x12H\n" +
+	"\vGetCPUState\x12\x15.vibemulator.v1.Empty\x1a .vibemulator.v1.CPUStateResponse\"\x00\x12\\\n" +
+	"\x0fReadMemoryBlock\x12\".vibemulator.v1.MemoryBlockRequest\x1a#.vibemulator.v1.MemoryBlockResponse\"\x00\x12O\n" +
+	"\x10WriteMemoryBlock\x12\".vibemulator.v1.MemoryWriteRequest\x1a\x15.vibemulator.v1.Empty\"\x00\x12I\n" +
+	"\rAddWatchpoint\x12\x1a.vibemulator.v1.Watchpoint\x1a\x1a.vibemulator.v1.Watchpoint\"\x00\x12G\n" +
+	"\x10RemoveWatchpoint\x12\x1a.vibemulator.v1.Watchpoint\x1a\x15.vibemulator.v1.Empty\"\x00\x12J\n" +
+	"\x0fListWatchpoints\x12\x15.vibemulator.v1.Empty\x1a\x1e.vibemulator.v1.WatchpointList\"\x00\x12@\n" +
+	"\vGetWatchHit\x12\x15.vibemulator.v1.Empty\x1a\x18.vibemulator.v1.WatchHit\"\x00\x12I\n" +
+	"\rAddBreakpoint\x12\x1a.vibemulator.v1.Breakpoint\x1a\x1a.vibemulator.v1.Breakpoint\"\x00\x12G\n" +
+	"\x10RemoveBreakpoint\x12\x1a.vibemulator.v1.Breakpoint\x1a\x15.vibemulator.v1.Empty\"\x00\x12J\n" +
+	"\x0fListBreakpoints\x12\x15.vibemulator.v1.Empty\x1a\x1e.vibemulator.v1.BreakpointList\"\x00\x12J\n" +
+	"\x10GetBreakpointHit\x12\x15.vibemulator.v1.Empty\x1a\x1d.vibemulator.v1.BreakpointHit\"\x00\x12:\n" +
+	"\bAddCheat\x12\x15.vibemulator.v1.Cheat\x1a\x15.vibemulator.v1.Cheat\"\x00\x12@\n" +
+	"\n" +
+	"ListCheats\x12\x15.vibemulator.v1.Empty\x1a\x19.vibemulator.v1.CheatList\"\x00\x12A\n" +
+	"\x0fSetCheatEnabled\x12\x15.vibemulator.v1.Cheat\x1a\x15.vibemulator.v1.Cheat\"\x00\x12O\n" +
+	"\bEvaluate\x12\x1f.vibemulator.v1.EvaluateRequest\x1a .vibemulator.v1.EvaluateResponse\"\x00\x12>\n" +
+	"\fStartProfile\x12\x15.vibemulator.v1.Empty\x1a\x15.vibemulator.v1.Empty\"\x00\x12E\n" +
+	"\vStopProfile\x12\x15.vibemulator.v1.Empty\x1a\x1d.vibemulator.v1.ProfileReport\"\x00\x12D\n" +
+	"\n" +
+	"GetProfile\x12\x15.vibemulator.v1.Empty\x1a\x1d.vibemulator.v1.ProfileReport\"\x00\x12?\n" +
+	"\bStartCDL\x12\x1a.vibemulator.v1.CDLRequest\x1a\x15.vibemulator.v1.Empty\"\x00\x12=\n" +
+	"\aStopCDL\x12\x15.vibemulator.v1.Empty\x1a\x19.vibemulator.v1.CDLReport\"\x00\x12<\n" +
+	"\x06GetCDL\x12\x15.vibemulator.v1.Empty\x1a\x19.vibemulator.v1.CDLReport\"\x00\x12X\n" +
+	"\vDisassemble\x12\".vibemulator.v1.DisassembleRequest\x1a#.vibemulator.v1.DisassembleResponse\"\x00\x12B\n" +
+	"\fGetMemoryMap\x12\x15.vibemulator.v1.Empty\x1a\x19.vibemulator.v1.MemoryMap\"\x00\x12N\n" +
+	"\x0eReadNametables\x12\x15.vibemulator.v1.Empty\x1a#.vibemulator.v1.MemoryBlockResponse\"\x00\x12H\n" +
+	"\vGetPPUState\x12\x15.vibemulator.v1.Empty\x1a .vibemulator.v1.PPUStateResponse\"\x00\x12H\n" +
+	"\vGetAPUState\x12\x15.vibemulator.v1.Empty\x1a .vibemulator.v1.APUStateResponse\"\x00\x12G\n" +
+	"\aReadOAM\x12\x15.vibemulator.v1.Empty\x1a#.vibemulator.v1.MemoryBlockResponse\"\x00\x12K\n" +
+	"\vReadPalette\x12\x15.vibemulator.v1.Empty\x1a#.vibemulator.v1.MemoryBlockResponse\"\x00\x12\\\n" +
+	"\x14GetPatternTableImage\x12#.vibemulator.v1.PatternTableRequest\x1a\x1d.vibemulator.v1.FrameResponse\"\x00\x12R\n" +
+	"\x11GetNametableImage\x12\x1c.vibemulator.v1.FrameRequest\x1a\x1d.vibemulator.v1.FrameResponse\"\x00\x12B\n" +
+	"\fGetLogLevels\x12\x15.vibemulator.v1.Empty\x1a\x19.vibemulator.v1.LogLevels\"\x00\x12F\n" +
+	"\fSetLogLevels\x12\x19.vibemulator.v1.LogLevels\x1a\x19.vibemulator.v1.LogLevels\"\x00B-Z+github.com/meadori/vibemulator/api/v1;apiv1b\x06proto3"
+
+var file_api_v1_services_proto_goTypes = []any{
+	(*InputState)(nil),          // 0: vibemulator.v1.InputState
+	(*Empty)(nil),               // 1: vibemulator.v1.Empty
+	(*MovieRequest)(nil),        // 2: vibemulator.v1.MovieRequest
+	(*MovieSeekRequest)(nil),    // 3: vibemulator.v1.MovieSeekRequest
+	(*StepRequest)(nil),         // 4: vibemulator.v1.StepRequest
+	(*FrameRequest)(nil),        // 5: vibemulator.v1.FrameRequest
+	(*StreamFramesRequest)(nil), // 6: vibemulator.v1.StreamFramesRequest
+	(*SpectateRequest)(nil),     // 7: vibemulator.v1.SpectateRequest
+	(*MemoryRequest)(nil),       // 8: vibemulator.v1.MemoryRequest
+	(*StateRequest)(nil),        // 9: vibemulator.v1.StateRequest
+	(*EpisodeRequest)(nil),      // 10: vibemulator.v1.EpisodeRequest
+	(*ObservationSpec)(nil),     // 11: vibemulator.v1.ObservationSpec
+	(*ROMRequest)(nil),          // 12: vibemulator.v1.ROMRequest
+	(*SessionRequest)(nil),      // 13: vibemulator.v1.SessionRequest
+	(*RunUntilRequest)(nil),     // 14: vibemulator.v1.RunUntilRequest
+	(*MemoryBlockRequest)(nil),  // 15: vibemulator.v1.MemoryBlockRequest
+	(*MemoryWriteRequest)(nil),  // 16: vibemulator.v1.MemoryWriteRequest
+	(*Watchpoint)(nil),          // 17: vibemulator.v1.Watchpoint
+	(*Breakpoint)(nil),          // 18: vibemulator.v1.Breakpoint
+	(*Cheat)(nil),               // 19: vibemulator.v1.Cheat
+	(*EvaluateRequest)(nil),     // 20: vibemulator.v1.EvaluateRequest
+	(*CDLRequest)(nil),          // 21: vibemulator.v1.CDLRequest
+	(*DisassembleRequest)(nil),  // 22: vibemulator.v1.DisassembleRequest
+	(*PatternTableRequest)(nil), // 23: vibemulator.v1.PatternTableRequest
+	(*LogLevels)(nil),           // 24: vibemulator.v1.LogLevels
+	(*MovieResponse)(nil),       // 25: vibemulator.v1.MovieResponse
+	(*FrameResponse)(nil),       // 26: vibemulator.v1.FrameResponse
+	(*FrameHashResponse)(nil),   // 27: vibemulator.v1.FrameHashResponse
+	(*SpectatorUpdate)(nil),     // 28: vibemulator.v1.SpectatorUpdate
+	(*PacingStats)(nil),         // 29: vibemulator.v1.PacingStats
+	(*MemoryResponse)(nil),      // 30: vibemulator.v1.MemoryResponse
+	(*StateResponse)(nil),       // 31: vibemulator.v1.StateResponse
+	(*Observation)(nil),         // 32: vibemulator.v1.Observation
+	(*SessionResponse)(nil),     // 33: vibemulator.v1.SessionResponse
+	(*RunUntilResponse)(nil),    // 34: vibemulator.v1.RunUntilResponse
+	(*CPUStateResponse)(nil),    // 35: vibemulator.v1.CPUStateResponse
+	(*MemoryBlockResponse)(nil), // 36: vibemulator.v1.MemoryBlockResponse
+	(*WatchpointList)(nil),      // 37: vibemulator.v1.WatchpointList
+	(*WatchHit)(nil),            // 38: vibemulator.v1.WatchHit
+	(*BreakpointList)(nil),      // 39: vibemulator.v1.BreakpointList
+	(*BreakpointHit)(nil),       // 40: vibemulator.v1.BreakpointHit
+	(*CheatList)(nil),           // 41: vibemulator.v1.CheatList
+	(*EvaluateResponse)(nil),    // 42: vibemulator.v1.EvaluateResponse
+	(*ProfileReport)(nil),       // 43: vibemulator.v1.ProfileReport
+	(*CDLReport)(nil),           // 44: vibemulator.v1.CDLReport
+	(*DisassembleResponse)(nil), // 45: vibemulator.v1.DisassembleResponse
+	(*MemoryMap)(nil),           // 46: vibemulator.v1.MemoryMap
+	(*PPUStateResponse)(nil),    // 47: vibemulator.v1.PPUStateResponse
+	(*APUStateResponse)(nil),    // 48: vibemulator.v1.APUStateResponse
+}
+var file_api_v1_services_proto_depIdxs = []int32{
+	0,  // 0: vibemulator.v1.InputService.StreamInput:input_type -> vibemulator.v1.InputState
+	1,  // 1: vibemulator.v1.InputService.StartRecording:input_type -> vibemulator.v1.Empty
+	2,  // 2: vibemulator.v1.InputService.StopRecording:input_type -> vibemulator.v1.MovieRequest
+	2,  // 3: vibemulator.v1.InputService.PlayMovie:input_type -> vibemulator.v1.MovieRequest
+	2,  // 4: vibemulator.v1.InputService.EditMovie:input_type -> vibemulator.v1.MovieRequest
+	3,  // 5: vibemulator.v1.InputService.SeekMovie:input_type -> vibemulator.v1.MovieSeekRequest
+	1,  // 6: vibemulator.v1.InputService.TruncateMovie:input_type -> vibemulator.v1.Empty
+	4,  // 7: vibemulator.v1.InputService.InsertMovieInput:input_type -> vibemulator.v1.StepRequest
+	2,  // 8: vibemulator.v1.InputService.SaveMovie:input_type -> vibemulator.v1.MovieRequest
+	1,  // 9: vibemulator.v1.InputService.MovieStatus:input_type -> vibemulator.v1.Empty
+	5,  // 10: vibemulator.v1.VideoService.GetFrame:input_type -> vibemulator.v1.FrameRequest
+	1,  // 11: vibemulator.v1.VideoService.GetFrameHash:input_type -> vibemulator.v1.Empty
+	6,  // 12: vibemulator.v1.VideoService.StreamFrames:input_type -> vibemulator.v1.StreamFramesRequest
+	7,  // 13: vibemulator.v1.VideoService.Spectate:input_type -> vibemulator.v1.SpectateRequest
+	1,  // 14: vibemulator.v1.VideoService.GetPacingStats:input_type -> vibemulator.v1.Empty
+	8,  // 15: vibemulator.v1.StateService.ReadMemory:input_type -> vibemulator.v1.MemoryRequest
+	9,  // 16: vibemulator.v1.StateService.LoadState:input_type -> vibemulator.v1.StateRequest
+	1,  // 17: vibemulator.v1.StateService.SaveState:input_type -> vibemulator.v1.Empty
+	1,  // 18: vibemulator.v1.StateService.ResetSystem:input_type -> vibemulator.v1.Empty
+	10, // 19: vibemulator.v1.StateService.ResetEpisode:input_type -> vibemulator.v1.EpisodeRequest
+	4,  // 20: vibemulator.v1.StateService.StepFrame:input_type -> vibemulator.v1.StepRequest
+	11, // 21: vibemulator.v1.StateService.SetObservationSpec:input_type -> vibemulator.v1.ObservationSpec
+	12, // 22: vibemulator.v1.StateService.LoadROM:input_type -> vibemulator.v1.ROMRequest
+	1,  // 23: vibemulator.v1.StateService.CreateSession:input_type -> vibemulator.v1.Empty
+	13, // 24: vibemulator.v1.StateService.DestroySession:input_type -> vibemulator.v1.SessionRequest
+	1,  // 25: vibemulator.v1.DebugService.Pause:input_type -> vibemulator.v1.Empty
+	1,  // 26: vibemulator.v1.DebugService.Resume:input_type -> vibemulator.v1.Empty
+	1,  // 27: vibemulator.v1.DebugService.Step:input_type -> vibemulator.v1.Empty
+	1,  // 28: vibemulator.v1.DebugService.AdvanceFrame:input_type -> vibemulator.v1.Empty
+	14, // 29: vibemulator.v1.DebugService.RunUntil:input_type -> vibemulator.v1.RunUntilRequest
+	1,  // 30: vibemulator.v1.DebugService.GetCPUState:input_type -> vibemulator.v1.Empty
+	15, // 31: vibemulator.v1.DebugService.ReadMemoryBlock:input_type -> vibemulator.v1.MemoryBlockRequest
+	16, // 32: vibemulator.v1.DebugService.WriteMemoryBlock:input_type -> vibemulator.v1.MemoryWriteRequest
+	17, // 33: vibemulator.v1.DebugService.AddWatchpoint:input_type -> vibemulator.v1.Watchpoint
+	17, // 34: vibemulator.v1.DebugService.RemoveWatchpoint:input_type -> vibemulator.v1.Watchpoint
+	1,  // 35: vibemulator.v1.DebugService.ListWatchpoints:input_type -> vibemulator.v1.Empty
+	1,  // 36: vibemulator.v1.DebugService.GetWatchHit:input_type -> vibemulator.v1.Empty
+	18, // 37: vibemulator.v1.DebugService.AddBreakpoint:input_type -> vibemulator.v1.Breakpoint
+	18, // 38: vibemulator.v1.DebugService.RemoveBreakpoint:input_type -> vibemulator.v1.Breakpoint
+	1,  // 39: vibemulator.v1.DebugService.ListBreakpoints:input_type -> vibemulator.v1.Empty
+	1,  // 40: vibemulator.v1.DebugService.GetBreakpointHit:input_type -> vibemulator.v1.Empty
+	19, // 41: vibemulator.v1.DebugService.AddCheat:input_type -> vibemulator.v1.Cheat
+	1,  // 42: vibemulator.v1.DebugService.ListCheats:input_type -> vibemulator.v1.Empty
+	19, // 43: vibemulator.v1.DebugService.SetCheatEnabled:input_type -> vibemulator.v1.Cheat
+	20, // 44: vibemulator.v1.DebugService.Evaluate:input_type -> vibemulator.v1.EvaluateRequest
+	1,  // 45: vibemulator.v1.DebugService.StartProfile:input_type -> vibemulator.v1.Empty
+	1,  // 46: vibemulator.v1.DebugService.StopProfile:input_type -> vibemulator.v1.Empty
+	1,  // 47: vibemulator.v1.DebugService.GetProfile:input_type -> vibemulator.v1.Empty
+	21, // 48: vibemulator.v1.DebugService.StartCDL:input_type -> vibemulator.v1.CDLRequest
+	1,  // 49: vibemulator.v1.DebugService.StopCDL:input_type -> vibemulator.v1.Empty
+	1,  // 50: vibemulator.v1.DebugService.GetCDL:input_type -> vibemulator.v1.Empty
+	22, // 51: vibemulator.v1.DebugService.Disassemble:input_type -> vibemulator.v1.DisassembleRequest
+	1,  // 52: vibemulator.v1.DebugService.GetMemoryMap:input_type -> vibemulator.v1.Empty
+	1,  // 53: vibemulator.v1.DebugService.ReadNametables:input_type -> vibemulator.v1.Empty
+	1,  // 54: vibemulator.v1.DebugService.GetPPUState:input_type -> vibemulator.v1.Empty
+	1,  // 55: vibemulator.v1.DebugService.GetAPUState:input_type -> vibemulator.v1.Empty
+	1,  // 56: vibemulator.v1.DebugService.ReadOAM:input_type -> vibemulator.v1.Empty
+	1,  // 57: vibemulator.v1.DebugService.ReadPalette:input_type -> vibemulator.v1.Empty
+	23, // 58: vibemulator.v1.DebugService.GetPatternTableImage:input_type -> vibemulator.v1.PatternTableRequest
+	5,  // 59: vibemulator.v1.DebugService.GetNametableImage:input_type -> vibemulator.v1.FrameRequest
+	1,  // 60: vibemulator.v1.DebugService.GetLogLevels:input_type -> vibemulator.v1.Empty
+	24, // 61: vibemulator.v1.DebugService.SetLogLevels:input_type -> vibemulator.v1.LogLevels
+	1,  // 62: vibemulator.v1.InputService.StreamInput:output_type -> vibemulator.v1.Empty
+	1,  // 63: vibemulator.v1.InputService.StartRecording:output_type -> vibemulator.v1.Empty
+	25, // 64: vibemulator.v1.InputService.StopRecording:output_type -> vibemulator.v1.MovieResponse
+	25, // 65: vibemulator.v1.InputService.PlayMovie:output_type -> vibemulator.v1.MovieResponse
+	25, // 66: vibemulator.v1.InputService.EditMovie:output_type -> vibemulator.v1.MovieResponse
+	25, // 67: vibemulator.v1.InputService.SeekMovie:output_type -> vibemulator.v1.MovieResponse
+	25, // 68: vibemulator.v1.InputService.TruncateMovie:output_type -> vibemulator.v1.MovieResponse
+	25, // 69: vibemulator.v1.InputService.InsertMovieInput:output_type -> vibemulator.v1.MovieResponse
+	25, // 70: vibemulator.v1.InputService.SaveMovie:output_type -> vibemulator.v1.MovieResponse
+	25, // 71: vibemulator.v1.InputService.MovieStatus:output_type -> vibemulator.v1.MovieResponse
+	26, // 72: vibemulator.v1.VideoService.GetFrame:output_type -> vibemulator.v1.FrameResponse
+	27, // 73: vibemulator.v1.VideoService.GetFrameHash:output_type -> vibemulator.v1.FrameHashResponse
+	26, // 74: vibemulator.v1.VideoService.StreamFrames:output_type -> vibemulator.v1.FrameResponse
+	28, // 75: vibemulator.v1.VideoService.Spectate:output_type -> vibemulator.v1.SpectatorUpdate
+	29, // 76: vibemulator.v1.VideoService.GetPacingStats:output_type -> vibemulator.v1.PacingStats
+	30, // 77: vibemulator.v1.StateService.ReadMemory:output_type -> vibemulator.v1.MemoryResponse
+	1,  // 78: vibemulator.v1.StateService.LoadState:output_type -> vibemulator.v1.Empty
+	31, // 79: vibemulator.v1.StateService.SaveState:output_type -> vibemulator.v1.StateResponse
+	1,  // 80: vibemulator.v1.StateService.ResetSystem:output_type -> vibemulator.v1.Empty
+	32, // 81: vibemulator.v1.StateService.ResetEpisode:output_type -> vibemulator.v1.Observation
+	32, // 82: vibemulator.v1.StateService.StepFrame:output_type -> vibemulator.v1.Observation
+	1,  // 83: vibemulator.v1.StateService.SetObservationSpec:output_type -> vibemulator.v1.Empty
+	1,  // 84: vibemulator.v1.StateService.LoadROM:output_type -> vibemulator.v1.Empty
+	33, // 85: vibemulator.v1.StateService.CreateSession:output_type -> vibemulator.v1.SessionResponse
+	1,  // 86: vibemulator.v1.StateService.DestroySession:output_type -> vibemulator.v1.Empty
+	1,  // 87: vibemulator.v1.DebugService.Pause:output_type -> vibemulator.v1.Empty
+	1,  // 88: vibemulator.v1.DebugService.Resume:output_type -> vibemulator.v1.Empty
+	1,  // 89: vibemulator.v1.DebugService.Step:output_type -> vibemulator.v1.Empty
+	1,  // 90: vibemulator.v1.DebugService.AdvanceFrame:output_type -> vibemulator.v1.Empty
+	34, // 91: vibemulator.v1.DebugService.RunUntil:output_type -> vibemulator.v1.RunUntilResponse
+	35, // 92: vibemulator.v1.DebugService.GetCPUState:output_type -> vibemulator.v1.CPUStateResponse
+	36, // 93: vibemulator.v1.DebugService.ReadMemoryBlock:output_type -> vibemulator.v1.MemoryBlockResponse
+	1,  // 94: vibemulator.v1.DebugService.WriteMemoryBlock:output_type -> vibemulator.v1.Empty
+	17, // 95: vibemulator.v1.DebugService.AddWatchpoint:output_type -> vibemulator.v1.Watchpoint
+	1,  // 96: vibemulator.v1.DebugService.RemoveWatchpoint:output_type -> vibemulator.v1.Empty
+	37, // 97: vibemulator.v1.DebugService.ListWatchpoints:output_type -> vibemulator.v1.WatchpointList
+	38, // 98: vibemulator.v1.DebugService.GetWatchHit:output_type -> vibemulator.v1.WatchHit
+	18, // 99: vibemulator.v1.DebugService.AddBreakpoint:output_type -> vibemulator.v1.Breakpoint
+	1,  // 100: vibemulator.v1.DebugService.RemoveBreakpoint:output_type -> vibemulator.v1.Empty
+	39, // 101: vibemulator.v1.DebugService.ListBreakpoints:output_type -> vibemulator.v1.BreakpointList
+	40, // 102: vibemulator.v1.DebugService.GetBreakpointHit:output_type -> vibemulator.v1.BreakpointHit
+	19, // 103: vibemulator.v1.DebugService.AddCheat:output_type -> vibemulator.v1.Cheat
+	41, // 104: vibemulator.v1.DebugService.ListCheats:output_type -> vibemulator.v1.CheatList
+	19, // 105: vibemulator.v1.DebugService.SetCheatEnabled:output_type -> vibemulator.v1.Cheat
+	42, // 106: vibemulator.v1.DebugService.Evaluate:output_type -> vibemulator.v1.EvaluateResponse
+	1,  // 107: vibemulator.v1.DebugService.StartProfile:output_type -> vibemulator.v1.Empty
+	43, // 108: vibemulator.v1.DebugService.StopProfile:output_type -> vibemulator.v1.ProfileReport
+	43, // 109: vibemulator.v1.DebugService.GetProfile:output_type -> vibemulator.v1.ProfileReport
+	1,  // 110: vibemulator.v1.DebugService.StartCDL:output_type -> vibemulator.v1.Empty
+	44, // 111: vibemulator.v1.DebugService.StopCDL:output_type -> vibemulator.v1.CDLReport
+	44, // 112: vibemulator.v1.DebugService.GetCDL:output_type -> vibemulator.v1.CDLReport
+	45, // 113: vibemulator.v1.DebugService.Disassemble:output_type -> vibemulator.v1.DisassembleResponse
+	46, // 114: vibemulator.v1.DebugService.GetMemoryMap:output_type -> vibemulator.v1.MemoryMap
+	36, // 115: vibemulator.v1.DebugService.ReadNametables:output_type -> vibemulator.v1.MemoryBlockResponse
+	47, // 116: vibemulator.v1.DebugService.GetPPUState:output_type -> vibemulator.v1.PPUStateResponse
+	48, // 117: vibemulator.v1.DebugService.GetAPUState:output_type -> vibemulator.v1.APUStateResponse
+	36, // 118: vibemulator.v1.DebugService.ReadOAM:output_type -> vibemulator.v1.MemoryBlockResponse
+	36, // 119: vibemulator.v1.DebugService.ReadPalette:output_type -> vibemulator.v1.MemoryBlockResponse
+	26, // 120: vibemulator.v1.DebugService.GetPatternTableImage:output_type -> vibemulator.v1.FrameResponse
+	26, // 121: vibemulator.v1.DebugService.GetNametableImage:output_type -> vibemulator.v1.FrameResponse
+	24, // 122: vibemulator.v1.DebugService.GetLogLevels:output_type -> vibemulator.v1.LogLevels
+	24, // 123: vibemulator.v1.DebugService.SetLogLevels:output_type -> vibemulator.v1.LogLevels
+	62, // [62:124] is the sub-list for method output_type
+	0,  // [0:62] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_services_proto_init() }
+func file_api_v1_services_proto_init() {
+	if File_api_v1_services_proto != nil {
+		return
+	}
+	file_api_v1_messages_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_services_proto_rawDesc), len(file_api_v1_services_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_api_v1_services_proto_goTypes,
+		DependencyIndexes: file_api_v1_services_proto_depIdxs,
+	}.Build()
+	File_api_v1_services_proto = out.File
+	file_api_v1_services_proto_goTypes = nil
+	file_api_v1_services_proto_depIdxs = nil
+}
@@ -0,0 +1,2777 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v6.33.1
+// source: api/v1/services.proto
+
+// The emulator's RPCs split by what clients use them for. Their messages are in
+// messages.proto, in this package, so the whole of v1 is versioned together. The server
+// still serves ControllerService for existing clients.
+
+package apiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InputService_StreamInput_FullMethodName      = "/vibemulator.v1.InputService/StreamInput"
+	InputService_StartRecording_FullMethodName   = "/vibemulator.v1.InputService/StartRecording"
+	InputService_StopRecording_FullMethodName    = "/vibemulator.v1.InputService/StopRecording"
+	InputService_PlayMovie_FullMethodName        = "/vibemulator.v1.InputService/PlayMovie"
+	InputService_EditMovie_FullMethodName        = "/vibemulator.v1.InputService/EditMovie"
+	InputService_SeekMovie_FullMethodName        = "/vibemulator.v1.InputService/SeekMovie"
+	InputService_TruncateMovie_FullMethodName    = "/vibemulator.v1.InputService/TruncateMovie"
+	InputService_InsertMovieInput_FullMethodName = "/vibemulator.v1.InputService/InsertMovieInput"
+	InputService_SaveMovie_FullMethodName        = "/vibemulator.v1.InputService/SaveMovie"
+	InputService_MovieStatus_FullMethodName      = "/vibemulator.v1.InputService/MovieStatus"
+)
+
+// InputServiceClient is the client API for InputService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Controller input and movies
+type InputServiceClient interface {
+	// Client streams button states to the emulator
+	StreamInput(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InputState, Empty], error)
+	// Movie recording and playback. Input is logged per frame (not wall-clock), so PlayMovie
+	// replays deterministically from the recorded starting state and reports the final frame hash.
+	StartRecording(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	StopRecording(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	PlayMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	// Movie editing, on one movie per instance. EditMovie loads a movie and seeks to its
+	// first frame, and SeekMovie replays it to the start of a frame. TruncateMovie drops the
+	// input after the current frame, and InsertMovieInput inserts input at it. SaveMovie
+	// returns the edited movie, optionally writing it to a file, and MovieStatus reports
+	// where the emulator is in it without returning it. Resuming plays the rest.
+	EditMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	SeekMovie(ctx context.Context, in *MovieSeekRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	TruncateMovie(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error)
+	InsertMovieInput(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	SaveMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error)
+	MovieStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error)
+}
+
+type inputServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInputServiceClient(cc grpc.ClientConnInterface) InputServiceClient {
+	return &inputServiceClient{cc}
+}
+
+func (c *inputServiceClient) StreamInput(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InputState, Empty], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InputService_ServiceDesc.Streams[0], InputService_StreamInput_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[InputState, Empty]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InputService_StreamInputClient = grpc.BidiStreamingClient[InputState, Empty]
+
+func (c *inputServiceClient) StartRecording(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, InputService_StartRecording_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) StopRecording(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_StopRecording_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) PlayMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_PlayMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) EditMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_EditMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) SeekMovie(ctx context.Context, in *MovieSeekRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_SeekMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) TruncateMovie(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_TruncateMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) InsertMovieInput(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_InsertMovieInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) SaveMovie(ctx context.Context, in *MovieRequest, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_SaveMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputServiceClient) MovieStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MovieResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieResponse)
+	err := c.cc.Invoke(ctx, InputService_MovieStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InputServiceServer is the server API for InputService service.
+// All implementations must embed UnimplementedInputServiceServer
+// for forward compatibility.
+//
+// Controller input and movies
+type InputServiceServer interface {
+	// Client streams button states to the emulator
+	StreamInput(grpc.BidiStreamingServer[InputState, Empty]) error
+	// Movie recording and playback. Input is logged per frame (not wall-clock), so PlayMovie
+	// replays deterministically from the recorded starting state and reports the final frame hash.
+	StartRecording(context.Context, *Empty) (*Empty, error)
+	StopRecording(context.Context, *MovieRequest) (*MovieResponse, error)
+	PlayMovie(context.Context, *MovieRequest) (*MovieResponse, error)
+	// Movie editing, on one movie per instance. EditMovie loads a movie and seeks to its
+	// first frame, and SeekMovie replays it to the start of a frame. TruncateMovie drops the
+	// input after the current frame, and InsertMovieInput inserts input at it. SaveMovie
+	// returns the edited movie, optionally writing it to a file, and MovieStatus reports
+	// where the emulator is in it without returning it. Resuming plays the rest.
+	EditMovie(context.Context, *MovieRequest) (*MovieResponse, error)
+	SeekMovie(context.Context, *MovieSeekRequest) (*MovieResponse, error)
+	TruncateMovie(context.Context, *Empty) (*MovieResponse, error)
+	InsertMovieInput(context.Context, *StepRequest) (*MovieResponse, error)
+	SaveMovie(context.Context, *MovieRequest) (*MovieResponse, error)
+	MovieStatus(context.Context, *Empty) (*MovieResponse, error)
+	mustEmbedUnimplementedInputServiceServer()
+}
+
+// UnimplementedInputServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInputServiceServer struct{}
+
+func (UnimplementedInputServiceServer) StreamInput(grpc.BidiStreamingServer[InputState, Empty]) error {
+	return status.Error(codes.Unimplemented, "method StreamInput not implemented")
+}
+func (UnimplementedInputServiceServer) StartRecording(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartRecording not implemented")
+}
+func (UnimplementedInputServiceServer) StopRecording(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopRecording not implemented")
+}
+func (UnimplementedInputServiceServer) PlayMovie(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlayMovie not implemented")
+}
+func (UnimplementedInputServiceServer) EditMovie(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EditMovie not implemented")
+}
+func (UnimplementedInputServiceServer) SeekMovie(context.Context, *MovieSeekRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SeekMovie not implemented")
+}
+func (UnimplementedInputServiceServer) TruncateMovie(context.Context, *Empty) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TruncateMovie not implemented")
+}
+func (UnimplementedInputServiceServer) InsertMovieInput(context.Context, *StepRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InsertMovieInput not implemented")
+}
+func (UnimplementedInputServiceServer) SaveMovie(context.Context, *MovieRequest) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveMovie not implemented")
+}
+func (UnimplementedInputServiceServer) MovieStatus(context.Context, *Empty) (*MovieResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MovieStatus not implemented")
+}
+func (UnimplementedInputServiceServer) mustEmbedUnimplementedInputServiceServer() {}
+func (UnimplementedInputServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeInputServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InputServiceServer will
+// result in compilation errors.
+type UnsafeInputServiceServer interface {
+	mustEmbedUnimplementedInputServiceServer()
+}
+
+func RegisterInputServiceServer(s grpc.ServiceRegistrar, srv InputServiceServer) {
+	// If the following call panics, it indicates UnimplementedInputServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InputService_ServiceDesc, srv)
+}
+
+func _InputService_StreamInput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InputServiceServer).StreamInput(&grpc.GenericServerStream[InputState, Empty]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InputService_StreamInputServer = grpc.BidiStreamingServer[InputState, Empty]
+
+func _InputService_StartRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).StartRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_StartRecording_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).StartRecording(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_StopRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).StopRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_StopRecording_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).StopRecording(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_PlayMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).PlayMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_PlayMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).PlayMovie(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_EditMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).EditMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_EditMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).EditMovie(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_SeekMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieSeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).SeekMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_SeekMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).SeekMovie(ctx, req.(*MovieSeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_TruncateMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).TruncateMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_TruncateMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).TruncateMovie(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_InsertMovieInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).InsertMovieInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_InsertMovieInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).InsertMovieInput(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_SaveMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).SaveMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_SaveMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).SaveMovie(ctx, req.(*MovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputService_MovieStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputServiceServer).MovieStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InputService_MovieStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputServiceServer).MovieStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InputService_ServiceDesc is the grpc.ServiceDesc for InputService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InputService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vibemulator.v1.InputService",
+	HandlerType: (*InputServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartRecording",
+			Handler:    _InputService_StartRecording_Handler,
+		},
+		{
+			MethodName: "StopRecording",
+			Handler:    _InputService_StopRecording_Handler,
+		},
+		{
+			MethodName: "PlayMovie",
+			Handler:    _InputService_PlayMovie_Handler,
+		},
+		{
+			MethodName: "EditMovie",
+			Handler:    _InputService_EditMovie_Handler,
+		},
+		{
+			MethodName: "SeekMovie",
+			Handler:    _InputService_SeekMovie_Handler,
+		},
+		{
+			MethodName: "TruncateMovie",
+			Handler:    _InputService_TruncateMovie_Handler,
+		},
+		{
+			MethodName: "InsertMovieInput",
+			Handler:    _InputService_InsertMovieInput_Handler,
+		},
+		{
+			MethodName: "SaveMovie",
+			Handler:    _InputService_SaveMovie_Handler,
+		},
+		{
+			MethodName: "MovieStatus",
+			Handler:    _InputService_MovieStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInput",
+			Handler:       _InputService_StreamInput_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "api/v1/services.proto",
+}
+
+const (
+	VideoService_GetFrame_FullMethodName       = "/vibemulator.v1.VideoService/GetFrame"
+	VideoService_GetFrameHash_FullMethodName   = "/vibemulator.v1.VideoService/GetFrameHash"
+	VideoService_StreamFrames_FullMethodName   = "/vibemulator.v1.VideoService/StreamFrames"
+	VideoService_Spectate_FullMethodName       = "/vibemulator.v1.VideoService/Spectate"
+	VideoService_GetPacingStats_FullMethodName = "/vibemulator.v1.VideoService/GetPacingStats"
+)
+
+// VideoServiceClient is the client API for VideoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Frames as the PPU finishes them, and how they are delivered
+type VideoServiceClient interface {
+	// Requests the current frame buffer (pixels) from the PPU
+	GetFrame(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error)
+	// FNV-64a hash of the raw RGBA frame, to verify output without transferring pixels
+	GetFrameHash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FrameHashResponse, error)
+	// Pushes every newly completed frame with its number and hash
+	StreamFrames(ctx context.Context, in *StreamFramesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FrameResponse], error)
+	// Read-only spectator feed: the input latched at every frame (and optionally the video),
+	// delivered a fixed number of frames behind the live game
+	Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectatorUpdate], error)
+	// Frame delivery and audio sync over the last ten seconds of play
+	GetPacingStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PacingStats, error)
+}
+
+type videoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVideoServiceClient(cc grpc.ClientConnInterface) VideoServiceClient {
+	return &videoServiceClient{cc}
+}
+
+func (c *videoServiceClient) GetFrame(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetFrame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetFrameHash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FrameHashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameHashResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetFrameHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) StreamFrames(ctx context.Context, in *StreamFramesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FrameResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VideoService_ServiceDesc.Streams[0], VideoService_StreamFrames_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamFramesRequest, FrameResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VideoService_StreamFramesClient = grpc.ServerStreamingClient[FrameResponse]
+
+func (c *videoServiceClient) Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectatorUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VideoService_ServiceDesc.Streams[1], VideoService_Spectate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SpectateRequest, SpectatorUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VideoService_SpectateClient = grpc.ServerStreamingClient[SpectatorUpdate]
+
+func (c *videoServiceClient) GetPacingStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PacingStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PacingStats)
+	err := c.cc.Invoke(ctx, VideoService_GetPacingStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VideoServiceServer is the server API for VideoService service.
+// All implementations must embed UnimplementedVideoServiceServer
+// for forward compatibility.
+//
+// Frames as the PPU finishes them, and how they are delivered
+type VideoServiceServer interface {
+	// Requests the current frame buffer (pixels) from the PPU
+	GetFrame(context.Context, *FrameRequest) (*FrameResponse, error)
+	// FNV-64a hash of the raw RGBA frame, to verify output without transferring pixels
+	GetFrameHash(context.Context, *Empty) (*FrameHashResponse, error)
+	// Pushes every newly completed frame with its number and hash
+	StreamFrames(*StreamFramesRequest, grpc.ServerStreamingServer[FrameResponse]) error
+	// Read-only spectator feed: the input latched at every frame (and optionally the video),
+	// delivered a fixed number of frames behind the live game
+	Spectate(*SpectateRequest, grpc.ServerStreamingServer[SpectatorUpdate]) error
+	// Frame delivery and audio sync over the last ten seconds of play
+	GetPacingStats(context.Context, *Empty) (*PacingStats, error)
+	mustEmbedUnimplementedVideoServiceServer()
+}
+
+// UnimplementedVideoServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVideoServiceServer struct{}
+
+func (UnimplementedVideoServiceServer) GetFrame(context.Context, *FrameRequest) (*FrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFrame not implemented")
+}
+func (UnimplementedVideoServiceServer) GetFrameHash(context.Context, *Empty) (*FrameHashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFrameHash not implemented")
+}
+func (UnimplementedVideoServiceServer) StreamFrames(*StreamFramesRequest, grpc.ServerStreamingServer[FrameResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamFrames not implemented")
+}
+func (UnimplementedVideoServiceServer) Spectate(*SpectateRequest, grpc.ServerStreamingServer[SpectatorUpdate]) error {
+	return status.Error(codes.Unimplemented, "method Spectate not implemented")
+}
+func (UnimplementedVideoServiceServer) GetPacingStats(context.Context, *Empty) (*PacingStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPacingStats not implemented")
+}
+func (UnimplementedVideoServiceServer) mustEmbedUnimplementedVideoServiceServer() {}
+func (UnimplementedVideoServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeVideoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VideoServiceServer will
+// result in compilation errors.
+type UnsafeVideoServiceServer interface {
+	mustEmbedUnimplementedVideoServiceServer()
+}
+
+func RegisterVideoServiceServer(s grpc.ServiceRegistrar, srv VideoServiceServer) {
+	// If the following call panics, it indicates UnimplementedVideoServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VideoService_ServiceDesc, srv)
+}
+
+func _VideoService_GetFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FrameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetFrame(ctx, req.(*FrameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetFrameHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetFrameHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetFrameHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetFrameHash(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_StreamFrames_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFramesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VideoServiceServer).StreamFrames(m, &grpc.GenericServerStream[StreamFramesRequest, FrameResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VideoService_StreamFramesServer = grpc.ServerStreamingServer[FrameResponse]
+
+func _VideoService_Spectate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpectateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VideoServiceServer).Spectate(m, &grpc.GenericServerStream[SpectateRequest, SpectatorUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VideoService_SpectateServer = grpc.ServerStreamingServer[SpectatorUpdate]
+
+func _VideoService_GetPacingStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetPacingStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetPacingStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetPacingStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VideoService_ServiceDesc is the grpc.ServiceDesc for VideoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VideoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vibemulator.v1.VideoService",
+	HandlerType: (*VideoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFrame",
+			Handler:    _VideoService_GetFrame_Handler,
+		},
+		{
+			MethodName: "GetFrameHash",
+			Handler:    _VideoService_GetFrameHash_Handler,
+		},
+		{
+			MethodName: "GetPacingStats",
+			Handler:    _VideoService_GetPacingStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFrames",
+			Handler:       _VideoService_StreamFrames_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Spectate",
+			Handler:       _VideoService_Spectate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/v1/services.proto",
+}
+
+const (
+	StateService_ReadMemory_FullMethodName         = "/vibemulator.v1.StateService/ReadMemory"
+	StateService_LoadState_FullMethodName          = "/vibemulator.v1.StateService/LoadState"
+	StateService_SaveState_FullMethodName          = "/vibemulator.v1.StateService/SaveState"
+	StateService_ResetSystem_FullMethodName        = "/vibemulator.v1.StateService/ResetSystem"
+	StateService_ResetEpisode_FullMethodName       = "/vibemulator.v1.StateService/ResetEpisode"
+	StateService_StepFrame_FullMethodName          = "/vibemulator.v1.StateService/StepFrame"
+	StateService_SetObservationSpec_FullMethodName = "/vibemulator.v1.StateService/SetObservationSpec"
+	StateService_LoadROM_FullMethodName            = "/vibemulator.v1.StateService/LoadROM"
+	StateService_CreateSession_FullMethodName      = "/vibemulator.v1.StateService/CreateSession"
+	StateService_DestroySession_FullMethodName     = "/vibemulator.v1.StateService/DestroySession"
+)
+
+// StateServiceClient is the client API for StateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Savestates, ROMs, sessions and the RL episode loop
+type StateServiceClient interface {
+	// Reads a byte from the NES system bus (used to calculate RL rewards/done state)
+	ReadMemory(ctx context.Context, in *MemoryRequest, opts ...grpc.CallOption) (*MemoryResponse, error)
+	// Loads an emulator save state from a file or inline bytes, bypassing the title screen
+	LoadState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Snapshots the emulator, with what is needed to anchor a recording to the snapshot
+	SaveState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateResponse, error)
+	// Triggers a hardware reset of the NES (returns game to title screen)
+	ResetSystem(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Power-cycles the NES into a known state (optionally from a ROM or state blob), pauses it,
+	// and returns the initial observation. Identical inputs then yield bit-identical trajectories.
+	ResetEpisode(ctx context.Context, in *EpisodeRequest, opts ...grpc.CallOption) (*Observation, error)
+	// Holds the given inputs for a number of whole frames and returns the resulting observation
+	StepFrame(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*Observation, error)
+	// Registers named memory features that every ResetEpisode/StepFrame observation will carry,
+	// saving clients a ReadMemory round trip per value per frame. Replaces any previous spec.
+	SetObservationSpec(ctx context.Context, in *ObservationSpec, opts ...grpc.CallOption) (*Empty, error)
+	// Inserts a ROM from raw iNES bytes and power-cycles the NES, so headless instances
+	// do not need the ROM provisioned on disk
+	LoadROM(ctx context.Context, in *ROMRequest, opts ...grpc.CallOption) (*Empty, error)
+	// CreateSession starts an independent headless emulator; every RPC on every service
+	// targets it when the "session-id" metadata key carries the returned ID, and the
+	// default emulator otherwise.
+	CreateSession(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SessionResponse, error)
+	DestroySession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type stateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStateServiceClient(cc grpc.ClientConnInterface) StateServiceClient {
+	return &stateServiceClient{cc}
+}
+
+func (c *stateServiceClient) ReadMemory(ctx context.Context, in *MemoryRequest, opts ...grpc.CallOption) (*MemoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryResponse)
+	err := c.cc.Invoke(ctx, StateService_ReadMemory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) LoadState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StateService_LoadState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) SaveState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateResponse)
+	err := c.cc.Invoke(ctx, StateService_SaveState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) ResetSystem(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StateService_ResetSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) ResetEpisode(ctx context.Context, in *EpisodeRequest, opts ...grpc.CallOption) (*Observation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Observation)
+	err := c.cc.Invoke(ctx, StateService_ResetEpisode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) StepFrame(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*Observation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Observation)
+	err := c.cc.Invoke(ctx, StateService_StepFrame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) SetObservationSpec(ctx context.Context, in *ObservationSpec, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StateService_SetObservationSpec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) LoadROM(ctx context.Context, in *ROMRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StateService_LoadROM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) CreateSession(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionResponse)
+	err := c.cc.Invoke(ctx, StateService_CreateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) DestroySession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StateService_DestroySession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateServiceServer is the server API for StateService service.
+// All implementations must embed UnimplementedStateServiceServer
+// for forward compatibility.
+//
+// Savestates, ROMs, sessions and the RL episode loop
+type StateServiceServer interface {
+	// Reads a byte from the NES system bus (used to calculate RL rewards/done state)
+	ReadMemory(context.Context, *MemoryRequest) (*MemoryResponse, error)
+	// Loads an emulator save state from a file or inline bytes, bypassing the title screen
+	LoadState(context.Context, *StateRequest) (*Empty, error)
+	// Snapshots the emulator, with what is needed to anchor a recording to the snapshot
+	SaveState(context.Context, *Empty) (*StateResponse, error)
+	// Triggers a hardware reset of the NES (returns game to title screen)
+	ResetSystem(context.Context, *Empty) (*Empty, error)
+	// Power-cycles the NES into a known state (optionally from a ROM or state blob), pauses it,
+	// and returns the initial observation. Identical inputs then yield bit-identical trajectories.
+	ResetEpisode(context.Context, *EpisodeRequest) (*Observation, error)
+	// Holds the given inputs for a number of whole frames and returns the resulting observation
+	StepFrame(context.Context, *StepRequest) (*Observation, error)
+	// Registers named memory features that every ResetEpisode/StepFrame observation will carry,
+	// saving clients a ReadMemory round trip per value per frame. Replaces any previous spec.
+	SetObservationSpec(context.Context, *ObservationSpec) (*Empty, error)
+	// Inserts a ROM from raw iNES bytes and power-cycles the NES, so headless instances
+	// do not need the ROM provisioned on disk
+	LoadROM(context.Context, *ROMRequest) (*Empty, error)
+	// CreateSession starts an independent headless emulator; every RPC on every service
+	// targets it when the "session-id" metadata key carries the returned ID, and the
+	// default emulator otherwise.
+	CreateSession(context.Context, *Empty) (*SessionResponse, error)
+	DestroySession(context.Context, *SessionRequest) (*Empty, error)
+	mustEmbedUnimplementedStateServiceServer()
+}
+
+// UnimplementedStateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStateServiceServer struct{}
+
+func (UnimplementedStateServiceServer) ReadMemory(context.Context, *MemoryRequest) (*MemoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadMemory not implemented")
+}
+func (UnimplementedStateServiceServer) LoadState(context.Context, *StateRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoadState not implemented")
+}
+func (UnimplementedStateServiceServer) SaveState(context.Context, *Empty) (*StateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveState not implemented")
+}
+func (UnimplementedStateServiceServer) ResetSystem(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetSystem not implemented")
+}
+func (UnimplementedStateServiceServer) ResetEpisode(context.Context, *EpisodeRequest) (*Observation, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetEpisode not implemented")
+}
+func (UnimplementedStateServiceServer) StepFrame(context.Context, *StepRequest) (*Observation, error) {
+	return nil, status.Error(codes.Unimplemented, "method StepFrame not implemented")
+}
+func (UnimplementedStateServiceServer) SetObservationSpec(context.Context, *ObservationSpec) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetObservationSpec not implemented")
+}
+func (UnimplementedStateServiceServer) LoadROM(context.Context, *ROMRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoadROM not implemented")
+}
+func (UnimplementedStateServiceServer) CreateSession(context.Context, *Empty) (*SessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedStateServiceServer) DestroySession(context.Context, *SessionRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DestroySession not implemented")
+}
+func (UnimplementedStateServiceServer) mustEmbedUnimplementedStateServiceServer() {}
+func (UnimplementedStateServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StateServiceServer will
+// result in compilation errors.
+type UnsafeStateServiceServer interface {
+	mustEmbedUnimplementedStateServiceServer()
+}
+
+func RegisterStateServiceServer(s grpc.ServiceRegistrar, srv StateServiceServer) {
+	// If the following call panics, it indicates UnimplementedStateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StateService_ServiceDesc, srv)
+}
+
+func _StateService_ReadMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).ReadMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_ReadMemory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).ReadMemory(ctx, req.(*MemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_LoadState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).LoadState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_LoadState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).LoadState(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_SaveState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).SaveState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_SaveState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).SaveState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_ResetSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).ResetSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_ResetSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).ResetSystem(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_ResetEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).ResetEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_ResetEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).ResetEpisode(ctx, req.(*EpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_StepFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).StepFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_StepFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).StepFrame(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_SetObservationSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObservationSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).SetObservationSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_SetObservationSpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).SetObservationSpec(ctx, req.(*ObservationSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_LoadROM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ROMRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).LoadROM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_LoadROM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).LoadROM(ctx, req.(*ROMRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_CreateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).CreateSession(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_DestroySession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).DestroySession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateService_DestroySession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).DestroySession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StateService_ServiceDesc is the grpc.ServiceDesc for StateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vibemulator.v1.StateService",
+	HandlerType: (*StateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadMemory",
+			Handler:    _StateService_ReadMemory_Handler,
+		},
+		{
+			MethodName: "LoadState",
+			Handler:    _StateService_LoadState_Handler,
+		},
+		{
+			MethodName: "SaveState",
+			Handler:    _StateService_SaveState_Handler,
+		},
+		{
+			MethodName: "ResetSystem",
+			Handler:    _StateService_ResetSystem_Handler,
+		},
+		{
+			MethodName: "ResetEpisode",
+			Handler:    _StateService_ResetEpisode_Handler,
+		},
+		{
+			MethodName: "StepFrame",
+			Handler:    _StateService_StepFrame_Handler,
+		},
+		{
+			MethodName: "SetObservationSpec",
+			Handler:    _StateService_SetObservationSpec_Handler,
+		},
+		{
+			MethodName: "LoadROM",
+			Handler:    _StateService_LoadROM_Handler,
+		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _StateService_CreateSession_Handler,
+		},
+		{
+			MethodName: "DestroySession",
+			Handler:    _StateService_DestroySession_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/services.proto",
+}
+
+const (
+	DebugService_Pause_FullMethodName                = "/vibemulator.v1.DebugService/Pause"
+	DebugService_Resume_FullMethodName               = "/vibemulator.v1.DebugService/Resume"
+	DebugService_Step_FullMethodName                 = "/vibemulator.v1.DebugService/Step"
+	DebugService_AdvanceFrame_FullMethodName         = "/vibemulator.v1.DebugService/AdvanceFrame"
+	DebugService_RunUntil_FullMethodName             = "/vibemulator.v1.DebugService/RunUntil"
+	DebugService_GetCPUState_FullMethodName          = "/vibemulator.v1.DebugService/GetCPUState"
+	DebugService_ReadMemoryBlock_FullMethodName      = "/vibemulator.v1.DebugService/ReadMemoryBlock"
+	DebugService_WriteMemoryBlock_FullMethodName     = "/vibemulator.v1.DebugService/WriteMemoryBlock"
+	DebugService_AddWatchpoint_FullMethodName        = "/vibemulator.v1.DebugService/AddWatchpoint"
+	DebugService_RemoveWatchpoint_FullMethodName     = "/vibemulator.v1.DebugService/RemoveWatchpoint"
+	DebugService_ListWatchpoints_FullMethodName      = "/vibemulator.v1.DebugService/ListWatchpoints"
+	DebugService_GetWatchHit_FullMethodName          = "/vibemulator.v1.DebugService/GetWatchHit"
+	DebugService_AddBreakpoint_FullMethodName        = "/vibemulator.v1.DebugService/AddBreakpoint"
+	DebugService_RemoveBreakpoint_FullMethodName     = "/vibemulator.v1.DebugService/RemoveBreakpoint"
+	DebugService_ListBreakpoints_FullMethodName      = "/vibemulator.v1.DebugService/ListBreakpoints"
+	DebugService_GetBreakpointHit_FullMethodName     = "/vibemulator.v1.DebugService/GetBreakpointHit"
+	DebugService_AddCheat_FullMethodName             = "/vibemulator.v1.DebugService/AddCheat"
+	DebugService_ListCheats_FullMethodName           = "/vibemulator.v1.DebugService/ListCheats"
+	DebugService_SetCheatEnabled_FullMethodName      = "/vibemulator.v1.DebugService/SetCheatEnabled"
+	DebugService_Evaluate_FullMethodName             = "/vibemulator.v1.DebugService/Evaluate"
+	DebugService_StartProfile_FullMethodName         = "/vibemulator.v1.DebugService/StartProfile"
+	DebugService_StopProfile_FullMethodName          = "/vibemulator.v1.DebugService/StopProfile"
+	DebugService_GetProfile_FullMethodName           = "/vibemulator.v1.DebugService/GetProfile"
+	DebugService_StartCDL_FullMethodName             = "/vibemulator.v1.DebugService/StartCDL"
+	DebugService_StopCDL_FullMethodName              = "/vibemulator.v1.DebugService/StopCDL"
+	DebugService_GetCDL_FullMethodName               = "/vibemulator.v1.DebugService/GetCDL"
+	DebugService_Disassemble_FullMethodName          = "/vibemulator.v1.DebugService/Disassemble"
+	DebugService_GetMemoryMap_FullMethodName         = "/vibemulator.v1.DebugService/GetMemoryMap"
+	DebugService_ReadNametables_FullMethodName       = "/vibemulator.v1.DebugService/ReadNametables"
+	DebugService_GetPPUState_FullMethodName          = "/vibemulator.v1.DebugService/GetPPUState"
+	DebugService_GetAPUState_FullMethodName          = "/vibemulator.v1.DebugService/GetAPUState"
+	DebugService_ReadOAM_FullMethodName              = "/vibemulator.v1.DebugService/ReadOAM"
+	DebugService_ReadPalette_FullMethodName          = "/vibemulator.v1.DebugService/ReadPalette"
+	DebugService_GetPatternTableImage_FullMethodName = "/vibemulator.v1.DebugService/GetPatternTableImage"
+	DebugService_GetNametableImage_FullMethodName    = "/vibemulator.v1.DebugService/GetNametableImage"
+	DebugService_GetLogLevels_FullMethodName         = "/vibemulator.v1.DebugService/GetLogLevels"
+	DebugService_SetLogLevels_FullMethodName         = "/vibemulator.v1.DebugService/SetLogLevels"
+)
+
+// DebugServiceClient is the client API for DebugService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Execution control, inspection and the hardware viewers used by vdb
+type DebugServiceClient interface {
+	// Pause returns once the emulator has stopped on an instruction boundary. Step and
+	// AdvanceFrame run the emulator one instruction, or to the end of the frame, and
+	// return once it has paused again.
+	Pause(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Step(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	AdvanceFrame(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Resumes the emulator and blocks until the condition is met, a watchpoint or Pause
+	// stops it first, or the call is cancelled (which pauses the emulator)
+	RunUntil(ctx context.Context, in *RunUntilRequest, opts ...grpc.CallOption) (*RunUntilResponse, error)
+	GetCPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CPUStateResponse, error)
+	ReadMemoryBlock(ctx context.Context, in *MemoryBlockRequest, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// Writes through the CPU address space (RAM, PPU/APU registers, mapper registers, PRG RAM)
+	WriteMemoryBlock(ctx context.Context, in *MemoryWriteRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Watchpoints pause the emulator when the CPU reads or writes an address range
+	AddWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Watchpoint, error)
+	RemoveWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Empty, error)
+	ListWatchpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchpointList, error)
+	// Returns and clears the last watchpoint hit, if any
+	GetWatchHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchHit, error)
+	// Breakpoints pause the emulator before the instruction at an address executes,
+	// optionally only when a condition holds (evaluated inside the emulator)
+	AddBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Breakpoint, error)
+	RemoveBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Empty, error)
+	ListBreakpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointList, error)
+	// Returns and clears the last breakpoint hit, if any
+	GetBreakpointHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointHit, error)
+	// Cheat codes (Game Genie or raw "AAAA:VV" / "AAAA?CC:VV") patch what the CPU reads
+	AddCheat(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error)
+	ListCheats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheatList, error)
+	// Turns the cheat with the given id on or off
+	SetCheatEnabled(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error)
+	// Evaluates expressions in the breakpoint condition language against the current state
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// Instruction profiler: counts the instructions executed at each address and JSR calls
+	// to each subroutine between StartProfile and StopProfile
+	StartProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	StopProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error)
+	// Returns the running or most recent profile
+	GetProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error)
+	// Code/data logger: marks each PRG ROM byte executed as code or read as data, for
+	// export as an FCEUX .cdl file. StartCDL continues from the given log, or starts an
+	// empty one.
+	StartCDL(ctx context.Context, in *CDLRequest, opts ...grpc.CallOption) (*Empty, error)
+	StopCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error)
+	// Returns the running or most recent log
+	GetCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error)
+	// Decodes instructions at an address (the PC by default)
+	Disassemble(ctx context.Context, in *DisassembleRequest, opts ...grpc.CallOption) (*DisassembleResponse, error)
+	// Describes the CPU address space, including where each PRG window currently points
+	GetMemoryMap(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryMap, error)
+	// --- PPU viewers (read without side effects such as MMC3 IRQ clocking) ---
+	// Logical nametables $2000-$2FFF (4KB) as currently mirrored
+	ReadNametables(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// Internal registers and raster position
+	GetPPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PPUStateResponse, error)
+	// Channel enables, periods, length counters and IRQ flags
+	GetAPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*APUStateResponse, error)
+	// 256 bytes of sprite OAM
+	ReadOAM(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// 32 bytes of palette RAM ($3F00-$3F1F)
+	ReadPalette(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error)
+	// 128x128 rendering of a pattern table
+	GetPatternTableImage(ctx context.Context, in *PatternTableRequest, opts ...grpc.CallOption) (*FrameResponse, error)
+	// 512x480 rendering of all four nametables
+	GetNametableImage(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error)
+	// --- Logging: one level per subsystem (bus, cartridge, cpu, ppu), shared by every session ---
+	GetLogLevels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogLevels, error)
+	// Sets the levels given (trace, debug, info, warn or error; "all" sets every
+	// subsystem) and returns the levels of all subsystems
+	SetLogLevels(ctx context.Context, in *LogLevels, opts ...grpc.CallOption) (*LogLevels, error)
+}
+
+type debugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDebugServiceClient(cc grpc.ClientConnInterface) DebugServiceClient {
+	return &debugServiceClient{cc}
+}
+
+func (c *debugServiceClient) Pause(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_Pause_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_Resume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) Step(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_Step_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) AdvanceFrame(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_AdvanceFrame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) RunUntil(ctx context.Context, in *RunUntilRequest, opts ...grpc.CallOption) (*RunUntilResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunUntilResponse)
+	err := c.cc.Invoke(ctx, DebugService_RunUntil_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetCPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CPUStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CPUStateResponse)
+	err := c.cc.Invoke(ctx, DebugService_GetCPUState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ReadMemoryBlock(ctx context.Context, in *MemoryBlockRequest, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, DebugService_ReadMemoryBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) WriteMemoryBlock(ctx context.Context, in *MemoryWriteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_WriteMemoryBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) AddWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Watchpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchpoint)
+	err := c.cc.Invoke(ctx, DebugService_AddWatchpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) RemoveWatchpoint(ctx context.Context, in *Watchpoint, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_RemoveWatchpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ListWatchpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchpointList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchpointList)
+	err := c.cc.Invoke(ctx, DebugService_ListWatchpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetWatchHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WatchHit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchHit)
+	err := c.cc.Invoke(ctx, DebugService_GetWatchHit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) AddBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Breakpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Breakpoint)
+	err := c.cc.Invoke(ctx, DebugService_AddBreakpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) RemoveBreakpoint(ctx context.Context, in *Breakpoint, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_RemoveBreakpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ListBreakpoints(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BreakpointList)
+	err := c.cc.Invoke(ctx, DebugService_ListBreakpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetBreakpointHit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BreakpointHit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BreakpointHit)
+	err := c.cc.Invoke(ctx, DebugService_GetBreakpointHit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) AddCheat(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cheat)
+	err := c.cc.Invoke(ctx, DebugService_AddCheat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ListCheats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheatList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheatList)
+	err := c.cc.Invoke(ctx, DebugService_ListCheats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) SetCheatEnabled(ctx context.Context, in *Cheat, opts ...grpc.CallOption) (*Cheat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cheat)
+	err := c.cc.Invoke(ctx, DebugService_SetCheatEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, DebugService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) StartProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_StartProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) StopProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfileReport)
+	err := c.cc.Invoke(ctx, DebugService_StopProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetProfile(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfileReport)
+	err := c.cc.Invoke(ctx, DebugService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) StartCDL(ctx context.Context, in *CDLRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DebugService_StartCDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) StopCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CDLReport)
+	err := c.cc.Invoke(ctx, DebugService_StopCDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetCDL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CDLReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CDLReport)
+	err := c.cc.Invoke(ctx, DebugService_GetCDL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) Disassemble(ctx context.Context, in *DisassembleRequest, opts ...grpc.CallOption) (*DisassembleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisassembleResponse)
+	err := c.cc.Invoke(ctx, DebugService_Disassemble_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetMemoryMap(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryMap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryMap)
+	err := c.cc.Invoke(ctx, DebugService_GetMemoryMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ReadNametables(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, DebugService_ReadNametables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetPPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PPUStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PPUStateResponse)
+	err := c.cc.Invoke(ctx, DebugService_GetPPUState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetAPUState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*APUStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APUStateResponse)
+	err := c.cc.Invoke(ctx, DebugService_GetAPUState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ReadOAM(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, DebugService_ReadOAM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) ReadPalette(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemoryBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryBlockResponse)
+	err := c.cc.Invoke(ctx, DebugService_ReadPalette_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetPatternTableImage(ctx context.Context, in *PatternTableRequest, opts ...grpc.CallOption) (*FrameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameResponse)
+	err := c.cc.Invoke(ctx, DebugService_GetPatternTableImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetNametableImage(ctx context.Context, in *FrameRequest, opts ...grpc.CallOption) (*FrameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FrameResponse)
+	err := c.cc.Invoke(ctx, DebugService_GetNametableImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) GetLogLevels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, DebugService_GetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugServiceClient) SetLogLevels(ctx context.Context, in *LogLevels, opts ...grpc.CallOption) (*LogLevels, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevels)
+	err := c.cc.Invoke(ctx, DebugService_SetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DebugServiceServer is the server API for DebugService service.
+// All implementations must embed UnimplementedDebugServiceServer
+// for forward compatibility.
+//
+// Execution control, inspection and the hardware viewers used by vdb
+type DebugServiceServer interface {
+	// Pause returns once the emulator has stopped on an instruction boundary. Step and
+	// AdvanceFrame run the emulator one instruction, or to the end of the frame, and
+	// return once it has paused again.
+	Pause(context.Context, *Empty) (*Empty, error)
+	Resume(context.Context, *Empty) (*Empty, error)
+	Step(context.Context, *Empty) (*Empty, error)
+	AdvanceFrame(context.Context, *Empty) (*Empty, error)
+	// Resumes the emulator and blocks until the condition is met, a watchpoint or Pause
+	// stops it first, or the call is cancelled (which pauses the emulator)
+	RunUntil(context.Context, *RunUntilRequest) (*RunUntilResponse, error)
+	GetCPUState(context.Context, *Empty) (*CPUStateResponse, error)
+	ReadMemoryBlock(context.Context, *MemoryBlockRequest) (*MemoryBlockResponse, error)
+	// Writes through the CPU address space (RAM, PPU/APU registers, mapper registers, PRG RAM)
+	WriteMemoryBlock(context.Context, *MemoryWriteRequest) (*Empty, error)
+	// Watchpoints pause the emulator when the CPU reads or writes an address range
+	AddWatchpoint(context.Context, *Watchpoint) (*Watchpoint, error)
+	RemoveWatchpoint(context.Context, *Watchpoint) (*Empty, error)
+	ListWatchpoints(context.Context, *Empty) (*WatchpointList, error)
+	// Returns and clears the last watchpoint hit, if any
+	GetWatchHit(context.Context, *Empty) (*WatchHit, error)
+	// Breakpoints pause the emulator before the instruction at an address executes,
+	// optionally only when a condition holds (evaluated inside the emulator)
+	AddBreakpoint(context.Context, *Breakpoint) (*Breakpoint, error)
+	RemoveBreakpoint(context.Context, *Breakpoint) (*Empty, error)
+	ListBreakpoints(context.Context, *Empty) (*BreakpointList, error)
+	// Returns and clears the last breakpoint hit, if any
+	GetBreakpointHit(context.Context, *Empty) (*BreakpointHit, error)
+	// Cheat codes (Game Genie or raw "AAAA:VV" / "AAAA?CC:VV") patch what the CPU reads
+	AddCheat(context.Context, *Cheat) (*Cheat, error)
+	ListCheats(context.Context, *Empty) (*CheatList, error)
+	// Turns the cheat with the given id on or off
+	SetCheatEnabled(context.Context, *Cheat) (*Cheat, error)
+	// Evaluates expressions in the breakpoint condition language against the current state
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Instruction profiler: counts the instructions executed at each address and JSR calls
+	// to each subroutine between StartProfile and StopProfile
+	StartProfile(context.Context, *Empty) (*Empty, error)
+	StopProfile(context.Context, *Empty) (*ProfileReport, error)
+	// Returns the running or most recent profile
+	GetProfile(context.Context, *Empty) (*ProfileReport, error)
+	// Code/data logger: marks each PRG ROM byte executed as code or read as data, for
+	// export as an FCEUX .cdl file. StartCDL continues from the given log, or starts an
+	// empty one.
+	StartCDL(context.Context, *CDLRequest) (*Empty, error)
+	StopCDL(context.Context, *Empty) (*CDLReport, error)
+	// Returns the running or most recent log
+	GetCDL(context.Context, *Empty) (*CDLReport, error)
+	// Decodes instructions at an address (the PC by default)
+	Disassemble(context.Context, *DisassembleRequest) (*DisassembleResponse, error)
+	// Describes the CPU address space, including where each PRG window currently points
+	GetMemoryMap(context.Context, *Empty) (*MemoryMap, error)
+	// --- PPU viewers (read without side effects such as MMC3 IRQ clocking) ---
+	// Logical nametables $2000-$2FFF (4KB) as currently mirrored
+	ReadNametables(context.Context, *Empty) (*MemoryBlockResponse, error)
+	// Internal registers and raster position
+	GetPPUState(context.Context, *Empty) (*PPUStateResponse, error)
+	// Channel enables, periods, length counters and IRQ flags
+	GetAPUState(context.Context, *Empty) (*APUStateResponse, error)
+	// 256 bytes of sprite OAM
+	ReadOAM(context.Context, *Empty) (*MemoryBlockResponse, error)
+	// 32 bytes of palette RAM ($3F00-$3F1F)
+	ReadPalette(context.Context, *Empty) (*MemoryBlockResponse, error)
+	// 128x128 rendering of a pattern table
+	GetPatternTableImage(context.Context, *PatternTableRequest) (*FrameResponse, error)
+	// 512x480 rendering of all four nametables
+	GetNametableImage(context.Context, *FrameRequest) (*FrameResponse, error)
+	// --- Logging: one level per subsystem (bus, cartridge, cpu, ppu), shared by every session ---
+	GetLogLevels(context.Context, *Empty) (*LogLevels, error)
+	// Sets the levels given (trace, debug, info, warn or error; "all" sets every
+	// subsystem) and returns the levels of all subsystems
+	SetLogLevels(context.Context, *LogLevels) (*LogLevels, error)
+	mustEmbedUnimplementedDebugServiceServer()
+}
+
+// UnimplementedDebugServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDebugServiceServer struct{}
+
+func (UnimplementedDebugServiceServer) Pause(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedDebugServiceServer) Resume(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedDebugServiceServer) Step(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Step not implemented")
+}
+func (UnimplementedDebugServiceServer) AdvanceFrame(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdvanceFrame not implemented")
+}
+func (UnimplementedDebugServiceServer) RunUntil(context.Context, *RunUntilRequest) (*RunUntilResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunUntil not implemented")
+}
+func (UnimplementedDebugServiceServer) GetCPUState(context.Context, *Empty) (*CPUStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCPUState not implemented")
+}
+func (UnimplementedDebugServiceServer) ReadMemoryBlock(context.Context, *MemoryBlockRequest) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadMemoryBlock not implemented")
+}
+func (UnimplementedDebugServiceServer) WriteMemoryBlock(context.Context, *MemoryWriteRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method WriteMemoryBlock not implemented")
+}
+func (UnimplementedDebugServiceServer) AddWatchpoint(context.Context, *Watchpoint) (*Watchpoint, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddWatchpoint not implemented")
+}
+func (UnimplementedDebugServiceServer) RemoveWatchpoint(context.Context, *Watchpoint) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveWatchpoint not implemented")
+}
+func (UnimplementedDebugServiceServer) ListWatchpoints(context.Context, *Empty) (*WatchpointList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWatchpoints not implemented")
+}
+func (UnimplementedDebugServiceServer) GetWatchHit(context.Context, *Empty) (*WatchHit, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWatchHit not implemented")
+}
+func (UnimplementedDebugServiceServer) AddBreakpoint(context.Context, *Breakpoint) (*Breakpoint, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddBreakpoint not implemented")
+}
+func (UnimplementedDebugServiceServer) RemoveBreakpoint(context.Context, *Breakpoint) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveBreakpoint not implemented")
+}
+func (UnimplementedDebugServiceServer) ListBreakpoints(context.Context, *Empty) (*BreakpointList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBreakpoints not implemented")
+}
+func (UnimplementedDebugServiceServer) GetBreakpointHit(context.Context, *Empty) (*BreakpointHit, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBreakpointHit not implemented")
+}
+func (UnimplementedDebugServiceServer) AddCheat(context.Context, *Cheat) (*Cheat, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddCheat not implemented")
+}
+func (UnimplementedDebugServiceServer) ListCheats(context.Context, *Empty) (*CheatList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCheats not implemented")
+}
+func (UnimplementedDebugServiceServer) SetCheatEnabled(context.Context, *Cheat) (*Cheat, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCheatEnabled not implemented")
+}
+func (UnimplementedDebugServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedDebugServiceServer) StartProfile(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartProfile not implemented")
+}
+func (UnimplementedDebugServiceServer) StopProfile(context.Context, *Empty) (*ProfileReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopProfile not implemented")
+}
+func (UnimplementedDebugServiceServer) GetProfile(context.Context, *Empty) (*ProfileReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedDebugServiceServer) StartCDL(context.Context, *CDLRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartCDL not implemented")
+}
+func (UnimplementedDebugServiceServer) StopCDL(context.Context, *Empty) (*CDLReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopCDL not implemented")
+}
+func (UnimplementedDebugServiceServer) GetCDL(context.Context, *Empty) (*CDLReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCDL not implemented")
+}
+func (UnimplementedDebugServiceServer) Disassemble(context.Context, *DisassembleRequest) (*DisassembleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Disassemble not implemented")
+}
+func (UnimplementedDebugServiceServer) GetMemoryMap(context.Context, *Empty) (*MemoryMap, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMemoryMap not implemented")
+}
+func (UnimplementedDebugServiceServer) ReadNametables(context.Context, *Empty) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadNametables not implemented")
+}
+func (UnimplementedDebugServiceServer) GetPPUState(context.Context, *Empty) (*PPUStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPPUState not implemented")
+}
+func (UnimplementedDebugServiceServer) GetAPUState(context.Context, *Empty) (*APUStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAPUState not implemented")
+}
+func (UnimplementedDebugServiceServer) ReadOAM(context.Context, *Empty) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadOAM not implemented")
+}
+func (UnimplementedDebugServiceServer) ReadPalette(context.Context, *Empty) (*MemoryBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadPalette not implemented")
+}
+func (UnimplementedDebugServiceServer) GetPatternTableImage(context.Context, *PatternTableRequest) (*FrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPatternTableImage not implemented")
+}
+func (UnimplementedDebugServiceServer) GetNametableImage(context.Context, *FrameRequest) (*FrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNametableImage not implemented")
+}
+func (UnimplementedDebugServiceServer) GetLogLevels(context.Context, *Empty) (*LogLevels, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (UnimplementedDebugServiceServer) SetLogLevels(context.Context, *LogLevels) (*LogLevels, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLogLevels not implemented")
+}
+func (UnimplementedDebugServiceServer) mustEmbedUnimplementedDebugServiceServer() {}
+func (UnimplementedDebugServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeDebugServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DebugServiceServer will
+// result in compilation errors.
+type UnsafeDebugServiceServer interface {
+	mustEmbedUnimplementedDebugServiceServer()
+}
+
+func RegisterDebugServiceServer(s grpc.ServiceRegistrar, srv DebugServiceServer) {
+	// If the following call panics, it indicates UnimplementedDebugServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DebugService_ServiceDesc, srv)
+}
+
+func _DebugService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).Pause(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).Resume(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_Step_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).Step(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_AdvanceFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).AdvanceFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_AdvanceFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).AdvanceFrame(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_RunUntil_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunUntilRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).RunUntil(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_RunUntil_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).RunUntil(ctx, req.(*RunUntilRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetCPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetCPUState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetCPUState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetCPUState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ReadMemoryBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ReadMemoryBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ReadMemoryBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ReadMemoryBlock(ctx, req.(*MemoryBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_WriteMemoryBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryWriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).WriteMemoryBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_WriteMemoryBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).WriteMemoryBlock(ctx, req.(*MemoryWriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_AddWatchpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Watchpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).AddWatchpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_AddWatchpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).AddWatchpoint(ctx, req.(*Watchpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_RemoveWatchpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Watchpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).RemoveWatchpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_RemoveWatchpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).RemoveWatchpoint(ctx, req.(*Watchpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ListWatchpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ListWatchpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ListWatchpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ListWatchpoints(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetWatchHit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetWatchHit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetWatchHit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetWatchHit(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_AddBreakpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Breakpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).AddBreakpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_AddBreakpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).AddBreakpoint(ctx, req.(*Breakpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_RemoveBreakpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Breakpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).RemoveBreakpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_RemoveBreakpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).RemoveBreakpoint(ctx, req.(*Breakpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ListBreakpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ListBreakpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ListBreakpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ListBreakpoints(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetBreakpointHit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetBreakpointHit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetBreakpointHit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetBreakpointHit(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_AddCheat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Cheat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).AddCheat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_AddCheat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).AddCheat(ctx, req.(*Cheat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ListCheats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ListCheats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ListCheats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ListCheats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_SetCheatEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Cheat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).SetCheatEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_SetCheatEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).SetCheatEnabled(ctx, req.(*Cheat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_StartProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).StartProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_StartProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).StartProfile(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_StopProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).StopProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_StopProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).StopProfile(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetProfile(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_StartCDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CDLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).StartCDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_StartCDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).StartCDL(ctx, req.(*CDLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_StopCDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).StopCDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_StopCDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).StopCDL(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetCDL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetCDL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetCDL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetCDL(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_Disassemble_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisassembleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).Disassemble(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_Disassemble_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).Disassemble(ctx, req.(*DisassembleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetMemoryMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetMemoryMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetMemoryMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetMemoryMap(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ReadNametables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ReadNametables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ReadNametables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ReadNametables(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetPPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetPPUState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetPPUState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetPPUState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetAPUState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetAPUState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetAPUState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetAPUState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ReadOAM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ReadOAM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ReadOAM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ReadOAM(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_ReadPalette_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).ReadPalette(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_ReadPalette_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).ReadPalette(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetPatternTableImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetPatternTableImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetPatternTableImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetPatternTableImage(ctx, req.(*PatternTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetNametableImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FrameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetNametableImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetNametableImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetNametableImage(ctx, req.(*FrameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_GetLogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).GetLogLevels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugService_SetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevels)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServiceServer).SetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DebugService_SetLogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServiceServer).SetLogLevels(ctx, req.(*LogLevels))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DebugService_ServiceDesc is the grpc.ServiceDesc for DebugService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DebugService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vibemulator.v1.DebugService",
+	HandlerType: (*DebugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Pause",
+			Handler:    _DebugService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _DebugService_Resume_Handler,
+		},
+		{
+			MethodName: "Step",
+			Handler:    _DebugService_Step_Handler,
+		},
+		{
+			MethodName: "AdvanceFrame",
+			Handler:    _DebugService_AdvanceFrame_Handler,
+		},
+		{
+			MethodName: "RunUntil",
+			Handler:    _DebugService_RunUntil_Handler,
+		},
+		{
+			MethodName: "GetCPUState",
+			Handler:    _DebugService_GetCPUState_Handler,
+		},
+		{
+			MethodName: "ReadMemoryBlock",
+			Handler:    _DebugService_ReadMemoryBlock_Handler,
+		},
+		{
+			MethodName: "WriteMemoryBlock",
+			Handler:    _DebugService_WriteMemoryBlock_Handler,
+		},
+		{
+			MethodName: "AddWatchpoint",
+			Handler:    _DebugService_AddWatchpoint_Handler,
+		},
+		{
+			MethodName: "RemoveWatchpoint",
+			Handler:    _DebugService_RemoveWatchpoint_Handler,
+		},
+		{
+			MethodName: "ListWatchpoints",
+			Handler:    _DebugService_ListWatchpoints_Handler,
+		},
+		{
+			MethodName: "GetWatchHit",
+			Handler:    _DebugService_GetWatchHit_Handler,
+		},
+		{
+			MethodName: "AddBreakpoint",
+			Handler:    _DebugService_AddBreakpoint_Handler,
+		},
+		{
+			MethodName: "RemoveBreakpoint",
+			Handler:    _DebugService_RemoveBreakpoint_Handler,
+		},
+		{
+			MethodName: "ListBreakpoints",
+			Handler:    _DebugService_ListBreakpoints_Handler,
+		},
+		{
+			MethodName: "GetBreakpointHit",
+			Handler:    _DebugService_GetBreakpointHit_Handler,
+		},
+		{
+			MethodName: "AddCheat",
+			Handler:    _DebugService_AddCheat_Handler,
+		},
+		{
+			MethodName: "ListCheats",
+			Handler:    _DebugService_ListCheats_Handler,
+		},
+		{
+			MethodName: "SetCheatEnabled",
+			Handler:    _DebugService_SetCheatEnabled_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _DebugService_Evaluate_Handler,
+		},
+		{
+			MethodName: "StartProfile",
+			Handler:    _DebugService_StartProfile_Handler,
+		},
+		{
+			MethodName: "StopProfile",
+			Handler:    _DebugService_StopProfile_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _DebugService_GetProfile_Handler,
+		},
+		{
+			MethodName: "StartCDL",
+			Handler:    _DebugService_StartCDL_Handler,
+		},
+		{
+			MethodName: "StopCDL",
+			Handler:    _DebugService_StopCDL_Handler,
+		},
+		{
+			MethodName: "GetCDL",
+			Handler:    _DebugService_GetCDL_Handler,
+		},
+		{
+			MethodName: "Disassemble",
+			Handler:    _DebugService_Disassemble_Handler,
+		},
+		{
+			MethodName: "GetMemoryMap",
+			Handler:    _DebugService_GetMemoryMap_Handler,
+		},
+		{
+			MethodName: "ReadNametables",
+			Handler:    _DebugService_ReadNametables_Handler,
+		},
+		{
+			MethodName: "GetPPUState",
+			Handler:    _DebugService_GetPPUState_Handler,
+		},
+		{
+			MethodName: "GetAPUState",
+			Handler:    _DebugService_GetAPUState_Handler,
+		},
+		{
+			MethodName: "ReadOAM",
+			Handler:    _DebugService_ReadOAM_Handler,
+		},
+		{
+			MethodName: "ReadPalette",
+			Handler:    _DebugService_ReadPalette_Handler,
+		},
+		{
+			MethodName: "GetPatternTableImage",
+			Handler:    _DebugService_GetPatternTableImage_Handler,
+		},
+		{
+			MethodName: "GetNametableImage",
+			Handler:    _DebugService_GetNametableImage_Handler,
+		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _DebugService_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevels",
+			Handler:    _DebugService_SetLogLevels_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/services.proto",
+}
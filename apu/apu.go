@@ -1,5 +1,7 @@
 package apu
 
+import "sync"
+
 var lengthCounterTable = [...]byte{
 	10, 254, 20, 2, 40, 4, 80, 6, 160, 8, 60, 10, 14, 12, 26, 14,
 	12, 16, 24, 18, 48, 20, 96, 22, 192, 24, 72, 26, 16, 28, 32, 30,
@@ -120,6 +122,7 @@ type DMCChannel struct {
 	sampleBuffer      byte
 	sampleBufferEmpty bool
 	silenceFlag       bool
+	fetched           bool // The memory reader fetched a byte on the last clock
 
 	irqPending bool      // New field to signal IRQ
 	bus        BusReader // Interface to read from the bus
@@ -145,9 +148,31 @@ type APU struct {
 	sampleRate         float64
 	cpuClockRate       float64
 	sampleCycleCounter float64
-	sampleBuffer       []float32
+
+	// Samples waiting for the audio device, which reads them from its own goroutine
+	sampleMu     sync.Mutex
+	sampleBuffer []float32
+
+	tap SampleTap
 }
 
+// Channel indexes of the samples a SampleTap receives.
+const (
+	Pulse1 = iota
+	Pulse2
+	Triangle
+	Noise
+	DMC
+	NumChannels
+)
+
+// ChannelNames names the channels, in index order.
+var ChannelNames = [NumChannels]string{"pulse1", "pulse2", "triangle", "noise", "dmc"}
+
+// SampleTap receives every sample the APU generates, along with each channel's part of
+// it. The parts are at their mixed levels, so they add up to the sample.
+type SampleTap func(mix float32, channels [NumChannels]float32)
+
 // BusReader defines the interface the APU needs to read from the bus.
 type BusReader interface {
 	Read(addr uint16) byte
@@ -169,6 +194,42 @@ func New() *APU {
 	return apu
 }
 
+// Reset returns every channel, the frame counter and the sample buffer to their power-on state.
+func (a *APU) Reset() {
+	a.pulse1 = &PulseChannel{isPulse1: true}
+	a.pulse2 = &PulseChannel{isPulse1: false}
+	a.triangle = &TriangleChannel{}
+	a.noise = &NoiseChannel{shiftRegister: 1}
+	a.dmc = &DMCChannel{sampleBufferEmpty: true, silenceFlag: true, bus: a.bus}
+	a.cycle = 0
+	a.frameCounter = 0
+	a.frameSequenceStep = 0
+	a.sequenceMode = 0
+	a.irqInhibit = false
+	a.DmcIRQ = false
+	a.FrameIRQ = false
+	a.sampleCycleCounter = 0
+	a.sampleMu.Lock()
+	a.sampleBuffer = a.sampleBuffer[:0]
+	a.sampleMu.Unlock()
+}
+
+// SetSampleRate sets the rate ReadSamples produces audio at, which must match the output device.
+func (a *APU) SetSampleRate(rate float64) {
+	a.sampleRate = rate
+}
+
+// SampleRate returns the rate samples are generated at.
+func (a *APU) SampleRate() float64 {
+	return a.sampleRate
+}
+
+// SetSampleTap has tap receive every sample from now on, e.g. to record them, or stops
+// if tap is nil. It is called on the goroutine clocking the APU.
+func (a *APU) SetSampleTap(tap SampleTap) {
+	a.tap = tap
+}
+
 // ConnectBus connects the bus to the APU.
 func (a *APU) ConnectBus(bus BusReader) {
 	a.bus = bus
@@ -180,8 +241,17 @@ func (d *DMCChannel) ConnectBus(bus BusReader) {
 	d.bus = bus
 }
 
+// QueuedSamples returns how many samples are waiting to be read.
+func (a *APU) QueuedSamples() int {
+	a.sampleMu.Lock()
+	defer a.sampleMu.Unlock()
+	return len(a.sampleBuffer)
+}
+
 // ReadSamples reads generated samples into a byte buffer.
 func (a *APU) ReadSamples(p []byte) (n int, err error) {
+	a.sampleMu.Lock()
+	defer a.sampleMu.Unlock()
 	numSamples := len(p) / 4 // 2 channels, 2 bytes each
 	if numSamples > len(a.sampleBuffer) {
 		numSamples = len(a.sampleBuffer)
@@ -204,19 +274,27 @@ func (a *APU) ReadSamples(p []byte) (n int, err error) {
 	return written, nil
 }
 
-// output returns the current mixed audio sample.
-func (a *APU) output() float32 {
-	p1 := a.pulse1.output()
-	p2 := a.pulse2.output()
-	t := a.triangle.output()
-	n := a.noise.output()
-	d := a.dmc.output()
-
+// output returns the current mixed audio sample and each channel's part of it.
+func (a *APU) output() (float32, [NumChannels]float32) {
 	// Approximation of NES mixing levels
-	pulseOut := 0.00752 * float32(p1+p2)
-	tndOut := 0.00851*float32(t) + 0.00494*float32(n) + 0.00335*float32(d)
+	channels := [NumChannels]float32{
+		Pulse1:   0.00752 * float32(a.pulse1.output()),
+		Pulse2:   0.00752 * float32(a.pulse2.output()),
+		Triangle: 0.00851 * float32(a.triangle.output()),
+		Noise:    0.00494 * float32(a.noise.output()),
+		DMC:      0.00335 * float32(a.dmc.output()),
+	}
+	var mix float32
+	for _, c := range channels {
+		mix += c
+	}
+	return mix, channels
+}
 
-	return pulseOut + tndOut
+// DMCFetched reports whether the DMC read a sample byte from CPU memory on the last
+// clock. On hardware the fetch halts the CPU, which repeats the read it was making.
+func (a *APU) DMCFetched() bool {
+	return a.dmc.fetched
 }
 
 // Clock performs one APU clock cycle.
@@ -283,7 +361,13 @@ func (a *APU) Clock() {
 	a.sampleCycleCounter += a.sampleRate / a.cpuClockRate
 	if a.sampleCycleCounter >= 1 {
 		a.sampleCycleCounter--
-		a.sampleBuffer = append(a.sampleBuffer, a.output())
+		sample, channels := a.output()
+		a.sampleMu.Lock()
+		a.sampleBuffer = append(a.sampleBuffer, sample)
+		a.sampleMu.Unlock()
+		if a.tap != nil {
+			a.tap(sample, channels)
+		}
 	}
 
 	a.cycle++
@@ -445,7 +529,8 @@ func (n *NoiseChannel) Clock() {
 
 func (d *DMCChannel) Clock(bus BusReader) {
 	// 1. Memory Reader: if sample buffer is empty and we have bytes remaining, fetch next byte
-	if d.sampleBufferEmpty && d.bytesRemaining > 0 {
+	d.fetched = d.sampleBufferEmpty && d.bytesRemaining > 0
+	if d.fetched {
 		d.sampleBuffer = bus.Read(d.currentAddress)
 		d.sampleBufferEmpty = false
 		d.currentAddress++
@@ -592,37 +677,45 @@ func (d *DMCChannel) output() byte {
 
 // CPURead handles CPU reads from the APU's registers.
 func (a *APU) CPURead(addr uint16) byte {
-	var data byte
-	switch addr {
-	case 0x4015: // Status register
-		// Bits 0-4: Length counter status for Pulse 1, Pulse 2, Triangle, Noise, DMC
-		if a.pulse1.lengthCounter > 0 {
-			data |= 0x01
-		}
-		if a.pulse2.lengthCounter > 0 {
-			data |= 0x02
-		}
-		if a.triangle.lengthCounter > 0 {
-			data |= 0x04
-		}
-		if a.noise.lengthCounter > 0 {
-			data |= 0x08
-		}
-		if a.dmc.bytesRemaining > 0 { // DMC status is bytes remaining, not length counter
-			data |= 0x10
-		}
-		// Bit 6: Frame Interrupt Flag (cleared on read)
-		if a.FrameIRQ {
-			data |= 0x40
-			a.FrameIRQ = false
-		}
-		// Bit 7: DMC Interrupt Flag (cleared on read)
-		if a.DmcIRQ {
-			data |= 0x80
-			a.DmcIRQ = false
-			a.dmc.irqPending = false
-		}
+	if addr != 0x4015 {
+		return 0
+	}
+	data := a.status()
+	// Reading clears the frame and DMC interrupt flags
+	a.FrameIRQ = false
+	if a.DmcIRQ {
+		a.DmcIRQ = false
+		a.dmc.irqPending = false
+	}
+	return data
+}
 
+// status returns the value of the status register ($4015).
+func (a *APU) status() byte {
+	var data byte
+	// Bits 0-4: Length counter status for Pulse 1, Pulse 2, Triangle, Noise, DMC
+	if a.pulse1.lengthCounter > 0 {
+		data |= 0x01
+	}
+	if a.pulse2.lengthCounter > 0 {
+		data |= 0x02
+	}
+	if a.triangle.lengthCounter > 0 {
+		data |= 0x04
+	}
+	if a.noise.lengthCounter > 0 {
+		data |= 0x08
+	}
+	if a.dmc.bytesRemaining > 0 { // DMC status is bytes remaining, not length counter
+		data |= 0x10
+	}
+	// Bit 6: Frame Interrupt Flag
+	if a.FrameIRQ {
+		data |= 0x40
+	}
+	// Bit 7: DMC Interrupt Flag
+	if a.DmcIRQ {
+		data |= 0x80
 	}
 	return data
 }
@@ -0,0 +1,93 @@
+package apu
+
+// Channel summarizes one sound channel for debuggers.
+type Channel struct {
+	Enabled       bool
+	Period        uint16 // Timer period in timer clocks, after the noise and DMC table lookups
+	LengthCounter byte
+	Halt          bool // Length counter halt (envelope loop)
+
+	// Current output level: the envelope or constant volume, the triangle's linear
+	// counter, or the DMC's output level
+	Volume byte
+}
+
+// Registers summarizes the APU for debuggers.
+type Registers struct {
+	Pulse1, Pulse2, Triangle, Noise, DMC Channel
+	DMCAddress, DMCBytesRemaining        uint16
+	FiveStep, IRQInhibit                 bool // Frame counter mode ($4017)
+	FrameIRQ, DMCIRQ                     bool
+}
+
+// CPUDebugRead returns what a CPU read of an APU register would, without clearing the
+// interrupt flags.
+func (a *APU) CPUDebugRead(addr uint16) byte {
+	if addr != 0x4015 {
+		return 0
+	}
+	return a.status()
+}
+
+// GetRegisters returns the APU's channel state without side effects.
+func (a *APU) GetRegisters() Registers {
+	return Registers{
+		Pulse1:            a.pulse1.debug(),
+		Pulse2:            a.pulse2.debug(),
+		Triangle:          a.triangle.debug(),
+		Noise:             a.noise.debug(),
+		DMC:               a.dmc.debug(),
+		DMCAddress:        a.dmc.currentAddress,
+		DMCBytesRemaining: a.dmc.bytesRemaining,
+		FiveStep:          a.sequenceMode == 1,
+		IRQInhibit:        a.irqInhibit,
+		FrameIRQ:          a.FrameIRQ,
+		DMCIRQ:            a.DmcIRQ,
+	}
+}
+
+func (p *PulseChannel) debug() Channel {
+	return Channel{
+		Enabled:       p.enabled,
+		Period:        p.timer,
+		LengthCounter: p.lengthCounter,
+		Halt:          p.lengthCounterHalt,
+		Volume:        envelope(p.constantVolume, p.volume, p.envelopeCounter),
+	}
+}
+
+func (t *TriangleChannel) debug() Channel {
+	return Channel{
+		Enabled:       t.enabled,
+		Period:        t.timer,
+		LengthCounter: t.lengthCounter,
+		Halt:          t.lengthCounterHalt,
+		Volume:        t.linearCounter,
+	}
+}
+
+func (n *NoiseChannel) debug() Channel {
+	return Channel{
+		Enabled:       n.enabled,
+		Period:        noiseTimerTable[n.timerPeriod&0x0F],
+		LengthCounter: n.lengthCounter,
+		Halt:          n.lengthCounterHalt,
+		Volume:        envelope(n.constantVolume, n.volume, n.envelopeCounter),
+	}
+}
+
+func (d *DMCChannel) debug() Channel {
+	return Channel{
+		Enabled: d.enabled,
+		Period:  dmcRateTable[d.rateIndex&0x0F],
+		Volume:  d.outputLevel,
+	}
+}
+
+// envelope returns the volume a pulse or noise channel's envelope unit is producing
+func envelope(constant bool, volume, decay byte) byte {
+	if constant {
+		return volume
+	}
+	return decay
+}
@@ -1,5 +1,7 @@
 package apu
 
+import "github.com/meadori/vibemulator/snap"
+
 type PulseState struct {
 	Enabled, IsPulse1, LengthCounterHalt, ConstantVolume, SweepEnabled, SweepNegate, SweepReloadFlag, EnvelopeStartFlag                      bool
 	DutyCycle, Volume, SweepPeriod, SweepShift, LengthCounter, DutySequencer, SweepCounter, EnvelopeVolume, EnvelopeDivider, EnvelopeCounter byte
@@ -82,3 +84,91 @@ func (a *APU) LoadState(s State) {
 	a.dmc.LoadState(s.DMC)
 	a.cycle, a.frameCounter, a.frameSequenceStep, a.sequenceMode, a.irqInhibit, a.DmcIRQ, a.FrameIRQ, a.sampleCycleCounter = s.Cycle, s.FrameCounter, s.FrameSequenceStep, s.SequenceMode, s.IrqInhibit, s.DmcIRQ, s.FrameIRQ, s.SampleCycleCounter
 }
+
+// Snapshot writes the state saved by SaveState in snap's flat encoding.
+func (a *APU) Snapshot(w *snap.Writer) {
+	s := a.SaveState()
+	for _, p := range [...]PulseState{s.Pulse1, s.Pulse2} {
+		for _, v := range [...]bool{p.Enabled, p.IsPulse1, p.LengthCounterHalt, p.ConstantVolume, p.SweepEnabled, p.SweepNegate, p.SweepReloadFlag, p.EnvelopeStartFlag} {
+			w.Bool(v)
+		}
+		for _, v := range [...]byte{p.DutyCycle, p.Volume, p.SweepPeriod, p.SweepShift, p.LengthCounter, p.DutySequencer, p.SweepCounter, p.EnvelopeVolume, p.EnvelopeDivider, p.EnvelopeCounter} {
+			w.U8(v)
+		}
+		w.U16(p.Timer)
+		w.U16(p.TimerCounter)
+	}
+
+	t := s.Triangle
+	for _, v := range [...]bool{t.Enabled, t.LengthCounterHalt, t.LinearCounterReloadFlag} {
+		w.Bool(v)
+	}
+	for _, v := range [...]byte{t.LinearCounterLoad, t.LinearCounter, t.LengthCounter, t.DutySequencer} {
+		w.U8(v)
+	}
+	w.U16(t.Timer)
+	w.U16(t.TimerCounter)
+
+	n := s.Noise
+	for _, v := range [...]bool{n.Enabled, n.LengthCounterHalt, n.ConstantVolume, n.Mode, n.EnvelopeStartFlag} {
+		w.Bool(v)
+	}
+	for _, v := range [...]byte{n.Volume, n.TimerPeriod, n.LengthCounter, n.EnvelopeVolume, n.EnvelopeDivider, n.EnvelopeCounter} {
+		w.U8(v)
+	}
+	w.U16(n.ShiftRegister)
+	w.U16(n.TimerCounter)
+
+	d := s.DMC
+	for _, v := range [...]bool{d.Enabled, d.IrqEnabled, d.Loop, d.SampleBufferEmpty, d.SilenceFlag, d.IrqPending} {
+		w.Bool(v)
+	}
+	for _, v := range [...]byte{d.RateIndex, d.OutputLevel, d.ShiftRegister, d.BitsRemaining, d.SampleBuffer} {
+		w.U8(v)
+	}
+	for _, v := range [...]uint16{d.Timer, d.SampleAddress, d.SampleLength, d.CurrentAddress, d.BytesRemaining} {
+		w.U16(v)
+	}
+
+	w.U64(s.Cycle)
+	w.U64(s.FrameCounter)
+	w.U8(s.FrameSequenceStep)
+	w.U8(s.SequenceMode)
+	w.Bool(s.IrqInhibit)
+	w.Bool(s.DmcIRQ)
+	w.Bool(s.FrameIRQ)
+	w.Float64(s.SampleCycleCounter)
+}
+
+// Restore loads a state written by Snapshot.
+func (a *APU) Restore(r *snap.Reader) {
+	var s State
+	for _, p := range [...]*PulseState{&s.Pulse1, &s.Pulse2} {
+		p.Enabled, p.IsPulse1, p.LengthCounterHalt, p.ConstantVolume = r.Bool(), r.Bool(), r.Bool(), r.Bool()
+		p.SweepEnabled, p.SweepNegate, p.SweepReloadFlag, p.EnvelopeStartFlag = r.Bool(), r.Bool(), r.Bool(), r.Bool()
+		p.DutyCycle, p.Volume, p.SweepPeriod, p.SweepShift, p.LengthCounter = r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+		p.DutySequencer, p.SweepCounter, p.EnvelopeVolume, p.EnvelopeDivider, p.EnvelopeCounter = r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+		p.Timer, p.TimerCounter = r.U16(), r.U16()
+	}
+
+	t := &s.Triangle
+	t.Enabled, t.LengthCounterHalt, t.LinearCounterReloadFlag = r.Bool(), r.Bool(), r.Bool()
+	t.LinearCounterLoad, t.LinearCounter, t.LengthCounter, t.DutySequencer = r.U8(), r.U8(), r.U8(), r.U8()
+	t.Timer, t.TimerCounter = r.U16(), r.U16()
+
+	n := &s.Noise
+	n.Enabled, n.LengthCounterHalt, n.ConstantVolume, n.Mode, n.EnvelopeStartFlag = r.Bool(), r.Bool(), r.Bool(), r.Bool(), r.Bool()
+	n.Volume, n.TimerPeriod, n.LengthCounter, n.EnvelopeVolume, n.EnvelopeDivider, n.EnvelopeCounter = r.U8(), r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+	n.ShiftRegister, n.TimerCounter = r.U16(), r.U16()
+
+	d := &s.DMC
+	d.Enabled, d.IrqEnabled, d.Loop, d.SampleBufferEmpty, d.SilenceFlag, d.IrqPending = r.Bool(), r.Bool(), r.Bool(), r.Bool(), r.Bool(), r.Bool()
+	d.RateIndex, d.OutputLevel, d.ShiftRegister, d.BitsRemaining, d.SampleBuffer = r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+	d.Timer, d.SampleAddress, d.SampleLength, d.CurrentAddress, d.BytesRemaining = r.U16(), r.U16(), r.U16(), r.U16(), r.U16()
+
+	s.Cycle, s.FrameCounter = r.U64(), r.U64()
+	s.FrameSequenceStep, s.SequenceMode = r.U8(), r.U8()
+	s.IrqInhibit, s.DmcIRQ, s.FrameIRQ = r.Bool(), r.Bool(), r.Bool()
+	s.SampleCycleCounter = r.Float64()
+	a.LoadState(s)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/meadori/vibemulator/bus"
+)
+
+// nesFPS is the NTSC NES frame rate, used to express speed as a multiple of real time.
+const nesFPS = 60.0988
+
+// runBenchmark emulates for d without a window or frame pacing, prints the achieved
+// speed and allocation statistics, then exits. The machine details make reports from
+// different computers and releases comparable.
+func runBenchmark(b *bus.Bus, d time.Duration) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	clocks := b.SystemClocks
+
+	frames := 0
+	start := time.Now()
+	for time.Since(start) < d {
+		b.RunFrame()
+		frames++
+	}
+	elapsed := time.Since(start).Seconds()
+
+	runtime.ReadMemStats(&after)
+	cycles := (b.SystemClocks - clocks) / 3
+	allocs := after.Mallocs - before.Mallocs
+
+	fmt.Printf("%s %s %s/%s, %d CPUs\n", bus.Version, runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	fmt.Printf("frames:      %d in %.2fs, %.1f fps (%.2fx real time)\n", frames, elapsed, float64(frames)/elapsed, float64(frames)/elapsed/nesFPS)
+	fmt.Printf("cpu cycles:  %d, %.2f MHz\n", cycles, float64(cycles)/elapsed/1e6)
+	fmt.Printf("allocations: %d (%.1f per frame), %d bytes, %d GCs\n",
+		allocs, float64(allocs)/float64(max(frames, 1)), after.TotalAlloc-before.TotalAlloc, after.NumGC-before.NumGC)
+	os.Exit(0)
+}
+
+// benchCmd runs the benchmark on the ROM given as the only argument.
+func benchCmd(args []string) {
+	fs := newFlagSet("bench")
+	d := fs.Duration("time", 10*time.Second, "how long to emulate")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	runBenchmark(newBus(fs.Arg(0)), *d)
+}
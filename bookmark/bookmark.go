@@ -0,0 +1,158 @@
+// Package bookmark saves a moment of play as one file: a screenshot, a savestate and a
+// note, so practice setups such as a speedrun split can be browsed by eye and loaded
+// later. A bookmark is a zip archive holding
+//
+//	bookmark.json   the Info below
+//	screenshot.png  the frame on screen
+//	state.sav       a savestate, as written by bus.SaveStateToBytes
+package bookmark
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/meadori/vibemulator/storage"
+)
+
+// Ext is the file extension of bookmarks.
+const Ext = ".zip"
+
+const (
+	infoName       = "bookmark.json"
+	screenshotName = "screenshot.png"
+	stateName      = "state.sav"
+)
+
+// Info describes a bookmark.
+type Info struct {
+	ROMHash string    `json:"rom_hash"` // SHA-1 of the ROM the state was saved on
+	ROMName string    `json:"rom_name"`
+	Frame   int       `json:"frame"` // PPU frame number when it was saved
+	Note    string    `json:"note"`
+	Created time.Time `json:"created"`
+}
+
+// Save writes a bookmark to path, replacing any file there.
+func Save(path string, info Info, screenshot image.Image, state []byte) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	add := func(name string, write func(io.Writer) error) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		return write(w)
+	}
+	err := add(infoName, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	})
+	if err == nil {
+		err = add(screenshotName, func(w io.Writer) error { return png.Encode(w, screenshot) })
+	}
+	if err == nil {
+		err = add(stateName, func(w io.Writer) error {
+			_, err := w.Write(state)
+			return err
+		})
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build bookmark %s: %v", path, err)
+	}
+	return storage.WriteFile(path, buf.Bytes())
+}
+
+// ReadInfo reads the Info of the bookmark at path.
+func ReadInfo(path string) (Info, error) {
+	var info Info
+	data, err := readFile(path, infoName)
+	if err == nil {
+		err = json.Unmarshal(data, &info)
+	}
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to read bookmark %s: %v", path, err)
+	}
+	return info, nil
+}
+
+// ReadState reads the savestate of the bookmark at path.
+func ReadState(path string) ([]byte, error) {
+	data, err := readFile(path, stateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmark %s: %v", path, err)
+	}
+	return data, nil
+}
+
+// ReadScreenshot reads the frame that was on screen when the bookmark at path was saved.
+func ReadScreenshot(path string) (image.Image, error) {
+	data, err := readFile(path, screenshotName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmark %s: %v", path, err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the screenshot in %s: %v", path, err)
+	}
+	return img, nil
+}
+
+func readFile(path, name string) ([]byte, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// Entry is a bookmark found by List.
+type Entry struct {
+	Path string
+	Info Info
+}
+
+// List reads every bookmark in dir, newest first. A missing directory has none; files
+// that can't be read are skipped.
+func List(dir string) ([]Entry, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %v", err)
+	}
+	var out []Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), Ext) {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		info, err := ReadInfo(path)
+		if err != nil {
+			continue
+		}
+		out = append(out, Entry{path, info})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Info.Created.After(out[j].Info.Created) })
+	return out, nil
+}
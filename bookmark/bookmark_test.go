@@ -0,0 +1,58 @@
+package bookmark
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveAndRead(t *testing.T) {
+	dir := t.TempDir()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(1, 1, color.RGBA{10, 20, 30, 255})
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i, note := range []string{"1-1 warp", "8-4 bowser"} {
+		info := Info{ROMHash: "abc", ROMName: "smb.nes", Frame: 100 * i, Note: note, Created: start.Add(time.Duration(i) * time.Minute)}
+		if err := Save(filepath.Join(dir, note+Ext), info, img, []byte{byte(i), 1, 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "junk"+Ext), []byte("not a zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Info.Note != "8-4 bowser" || list[1].Info.Frame != 0 {
+		t.Fatalf("Expected both bookmarks newest first, got %+v", list)
+	}
+	if !list[0].Info.Created.Equal(start.Add(time.Minute)) || list[0].Info.ROMHash != "abc" {
+		t.Errorf("Unexpected info %+v", list[0].Info)
+	}
+
+	state, err := ReadState(list[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(state, []byte{1, 1, 2}) {
+		t.Errorf("Expected the saved state, got %v", state)
+	}
+	shot, err := ReadScreenshot(list[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := shot.At(1, 1).RGBA(); r>>8 != 10 || g>>8 != 20 || b>>8 != 30 {
+		t.Errorf("Expected the saved screenshot, got %v", shot.At(1, 1))
+	}
+
+	if list, err := List(filepath.Join(dir, "missing")); err != nil || len(list) != 0 {
+		t.Errorf("Expected no bookmarks in a missing directory, got %v (err=%v)", list, err)
+	}
+}
@@ -0,0 +1,84 @@
+package bus
+
+import (
+	"os"
+	"testing"
+)
+
+// newBenchBus boots the nestest ROM to its menu, which renders the background and
+// handles NMIs every frame.
+func newBenchBus(tb testing.TB) *Bus {
+	tb.Helper()
+	rom, err := os.ReadFile(determinismROM)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	b := New()
+	if err := b.LoadROM(rom); err != nil {
+		tb.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		b.RunFrame()
+	}
+	return b
+}
+
+func BenchmarkRunFrame(b *testing.B) {
+	bus := newBenchBus(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		bus.RunFrame()
+	}
+}
+
+// BenchmarkMemoryAccess reads and writes RAM and reads PRG ROM, the accesses that make up
+// most of a frame.
+func BenchmarkMemoryAccess(b *testing.B) {
+	bus := newBenchBus(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		for addr := uint16(0); addr < 0x0800; addr++ {
+			bus.Write(addr, bus.Read(addr)+1)
+			bus.Read(0xC000 | addr)
+		}
+	}
+}
+
+// TestRunFrameAllocs holds emulation to zero allocations per frame, so the garbage
+// collector never runs during gameplay.
+func TestRunFrameAllocs(t *testing.T) {
+	b := newBenchBus(t)
+	if allocs := testing.AllocsPerRun(10, b.RunFrame); allocs != 0 {
+		t.Errorf("Expected RunFrame not to allocate, got %v allocations per frame", allocs)
+	}
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+	bus := newBenchBus(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		ReleaseSnapshot(bus.Snapshot())
+	}
+}
+
+func BenchmarkRestoreSnapshot(b *testing.B) {
+	bus := newBenchBus(b)
+	s := bus.Snapshot()
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := bus.RestoreSnapshot(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSaveStateToBytes is the gob savestate path, for comparison with BenchmarkSnapshot.
+func BenchmarkSaveStateToBytes(b *testing.B) {
+	bus := newBenchBus(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := bus.SaveStateToBytes(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
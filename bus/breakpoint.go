@@ -0,0 +1,195 @@
+package bus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/meadori/vibemulator/expr"
+)
+
+// Breakpoint pauses the emulator before the instruction at Addr executes, when its
+// condition (if any) holds. A pausepoint instead pauses on the first instruction boundary
+// of frame Frame.
+type Breakpoint struct {
+	ID        HookID
+	Addr      uint16
+	Condition string // Empty for an unconditional breakpoint
+	Frame     int    // Non-zero for a pausepoint
+}
+
+// BreakHit describes the breakpoint that paused the emulator.
+type BreakHit struct {
+	Breakpoint Breakpoint
+	Frame      int
+}
+
+// breakState tracks breakpoints; it is shared between the emulation and debugger goroutines.
+type breakState struct {
+	mu     sync.Mutex
+	points map[HookID]Breakpoint
+	conds  map[HookID]*expr.Expr // Parsed conditions of address breakpoints
+	hit    *BreakHit
+}
+
+// AddBreakpoint installs a breakpoint at addr. The condition uses the expr language and
+// is evaluated on the emulation goroutine each time the CPU reaches addr.
+func (b *Bus) AddBreakpoint(addr uint16, condition string) (Breakpoint, error) {
+	var cond *expr.Expr
+	if condition != "" {
+		var err error
+		if cond, err = expr.Parse(condition); err != nil {
+			return Breakpoint{}, fmt.Errorf("failed to parse condition: %v", err)
+		}
+	}
+
+	bp := Breakpoint{ID: b.hooks.newID(), Addr: addr, Condition: condition}
+	b.breaks.mu.Lock()
+	defer b.breaks.mu.Unlock()
+	b.addBreakpoint(bp)
+	if b.breaks.conds == nil {
+		b.breaks.conds = make(map[HookID]*expr.Expr)
+	}
+	b.breaks.conds[bp.ID] = cond
+	b.cpu.SetBreakpoint(addr, true)
+	return bp, nil
+}
+
+// onBreakpoint is the CPU's break hook: it pauses the emulator if a breakpoint at pc
+// has no condition or one that holds. Runs on the emulation goroutine.
+func (b *Bus) onBreakpoint(pc uint16) {
+	b.breaks.mu.Lock()
+	var hit *Breakpoint
+	for _, bp := range b.breaks.points {
+		if bp.Frame == 0 && bp.Addr == pc && (hit == nil || bp.ID < hit.ID) {
+			if cond := b.breaks.conds[bp.ID]; cond == nil || cond.True(b.exprEnv()) {
+				hit = &bp
+			}
+		}
+	}
+	b.breaks.mu.Unlock()
+
+	if hit != nil {
+		b.breakHit(*hit)
+	}
+}
+
+// AddPausepoint pauses the emulator when frame starts, after its input has been latched.
+// Frame starts land mid-instruction, so the pause takes effect on the next instruction
+// boundary.
+func (b *Bus) AddPausepoint(frame int) (Breakpoint, error) {
+	if frame <= 0 {
+		return Breakpoint{}, fmt.Errorf("invalid pausepoint frame %d", frame)
+	}
+
+	bp := Breakpoint{Frame: frame}
+	bp.ID = b.OnFrame(func(f int) {
+		if f == frame {
+			b.recordBreakHit(bp)
+			b.pauseAtBoundary.Store(true)
+		}
+	})
+	b.breaks.mu.Lock()
+	defer b.breaks.mu.Unlock()
+	b.addBreakpoint(bp)
+	return bp, nil
+}
+
+// addBreakpoint lists bp. The caller holds breaks.mu.
+func (b *Bus) addBreakpoint(bp Breakpoint) {
+	if b.breaks.points == nil {
+		b.breaks.points = make(map[HookID]Breakpoint)
+	}
+	b.breaks.points[bp.ID] = bp
+}
+
+// RemoveBreakpoint deletes a breakpoint and reports whether it existed.
+func (b *Bus) RemoveBreakpoint(id HookID) bool {
+	b.breaks.mu.Lock()
+	defer b.breaks.mu.Unlock()
+	bp, ok := b.breaks.points[id]
+	if !ok {
+		return false
+	}
+	delete(b.breaks.points, id)
+	delete(b.breaks.conds, id)
+
+	if bp.Frame != 0 {
+		b.RemoveHook(id)
+		return true
+	}
+	// The CPU stops at an address until its last breakpoint goes
+	for _, other := range b.breaks.points {
+		if other.Frame == 0 && other.Addr == bp.Addr {
+			return true
+		}
+	}
+	b.cpu.SetBreakpoint(bp.Addr, false)
+	return true
+}
+
+// Breakpoints lists the installed breakpoints in creation order.
+func (b *Bus) Breakpoints() []Breakpoint {
+	b.breaks.mu.Lock()
+	defer b.breaks.mu.Unlock()
+
+	list := make([]Breakpoint, 0, len(b.breaks.points))
+	for _, bp := range b.breaks.points {
+		list = append(list, bp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// TakeBreakHit returns and clears the most recent breakpoint hit.
+func (b *Bus) TakeBreakHit() (BreakHit, bool) {
+	b.breaks.mu.Lock()
+	defer b.breaks.mu.Unlock()
+
+	if b.breaks.hit == nil {
+		return BreakHit{}, false
+	}
+	hit := *b.breaks.hit
+	b.breaks.hit = nil
+	return hit, true
+}
+
+// breakHit records a hit and pauses the emulator. Runs on the emulation goroutine.
+func (b *Bus) breakHit(bp Breakpoint) {
+	b.recordBreakHit(bp)
+	b.setExecState(Paused)
+}
+
+// recordBreakHit records a hit and abandons any pending RunUntil, which the hit preempts
+func (b *Bus) recordBreakHit(bp Breakpoint) {
+	hit := BreakHit{Breakpoint: bp, Frame: b.PPU.FrameCounter}
+
+	b.breaks.mu.Lock()
+	b.breaks.hit = &hit
+	b.breaks.mu.Unlock()
+
+	b.cancelUntil()
+}
+
+// Evaluate parses and evaluates an expression in the expr language against the current
+// machine state.
+func (b *Bus) Evaluate(src string) (int64, error) {
+	e, err := expr.Parse(src)
+	if err != nil {
+		return 0, err
+	}
+	return e.Eval(b.exprEnv()), nil
+}
+
+// exprEnv captures the machine state for evaluating debugger expressions
+func (b *Bus) exprEnv() *expr.Env {
+	a, x, y, sp, p, pc, _ := b.cpu.GetState()
+	return &expr.Env{
+		A: a, X: x, Y: y, SP: sp, P: p, PC: pc,
+		Scanline: b.PPU.Scanline,
+		Dot:      b.PPU.Cycle,
+		Frame:    b.PPU.FrameCounter,
+		// Like GetMemoryBlock, condition reads have no side effects
+		Read: b.debugRead,
+	}
+}
@@ -0,0 +1,116 @@
+package bus
+
+import "testing"
+
+func TestBreakpoint(t *testing.T) {
+	b := newTestBus(t)
+	bp, err := b.AddBreakpoint(0x8007, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clockUntilPaused(t, b)
+	hit, ok := b.TakeBreakHit()
+	if !ok || hit.Breakpoint.ID != bp.ID {
+		t.Fatalf("Expected a hit on breakpoint %d, got %+v", bp.ID, hit)
+	}
+	if _, _, _, _, _, pc, _ := b.GetCPUState(); pc != 0x8007 {
+		t.Errorf("Expected to stop before $8007, got PC $%04X", pc)
+	}
+	if b.ram[0] != 1 {
+		t.Errorf("Expected the INC before the breakpoint to have run once, got %d", b.ram[0])
+	}
+}
+
+func TestConditionalBreakpoint(t *testing.T) {
+	b := newTestBus(t)
+	if _, err := b.AddBreakpoint(0x8005, "X == 0 && [$0000] == 5"); err != nil {
+		t.Fatal(err)
+	}
+
+	clockUntilPaused(t, b)
+	if _, ok := b.TakeBreakHit(); !ok {
+		t.Fatal("Expected a breakpoint hit")
+	}
+	// Stopped before INC $00 runs for the sixth time
+	if b.ram[0] != 5 {
+		t.Errorf("Expected [$0000] == 5 at the hit, got %d", b.ram[0])
+	}
+}
+
+func TestBreakpointErrors(t *testing.T) {
+	b := newTestBus(t)
+	if _, err := b.AddBreakpoint(0x8005, "A =="); err == nil {
+		t.Error("Expected an error for a bad condition")
+	}
+
+	bp, _ := b.AddBreakpoint(0x8005, "")
+	if !b.RemoveBreakpoint(bp.ID) || b.RemoveBreakpoint(bp.ID) {
+		t.Error("Expected RemoveBreakpoint to succeed exactly once")
+	}
+	if len(b.Breakpoints()) != 0 {
+		t.Error("Expected no breakpoints after removal")
+	}
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	if b.Paused() {
+		t.Error("Removed breakpoint should not pause the emulator")
+	}
+}
+
+func TestBreakpointsShareAddress(t *testing.T) {
+	b := newTestBus(t)
+	bp1, _ := b.AddBreakpoint(0x8007, "")
+	bp2, _ := b.AddBreakpoint(0x8007, "")
+
+	// The CPU keeps stopping at $8007 until its last breakpoint goes
+	b.RemoveBreakpoint(bp1.ID)
+	clockUntilPaused(t, b)
+	if hit, ok := b.TakeBreakHit(); !ok || hit.Breakpoint.ID != bp2.ID {
+		t.Errorf("Expected a hit on the remaining breakpoint %d, got %+v", bp2.ID, hit)
+	}
+
+	b.RemoveBreakpoint(bp2.ID)
+	b.SetPaused(false)
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	if _, ok := b.TakeBreakHit(); ok {
+		t.Error("Expected no hits once both breakpoints are removed")
+	}
+}
+
+func TestPausepoint(t *testing.T) {
+	b := newTestBus(t)
+	if _, err := b.AddPausepoint(0); err == nil {
+		t.Error("Expected an error for frame 0")
+	}
+	target := b.PPU.FrameCounter + 2
+	bp, err := b.AddPausepoint(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3*341*262 && !b.Paused(); i++ {
+		b.Clock()
+	}
+	hit, ok := b.TakeBreakHit()
+	if !ok || hit.Breakpoint.ID != bp.ID || hit.Frame != target {
+		t.Fatalf("Expected a hit on pausepoint %d in frame %d, got %+v", bp.ID, target, hit)
+	}
+	if !b.Paused() || !b.IsInstructionComplete() {
+		t.Error("Expected the pausepoint to pause on an instruction boundary")
+	}
+	if b.PPU.FrameCounter != target {
+		t.Errorf("Expected to stop in frame %d, got %d", target, b.PPU.FrameCounter)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	b := newTestBus(t)
+	b.ram[0xD0] = 7
+
+	if v, err := b.Evaluate("[$00D0] * 2 + (PC == $8000)"); err != nil || v != 15 {
+		t.Errorf("Expected 15, got %d (%v)", v, err)
+	}
+	if _, err := b.Evaluate("[$00D0"); err == nil {
+		t.Error("Expected a parse error")
+	}
+}
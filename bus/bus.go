@@ -1,17 +1,22 @@
 package bus
 
 import (
-	"log"
+	"image"
+	"log/slog"
+	"sync/atomic"
 
 	"github.com/meadori/vibemulator/apu"
 	"github.com/meadori/vibemulator/cartridge"
 	"github.com/meadori/vibemulator/controller"
 	"github.com/meadori/vibemulator/cpu"
+	"github.com/meadori/vibemulator/logging"
 	"github.com/meadori/vibemulator/ppu"
+	"github.com/meadori/vibemulator/script"
 )
 
-// Declare logDebug function from main package
-var LogDebug func(format string, a ...interface{})
+// Version identifies the emulation core in recordings. Bump it when a change alters what
+// games compute, since recordings from other versions may then desync.
+const Version = "vibemulator/6"
 
 // Bus represents the main bus of the NES.
 type Bus struct {
@@ -23,52 +28,129 @@ type Bus struct {
 	joy1 *controller.Controller
 	joy2 *controller.Controller
 
-	// Debugger specific fields
-	IsPaused      bool
-	StepRequested bool
+	log     *slog.Logger
+	cartLog *slog.Logger // Cartridge insertion and removal
+
+	// Debugger execution control: running, paused or stepping
+	exec execControl
 
 	// SystemClocks keeps track of the total number of clock cycles.
 	SystemClocks int
+
+	// pages maps each 256-byte page of CPU address space to the device behind it
+	pages [256]pageKind
+
+	// snapshotSize is the size of the last snapshot, used to size the next buffer
+	snapshotSize int
+
+	// The last value on the CPU data bus, which reads that nothing answers return
+	openBus byte
+
+	// OAM DMA in progress, and whether it took the CPU's place on the last CPU cycle
+	dma     DMAState
+	stalled bool
+
+	// lastFrame is the PPU frame counter seen by the previous clock, used to detect frame starts
+	lastFrame int
+
+	// Movie recording and playback, driven from startFrame
+	recording *Movie
+	playback  *Movie
+	playIndex int
+	edit      movieEdit
+
+	// Controller input: live states from SetController*State, states latched from the
+	// frame-targeted queue, and the queue itself. Each controller sees live OR queued.
+	live       [2][8]bool
+	queued     [2][8]bool
+	inputQueue []queuedInput
+
+	// The controller the running instruction read, and whether to ignore DMC fetches
+	// that land on that read (see SetDPCMConflictFilter)
+	portRead   *controller.Controller
+	dpcmFilter atomic.Bool
+
+	// Callbacks registered through OnFrame, OnMemoryRead/Write and OnNMI
+	hooks hooks
+
+	// Debugger watchpoints and breakpoints, and the last of each hit
+	watch  watchState
+	breaks breakState
+
+	// Cheat codes applied to CPU reads
+	cheats cheatState
+
+	// Pending RunUntil condition, checked by Clock
+	until atomic.Pointer[runUntil]
+
+	// Running or most recent instruction profile
+	profiler atomic.Pointer[profiler]
+
+	// Running or most recent code/data log
+	cdl atomic.Pointer[cdlLogger]
+
+	// The CPU's record of its last instructions, if enabled
+	trace *cpu.Trace
+
+	// Set by hooks that fire mid-instruction (e.g. pausepoints) to pause on the next
+	// instruction boundary
+	pauseAtBoundary atomic.Bool
 }
 
-// New creates a new Bus instance.
+// New creates a new Bus instance, with each component logging through its subsystem's
+// logger.
 func New() *Bus {
-	log.Println("Creating new bus")
-
 	b := &Bus{
-		cpu:  cpu.New(),
-		PPU:  ppu.New(),
-		APU:  apu.New(),
-		joy1: controller.New(),
-		joy2: controller.New(),
+		log:     logging.Logger("bus"),
+		cartLog: logging.Logger("cartridge"),
+		cpu:     cpu.New(logging.Logger("cpu")),
+		PPU:     ppu.New(logging.Logger("ppu")),
+		APU:     apu.New(),
+		joy1:    controller.New(),
+		joy2:    controller.New(),
 	}
 
 	b.cpu.ConnectBus(b)
+	b.cpu.SetDebugHooks(cpu.DebugHooks{
+		Break: b.onBreakpoint,
+		Read:  func(addr uint16, data byte) { b.onWatch(addr, data, false) },
+		Write: func(addr uint16, data byte) { b.onWatch(addr, data, true) },
+	})
 	b.APU.ConnectBus(b)
+	b.mapPages()
+	b.log.Debug("created")
 
 	return b
 }
 
 // LoadCartridge loads a cartridge into the bus.
 func (b *Bus) LoadCartridge(cart *cartridge.Cartridge) error {
-	log.Println("Loading cartridge into bus")
+	b.cartLog.Info("cartridge inserted", "header", cart.Header)
 	b.cart = cart
+	b.mapPages()
 	b.PPU.ConnectCartridge(cart)
 	b.cpu.Reset()
+	b.dma = DMAState{}
+	// A code/data log carries on across power cycles of the same ROM only
+	if c := b.cdl.Load(); c != nil && c.romSum != b.romSum() {
+		b.cdl.Store(nil)
+	}
+	b.prgMapChanged()
 	return nil
 }
 
 // EjectCartridge removes the cartridge from the bus.
 func (b *Bus) EjectCartridge() {
-	log.Println("Ejecting cartridge from bus")
+	b.cartLog.Info("cartridge ejected")
 	b.PowerOff()
 	b.cart = nil
+	b.mapPages()
 	b.PPU.ConnectCartridge(nil)
 }
 
 // PowerOff silences the system and resets internal state but keeps the cartridge.
 func (b *Bus) PowerOff() {
-	log.Println("Powering off bus")
+	b.log.Debug("power off")
 	b.APU.CPUWrite(0x4015, 0) // Disable all sound channels
 	b.PPU.Reset()
 	// Clear internal RAM
@@ -79,19 +161,15 @@ func (b *Bus) PowerOff() {
 
 // PowerOn resets the system components to start execution.
 func (b *Bus) PowerOn() {
-	log.Println("Powering on bus")
+	b.log.Debug("power on")
 	b.PPU.Reset()
 	b.cpu.Reset()
 }
 
-// SetPaused toggles the debugger pause state.
-func (b *Bus) SetPaused(paused bool) {
-	b.IsPaused = paused
-}
-
-// RequestStep signals the emulator to advance one instruction.
-func (b *Bus) RequestStep() {
-	b.StepRequested = true
+// SetInstructionStepping switches the CPU between running instructions a cycle at a
+// time and all at once; see cpu.CPU.SetInstructionStepping.
+func (b *Bus) SetInstructionStepping(on bool) {
+	b.cpu.SetInstructionStepping(on)
 }
 
 // IsInstructionComplete checks if the CPU has finished its instruction.
@@ -99,6 +177,22 @@ func (b *Bus) IsInstructionComplete() bool {
 	return b.cpu.IsInstructionComplete()
 }
 
+// BatteryRAM returns the cartridge's battery-backed RAM, or nil if it has none.
+func (b *Bus) BatteryRAM() []byte {
+	if b.cart == nil {
+		return nil
+	}
+	return b.cart.BatteryRAM()
+}
+
+// ROMHash returns the hex SHA-1 of the loaded ROM image, or "" without a cartridge.
+func (b *Bus) ROMHash() string {
+	if b.cart == nil || b.cart.Image() == nil {
+		return ""
+	}
+	return script.ROMHash(b.cart.Image())
+}
+
 // HasCartridge returns true if a cartridge is currently loaded.
 func (b *Bus) HasCartridge() bool {
 	return b.cart != nil
@@ -106,35 +200,104 @@ func (b *Bus) HasCartridge() bool {
 
 // Clock performs one clock cycle of the system.
 func (b *Bus) Clock() {
+	until := b.until.Load()
+	if until != nil {
+		until.checkUntilPosition(b.PPU.Scanline, b.PPU.Cycle, b.PPU.FrameCounter)
+	}
+
 	b.PPU.Clock()
+	if b.PPU.FrameCounter != b.lastFrame {
+		b.lastFrame = b.PPU.FrameCounter
+		b.startFrame()
+	}
 	// The CPU runs at 1/3 the speed of the PPU
 	if b.SystemClocks%3 == 0 {
 		// Clock APU first to ensure IRQ status is updated for current CPU cycle
+		cdl := b.cdl.Load()
+		if cdl != nil {
+			cdl.dmc = true
+		}
 		b.APU.Clock()
+		if cdl != nil {
+			cdl.dmc = false
+		}
+		if b.portRead != nil && b.cpu.InstructionStepping() && b.cpu.Cycles == 1 {
+			b.checkDPCMConflict()
+		}
 		if b.cart != nil {
 			b.cart.Mapper.Clock()
 		}
-		// Check for NMI (PPU)
-		if b.PPU.NMI {
-			b.PPU.NMI = false
-			b.cpu.NMI()
+		// Drive the interrupt lines: the PPU's NMI, and the APU's (DMC or frame) and the
+		// cartridge's IRQ
+		if !b.PPU.NMIOutput() {
+			b.cpu.ReleaseNMI()
+		} else if b.cpu.AssertNMI() {
+			b.runNMIHooks()
 		}
-
-		// Check for APU IRQ (DMC or Frame IRQ)
 		cartIRQ := false
 		if b.cart != nil {
 			cartIRQ = b.cart.Mapper.IRQPending()
 		}
 		if b.APU.DmcIRQ || b.APU.FrameIRQ || cartIRQ {
-			b.cpu.IRQ()
+			b.cpu.AssertIRQ()
+		} else {
+			b.cpu.ReleaseIRQ()
 		}
 
-		b.cpu.Clock() // Clock the CPU after all IRQ checks
+		// OAM DMA halts the CPU between instructions
+		b.stalled = b.dma.Active && b.cpu.IsInstructionComplete()
+		if b.stalled {
+			b.clockDMA()
+		} else {
+			b.cpu.Clock() // Clock the CPU after all IRQ checks
+		}
+		if b.portRead != nil && !b.cpu.InstructionStepping() {
+			b.checkDPCMConflict()
+		}
+		if !b.stalled && b.cpu.IsInstructionComplete() {
+			if b.pauseAtBoundary.CompareAndSwap(true, false) {
+				b.setExecState(Paused)
+			}
+			if p := b.profiler.Load(); p != nil {
+				b.profileInstruction(p, b.cpu.PC)
+			}
+			if cdl != nil {
+				b.logInstruction(cdl, b.cpu.PC)
+			}
+			b.runExecHooks(b.cpu.PC)
+			if until != nil {
+				b.checkUntilInstruction(until)
+			}
+		}
 	}
 
 	b.SystemClocks++
 }
 
+// startFrame runs once at the start of every frame, before any CPU work for it.
+func (b *Bus) startFrame() {
+	b.latchQueuedInput()
+
+	if b.playback != nil {
+		b.applyInput(b.playback.Frames[b.playIndex])
+		b.playIndex++
+		if b.playIndex == len(b.playback.Frames) {
+			b.playback = nil
+		}
+	}
+
+	if b.edit.movie != nil {
+		b.edit.frame++
+	}
+
+	if b.recording != nil {
+		b.recording.Frames = append(b.recording.Frames, b.currentInput())
+		b.recording.FinalHash = b.FrameHash()
+	}
+
+	b.runFrameHooks(b.PPU.FrameCounter)
+}
+
 // GetFramePixels returns the raw PPU frame buffer for the RL Agent
 func (b *Bus) GetFramePixels() []byte {
 	return b.PPU.GetFrame().Pix
@@ -145,79 +308,202 @@ func (b *Bus) GetCPUState() (a, x, y, sp, p byte, pc uint16, cycles int) {
 	return b.cpu.GetState()
 }
 
-// GetMemoryBlock returns a slice of memory bytes
-func (b *Bus) GetMemoryBlock(addr uint16, size uint16) []byte {
+// Jammed reports whether the CPU has executed a JAM and is locked up until reset.
+func (b *Bus) Jammed() bool {
+	return b.cpu.Jammed
+}
+
+// GetMemoryBlock returns size bytes of the CPU address space from addr, wrapping past
+// $FFFF. It reads without side effects: registers such as $2002, $2007, $4015 and the
+// controllers are peeked, and watchpoints never trip.
+func (b *Bus) GetMemoryBlock(addr uint16, size int) []byte {
+	block := make([]byte, size)
+	for i := range block {
+		block[i] = b.debugRead(addr + uint16(i))
+	}
+	return block
+}
+
+// ReadMemoryBlock is GetMemoryBlock with the side effects of real CPU reads, for
+// debuggers that want them. It still bypasses watchpoints.
+func (b *Bus) ReadMemoryBlock(addr uint16, size int) []byte {
 	block := make([]byte, size)
-	for i := uint16(0); i < size; i++ {
-		// Read directly without triggering side effects if possible, but Read() is fine for now
-		block[i] = b.Read(addr + i)
+	for i := range block {
+		block[i] = b.read(addr + uint16(i))
 	}
 	return block
 }
 
+// WriteMemoryBlock writes bytes through the CPU address space, so mapper registers and
+// PRG RAM see them as the CPU would
+func (b *Bus) WriteMemoryBlock(addr uint16, data []byte) {
+	for i, v := range data {
+		// Bypass the write hooks so patching memory never trips a watchpoint
+		b.write(addr+uint16(i), v)
+	}
+}
+
+// GetPPURegisters returns the PPU's internal registers without side effects
+func (b *Bus) GetPPURegisters() ppu.Registers {
+	r := b.PPU.GetRegisters()
+	r.NMIPending = b.cpu.NMIPending()
+	return r
+}
+
+// GetAPURegisters returns the APU's channel state without side effects
+func (b *Bus) GetAPURegisters() apu.Registers {
+	return b.APU.GetRegisters()
+}
+
+// GetNametableMemory returns the four logical nametables without PPU side effects
+func (b *Bus) GetNametableMemory() []byte {
+	return b.PPU.GetNametableMemory()
+}
+
+// GetOAM returns a copy of sprite memory
+func (b *Bus) GetOAM() []byte {
+	return b.PPU.GetOAM()
+}
+
+// GetPaletteRAM returns a copy of palette memory
+func (b *Bus) GetPaletteRAM() []byte {
+	return b.PPU.GetPaletteRAM()
+}
+
+// GetPatternTableImage renders pattern table 0 or 1 with one of the eight palettes
+func (b *Bus) GetPatternTableImage(table int, palette byte) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 128, 128))
+	b.PPU.GetPatternTable(table, palette, img.Pix)
+	return img
+}
+
+// GetNametableImage renders all four nametables as a 512x480 image
+func (b *Bus) GetNametableImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 512, 480))
+	b.PPU.GetNametableImage(img.Pix)
+	return img
+}
+
 // Read reads a byte from the bus.
 func (b *Bus) Read(addr uint16) byte {
-	var data byte
-	if b.cart != nil {
-		if data, ok := b.cart.Mapper.CPUMapRead(addr); ok {
-			return data
-		}
+	data := b.read(addr)
+	// $4015 is inside the CPU, so reading it leaves the external data bus alone
+	if addr != 0x4015 {
+		b.openBus = data
+	}
+	if c := b.cdl.Load(); c != nil {
+		b.logRead(c, addr)
 	}
+	b.runReadHooks(addr, data)
+	return data
+}
 
+// peek returns the byte at addr if it can be read without side effects (RAM and cartridge space).
+func (b *Bus) peek(addr uint16) (byte, bool) {
 	switch {
-	case addr >= 0x0000 && addr <= 0x1FFF:
+	case addr <= 0x1FFF:
+		return b.ram[addr&0x07FF], true
+	case addr >= 0x4020 && b.cart != nil:
+		return b.cart.Mapper.CPUMapRead(addr)
+	}
+	return 0, false
+}
+
+// read performs a CPU read without running hooks. Cheats apply, so the debugger sees
+// memory the way the game does.
+func (b *Bus) read(addr uint16) byte {
+	return b.applyCheats(addr, b.readHardware(addr))
+}
+
+// debugRead returns what read would without changing any device state. Cheats apply.
+func (b *Bus) debugRead(addr uint16) byte {
+	var data byte
+	switch b.pages[addr>>8] {
+	case pageRAM:
 		data = b.ram[addr&0x07FF]
-	case addr >= 0x2000 && addr <= 0x3FFF:
-		data = b.PPU.CPURead(addr & 0x0007)
-	case addr == 0x4016:
-		data = b.joy1.Read()
-	case addr == 0x4017:
-		data = b.joy2.Read()
-	case addr >= 0x4000 && addr <= 0x4017:
-		data = b.APU.CPURead(addr)
+	case pagePPU:
+		data = b.PPU.CPUDebugRead(addr & 0x0007)
+	case pageCart:
+		data = b.readCart(addr)
+	case pageIO:
+		switch {
+		case addr >= 0x4020:
+			data = b.readCart(addr)
+		case addr == 0x4015:
+			data = b.openBus&statusOpenBus | b.APU.CPUDebugRead(addr)
+		case addr == 0x4016:
+			data = b.openBus&portOpenBus | b.joy1.DebugRead()
+		case addr == 0x4017:
+			data = b.openBus&portOpenBus | b.joy2.DebugRead()
+		default:
+			data = b.openBus
+		}
+	default:
+		data = b.openBus
 	}
-	return data
+	return b.applyCheats(addr, data)
+}
+
+func (b *Bus) readHardware(addr uint16) byte {
+	switch b.pages[addr>>8] {
+	case pageRAM:
+		return b.ram[addr&0x07FF]
+	case pagePPU:
+		return b.PPU.CPURead(addr & 0x0007)
+	case pageCart:
+		return b.readCart(addr)
+	case pageIO:
+		return b.readIO(addr)
+	}
+	return b.openBus
 }
 
 // Write writes a byte to the bus.
 func (b *Bus) Write(addr uint16, data byte) {
-	if b.cart != nil {
-		if ok := b.cart.Mapper.CPUMapWrite(addr, data); ok {
-			return
-		}
-	}
+	b.openBus = data
+	b.runWriteHooks(addr, data)
+	b.write(addr, data)
+}
 
-	switch {
-	case addr >= 0x0000 && addr <= 0x1FFF:
+// write performs a CPU write without running hooks
+func (b *Bus) write(addr uint16, data byte) {
+	switch b.pages[addr>>8] {
+	case pageRAM:
 		b.ram[addr&0x07FF] = data
-	case addr >= 0x2000 && addr <= 0x3FFF:
+	case pagePPU:
 		b.PPU.CPUWrite(addr&0x0007, data)
-	case addr == 0x4014:
-		// OAMDMA
-		oamData := [256]byte{}
-		dmaAddr := uint16(data) << 8
-		for i := 0; i < 256; i++ {
-			oamData[i] = b.Read(dmaAddr + uint16(i))
+	case pageCart:
+		b.cart.Mapper.CPUMapWrite(addr, data)
+		// Writes outside PRG RAM may switch banks
+		if addr < 0x6000 || addr >= 0x8000 {
+			b.prgMapChanged()
 		}
-		b.PPU.DoOAMDMA(oamData)
-	case addr == 0x4016:
-		b.joy1.Write(data)
-		b.joy2.Write(data)
-	case addr >= 0x4000 && addr <= 0x4017:
-		b.APU.CPUWrite(addr, data)
+	case pageIO:
+		b.writeIO(addr, data)
 	}
 }
 
-// SetController1State sets the state of the buttons for controller 1.
+// SetController1State sets the state of the buttons for controller 1. It waits for a
+// running Advance to finish its frame.
 func (b *Bus) SetController1State(buttons [8]bool) {
-	b.joy1.SetButtons(buttons)
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.setLiveInput(0, buttons)
 }
 
-// SetController2State sets the state of the buttons for controller 2.
+// SetController2State sets the state of the buttons for controller 2, like
+// SetController1State.
 func (b *Bus) SetController2State(buttons [8]bool) {
-	b.joy2.SetButtons(buttons)
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.setLiveInput(1, buttons)
 }
 
+// Reset presses the console's reset button. It waits for a running Advance to finish
+// its frame.
 func (b *Bus) Reset() {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
 	b.cpu.Reset()
+	b.dma = DMAState{}
 }
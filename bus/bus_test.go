@@ -0,0 +1,160 @@
+package bus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/script"
+)
+
+// testProgram enables rendering and then increments $00 forever.
+var testProgram = []byte{
+	0xA9, 0x1E, // LDA #$1E
+	0x8D, 0x01, 0x20, // STA $2001
+	0xE6, 0x00, // loop: INC $00
+	0xAD, 0x16, 0x40, // LDA $4016
+	0x85, 0x01, // STA $01
+	0x4C, 0x05, 0x80, // JMP loop
+}
+
+// writeTestROM builds a 16KB NROM image running prg from $8000, with NMI and IRQ
+// handlers that just return, and returns its path.
+func writeTestROM(t *testing.T, prg []byte) string {
+	t.Helper()
+	header := []byte{'N', 'E', 'S', 0x1A, 0x01, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+	prgROM := make([]byte, 16384)
+	copy(prgROM, prg)
+	prgROM[0x3FF0] = 0x40 // RTI at $BFF0 for NMI and IRQ
+	prgROM[0x3FFA] = 0xF0 // NMI vector -> $BFF0
+	prgROM[0x3FFB] = 0xBF
+	prgROM[0x3FFC] = 0x00 // Reset vector -> $8000
+	prgROM[0x3FFD] = 0x80
+	prgROM[0x3FFE] = 0xF0 // IRQ vector -> $BFF0
+	prgROM[0x3FFF] = 0xBF
+
+	data := append(header, prgROM...)
+	data = append(data, make([]byte, 8192)...)
+
+	path := filepath.Join(t.TempDir(), "test.nes")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newTestBus returns a bus with the test program inserted.
+func newTestBus(t *testing.T) *Bus {
+	t.Helper()
+	cart, err := cartridge.New(writeTestROM(t, testProgram))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	if err := b.LoadCartridge(cart); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWriteMemoryBlock(t *testing.T) {
+	b := newTestBus(t)
+	b.AddWatchpoint(0x0000, 0x07FF, true)
+
+	b.WriteMemoryBlock(0x0802, []byte{0x12, 0x34}) // Mirror of $0002
+	if got := b.GetMemoryBlock(0x0002, 2); got[0] != 0x12 || got[1] != 0x34 {
+		t.Errorf("Expected 12 34 at $0002, got % X", got)
+	}
+	if b.Paused() {
+		t.Error("Debugger writes should not trigger watchpoints")
+	}
+}
+
+func TestGetMemoryBlockSideEffects(t *testing.T) {
+	b := newTestBus(t)
+	b.SetController1State([8]bool{true}) // A
+	b.Write(0x4016, 1)
+	b.Write(0x4016, 0)
+	b.PPU.Status |= 0x80
+	b.APU.FrameIRQ = true
+	b.openBus = 0x40 // As LDA $4016 leaves it
+
+	for i := 0; i < 2; i++ {
+		if got := b.GetMemoryBlock(0x2002, 1)[0]; got&0x80 == 0 {
+			t.Errorf("Peek %d of $2002: expected the vblank flag, got %02X", i, got)
+		}
+		if got := b.GetMemoryBlock(0x4015, 1)[0]; got&0x40 == 0 {
+			t.Errorf("Peek %d of $4015: expected the frame IRQ flag, got %02X", i, got)
+		}
+		if got := b.GetMemoryBlock(0x4016, 1)[0]; got != 0x41 {
+			t.Errorf("Peek %d of $4016: expected button A, got %02X", i, got)
+		}
+		b.GetMemoryBlock(0x2007, 1)
+	}
+	if regs := b.GetPPURegisters(); regs.V != 0 {
+		t.Errorf("Expected peeking $2007 not to advance v, got $%04X", regs.V)
+	}
+
+	// The side-effect path reads like the CPU
+	b.ReadMemoryBlock(0x2002, 1)
+	b.ReadMemoryBlock(0x4015, 2)
+	if b.PPU.Status&0x80 != 0 || b.APU.FrameIRQ {
+		t.Error("Expected ReadMemoryBlock to clear the vblank and frame IRQ flags")
+	}
+	if got := b.GetMemoryBlock(0x4016, 1)[0]; got&0x01 != 0 {
+		t.Errorf("Expected ReadMemoryBlock to shift out button A, got %02X", got)
+	}
+
+	if got := len(b.GetMemoryBlock(0x0000, 0x10000)); got != 0x10000 {
+		t.Errorf("Expected the whole address space, got %d bytes", got)
+	}
+}
+
+func TestROMHash(t *testing.T) {
+	path := writeTestROM(t, testProgram)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cart, err := cartridge.New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := New()
+	if b.ROMHash() != "" {
+		t.Error("Expected no ROM hash without a cartridge")
+	}
+	b.LoadCartridge(cart)
+	if got, want := b.ROMHash(), script.ROMHash(data); got != want {
+		t.Errorf("Expected ROM hash %s, got %s", want, got)
+	}
+}
+
+func TestAddressDecoding(t *testing.T) {
+	b := newTestBus(t)
+
+	b.Write(0x1803, 0x42) // Mirror of $0003
+	if v := b.Read(0x0003); v != 0x42 {
+		t.Errorf("Expected RAM mirror write at $0003, got 0x%02X", v)
+	}
+	b.Write(0x6000, 0x99) // NROM PRG RAM
+	if v := b.Read(0x6000); v != 0x99 {
+		t.Errorf("Expected PRG RAM at $6000 to read back 0x99, got 0x%02X", v)
+	}
+	if v := b.Read(0x8000); v != testProgram[0] {
+		t.Errorf("Expected PRG ROM at $8000 to read 0x%02X, got 0x%02X", testProgram[0], v)
+	}
+
+	// Removing the cartridge leaves cartridge space open
+	b.EjectCartridge()
+	b.Write(0x4000, 0x5A)
+	if v := b.Read(0x8000); v != 0x5A {
+		t.Errorf("Expected open bus 0x5A from $8000 with no cartridge, got 0x%02X", v)
+	}
+	b.Write(0x6000, 0x01)
+	if v := b.Read(0x0003); v != 0 {
+		t.Errorf("Expected power off to clear RAM, got 0x%02X at $0003", v)
+	}
+}
@@ -0,0 +1,166 @@
+package bus
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/cpu"
+)
+
+// Flags a code/data log sets on each PRG ROM byte, as in FCEUX's .cdl format.
+const (
+	CDLCode = 0x01 // Executed as an opcode or operand
+	CDLData = 0x02 // Read as data
+	// Bits 2-3 record which 8KB window of $8000-$FFFF the byte was last accessed through
+	CDLWindowMask = 0x0C
+	CDLPCM        = 0x40 // Fetched by the DMC as sample data
+)
+
+// CodeDataLog is a code/data log in FCEUX's .cdl format: a flag byte for each byte of
+// PRG ROM, followed by one for each byte of CHR ROM. Only the PRG flags are logged.
+type CodeDataLog struct {
+	Running bool
+	PRG     []byte
+	CHR     []byte
+}
+
+// Bytes returns the log as an FCEUX .cdl file.
+func (l CodeDataLog) Bytes() []byte {
+	return append(append([]byte(nil), l.PRG...), l.CHR...)
+}
+
+// Coverage counts the PRG ROM bytes logged as code and as data. A byte can be both.
+func (l CodeDataLog) Coverage() (code, data int) {
+	for _, f := range l.PRG {
+		if f&CDLCode != 0 {
+			code++
+		}
+		if f&(CDLData|CDLPCM) != 0 {
+			data++
+		}
+	}
+	return code, data
+}
+
+// cdlLogger marks PRG ROM bytes as the CPU and DMC read them. Flags are packed four to a
+// word and set atomically so the debugger can export the log while the emulator runs.
+type cdlLogger struct {
+	running atomic.Bool
+	romSum  [sha1.Size]byte
+	flags   []atomic.Uint32
+	prgSize int
+	chrSize int
+
+	// The mapper's PRG windows, re-read after the game writes to cartridge space or a
+	// state is loaded
+	windows []cartridge.PRGWindow
+	stale   atomic.Bool
+
+	// The bytes of the instruction about to execute, which reads treat as code
+	codeStart, codeEnd uint16
+	// Set while the APU clocks, so its reads are logged as DMC samples
+	dmc bool
+}
+
+// StartCDL starts logging which bytes of PRG ROM are executed and read. It continues
+// from prev, an FCEUX .cdl file for the same ROM, or starts from nothing if prev is nil.
+func (b *Bus) StartCDL(prev []byte) error {
+	if b.cart == nil {
+		return fmt.Errorf("failed to start code/data log: no cartridge")
+	}
+	c := &cdlLogger{romSum: b.romSum(), prgSize: len(b.cart.PRGROM)}
+	if !b.cart.IsCHRRAM {
+		c.chrSize = len(b.cart.CHRROM) - b.cart.CHRRAMSize
+	}
+	if prev != nil && len(prev) != c.prgSize+c.chrSize {
+		return fmt.Errorf("code/data log is %d bytes, expected %d for this ROM", len(prev), c.prgSize+c.chrSize)
+	}
+	c.flags = make([]atomic.Uint32, (c.prgSize+3)/4)
+	for i := range min(len(prev), c.prgSize) {
+		c.set(i, prev[i])
+	}
+	c.stale.Store(true)
+	c.running.Store(true)
+	b.cdl.Store(c)
+	return nil
+}
+
+// StopCDL stops logging and returns the log, which CDL keeps returning until the next
+// StartCDL.
+func (b *Bus) StopCDL() (CodeDataLog, bool) {
+	if c := b.cdl.Load(); c != nil {
+		c.running.Store(false)
+	}
+	return b.CDL()
+}
+
+// CDL returns the running or most recent code/data log, and false if there is none.
+func (b *Bus) CDL() (CodeDataLog, bool) {
+	c := b.cdl.Load()
+	if c == nil {
+		return CodeDataLog{}, false
+	}
+	l := CodeDataLog{Running: c.running.Load(), PRG: make([]byte, c.prgSize), CHR: make([]byte, c.chrSize)}
+	for i := range l.PRG {
+		l.PRG[i] = byte(c.flags[i/4].Load() >> (i % 4 * 8))
+	}
+	return l, true
+}
+
+// set ORs flags into the byte at PRG ROM offset i.
+func (c *cdlLogger) set(i int, flags byte) {
+	word, shift := &c.flags[i/4], i%4*8
+	if bits := uint32(flags) << shift; word.Load()&bits != bits {
+		word.Or(bits)
+	}
+}
+
+// prgMapChanged tells a running code/data log to re-read the mapper's banking.
+func (b *Bus) prgMapChanged() {
+	if c := b.cdl.Load(); c != nil {
+		c.stale.Store(true)
+	}
+}
+
+// logInstruction notes the bytes of the instruction at pc, which the CPU fetches next.
+func (b *Bus) logInstruction(c *cdlLogger, pc uint16) {
+	op, _ := b.peek(pc)
+	c.codeStart = pc
+	c.codeEnd = pc + uint16(1+cpu.OperandSize(b.cpu.Lookup[op].AddrModeName))
+}
+
+// logRead flags the PRG ROM byte behind a CPU or DMC read of addr.
+func (b *Bus) logRead(c *cdlLogger, addr uint16) {
+	if addr < 0x4020 || !c.running.Load() {
+		return
+	}
+	if c.stale.Swap(false) {
+		c.windows = b.cart.PRGMap()
+	}
+	for _, w := range c.windows {
+		if addr < w.Start || addr > w.End {
+			continue
+		}
+		if w.RAM {
+			return
+		}
+		offset := w.Offset + int(addr-w.Start)
+		if offset >= c.prgSize {
+			return
+		}
+		flags := byte(CDLData)
+		switch {
+		case c.dmc:
+			flags = CDLPCM
+		case addr-c.codeStart < c.codeEnd-c.codeStart:
+			flags = CDLCode
+		}
+		if addr >= 0x8000 {
+			flags |= byte(addr>>11) & CDLWindowMask
+		}
+		c.set(offset, flags)
+		return
+	}
+}
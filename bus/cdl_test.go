@@ -0,0 +1,59 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+func TestCDL(t *testing.T) {
+	cart, err := cartridge.New(writeTestROM(t, []byte{
+		0xAD, 0x00, 0xC1, // loop: LDA $C100
+		0x4C, 0x00, 0x80, // JMP loop
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	if err := b.LoadCartridge(cart); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.CDL(); ok {
+		t.Error("Expected no code/data log before StartCDL")
+	}
+	if err := b.StartCDL(make([]byte, 10)); err == nil {
+		t.Error("Expected an error continuing a log for a different ROM size")
+	}
+
+	if err := b.StartCDL(nil); err != nil {
+		t.Fatal(err)
+	}
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	l, ok := b.StopCDL()
+	if !ok || l.Running {
+		t.Fatalf("Expected a stopped log, got running=%v ok=%v", l.Running, ok)
+	}
+	if len(l.Bytes()) != 16384+8192 {
+		t.Errorf("Expected PRG and CHR flags, got %d bytes", len(l.Bytes()))
+	}
+	for i := range 6 {
+		if l.PRG[i] != CDLCode {
+			t.Errorf("Expected PRG byte %d to be code, got $%02X", i, l.PRG[i])
+		}
+	}
+	// 16KB of PRG ROM is mirrored at $C000, the third 8KB window
+	if l.PRG[0x100] != CDLData|0x08 {
+		t.Errorf("Expected PRG byte $100 to be data read through $C000, got $%02X", l.PRG[0x100])
+	}
+	if code, data := l.Coverage(); code != 6 || data != 1 {
+		t.Errorf("Expected 6 code bytes and 1 data byte, got %d and %d", code, data)
+	}
+
+	// Continuing keeps what was logged
+	if err := b.StartCDL(l.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if l, _ := b.CDL(); l.PRG[0x100] == 0 || !l.Running {
+		t.Error("Expected the continued log to keep earlier flags")
+	}
+}
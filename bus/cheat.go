@@ -0,0 +1,92 @@
+package bus
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/meadori/vibemulator/cheat"
+)
+
+// Cheat is an installed cheat code. While enabled, CPU reads of its address return the
+// patched value, which freezes RAM values and patches ROM alike.
+type Cheat struct {
+	ID      int
+	Code    string // As entered
+	Decoded cheat.Code
+	Enabled bool
+}
+
+// cheatState tracks the installed cheats. The emulation goroutine only reads patches, a
+// snapshot of the enabled codes that is swapped in whenever the list changes.
+type cheatState struct {
+	mu      sync.Mutex
+	list    []Cheat
+	nextID  int
+	patches atomic.Pointer[map[uint16][]cheat.Code]
+}
+
+// AddCheat decodes a Game Genie or raw code and enables it.
+func (b *Bus) AddCheat(code string) (Cheat, error) {
+	decoded, err := cheat.Parse(code)
+	if err != nil {
+		return Cheat{}, err
+	}
+
+	b.cheats.mu.Lock()
+	defer b.cheats.mu.Unlock()
+	b.cheats.nextID++
+	c := Cheat{ID: b.cheats.nextID, Code: code, Decoded: decoded, Enabled: true}
+	b.cheats.list = append(b.cheats.list, c)
+	b.cheats.publish()
+	return c, nil
+}
+
+// SetCheatEnabled turns a cheat on or off.
+func (b *Bus) SetCheatEnabled(id int, enabled bool) (Cheat, error) {
+	b.cheats.mu.Lock()
+	defer b.cheats.mu.Unlock()
+	for i := range b.cheats.list {
+		if b.cheats.list[i].ID == id {
+			b.cheats.list[i].Enabled = enabled
+			b.cheats.publish()
+			return b.cheats.list[i], nil
+		}
+	}
+	return Cheat{}, fmt.Errorf("no cheat %d", id)
+}
+
+// Cheats lists the installed cheats in the order they were added.
+func (b *Bus) Cheats() []Cheat {
+	b.cheats.mu.Lock()
+	defer b.cheats.mu.Unlock()
+	return append([]Cheat(nil), b.cheats.list...)
+}
+
+// publish rebuilds the patch snapshot; callers hold mu
+func (s *cheatState) publish() {
+	patches := make(map[uint16][]cheat.Code)
+	for _, c := range s.list {
+		if c.Enabled {
+			patches[c.Decoded.Addr] = append(patches[c.Decoded.Addr], c.Decoded)
+		}
+	}
+	if len(patches) == 0 {
+		// Keep the read path to a single nil check when nothing is enabled
+		s.patches.Store(nil)
+		return
+	}
+	s.patches.Store(&patches)
+}
+
+// applyCheats returns the byte the CPU sees at addr after any enabled cheats
+func (b *Bus) applyCheats(addr uint16, data byte) byte {
+	patches := b.cheats.patches.Load()
+	if patches == nil {
+		return data
+	}
+	for _, c := range (*patches)[addr] {
+		data = c.Apply(data)
+	}
+	return data
+}
@@ -0,0 +1,50 @@
+package bus
+
+import "testing"
+
+func TestCheatPatchesROM(t *testing.T) {
+	b := newTestBus(t)
+	// $8000 LDA #$1E: patch the operand, but only while it still reads $1E
+	c, err := b.AddCheat("8001?1E:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.RunUntil(StopCondition{Kind: StopAtAddress, Addr: 0x8002})
+	clockUntilPaused(t, b)
+	if a, _, _, _, _, _, _ := b.GetCPUState(); a != 0x55 {
+		t.Errorf("Expected the cheat to load $55, got $%02X", a)
+	}
+
+	if _, err := b.SetCheatEnabled(c.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.GetMemoryBlock(0x8001, 1)[0]; got != 0x1E {
+		t.Errorf("Expected the original byte with the cheat disabled, got $%02X", got)
+	}
+}
+
+func TestCheatFreezesRAM(t *testing.T) {
+	b := newTestBus(t)
+	if _, err := b.AddCheat("0000:07"); err != nil {
+		t.Fatal(err)
+	}
+	// INC $00 keeps writing, but every read sees the frozen value
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	if got := b.GetMemoryBlock(0x0000, 1)[0]; got != 0x07 {
+		t.Errorf("Expected $0000 to read $07, got $%02X", got)
+	}
+}
+
+func TestCheatErrors(t *testing.T) {
+	b := newTestBus(t)
+	if _, err := b.AddCheat("NOTACODE"); err == nil {
+		t.Error("Expected an error for a bad code")
+	}
+	if _, err := b.SetCheatEnabled(1, true); err == nil {
+		t.Error("Expected an error for an unknown cheat")
+	}
+	if len(b.Cheats()) != 0 {
+		t.Error("Expected no cheats")
+	}
+}
@@ -0,0 +1,112 @@
+package bus
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+// determinismROM renders a menu that reacts to the controller, so input that is
+// dropped or applied on the wrong frame shows up in the frame hashes.
+const determinismROM = "../nestest/testdata/nestest.nes"
+
+// frameDigest hashes the finished frame together with work RAM.
+func frameDigest(b *Bus) uint64 {
+	h := fnv.New64a()
+	h.Write(b.GetFramePixels())
+	h.Write(b.ram[:])
+	return h.Sum64()
+}
+
+// playDigests plays m on a freshly powered-on bus and returns a digest per frame.
+func playDigests(t *testing.T, rom []byte, m *Movie) []uint64 {
+	t.Helper()
+	b := New()
+	if err := b.LoadROM(rom); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.StartPlayback(m); err != nil {
+		t.Fatal(err)
+	}
+	var digests []uint64
+	for b.IsPlaying() {
+		b.RunFrame()
+		digests = append(digests, frameDigest(b))
+	}
+	return digests
+}
+
+// randomMovie returns a movie from power-on that holds random buttons for a few frames at a time.
+func randomMovie(t *testing.T, rom []byte, frames int) *Movie {
+	t.Helper()
+	b := New()
+	if err := b.LoadROM(rom); err != nil {
+		t.Fatal(err)
+	}
+	state, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	m := &Movie{State: state}
+	var held MovieFrame
+	for len(m.Frames) < frames {
+		if rng.Intn(4) == 0 {
+			held = MovieFrame{}
+			for i := range held.P1 {
+				held.P1[i] = rng.Intn(6) == 0
+			}
+		}
+		m.Frames = append(m.Frames, held)
+	}
+	return m
+}
+
+// TestDeterminism guards what rewind, netplay and RL rely on: the same ROM and input
+// produce the same frames, including across a savestate taken mid-run.
+func TestDeterminism(t *testing.T) {
+	rom, err := os.ReadFile(determinismROM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := randomMovie(t, rom, 300)
+
+	first := playDigests(t, rom, m)
+	second := playDigests(t, rom, m)
+	if i := firstDifference(first, second); i >= 0 {
+		t.Fatalf("Identical runs diverge at frame %d", i+1)
+	}
+
+	// Play half of the movie, snapshot, and finish it on another bus from the snapshot
+	half := len(m.Frames) / 2
+	b := New()
+	if err := b.LoadROM(rom); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.StartPlayback(m); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < half; i++ {
+		b.RunFrame()
+	}
+	mid, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed := playDigests(t, rom, &Movie{State: mid, Frames: m.Frames[half:]})
+	if i := firstDifference(first[half:], resumed); i >= 0 {
+		t.Errorf("Run resumed from a savestate at frame %d diverges at frame %d", half, half+i+1)
+	}
+}
+
+// firstDifference returns the first index where a and b differ, or -1 if they are equal.
+func firstDifference(a, b []uint64) int {
+	for i := range max(len(a), len(b)) {
+		if i >= len(a) || i >= len(b) || a[i] != b[i] {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,59 @@
+package bus
+
+import "github.com/meadori/vibemulator/snap"
+
+// DMAState is the progress of an OAM DMA transfer. Writing a page number to $4014 halts
+// the CPU once its instruction finishes, then copies that page to OAM through $2004, a
+// read on one cycle and a write on the next. Reads only happen on odd CPU cycles, so the
+// transfer takes 513 cycles, or 514 when it has to wait one out.
+type DMAState struct {
+	Active bool // $4014 was written and the transfer hasn't finished
+	Halted bool // The CPU has spent its halt cycle
+	Page   byte
+	Count  int  // Bytes copied so far
+	Loaded bool // Data holds the next byte, to be written on the next cycle
+	Data   byte
+}
+
+// startDMA begins a transfer from the page at page<<8.
+func (b *Bus) startDMA(page byte) {
+	b.dma = DMAState{Active: true, Page: page}
+}
+
+// clockDMA runs one CPU cycle of the transfer in place of the CPU.
+func (b *Bus) clockDMA() {
+	d := &b.dma
+	switch {
+	case !d.Halted:
+		d.Halted = true
+	case d.Loaded:
+		b.PPU.CPUWrite(0x0004, d.Data)
+		d.Loaded = false
+		d.Count++
+		if d.Count == 256 {
+			*d = DMAState{}
+		}
+	case (b.SystemClocks/3)%2 == 1:
+		d.Data = b.Read(uint16(d.Page)<<8 | uint16(d.Count))
+		d.Loaded = true
+	}
+	// Otherwise this is the alignment cycle before the first read
+}
+
+func (d *DMAState) snapshot(w *snap.Writer) {
+	w.Bool(d.Active)
+	w.Bool(d.Halted)
+	w.U8(d.Page)
+	w.Int(d.Count)
+	w.Bool(d.Loaded)
+	w.U8(d.Data)
+}
+
+func (d *DMAState) restore(r *snap.Reader) {
+	d.Active = r.Bool()
+	d.Halted = r.Bool()
+	d.Page = r.U8()
+	d.Count = r.Int()
+	d.Loaded = r.Bool()
+	d.Data = r.U8()
+}
@@ -0,0 +1,59 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+func TestOAMDMAStall(t *testing.T) {
+	prg := []byte{
+		0xA9, 0xAB, // LDA #$AB
+		0x8D, 0x03, 0x02, // STA $0203
+		0xA9, 0x02, // LDA #$02
+		0x8D, 0x14, 0x40, // STA $4014
+		0x8D, 0x14, 0x40, // STA $4014
+		0x8D, 0x14, 0x40, // STA $4014
+		0xEA, // NOP
+	}
+	for _, instructionStepping := range []bool{false, true} {
+		cart, err := cartridge.New(writeTestROM(t, prg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := New()
+		if err := b.LoadCartridge(cart); err != nil {
+			t.Fatal(err)
+		}
+		b.cpu.SetInstructionStepping(instructionStepping)
+		b.clockInstruction() // Reset
+		for range 4 {
+			b.clockInstruction()
+		}
+
+		// Each instruction after the first STA $4014 waits out a transfer first
+		seen := map[int]bool{}
+		for i := range 3 {
+			halt := (b.SystemClocks + 2) / 3
+			start := b.SystemClocks
+			b.clockInstruction()
+			stall, cycles := 513, 4
+			if halt%2 == 1 {
+				stall++ // Wait for a read cycle
+			}
+			if i == 2 {
+				cycles = 2 // NOP
+			}
+			if got := (b.SystemClocks - start) / 3; got != stall+cycles {
+				t.Errorf("Instruction %d: expected %d cycles, got %d", i, stall+cycles, got)
+			}
+			seen[stall] = true
+		}
+		if !seen[513] || !seen[514] {
+			t.Error("Expected transfers of both 513 and 514 cycles")
+		}
+		if oam := b.GetOAM(); oam[3] != 0xAB {
+			t.Errorf("Expected $AB in OAM byte 3, got $%02X", oam[3])
+		}
+	}
+}
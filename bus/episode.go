@@ -0,0 +1,109 @@
+package bus
+
+import (
+	"fmt"
+
+	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/controller"
+)
+
+// ResetEpisode power-cycles the system into a known state for reproducible RL episodes.
+// The current cartridge is re-inserted in its power-on state. If state is non-empty the
+// episode starts from that savestate blob instead of the power-on state. The emulator is
+// left paused so that only StepFrame advances it.
+func (b *Bus) ResetEpisode(state []byte) error {
+	if b.cart == nil {
+		return fmt.Errorf("no cartridge loaded")
+	}
+	cart, err := b.cart.Clone()
+	if err != nil {
+		return err
+	}
+
+	b.cancelUntil()
+	b.setExecState(Paused)
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.releaseWaiters()
+
+	if err := b.powerCycle(cart); err != nil {
+		return err
+	}
+
+	if len(state) > 0 {
+		if err := b.loadStateBytes(state, true); err != nil {
+			return fmt.Errorf("failed to decode state: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadROM inserts a ROM from an in-memory iNES image and power-cycles the system. Like
+// ResetEpisode, it leaves the emulator paused.
+func (b *Bus) LoadROM(data []byte) error {
+	cart, err := cartridge.NewFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	b.cancelUntil()
+	b.setExecState(Paused)
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.releaseWaiters()
+
+	return b.powerCycle(cart)
+}
+
+// powerCycle clears everything a previous run may have touched and inserts cart.
+func (b *Bus) powerCycle(cart *cartridge.Cartridge) error {
+	for i := range b.ram {
+		b.ram[i] = 0
+	}
+	b.SystemClocks = 0
+	b.openBus = 0
+	b.joy1 = controller.New()
+	b.joy2 = controller.New()
+	b.live = [2][8]bool{}
+	b.clearInputQueue()
+	b.APU.Reset()
+	b.PPU.Reset()
+	b.lastFrame = b.PPU.FrameCounter
+	return b.LoadCartridge(cart)
+}
+
+// StepFrame holds the given controller states for the requested number of frames. It
+// waits for a running Advance to finish its frame rather than clocking alongside it.
+func (b *Bus) StepFrame(p1, p2 [8]bool, frames int) {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.setLiveInput(0, p1)
+	b.setLiveInput(1, p2)
+	for i := 0; i < frames; i++ {
+		b.RunFrame()
+	}
+}
+
+// RunFrame clocks the system until the PPU finishes the frame currently being drawn.
+func (b *Bus) RunFrame() {
+	if b.cart == nil {
+		// The PPU does not advance without a cartridge, so there is no frame to finish
+		return
+	}
+	frame := b.PPU.FrameCounter
+	for b.PPU.FrameCounter == frame {
+		b.Clock()
+	}
+}
+
+// GetFrameNumber returns the number of frames the PPU has completed.
+func (b *Bus) GetFrameNumber() int {
+	return b.PPU.FrameCounter
+}
+
+// Clocks returns the PPU dots and CPU cycles run since the bus was created or the last
+// ResetEpisode. Savestates carry them.
+func (b *Bus) Clocks() (ppu, cpu uint64) {
+	// The CPU is clocked on every third dot, starting with the first
+	return uint64(b.SystemClocks), uint64((b.SystemClocks + 2) / 3)
+}
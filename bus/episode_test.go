@@ -0,0 +1,110 @@
+package bus
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"testing"
+)
+
+func TestResetEpisodeIsDeterministic(t *testing.T) {
+	b := newTestBus(t)
+
+	// Dirty the machine before the first episode starts
+	b.StepFrame([8]bool{true}, [8]bool{}, 3)
+
+	run := func() ([]byte, [2048]byte) {
+		if err := b.ResetEpisode(nil); err != nil {
+			t.Fatal(err)
+		}
+		if !b.Paused() {
+			t.Error("ResetEpisode should leave the emulator paused")
+		}
+		if b.GetFrameNumber() != 0 {
+			t.Errorf("Expected frame 0 after reset, got %d", b.GetFrameNumber())
+		}
+		b.StepFrame([8]bool{true, false, false, true}, [8]bool{}, 5)
+		pixels := make([]byte, len(b.GetFramePixels()))
+		copy(pixels, b.GetFramePixels())
+		return pixels, b.ram
+	}
+
+	pixels1, ram1 := run()
+	pixels2, ram2 := run()
+
+	if !bytes.Equal(pixels1, pixels2) {
+		t.Error("Frame buffers differ between identical episodes")
+	}
+	if ram1 != ram2 {
+		t.Error("RAM differs between identical episodes")
+	}
+	if b.GetFrameNumber() != 5 {
+		t.Errorf("Expected frame 5 after stepping, got %d", b.GetFrameNumber())
+	}
+}
+
+func TestResetEpisodeFromState(t *testing.T) {
+	b := newTestBus(t)
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+	b.ram[0x10] = 0x42
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b.SaveStateToMemory()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.ResetEpisode(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if b.ram[0x10] != 0x42 {
+		t.Errorf("Expected RAM from state blob, got %02X", b.ram[0x10])
+	}
+	if b.GetFrameNumber() != 2 {
+		t.Errorf("Expected frame 2 from state blob, got %d", b.GetFrameNumber())
+	}
+}
+
+func TestResetEpisodeWithoutCartridge(t *testing.T) {
+	b := New()
+	if err := b.ResetEpisode(nil); err == nil {
+		t.Error("Expected an error when no cartridge is loaded")
+	}
+}
+
+func TestLoadROMPauses(t *testing.T) {
+	b := newTestBus(t)
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+	rom, err := os.ReadFile(writeTestROM(t, testProgram))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.LoadROM(rom); err != nil {
+		t.Fatal(err)
+	}
+	if !b.Paused() {
+		t.Error("LoadROM should leave the emulator paused")
+	}
+	if b.GetFrameNumber() != 0 {
+		t.Errorf("Expected frame 0 after LoadROM, got %d", b.GetFrameNumber())
+	}
+}
+
+func TestClocks(t *testing.T) {
+	b := newTestBus(t)
+	if err := b.ResetEpisode(nil); err != nil {
+		t.Fatal(err)
+	}
+	if ppu, cpu := b.Clocks(); ppu != 0 || cpu != 0 {
+		t.Errorf("Expected no clocks after reset, got %d PPU and %d CPU", ppu, cpu)
+	}
+	b.Clock()
+	if ppu, cpu := b.Clocks(); ppu != 1 || cpu != 1 {
+		t.Errorf("Expected 1 PPU and 1 CPU clock, got %d and %d", ppu, cpu)
+	}
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+	ppu, cpu := b.Clocks()
+	if ppu != uint64(b.SystemClocks) || cpu != (ppu+2)/3 {
+		t.Errorf("Expected %d PPU and %d CPU clocks, got %d and %d", b.SystemClocks, (b.SystemClocks+2)/3, ppu, cpu)
+	}
+}
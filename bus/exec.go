@@ -0,0 +1,173 @@
+package bus
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ExecState says how Advance moves the emulator on. The debugger changes it from any
+// goroutine; the goroutine that owns the bus (the display loop, or the headless
+// server) acts on it.
+type ExecState int32
+
+const (
+	// Running clocks a frame's worth of cycles per Advance
+	Running ExecState = iota
+	// Paused clocks nothing
+	Paused
+	// SteppingInstruction runs one instruction on the next Advance, then pauses
+	SteppingInstruction
+	// SteppingFrame runs to the end of the current frame, then pauses
+	SteppingFrame
+	// RunningUntil runs until a RunUntil condition pauses the emulator
+	RunningUntil
+)
+
+var execStateNames = [...]string{"running", "paused", "stepping instruction", "stepping frame", "running until"}
+
+func (s ExecState) String() string {
+	if int(s) < len(execStateNames) {
+		return execStateNames[s]
+	}
+	return "unknown"
+}
+
+// cyclesPerFrame is the number of system clocks in an NTSC frame.
+const cyclesPerFrame = 89342
+
+// execControl coordinates the owner of the bus with the debugger.
+type execControl struct {
+	state atomic.Int32
+
+	// clock is held while the bus is clocked by Advance or StepFrame, so requests can
+	// wait for a running Advance to stop, and RPCs never clock alongside the owner
+	clock sync.Mutex
+
+	mu      sync.Mutex
+	waiters []chan struct{} // Released when an Advance ends paused
+}
+
+// ExecState returns what the emulator is doing.
+func (b *Bus) ExecState() ExecState {
+	return ExecState(b.exec.state.Load())
+}
+
+// Paused reports whether the emulator is stopped for the debugger.
+func (b *Bus) Paused() bool {
+	return b.ExecState() == Paused
+}
+
+// Running reports whether Advance clocks whole frames, so live input should apply.
+func (b *Bus) Running() bool {
+	s := b.ExecState()
+	return s == Running || s == RunningUntil
+}
+
+// SetPaused pauses or resumes the emulator. Pausing abandons any RunUntil and step
+// request, and returns once the owner has stopped clocking, on an instruction boundary.
+// It must not be called from the goroutine that calls Advance.
+func (b *Bus) SetPaused(paused bool) {
+	if !paused {
+		b.setExecState(Running)
+		return
+	}
+	b.cancelUntil()
+	b.setExecState(Paused)
+	b.exec.clock.Lock()
+	b.exec.clock.Unlock()
+	b.releaseWaiters()
+}
+
+// RequestStep asks the owner to run one instruction on its next Advance. The channel is
+// closed once it has, or when something else pauses the emulator first.
+func (b *Bus) RequestStep() <-chan struct{} {
+	return b.request(SteppingInstruction)
+}
+
+// RequestFrameStep asks the owner to run to the end of the current frame, like
+// RequestStep.
+func (b *Bus) RequestFrameStep() <-chan struct{} {
+	return b.request(SteppingFrame)
+}
+
+func (b *Bus) request(s ExecState) <-chan struct{} {
+	b.cancelUntil()
+	done := make(chan struct{})
+	b.exec.mu.Lock()
+	b.exec.waiters = append(b.exec.waiters, done)
+	b.exec.mu.Unlock()
+	b.setExecState(s)
+	return done
+}
+
+func (b *Bus) setExecState(s ExecState) {
+	b.exec.state.Store(int32(s))
+}
+
+// releaseWaiters acknowledges every request waiting for the emulator to stop.
+func (b *Bus) releaseWaiters() {
+	b.exec.mu.Lock()
+	defer b.exec.mu.Unlock()
+	for _, w := range b.exec.waiters {
+		close(w)
+	}
+	b.exec.waiters = nil
+}
+
+// Advance clocks the bus as the execution state allows, once per display frame: a
+// frame's worth of cycles when running, one instruction or the rest of the frame when
+// stepping, and nothing when paused. When the state changes under it, it stops on the
+// next instruction boundary. Requests waiting for the emulator to stop are released
+// once it is paused.
+func (b *Bus) Advance() {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+
+	switch s := b.ExecState(); {
+	case s == Paused:
+	case b.cart == nil:
+		// Nothing to run, and no frame would ever finish
+		if s != Running {
+			b.setExecState(Paused)
+		}
+	case s == SteppingInstruction:
+		b.clockInstruction()
+		b.exec.state.CompareAndSwap(int32(SteppingInstruction), int32(Paused))
+	case s == SteppingFrame:
+		frame := b.PPU.FrameCounter
+		for {
+			b.Clock()
+			if b.atBoundary() && (b.ExecState() != SteppingFrame || b.PPU.FrameCounter != frame) {
+				break
+			}
+		}
+		b.exec.state.CompareAndSwap(int32(SteppingFrame), int32(Paused))
+	default:
+		for i := 0; i < cyclesPerFrame; i++ {
+			b.Clock()
+			if !b.Running() && b.atBoundary() {
+				break
+			}
+		}
+	}
+
+	if b.Paused() {
+		b.releaseWaiters()
+	}
+}
+
+// clockInstruction clocks until the CPU finishes an instruction.
+func (b *Bus) clockInstruction() {
+	for {
+		b.Clock()
+		if b.atBoundary() {
+			return
+		}
+	}
+}
+
+// atBoundary reports whether the last clock completed a CPU instruction. The CPU runs
+// on every third clock, unless OAM DMA has it halted.
+func (b *Bus) atBoundary() bool {
+	return (b.SystemClocks-1)%3 == 0 && !b.stalled && b.cpu.IsInstructionComplete()
+}
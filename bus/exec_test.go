@@ -0,0 +1,135 @@
+package bus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdvanceStates(t *testing.T) {
+	b := newTestBus(t)
+
+	b.Advance()
+	if b.SystemClocks != cyclesPerFrame {
+		t.Errorf("Expected a running Advance to run a frame's worth of clocks, ran %d", b.SystemClocks)
+	}
+
+	b.SetPaused(true)
+	clocks := b.SystemClocks
+	b.Advance()
+	if b.SystemClocks != clocks {
+		t.Errorf("Expected a paused Advance not to clock, ran %d clocks", b.SystemClocks-clocks)
+	}
+
+	// Step over INC $00 (5 cycles) from the top of the loop
+	for {
+		_, _, _, _, _, pc, _ := b.GetCPUState()
+		if pc == 0x8005 && b.IsInstructionComplete() {
+			break
+		}
+		b.clockInstruction()
+	}
+	done := b.RequestStep()
+	if b.ExecState() != SteppingInstruction {
+		t.Errorf("Expected %v, got %v", SteppingInstruction, b.ExecState())
+	}
+	b.Advance()
+	select {
+	case <-done:
+	default:
+		t.Fatal("Expected the step to be acknowledged")
+	}
+	if _, _, _, _, _, pc, _ := b.GetCPUState(); pc != 0x8007 || !b.Paused() {
+		t.Errorf("Expected to pause at $8007 after one instruction, got $%04X (%v)", pc, b.ExecState())
+	}
+
+	frame := b.GetFrameNumber()
+	done = b.RequestFrameStep()
+	b.Advance()
+	<-done
+	if b.GetFrameNumber() == frame || !b.Paused() || !b.IsInstructionComplete() {
+		t.Errorf("Expected to pause on an instruction boundary after frame %d, got frame %d (%v)", frame, b.GetFrameNumber(), b.ExecState())
+	}
+}
+
+func TestSetPausedWaitsForAdvance(t *testing.T) {
+	b := newTestBus(t)
+	stop := make(chan struct{})
+	advancing := make(chan struct{})
+	go func() {
+		defer close(advancing)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				b.Advance()
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-advancing
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	b.SetPaused(true)
+	// Nothing may clock once SetPaused returns
+	clocks := b.SystemClocks
+	time.Sleep(10 * time.Millisecond)
+	if b.SystemClocks != clocks {
+		t.Errorf("Expected the emulator to stay stopped after SetPaused, ran %d more clocks", b.SystemClocks-clocks)
+	}
+
+	// A step request that is overridden by a pause is released, not left hanging
+	b.SetPaused(false)
+	time.Sleep(5 * time.Millisecond)
+	done := b.RequestStep()
+	b.SetPaused(true)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expected SetPaused to release a pending step")
+	}
+}
+
+func TestStateChangesWaitForAdvance(t *testing.T) {
+	b := newTestBus(t)
+	b.RunFrame()
+	state, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := b.Snapshot()
+
+	stop := make(chan struct{})
+	advancing := make(chan struct{})
+	go func() {
+		defer close(advancing)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				b.Advance()
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-advancing
+	}()
+
+	// Under -race, any of these touching the state mid-frame is reported
+	for range 10 {
+		if err := b.LoadStateFromBytes(state); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.SaveStateToBytes(); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.RestoreSnapshot(snapshot); err != nil {
+			t.Fatal(err)
+		}
+		b.Reset()
+	}
+}
@@ -0,0 +1,199 @@
+package bus
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// HookID identifies a registered hook so it can be removed with RemoveHook.
+type HookID int
+
+type frameHook struct {
+	id HookID
+	fn func(frame int)
+}
+
+// memoryHook watches CPU reads or writes to an address range
+type memoryHook struct {
+	id         HookID
+	start, end uint16
+	fn         func(addr uint16, data byte)
+}
+
+// execHook runs before the instruction at addr executes
+type execHook struct {
+	id   HookID
+	addr uint16
+	fn   func(pc uint16)
+}
+
+type nmiHook struct {
+	id HookID
+	fn func()
+}
+
+// hookSet is an immutable snapshot of the registered hooks
+type hookSet struct {
+	frame []frameHook
+	read  []memoryHook
+	write []memoryHook
+	exec  []execHook
+	nmi   []nmiHook
+}
+
+// hooks holds the callbacks registered by Go programs embedding the core. They run on the
+// emulation goroutine, so they must not block. Registration may happen from any goroutine:
+// it swaps in a new snapshot, so the emulation loop never takes a lock.
+type hooks struct {
+	mu     sync.Mutex
+	nextID HookID
+	set    atomic.Pointer[hookSet]
+}
+
+// update applies fn to a copy of the current hooks and publishes the result
+func (h *hooks) update(fn func(s *hookSet)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var s hookSet
+	if cur := h.set.Load(); cur != nil {
+		s = hookSet{
+			frame: append([]frameHook(nil), cur.frame...),
+			read:  append([]memoryHook(nil), cur.read...),
+			write: append([]memoryHook(nil), cur.write...),
+			exec:  append([]execHook(nil), cur.exec...),
+			nmi:   append([]nmiHook(nil), cur.nmi...),
+		}
+	}
+	fn(&s)
+	h.set.Store(&s)
+}
+
+func (h *hooks) newID() HookID {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	return h.nextID
+}
+
+// OnFrame registers fn to run at the start of every frame with the new frame number,
+// after frame-targeted input has been latched.
+func (b *Bus) OnFrame(fn func(frame int)) HookID {
+	id := b.hooks.newID()
+	b.hooks.update(func(s *hookSet) { s.frame = append(s.frame, frameHook{id, fn}) })
+	return id
+}
+
+// OnMemoryWrite registers fn to run for every CPU write to an address in [start, end],
+// before the write takes effect.
+func (b *Bus) OnMemoryWrite(start, end uint16, fn func(addr uint16, data byte)) HookID {
+	id := b.hooks.newID()
+	b.hooks.update(func(s *hookSet) { s.write = append(s.write, memoryHook{id, start, end, fn}) })
+	return id
+}
+
+// OnMemoryRead registers fn to run for every CPU read from an address in [start, end],
+// including opcode fetches, with the value read. Debugger reads do not trigger it.
+func (b *Bus) OnMemoryRead(start, end uint16, fn func(addr uint16, data byte)) HookID {
+	id := b.hooks.newID()
+	b.hooks.update(func(s *hookSet) { s.read = append(s.read, memoryHook{id, start, end, fn}) })
+	return id
+}
+
+// OnExecute registers fn to run when the CPU is about to execute the instruction at addr.
+// The CPU state passed to fn is the state just before that instruction.
+func (b *Bus) OnExecute(addr uint16, fn func(pc uint16)) HookID {
+	id := b.hooks.newID()
+	b.hooks.update(func(s *hookSet) { s.exec = append(s.exec, execHook{id, addr, fn}) })
+	return id
+}
+
+// OnNMI registers fn to run whenever the PPU raises an NMI, just before the CPU services it.
+func (b *Bus) OnNMI(fn func()) HookID {
+	id := b.hooks.newID()
+	b.hooks.update(func(s *hookSet) { s.nmi = append(s.nmi, nmiHook{id, fn}) })
+	return id
+}
+
+// RemoveHook unregisters a hook of any kind. Unknown IDs are ignored.
+func (b *Bus) RemoveHook(id HookID) {
+	b.hooks.update(func(s *hookSet) {
+		for i, h := range s.frame {
+			if h.id == id {
+				s.frame = append(s.frame[:i], s.frame[i+1:]...)
+				return
+			}
+		}
+		for i, h := range s.read {
+			if h.id == id {
+				s.read = append(s.read[:i], s.read[i+1:]...)
+				return
+			}
+		}
+		for i, h := range s.write {
+			if h.id == id {
+				s.write = append(s.write[:i], s.write[i+1:]...)
+				return
+			}
+		}
+		for i, h := range s.exec {
+			if h.id == id {
+				s.exec = append(s.exec[:i], s.exec[i+1:]...)
+				return
+			}
+		}
+		for i, h := range s.nmi {
+			if h.id == id {
+				s.nmi = append(s.nmi[:i], s.nmi[i+1:]...)
+				return
+			}
+		}
+	})
+}
+
+func (b *Bus) runFrameHooks(frame int) {
+	if s := b.hooks.set.Load(); s != nil {
+		for _, h := range s.frame {
+			h.fn(frame)
+		}
+	}
+}
+
+func (b *Bus) runReadHooks(addr uint16, data byte) {
+	if s := b.hooks.set.Load(); s != nil {
+		runMemoryHooks(s.read, addr, data)
+	}
+}
+
+func (b *Bus) runWriteHooks(addr uint16, data byte) {
+	if s := b.hooks.set.Load(); s != nil {
+		runMemoryHooks(s.write, addr, data)
+	}
+}
+
+func runMemoryHooks(hooks []memoryHook, addr uint16, data byte) {
+	for _, h := range hooks {
+		if addr >= h.start && addr <= h.end {
+			h.fn(addr, data)
+		}
+	}
+}
+
+// runExecHooks runs on every instruction boundary with the address of the next instruction
+func (b *Bus) runExecHooks(pc uint16) {
+	if s := b.hooks.set.Load(); s != nil {
+		for _, h := range s.exec {
+			if h.addr == pc {
+				h.fn(pc)
+			}
+		}
+	}
+}
+
+func (b *Bus) runNMIHooks() {
+	if s := b.hooks.set.Load(); s != nil {
+		for _, h := range s.nmi {
+			h.fn()
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package bus
+
+import "testing"
+
+func TestHooks(t *testing.T) {
+	b := newTestBus(t)
+	b.PPU.Ctrl = 0x80 // Enable NMI so the hook has something to see
+
+	var frames []int
+	var writes, nmis int
+	b.OnFrame(func(frame int) { frames = append(frames, frame) })
+	writeID := b.OnMemoryWrite(0x0001, 0x0001, func(addr uint16, data byte) {
+		if addr != 0x0001 {
+			t.Errorf("Write hook fired for $%04X outside its range", addr)
+		}
+		writes++
+	})
+	b.OnNMI(func() { nmis++ })
+
+	b.StepFrame([8]bool{}, [8]bool{}, 3)
+
+	if len(frames) != 3 || frames[2] != b.GetFrameNumber() {
+		t.Errorf("Expected three frame hooks ending at frame %d, got %v", b.GetFrameNumber(), frames)
+	}
+	if writes == 0 {
+		t.Error("Expected the write hook to see STA $01")
+	}
+	if nmis < 2 {
+		t.Errorf("Expected an NMI hook per frame, got %d", nmis)
+	}
+
+	b.RemoveHook(writeID)
+	before := writes
+	b.RunFrame()
+	if writes != before {
+		t.Error("Write hook still fired after RemoveHook")
+	}
+}
@@ -0,0 +1,45 @@
+package bus
+
+import (
+	"go/build"
+	"path"
+	"strings"
+	"testing"
+)
+
+// nondeterministic are the packages whose values differ from run to run. Randomness and
+// wall-clock time belong to the frontend; emulation must depend only on the ROM, the
+// input and the savestate.
+var nondeterministic = []string{"math/rand", "math/rand/v2", "crypto/rand", "time"}
+
+// TestCoreIsDeterministic checks that neither the bus nor any package of this module it
+// depends on imports a source of nondeterminism.
+func TestCoreIsDeterministic(t *testing.T) {
+	const module = "github.com/meadori/vibemulator/"
+	seen := map[string]bool{}
+	var visit func(dir, importPath string)
+	visit = func(dir, importPath string) {
+		if seen[importPath] {
+			return
+		}
+		seen[importPath] = true
+		pkg, err := build.ImportDir(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, imp := range pkg.Imports {
+			for _, bad := range nondeterministic {
+				if imp == bad {
+					t.Errorf("%s imports %s", importPath, imp)
+				}
+			}
+			if rest, ok := strings.CutPrefix(imp, module); ok {
+				visit(path.Join("..", rest), imp)
+			}
+		}
+	}
+	visit(".", module+"bus")
+	if !seen[module+"cpu"] || !seen[module+"ppu"] {
+		t.Errorf("Expected to check the CPU and PPU, checked %v", seen)
+	}
+}
@@ -0,0 +1,122 @@
+package bus
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxQueuedInputs bounds the frame-targeted input buffer (about 9 minutes of two-player input)
+const maxQueuedInputs = 1 << 16
+
+// queuedInput is a controller state waiting to be latched at a given frame
+type queuedInput struct {
+	frame   int
+	player  int
+	buttons [8]bool
+}
+
+// QueueInput buffers a controller state for player 1 or 2 to be latched at the start of the
+// given frame. It stays held until the next queued state for that player, and is combined
+// with any live input set through SetController1State/SetController2State. It waits for a
+// running Advance to finish its frame.
+func (b *Bus) QueueInput(player int, frame int, buttons [8]bool) error {
+	if player != 1 && player != 2 {
+		return fmt.Errorf("invalid player %d", player)
+	}
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	if len(b.inputQueue) >= maxQueuedInputs {
+		return fmt.Errorf("input queue is full")
+	}
+
+	// Keep the queue ordered by frame; states for the same frame apply in arrival order
+	i := sort.Search(len(b.inputQueue), func(i int) bool { return b.inputQueue[i].frame > frame })
+	b.inputQueue = append(b.inputQueue, queuedInput{})
+	copy(b.inputQueue[i+1:], b.inputQueue[i:])
+	b.inputQueue[i] = queuedInput{frame: frame, player: player, buttons: buttons}
+	return nil
+}
+
+// Input returns the controller states the game currently sees.
+func (b *Bus) Input() (p1, p2 [8]bool) {
+	return b.joy1.Buttons(), b.joy2.Buttons()
+}
+
+// clearInputQueue drops buffered input and releases anything it was holding
+func (b *Bus) clearInputQueue() {
+	b.inputQueue = nil
+	b.queued = [2][8]bool{}
+	b.syncInput(0)
+	b.syncInput(1)
+}
+
+// latchQueuedInput applies every queued state due at or before the current frame
+func (b *Bus) latchQueuedInput() {
+	n := 0
+	for n < len(b.inputQueue) && b.inputQueue[n].frame <= b.PPU.FrameCounter {
+		in := b.inputQueue[n]
+		b.queued[in.player-1] = in.buttons
+		b.syncInput(in.player - 1)
+		n++
+	}
+	b.inputQueue = b.inputQueue[n:]
+}
+
+// setLiveInput sets the live state of a player. The caller holds the clock.
+func (b *Bus) setLiveInput(player int, buttons [8]bool) {
+	b.live[player] = buttons
+	b.syncInput(player)
+}
+
+// syncInput pushes the combined live and queued state of a player to its controller
+func (b *Bus) syncInput(player int) {
+	var buttons [8]bool
+	for i := range buttons {
+		buttons[i] = b.live[player][i] || b.queued[player][i]
+	}
+	if player == 0 {
+		b.joy1.SetButtons(buttons)
+	} else {
+		b.joy2.SetButtons(buttons)
+	}
+}
+
+// SetDPCMConflictFilter turns off the DPCM conflict: on hardware, a DMC sample fetch that
+// lands on a controller read clocks the controller twice, dropping a button. Games that
+// play DPCM samples reread the controller until two reads match; with the filter on,
+// games that don't (e.g. Burger Time) no longer lose inputs.
+func (b *Bus) SetDPCMConflictFilter(on bool) {
+	b.dpcmFilter.Store(on)
+}
+
+// checkDPCMConflict runs on the cycle the CPU read a controller port: the last cycle of
+// the instruction when the CPU runs instructions all at once.
+func (b *Bus) checkDPCMConflict() {
+	if b.APU.DMCFetched() && !b.dpcmFilter.Load() {
+		b.portRead.Read()
+	}
+	b.portRead = nil
+}
+
+// portReadID returns the port in portRead as savestates store it: 1 or 2, or 0 for none.
+func (b *Bus) portReadID() byte {
+	switch b.portRead {
+	case b.joy1:
+		return 1
+	case b.joy2:
+		return 2
+	}
+	return 0
+}
+
+// setPortRead restores portRead from portReadID's encoding.
+func (b *Bus) setPortRead(id byte) {
+	switch id {
+	case 1:
+		b.portRead = b.joy1
+	case 2:
+		b.portRead = b.joy2
+	default:
+		b.portRead = nil
+	}
+}
@@ -0,0 +1,138 @@
+package bus
+
+import "testing"
+
+func TestQueueInputLatchesAtTargetFrame(t *testing.T) {
+	b := newTestBus(t)
+	start := b.GetFrameNumber()
+
+	// Queued out of order on purpose
+	if err := b.QueueInput(1, start+3, [8]bool{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.QueueInput(1, start+2, [8]bool{true}); err != nil {
+		t.Fatal(err)
+	}
+
+	b.RunFrame()
+	if b.joy1.Buttons()[0] {
+		t.Error("Input applied before its target frame")
+	}
+	b.RunFrame()
+	if !b.joy1.Buttons()[0] {
+		t.Error("Input not applied at its target frame")
+	}
+	b.RunFrame()
+	if b.joy1.Buttons()[0] {
+		t.Error("Input not released by the next queued state")
+	}
+}
+
+func TestQueuedInputCombinesWithLive(t *testing.T) {
+	b := newTestBus(t)
+	b.SetController2State([8]bool{false, true})
+	if err := b.QueueInput(2, b.GetFrameNumber()+1, [8]bool{true}); err != nil {
+		t.Fatal(err)
+	}
+	b.RunFrame()
+
+	got := b.joy2.Buttons()
+	if !got[0] || !got[1] {
+		t.Errorf("Expected live B and queued A together, got %v", got)
+	}
+
+	if err := b.QueueInput(3, 0, [8]bool{}); err == nil {
+		t.Error("Expected an error for an invalid player")
+	}
+}
+
+func TestControllerPortReads(t *testing.T) {
+	b := newTestBus(t)
+	b.SetController1State([8]bool{true, false, false, true}) // A and Start
+
+	// LDA $4016 leaves the $40 of the address on the bus
+	read := func() byte {
+		b.openBus = 0x40
+		return b.Read(0x4016)
+	}
+	b.Write(0x4016, 1)
+	for i := 0; i < 10; i++ {
+		if got := read(); got != 0x41 {
+			t.Fatalf("Read %d with strobe high: expected A ($41), got $%02X", i, got)
+		}
+	}
+
+	b.Write(0x4016, 0)
+	want := []byte{0x41, 0x40, 0x40, 0x41, 0x40, 0x40, 0x40, 0x40, 0x41, 0x41}
+	for i, w := range want {
+		if got := read(); got != w {
+			t.Errorf("Read %d: expected $%02X, got $%02X", i, w, got)
+		}
+	}
+	b.openBus = 0x40
+	if got := b.Read(0x4017); got&0xE0 != 0x40 {
+		t.Errorf("Expected $4017 to float its upper bits at $40, got $%02X", got)
+	}
+}
+
+func TestDPCMConflict(t *testing.T) {
+	tests := []struct {
+		filter bool
+		want   byte // Bit 0 of the read after the conflicting one
+	}{
+		{false, 0x01}, // The fetch clocked B out, so Select is next
+		{true, 0x00},  // B
+	}
+	for _, tt := range tests {
+		b := newTestBus(t)
+		b.SetDPCMConflictFilter(tt.filter)
+		b.SetController1State([8]bool{true, false, true}) // A and Select
+		b.Write(0x4016, 1)
+		b.Write(0x4016, 0)
+
+		b.Read(0x4016)     // A
+		b.Write(0x4013, 0) // A one-byte sample
+		b.Write(0x4015, 0x10)
+		b.APU.Clock() // Fetches the sample during the read
+		b.checkDPCMConflict()
+
+		if got := b.Read(0x4016) & 0x01; got != tt.want {
+			t.Errorf("Filter %v: expected $%02X after the conflict, got $%02X", tt.filter, tt.want, got)
+		}
+	}
+}
+
+func TestInputWaitsForStepFrame(t *testing.T) {
+	b := newTestBus(t)
+
+	stop := make(chan struct{})
+	started := make(chan struct{})
+	stepping := make(chan struct{})
+	go func() {
+		defer close(stepping)
+		b.StepFrame([8]bool{}, [8]bool{}, 1)
+		close(started)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				b.StepFrame([8]bool{}, [8]bool{}, 1)
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-stepping
+	}()
+	<-started
+
+	// Under -race, input landing mid-frame is reported
+	for i := range 1000 {
+		if err := b.QueueInput(1, i, [8]bool{i%2 == 0}); err != nil {
+			t.Fatal(err)
+		}
+		b.SetController1State([8]bool{i%2 == 0})
+		b.SetController2State([8]bool{i%2 == 1})
+	}
+}
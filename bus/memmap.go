@@ -0,0 +1,73 @@
+package bus
+
+import "sort"
+
+// RegionKind names what decodes a region of the CPU address space.
+type RegionKind string
+
+const (
+	RegionRAM    RegionKind = "ram"     // 2KB of work RAM, mirrored
+	RegionPPU    RegionKind = "ppu"     // The 8 PPU registers, mirrored
+	RegionIO     RegionKind = "io"      // APU and controller registers
+	RegionPRGRAM RegionKind = "prg-ram" // Cartridge RAM
+	RegionPRGROM RegionKind = "prg-rom" // A window onto PRG ROM
+	RegionCart   RegionKind = "cart"    // Cartridge space the mapper doesn't report
+	RegionOpen   RegionKind = "open"    // Nothing responds
+)
+
+// Region is a range of the CPU address space and what it maps to.
+type Region struct {
+	Start, End uint16
+	Kind       RegionKind
+	Mirror     int // Size of the block that repeats through a mirrored region, or 0
+	Offset     int // Where Start falls in PRG ROM or RAM, or -1
+	Bank       int // Offset in units of the region's size (the mapper's bank number), or -1
+}
+
+// MemoryMap describes the whole CPU address space, in address order. PRG regions
+// follow the mapper's current banking, so the map changes as the game switches banks.
+func (b *Bus) MemoryMap() []Region {
+	regions := []Region{
+		{Start: 0x0000, End: 0x1FFF, Kind: RegionRAM, Mirror: len(b.ram), Offset: -1, Bank: -1},
+		{Start: 0x2000, End: 0x3FFF, Kind: RegionPPU, Mirror: 8, Offset: -1, Bank: -1},
+		{Start: 0x4000, End: 0x401F, Kind: RegionIO, Offset: -1, Bank: -1},
+	}
+	if b.cart == nil {
+		return append(regions, Region{Start: 0x4020, End: 0xFFFF, Kind: RegionOpen, Offset: -1, Bank: -1})
+	}
+	windows := b.cart.PRGMap()
+	if windows == nil {
+		return append(regions, Region{Start: 0x4020, End: 0xFFFF, Kind: RegionCart, Offset: -1, Bank: -1})
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Start < windows[j].Start })
+
+	// Fill the gaps between the windows with open bus
+	next := 0x4020
+	for _, w := range windows {
+		if int(w.Start) > next {
+			regions = append(regions, Region{Start: uint16(next), End: w.Start - 1, Kind: RegionOpen, Offset: -1, Bank: -1})
+		}
+		kind := RegionPRGROM
+		if w.RAM {
+			kind = RegionPRGRAM
+		}
+		size := int(w.End) - int(w.Start) + 1
+		regions = append(regions, Region{Start: w.Start, End: w.End, Kind: kind, Offset: w.Offset, Bank: w.Offset / size})
+		next = int(w.End) + 1
+	}
+	if next <= 0xFFFF {
+		regions = append(regions, Region{Start: uint16(next), End: 0xFFFF, Kind: RegionOpen, Offset: -1, Bank: -1})
+	}
+	return regions
+}
+
+// RegionAt returns the region of the memory map holding addr.
+func (b *Bus) RegionAt(addr uint16) Region {
+	for _, r := range b.MemoryMap() {
+		if addr >= r.Start && addr <= r.End {
+			return r
+		}
+	}
+	// The map covers every address, so this isn't reached
+	return Region{Start: addr, End: addr, Kind: RegionOpen, Offset: -1, Bank: -1}
+}
@@ -0,0 +1,25 @@
+package bus
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemoryMap(t *testing.T) {
+	b := newTestBus(t)
+	want := []Region{
+		{Start: 0x0000, End: 0x1FFF, Kind: RegionRAM, Mirror: 2048, Offset: -1, Bank: -1},
+		{Start: 0x2000, End: 0x3FFF, Kind: RegionPPU, Mirror: 8, Offset: -1, Bank: -1},
+		{Start: 0x4000, End: 0x401F, Kind: RegionIO, Offset: -1, Bank: -1},
+		{Start: 0x4020, End: 0x5FFF, Kind: RegionOpen, Offset: -1, Bank: -1},
+		{Start: 0x6000, End: 0x7FFF, Kind: RegionPRGRAM},
+		{Start: 0x8000, End: 0xBFFF, Kind: RegionPRGROM},
+		{Start: 0xC000, End: 0xFFFF, Kind: RegionPRGROM},
+	}
+	if got := b.MemoryMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected memory map %+v, got %+v", want, got)
+	}
+	if r := b.RegionAt(0xC123); r.Start != 0xC000 || r.Kind != RegionPRGROM {
+		t.Errorf("Expected $C123 in the PRG ROM window at $C000, got %+v", r)
+	}
+}
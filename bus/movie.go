@@ -0,0 +1,203 @@
+package bus
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/meadori/vibemulator/script"
+)
+
+// MovieFrame holds the controller state latched at the start of one frame.
+type MovieFrame struct {
+	P1, P2 [8]bool
+}
+
+// Movie is a frame-indexed input log. Frames[0] is the input held when recording began and
+// each later entry is the input at the start of the next frame, so playback does not depend
+// on wall-clock timing.
+type Movie struct {
+	// State is the savestate recording began from
+	State  []byte
+	Frames []MovieFrame
+
+	// FinalHash is the FrameHash at the start of the last recorded frame
+	FinalHash uint64
+}
+
+// Encode serializes the movie for storage.
+func (m *Movie) Encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeMovie parses a movie written by Encode.
+func DecodeMovie(data []byte) (*Movie, error) {
+	var m Movie
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// FrameHash returns an FNV-64a hash of the last frame the PPU drew.
+func (b *Bus) FrameHash() uint64 {
+	return HashPixels(b.GetFramePixels())
+}
+
+// HashPixels returns the FNV-64a hash used by FrameHash for a raw RGBA frame.
+func HashPixels(pix []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(pix)
+	return h.Sum64()
+}
+
+// StartRecording snapshots the current state and begins logging input at every frame start.
+func (b *Bus) StartRecording() error {
+	if b.cart == nil {
+		return fmt.Errorf("no cartridge loaded")
+	}
+	if b.playback != nil {
+		return fmt.Errorf("cannot record during movie playback")
+	}
+
+	state, err := b.SaveStateToBytes()
+	if err != nil {
+		return err
+	}
+	b.recording = &Movie{
+		State:     state,
+		Frames:    []MovieFrame{b.currentInput()},
+		FinalHash: b.FrameHash(),
+	}
+	return nil
+}
+
+// StopRecording ends the current recording and returns it.
+func (b *Bus) StopRecording() (*Movie, error) {
+	if b.recording == nil {
+		return nil, fmt.Errorf("not recording")
+	}
+	m := b.recording
+	b.recording = nil
+	return m, nil
+}
+
+// IsRecording reports whether a movie is being recorded.
+func (b *Bus) IsRecording() bool {
+	return b.recording != nil
+}
+
+// ScriptMovie converts an input script into a movie starting from state, with one frame of
+// input for every frame the script lasts.
+func ScriptMovie(s *script.Script, state []byte) *Movie {
+	m := &Movie{State: state, Frames: make([]MovieFrame, s.End)}
+	var held MovieFrame
+	next := 0
+	for i := range m.Frames {
+		for next < len(s.Entries) && s.Entries[next].Frame <= i {
+			held = MovieFrame{P1: s.Entries[next].P1, P2: s.Entries[next].P2}
+			next++
+		}
+		m.Frames[i] = held
+	}
+	return m
+}
+
+// PlayMovie restores the movie's starting state, replays its input as fast as possible and
+// returns the hash of the final frame. The emulator is paused while the movie runs.
+func (b *Bus) PlayMovie(m *Movie) (uint64, error) {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	was := b.exec.state.Swap(int32(Paused))
+	defer b.exec.state.Store(was)
+
+	if err := b.startPlayback(m); err != nil {
+		return 0, err
+	}
+	for b.playback != nil {
+		b.RunFrame()
+	}
+	return b.FrameHash(), nil
+}
+
+// StartPlayback restores the movie's starting state and feeds its input at each frame
+// start as the emulator runs, in place of live controller input. It waits for a running
+// Advance to finish its frame.
+func (b *Bus) StartPlayback(m *Movie) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	return b.startPlayback(m)
+}
+
+// startPlayback is StartPlayback for callers that hold the clock.
+func (b *Bus) startPlayback(m *Movie) error {
+	if b.cart == nil {
+		return fmt.Errorf("no cartridge loaded")
+	}
+	if len(m.Frames) == 0 {
+		return fmt.Errorf("movie has no frames")
+	}
+	if err := b.loadStateBytes(m.State, true); err != nil {
+		return fmt.Errorf("failed to decode movie state: %w", err)
+	}
+
+	b.recording = nil
+	b.applyInput(m.Frames[0])
+	if len(m.Frames) > 1 {
+		b.playback = m
+		b.playIndex = 1
+	}
+	return nil
+}
+
+// IsPlaying reports whether a movie started with StartPlayback is still feeding input.
+func (b *Bus) IsPlaying() bool {
+	return b.playback != nil
+}
+
+func (b *Bus) currentInput() MovieFrame {
+	return MovieFrame{P1: b.joy1.Buttons(), P2: b.joy2.Buttons()}
+}
+
+func (b *Bus) applyInput(f MovieFrame) {
+	b.joy1.SetButtons(f.P1)
+	b.joy2.SetButtons(f.P2)
+}
+
+// Divergence is the first frame whose hash differs from the expected one.
+type Divergence struct {
+	Frame     int
+	Want, Got uint64
+}
+
+// VerifyMovie restores the movie's starting state and runs frames as headless
+// -dump-hashes does, numbering them from 0, until the last frame in want. It compares
+// the hash of each frame in want and returns the first that differs, or nil if they all
+// match. Frames past the end of the movie run with its last input held. The emulator is
+// paused while the movie runs.
+func (b *Bus) VerifyMovie(m *Movie, want map[int]uint64) (*Divergence, error) {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	was := b.exec.state.Swap(int32(Paused))
+	defer b.exec.state.Store(was)
+
+	last := -1
+	for frame := range want {
+		last = max(last, frame)
+	}
+	if err := b.startPlayback(m); err != nil {
+		return nil, err
+	}
+	for i := 0; i <= last; i++ {
+		b.RunFrame()
+		if hash, ok := want[i]; ok && hash != b.FrameHash() {
+			return &Divergence{Frame: i, Want: hash, Got: b.FrameHash()}, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,128 @@
+package bus
+
+import (
+	"fmt"
+	"slices"
+)
+
+// movieEdit is the movie loaded for editing and the frame of it the emulator is on.
+type movieEdit struct {
+	movie *Movie
+	frame int  // Counted at each frame start, so it follows the emulator when it runs on
+	dirty bool // The input changed since FinalHash was taken
+}
+
+// EditMovie loads m for editing and seeks to its first frame. Later edits change m in
+// place. Resuming the emulator plays the rest of the movie from wherever it is.
+func (b *Bus) EditMovie(m *Movie) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.edit = movieEdit{movie: m}
+	if err := b.seekMovie(0); err != nil {
+		b.edit = movieEdit{}
+		return err
+	}
+	return nil
+}
+
+// SeekMovie replays the movie being edited from its starting state to the start of
+// frame, with the frame's input latched. Seeking one past the last frame leaves the
+// emulator where inserted input is appended.
+func (b *Bus) SeekMovie(frame int) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	if b.edit.movie == nil {
+		return fmt.Errorf("no movie is being edited")
+	}
+	return b.seekMovie(frame)
+}
+
+// TruncateMovie drops the movie's input after the current frame.
+func (b *Bus) TruncateMovie() error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	m, frame, err := b.editPosition()
+	if err != nil {
+		return err
+	}
+	m.Frames = m.Frames[:min(frame+1, len(m.Frames))]
+	b.edit.dirty = true
+	return b.seekMovie(frame)
+}
+
+// InsertMovieInput inserts n frames of input at the current frame, moving the input
+// there and after it later, and replays to the current frame so the emulator runs the
+// inserted input next. At the end of the movie it appends the input.
+func (b *Bus) InsertMovieInput(input MovieFrame, n int) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	m, frame, err := b.editPosition()
+	if err != nil {
+		return err
+	}
+	if n < 1 {
+		return fmt.Errorf("cannot insert %d frames", n)
+	}
+	m.Frames = slices.Insert(m.Frames, frame, slices.Repeat([]MovieFrame{input}, n)...)
+	b.edit.dirty = true
+	return b.seekMovie(frame)
+}
+
+// EditedMovie returns the movie being edited and the frame the emulator is on. The
+// movie's FinalHash is as of the last UpdateMovieHash.
+func (b *Bus) EditedMovie() (*Movie, int, error) {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	return b.editPosition()
+}
+
+// UpdateMovieHash brings the edited movie's FinalHash up to date with its input. If the
+// input changed since the hash was taken, that replays the movie to its last frame and
+// back, so call it once before saving rather than after every edit.
+func (b *Bus) UpdateMovieHash() error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	m, frame, err := b.editPosition()
+	if err != nil || !b.edit.dirty {
+		return err
+	}
+	if err := b.seekMovie(len(m.Frames) - 1); err != nil {
+		return err
+	}
+	m.FinalHash = b.FrameHash()
+	b.edit.dirty = false
+	return b.seekMovie(frame)
+}
+
+// editPosition returns the movie being edited and the frame the emulator is on, which
+// may be one past its last frame, where inserted input is appended.
+func (b *Bus) editPosition() (*Movie, int, error) {
+	m := b.edit.movie
+	switch {
+	case m == nil:
+		return nil, 0, fmt.Errorf("no movie is being edited")
+	case b.edit.frame > len(m.Frames):
+		return nil, 0, fmt.Errorf("frame %d is past the end of the movie (%d frames); seek back into it", b.edit.frame, len(m.Frames))
+	}
+	return m, b.edit.frame, nil
+}
+
+// seekMovie replays the movie being edited to the start of frame. The emulator is
+// paused while the movie runs. The caller holds the clock.
+func (b *Bus) seekMovie(frame int) error {
+	m := b.edit.movie
+	if frame < 0 || frame > len(m.Frames) {
+		return fmt.Errorf("frame %d is outside the movie (%d frames)", frame, len(m.Frames))
+	}
+	was := b.exec.state.Swap(int32(Paused))
+	defer b.exec.state.Store(was)
+
+	if err := b.startPlayback(m); err != nil {
+		return err
+	}
+	b.edit.frame = 0
+	for b.edit.frame < frame {
+		b.RunFrame()
+	}
+	return nil
+}
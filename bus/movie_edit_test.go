@@ -0,0 +1,80 @@
+package bus
+
+import "testing"
+
+func TestEditMovie(t *testing.T) {
+	b := newTestBus(t)
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+	if err := b.StartRecording(); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 5 {
+		b.StepFrame([8]bool{i%2 == 0}, [8]bool{}, 1) // A on every other frame
+	}
+	movie, err := b.StopRecording()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SeekMovie(1); err == nil {
+		t.Error("Expected seeking with no movie loaded to fail")
+	}
+
+	if err := b.EditMovie(movie); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SeekMovie(3); err != nil {
+		t.Fatal(err)
+	}
+	if p1, _ := b.Input(); p1 != movie.Frames[3].P1 {
+		t.Errorf("Expected frame 3's input after seeking to it, got %v", p1)
+	}
+
+	// Seeking lands where playing the movie does
+	ram := b.ram
+	hash := b.FrameHash()
+	b.StartPlayback(movie)
+	b.StepFrame([8]bool{}, [8]bool{}, 3)
+	if b.ram != ram || b.FrameHash() != hash {
+		t.Error("Expected seeking to frame 3 to match playing 3 frames")
+	}
+	if err := b.SeekMovie(3); err != nil {
+		t.Fatal(err)
+	}
+
+	start := [8]bool{false, false, false, true}
+	if err := b.InsertMovieInput(MovieFrame{P1: start}, 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(movie.Frames) != 8 || movie.Frames[3].P1 != start || movie.Frames[4].P1 != start || movie.Frames[5].P1 != [8]bool{true} {
+		t.Errorf("Expected two frames of Start inserted before frame 3, got %v", movie.Frames)
+	}
+	if p1, _ := b.Input(); p1 != start {
+		t.Errorf("Expected the inserted input to be latched, got %v", p1)
+	}
+
+	if err := b.TruncateMovie(); err != nil {
+		t.Fatal(err)
+	}
+	if len(movie.Frames) != 4 {
+		t.Errorf("Expected the movie to end at frame 3, got %d frames", len(movie.Frames))
+	}
+
+	// The saved movie's hash follows the edits
+	if err := b.UpdateMovieHash(); err != nil {
+		t.Fatal(err)
+	}
+	edited, frame, err := b.EditedMovie()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame != 3 {
+		t.Errorf("Expected to stay on frame 3, got %d", frame)
+	}
+	if hash, _ := b.PlayMovie(edited); hash != edited.FinalHash {
+		t.Errorf("Expected the edited movie to end on hash %016X, got %016X", edited.FinalHash, hash)
+	}
+
+	if err := b.SeekMovie(5); err == nil {
+		t.Error("Expected seeking past the end of the movie to fail")
+	}
+}
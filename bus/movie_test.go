@@ -0,0 +1,112 @@
+package bus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/meadori/vibemulator/script"
+)
+
+func TestMoviePlaybackMatchesRecording(t *testing.T) {
+	b := newTestBus(t)
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+
+	if err := b.StartRecording(); err != nil {
+		t.Fatal(err)
+	}
+	inputs := [][8]bool{{true}, {}, {false, true}, {true, true}, {}}
+	for _, in := range inputs {
+		b.StepFrame(in, [8]bool{}, 1)
+	}
+	movie, err := b.StopRecording()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ram := b.ram
+
+	// One entry for the starting input plus one per frame boundary
+	if len(movie.Frames) != len(inputs)+1 {
+		t.Errorf("Expected %d movie frames, got %d", len(inputs)+1, len(movie.Frames))
+	}
+
+	data, err := movie.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	movie, err = DecodeMovie(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wander off before replaying
+	b.StepFrame([8]bool{true, true, true, true}, [8]bool{}, 4)
+
+	hash, err := b.PlayMovie(movie)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != movie.FinalHash {
+		t.Errorf("Playback hash %016X does not match recording %016X", hash, movie.FinalHash)
+	}
+	if b.ram[0] != ram[0] {
+		t.Errorf("Expected the frame counter in RAM to match the recording, got %02X want %02X", b.ram[0], ram[0])
+	}
+	if b.Paused() {
+		t.Error("PlayMovie should restore the previous pause state")
+	}
+}
+
+func TestStopRecordingWithoutRecording(t *testing.T) {
+	b := newTestBus(t)
+	if _, err := b.StopRecording(); err == nil {
+		t.Error("Expected an error when not recording")
+	}
+}
+
+func TestScriptMovie(t *testing.T) {
+	start := [8]bool{3: true}
+	s := &script.Script{
+		Entries: []script.Entry{{Frame: 0}, {Frame: 2, P1: start}, {Frame: 3}},
+		End:     5,
+	}
+	m := ScriptMovie(s, []byte{1})
+
+	want := []MovieFrame{{}, {}, {P1: start}, {}, {}}
+	if !reflect.DeepEqual(m.Frames, want) {
+		t.Errorf("Expected frames %v, got %v", want, m.Frames)
+	}
+}
+
+func TestVerifyMovie(t *testing.T) {
+	b := newTestBus(t)
+	state, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	movie := &Movie{State: state, Frames: []MovieFrame{{}, {P1: [8]bool{true}}, {}}}
+
+	if err := b.StartPlayback(movie); err != nil {
+		t.Fatal(err)
+	}
+	want := map[int]uint64{}
+	for i := range 5 {
+		b.RunFrame()
+		want[i] = b.FrameHash()
+	}
+
+	d, err := b.VerifyMovie(movie, want)
+	if err != nil || d != nil {
+		t.Errorf("Expected the movie to verify, got %+v (err=%v)", d, err)
+	}
+
+	got := want[3]
+	want[3]++
+	delete(want, 4)
+	d, err = b.VerifyMovie(movie, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d == nil || *d != (Divergence{Frame: 3, Want: got + 1, Got: got}) {
+		t.Errorf("Expected a divergence at frame 3, got %+v", d)
+	}
+}
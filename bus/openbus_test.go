@@ -0,0 +1,49 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+func TestOpenBus(t *testing.T) {
+	prg := []byte{
+		0xAD, 0x00, 0x50, // LDA $5000 (nothing there on NROM)
+		0x85, 0x00, // STA $00
+		0xAD, 0x00, 0x40, // LDA $4000 (write-only)
+		0x85, 0x01, // STA $01
+	}
+	cart, err := cartridge.New(writeTestROM(t, prg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	if err := b.LoadCartridge(cart); err != nil {
+		t.Fatal(err)
+	}
+	b.clockInstruction() // Reset
+	for range 4 {
+		b.clockInstruction()
+	}
+
+	// Each read returns the high byte of its address, the last byte the CPU fetched
+	if got := b.GetMemoryBlock(0x0000, 2); got[0] != 0x50 || got[1] != 0x40 {
+		t.Errorf("Expected open bus reads of 50 40, got % X", got)
+	}
+
+	// A write drives the bus; reading $4015 doesn't
+	b.Write(0x0000, 0xA5)
+	b.Read(0x4015)
+	if got := b.Read(0x4018); got != 0xA5 {
+		t.Errorf("Expected $A5 left on the bus by the write, got $%02X", got)
+	}
+	if got := b.Read(0x4015) & statusOpenBus; got != 0x20 {
+		t.Errorf("Expected bit 5 of $4015 from the bus, got $%02X", got)
+	}
+	if got := b.Read(0x4016) & portOpenBus; got != 0xA0 {
+		t.Errorf("Expected the top bits of $4016 from the bus, got $%02X", got)
+	}
+	if got := b.GetMemoryBlock(0x5000, 1)[0]; got != 0xA0 {
+		t.Errorf("Expected debugger reads to see the bus, got $%02X", got)
+	}
+}
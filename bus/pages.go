@@ -0,0 +1,85 @@
+package bus
+
+// pageKind says which device decodes a 256-byte page of CPU address space. The table is
+// resolved when a cartridge is inserted or removed, so RAM and PPU register accesses skip
+// the mapper call and the range checks, and only cartridge pages pay for the interface call.
+type pageKind byte
+
+const (
+	pageOpen pageKind = iota // Nothing responds: cartridge space with no cartridge
+	pageRAM                  // $0000-$1FFF, 2KB mirrored
+	pagePPU                  // $2000-$3FFF, 8 registers mirrored
+	pageIO                   // $4000-$40FF: APU and controllers, then cartridge space from $4020
+	pageCart                 // $4100-$FFFF
+)
+
+// mapPages rebuilds the page table for the current cartridge.
+func (b *Bus) mapPages() {
+	for p := range b.pages {
+		addr := p << 8
+		switch {
+		case addr <= 0x1FFF:
+			b.pages[p] = pageRAM
+		case addr <= 0x3FFF:
+			b.pages[p] = pagePPU
+		case addr == 0x4000:
+			b.pages[p] = pageIO
+		case b.cart != nil:
+			b.pages[p] = pageCart
+		default:
+			b.pages[p] = pageOpen
+		}
+	}
+}
+
+// Reads that nothing answers return the last value on the data bus, and the
+// controller ports and $4015 only drive some of its bits. For LDA $4016 the bus holds
+// the $40 of the address.
+const (
+	portOpenBus   = 0xE0 // Bits 5-7 of $4016 and $4017
+	statusOpenBus = 0x20 // Bit 5 of $4015
+)
+
+func (b *Bus) readIO(addr uint16) byte {
+	switch {
+	case addr >= 0x4020:
+		return b.readCart(addr)
+	case addr == 0x4015:
+		return b.openBus&statusOpenBus | b.APU.CPURead(addr)
+	case addr == 0x4016:
+		b.portRead = b.joy1
+		return b.openBus&portOpenBus | b.joy1.Read()
+	case addr == 0x4017:
+		b.portRead = b.joy2
+		return b.openBus&portOpenBus | b.joy2.Read()
+	}
+	// The APU's other registers are write-only
+	return b.openBus
+}
+
+// readCart reads cartridge space, which is open bus where the mapper has nothing.
+func (b *Bus) readCart(addr uint16) byte {
+	if b.cart == nil {
+		return b.openBus
+	}
+	if data, ok := b.cart.Mapper.CPUMapRead(addr); ok {
+		return data
+	}
+	return b.openBus
+}
+
+func (b *Bus) writeIO(addr uint16, data byte) {
+	switch {
+	case addr >= 0x4020:
+		if b.cart != nil {
+			b.cart.Mapper.CPUMapWrite(addr, data)
+		}
+	case addr == 0x4014:
+		b.startDMA(data)
+	case addr == 0x4016:
+		b.joy1.Write(data)
+		b.joy2.Write(data)
+	case addr <= 0x4017:
+		b.APU.CPUWrite(addr, data)
+	}
+}
@@ -0,0 +1,52 @@
+package bus
+
+import (
+	"fmt"
+
+	"github.com/meadori/vibemulator/expr"
+)
+
+// Practice loops a section of a game: it reloads the state saved when practice started
+// each time a condition in the expr language becomes true, such as "[$075A] < 2" for
+// losing a life in Super Mario Bros. that started the section with two.
+type Practice struct {
+	cond  *expr.Expr
+	state []byte
+	met   bool // The condition held at the last check; it must fail before it can fire again
+	Loops int  // Times the state has been reloaded
+}
+
+// StartPractice saves the current state as the start of the section and arms condition.
+func (b *Bus) StartPractice(condition string) (*Practice, error) {
+	cond, err := expr.Parse(condition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse condition: %v", err)
+	}
+	state, err := b.SaveStateToBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Practice{cond: cond, state: state, met: cond.True(b.exprEnv())}, nil
+}
+
+// Condition returns the condition practice reloads on.
+func (p *Practice) Condition() string {
+	return p.cond.String()
+}
+
+// Check reloads the start of the section into b if the condition has become true since
+// the last check, and reports whether it did. Call it between frames.
+func (p *Practice) Check(b *Bus) (bool, error) {
+	met := p.cond.True(b.exprEnv())
+	fire := met && !p.met
+	p.met = met
+	if !fire {
+		return false, nil
+	}
+	if err := b.LoadStateFromBytes(p.state); err != nil {
+		return false, err
+	}
+	p.met = p.cond.True(b.exprEnv())
+	p.Loops++
+	return true, nil
+}
@@ -0,0 +1,31 @@
+package bus
+
+import "testing"
+
+func TestPractice(t *testing.T) {
+	b := newTestBus(t)
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	start := b.ram[0]
+
+	p, err := b.StartPractice("[$0000] == 0x80")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := false
+	for i := 0; i < 2000 && !reloaded; i++ {
+		b.clockInstruction()
+		if reloaded, err = p.Check(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reloaded || p.Loops != 1 {
+		t.Fatalf("Expected one reload, got %d", p.Loops)
+	}
+	if b.ram[0] != start {
+		t.Errorf("Expected the reload to restore [$0000] to %d, got %d", start, b.ram[0])
+	}
+
+	if _, err := b.StartPractice("[$0000 =="); err == nil {
+		t.Error("Expected an error for a bad condition")
+	}
+}
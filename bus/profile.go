@@ -0,0 +1,97 @@
+package bus
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// ProfileEntry is an address with the number of times it was executed or called.
+type ProfileEntry struct {
+	Addr  uint16
+	Count uint64
+}
+
+// Profile summarizes the instructions executed while profiling.
+type Profile struct {
+	Running      bool
+	Instructions uint64
+	Frames       int
+	PCs          []ProfileEntry // Instructions executed at each address, busiest first
+	Subroutines  []ProfileEntry // JSR calls to each target, busiest first
+}
+
+// profiler counts instructions at every instruction boundary. The counters are atomic so
+// the debugger can report while the emulator is still running.
+type profiler struct {
+	running    atomic.Bool
+	startFrame int
+	endFrame   atomic.Int64
+	pcs        [0x10000]atomic.Uint64
+	calls      [0x10000]atomic.Uint64
+}
+
+// StartProfile discards any previous profile and starts counting executed instructions.
+func (b *Bus) StartProfile() {
+	p := &profiler{startFrame: b.PPU.FrameCounter}
+	p.running.Store(true)
+	b.profiler.Store(p)
+}
+
+// StopProfile stops counting and returns the profile, which Profile keeps returning until
+// the next StartProfile.
+func (b *Bus) StopProfile() (Profile, bool) {
+	p := b.profiler.Load()
+	if p == nil {
+		return Profile{}, false
+	}
+	if p.running.CompareAndSwap(true, false) {
+		p.endFrame.Store(int64(b.PPU.FrameCounter))
+	}
+	return b.Profile()
+}
+
+// Profile returns the running or most recent profile, and false if there is none.
+func (b *Bus) Profile() (Profile, bool) {
+	p := b.profiler.Load()
+	if p == nil {
+		return Profile{}, false
+	}
+
+	prof := Profile{Running: p.running.Load()}
+	end := int(p.endFrame.Load())
+	if prof.Running {
+		end = b.PPU.FrameCounter
+	}
+	prof.Frames = end - p.startFrame
+	for addr := range p.pcs {
+		if n := p.pcs[addr].Load(); n != 0 {
+			prof.Instructions += n
+			prof.PCs = append(prof.PCs, ProfileEntry{uint16(addr), n})
+		}
+		if n := p.calls[addr].Load(); n != 0 {
+			prof.Subroutines = append(prof.Subroutines, ProfileEntry{uint16(addr), n})
+		}
+	}
+	sortProfile(prof.PCs)
+	sortProfile(prof.Subroutines)
+	return prof, true
+}
+
+func sortProfile(entries []ProfileEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Count > entries[j].Count })
+}
+
+// profileInstruction counts the instruction about to execute at pc, and the target of a JSR
+func (b *Bus) profileInstruction(p *profiler, pc uint16) {
+	if !p.running.Load() {
+		return
+	}
+	p.pcs[pc].Add(1)
+
+	// Code runs from RAM or cartridge space, which peek reads without side effects
+	if op, ok := b.peek(pc); ok && op == 0x20 {
+		lo, _ := b.peek(pc + 1)
+		hi, _ := b.peek(pc + 2)
+		p.calls[uint16(hi)<<8|uint16(lo)].Add(1)
+	}
+}
@@ -0,0 +1,56 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+func TestProfile(t *testing.T) {
+	cart, err := cartridge.New(writeTestROM(t, []byte{
+		0x20, 0x06, 0x80, // loop: JSR sub
+		0x4C, 0x00, 0x80, // JMP loop
+		0x60, // sub: RTS
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	if err := b.LoadCartridge(cart); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.Profile(); ok {
+		t.Error("Expected no profile before StartProfile")
+	}
+
+	b.StartProfile()
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+	prof, ok := b.StopProfile()
+	if !ok || prof.Running {
+		t.Fatalf("Expected a stopped profile, got %+v", prof)
+	}
+	if prof.Frames != 2 {
+		t.Errorf("Expected 2 frames, got %d", prof.Frames)
+	}
+	if len(prof.Subroutines) != 1 || prof.Subroutines[0].Addr != 0x8006 {
+		t.Fatalf("Expected calls to $8006 only, got %+v", prof.Subroutines)
+	}
+
+	// The loop runs JSR, RTS and JMP once per call, give or take the ends of the interval
+	counts := map[uint16]uint64{}
+	for _, e := range prof.PCs {
+		counts[e.Addr] = e.Count
+	}
+	calls := prof.Subroutines[0].Count
+	for _, addr := range []uint16{0x8000, 0x8003, 0x8006} {
+		if counts[addr] < calls-1 || counts[addr] > calls+1 {
+			t.Errorf("Expected about %d executions at $%04X, got %d", calls, addr, counts[addr])
+		}
+	}
+
+	// Stopped profiles stay available but no longer count
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	if again, _ := b.Profile(); again.Instructions != prof.Instructions {
+		t.Errorf("Expected a stopped profile to stay at %d instructions, got %d", prof.Instructions, again.Instructions)
+	}
+}
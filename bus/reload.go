@@ -0,0 +1,54 @@
+package bus
+
+import (
+	"fmt"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+// ReloadOptions says what a hot reload carries over from the build it replaces.
+type ReloadOptions struct {
+	// KeepRAM keeps CPU RAM and the cartridge's PRG RAM instead of clearing them
+	KeepRAM bool
+	// State is a savestate to load into the new build, though it was taken on an
+	// earlier one. It includes RAM, so KeepRAM makes no difference with it.
+	State []byte
+}
+
+// Reload swaps in a rebuilt ROM of the game that is running, for homebrew developers'
+// edit-assemble-run loop. The new build starts from power-on, keeping what opts asks
+// for. A savestate can only carry over to a build with the same mapper.
+func (b *Bus) Reload(cart *cartridge.Cartridge, opts ReloadOptions) error {
+	if opts.State != nil && b.cart != nil && cart.Header.Mapper != b.cart.Header.Mapper {
+		return fmt.Errorf("failed to reload: mapper changed from %d to %d, so the savestate doesn't fit", b.cart.Header.Mapper, cart.Header.Mapper)
+	}
+	ram := b.ram
+	var prgRAM []byte
+	if opts.KeepRAM && b.cart != nil {
+		prgRAM = append(prgRAM, cartPRGRAM(b.cart)...)
+	}
+
+	b.PowerOff()
+	if err := b.LoadCartridge(cart); err != nil {
+		return err
+	}
+	if opts.State != nil {
+		if err := b.loadStateBytes(opts.State, false); err != nil {
+			return fmt.Errorf("failed to load savestate into the new build: %v", err)
+		}
+		return nil
+	}
+	if opts.KeepRAM {
+		b.ram = ram
+		copy(cartPRGRAM(cart), prgRAM)
+	}
+	return nil
+}
+
+// cartPRGRAM returns the cartridge's PRG RAM, or nil if its mapper has none.
+func cartPRGRAM(cart *cartridge.Cartridge) []byte {
+	if m, ok := cart.Mapper.(interface{ GetPRGRAM() []byte }); ok {
+		return m.GetPRGRAM()
+	}
+	return nil
+}
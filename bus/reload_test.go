@@ -0,0 +1,101 @@
+package bus
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+// rebuiltProgram is testProgram after an edit: it counts in $02 instead of $00.
+var rebuiltProgram = []byte{
+	0xA9, 0x1E, // LDA #$1E
+	0x8D, 0x01, 0x20, // STA $2001
+	0xE6, 0x02, // loop: INC $02
+	0x4C, 0x05, 0x80, // JMP loop
+}
+
+func loadTestCart(t *testing.T, path string) *cartridge.Cartridge {
+	t.Helper()
+	cart, err := cartridge.New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cart
+}
+
+func TestReload(t *testing.T) {
+	rebuilt := writeTestROM(t, rebuiltProgram)
+	tests := []struct {
+		name     string
+		keepRAM  bool
+		wantKept bool
+	}{
+		{"power-on", false, false},
+		{"keep RAM", true, true},
+	}
+	for _, tt := range tests {
+		b := newTestBus(t)
+		b.RunFrame()
+		counter := b.GetMemoryBlock(0x0000, 1)[0]
+		if counter == 0 {
+			t.Fatal("Expected the test program to count in $00")
+		}
+
+		if err := b.Reload(loadTestCart(t, rebuilt), ReloadOptions{KeepRAM: tt.keepRAM}); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := b.GetMemoryBlock(0x0000, 1)[0]; (got == counter) != tt.wantKept {
+			t.Errorf("%s: Expected RAM kept = %v, $00 was %02X and is %02X", tt.name, tt.wantKept, counter, got)
+		}
+		b.RunFrame()
+		if b.GetMemoryBlock(0x0002, 1)[0] == 0 {
+			t.Errorf("%s: Expected the rebuilt program to run", tt.name)
+		}
+	}
+}
+
+func TestReloadToState(t *testing.T) {
+	b := newTestBus(t)
+	b.RunFrame()
+	state, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter := b.GetMemoryBlock(0x0000, 1)[0]
+	b.RunFrame()
+
+	// A savestate from the old build is refused by LoadStateFromBytes, but not by Reload
+	rebuilt := writeTestROM(t, rebuiltProgram)
+	other := New()
+	if err := other.LoadCartridge(loadTestCart(t, rebuilt)); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.LoadStateFromBytes(state); !errors.Is(err, ErrStateROMMismatch) {
+		t.Fatalf("Expected a savestate of the old build to be refused, got %v", err)
+	}
+	if err := b.Reload(loadTestCart(t, rebuilt), ReloadOptions{State: state}); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.GetMemoryBlock(0x0000, 1)[0]; got != counter {
+		t.Errorf("Expected the savestate's RAM, $00 = %02X, got %02X", counter, got)
+	}
+	if b.ROMHash() != other.ROMHash() {
+		t.Error("Expected the rebuilt ROM to stay inserted")
+	}
+
+	// A build with another mapper can't take the savestate
+	data, err := os.ReadFile(rebuilt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[6] |= 0x20 // Mapper 2
+	cart, err := cartridge.NewFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Reload(cart, ReloadOptions{State: state}); err == nil {
+		t.Error("Expected a savestate not to carry over to another mapper")
+	}
+}
@@ -0,0 +1,49 @@
+package bus
+
+// RewindBuffer keeps the most recent snapshots in a ring. Each slot keeps its buffer when
+// it is popped or overwritten, so once the ring has filled, recording and rewinding
+// don't allocate.
+type RewindBuffer struct {
+	slots [][]byte
+	start int // Index of the oldest snapshot
+	n     int // Number of snapshots held
+}
+
+// NewRewindBuffer returns a buffer holding up to capacity snapshots.
+func NewRewindBuffer(capacity int) *RewindBuffer {
+	return &RewindBuffer{slots: make([][]byte, capacity)}
+}
+
+// Len returns the number of snapshots that can be rewound.
+func (r *RewindBuffer) Len() int {
+	return r.n
+}
+
+// Push snapshots b, overwriting the oldest snapshot when the buffer is full.
+func (r *RewindBuffer) Push(b *Bus) {
+	if len(r.slots) == 0 {
+		return
+	}
+	i := (r.start + r.n) % len(r.slots)
+	if r.n == len(r.slots) {
+		r.start = (r.start + 1) % len(r.slots)
+	} else {
+		r.n++
+	}
+	r.slots[i] = b.AppendSnapshot(r.slots[i][:0])
+}
+
+// Pop restores the newest snapshot into b and removes it. It reports false when the
+// buffer is empty.
+func (r *RewindBuffer) Pop(b *Bus) (bool, error) {
+	if r.n == 0 {
+		return false, nil
+	}
+	r.n--
+	return true, b.RestoreSnapshot(r.slots[(r.start+r.n)%len(r.slots)])
+}
+
+// Clear drops every snapshot but keeps the buffers for reuse.
+func (r *RewindBuffer) Clear() {
+	r.start, r.n = 0, 0
+}
@@ -0,0 +1,63 @@
+package bus
+
+import "testing"
+
+func TestRewindBuffer(t *testing.T) {
+	b := newTestBus(t)
+	r := NewRewindBuffer(3)
+
+	var frames []int
+	for i := 0; i < 5; i++ {
+		b.RunFrame()
+		r.Push(b)
+		frames = append(frames, b.GetFrameNumber())
+	}
+	if r.Len() != 3 {
+		t.Fatalf("Expected 3 snapshots, got %d", r.Len())
+	}
+
+	// Only the newest three remain, and they come back newest first
+	b.RunFrame()
+	for i := 4; i >= 2; i-- {
+		ok, err := r.Pop(b)
+		if !ok || err != nil {
+			t.Fatalf("Expected snapshot of frame %d, got ok=%v err=%v", frames[i], ok, err)
+		}
+		if got := b.GetFrameNumber(); got != frames[i] {
+			t.Errorf("Expected to rewind to frame %d, got %d", frames[i], got)
+		}
+	}
+	if ok, _ := r.Pop(b); ok {
+		t.Error("Expected an empty buffer after popping every snapshot")
+	}
+
+	r.Push(b)
+	r.Clear()
+	if r.Len() != 0 {
+		t.Errorf("Expected Clear to empty the buffer, got %d snapshots", r.Len())
+	}
+}
+
+// TestRewindBufferAllocs holds recording and rewinding to zero allocations once the
+// ring has filled.
+func TestRewindBufferAllocs(t *testing.T) {
+	b := newTestBus(t)
+	r := NewRewindBuffer(4)
+	for i := 0; i < 4; i++ {
+		b.RunFrame()
+		r.Push(b)
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() { r.Push(b) }); allocs != 0 {
+		t.Errorf("Expected Push into a full buffer not to allocate, got %v", allocs)
+	}
+	rewind := func() {
+		if _, err := r.Pop(b); err != nil {
+			t.Fatal(err)
+		}
+		r.Push(b)
+	}
+	if allocs := testing.AllocsPerRun(10, rewind); allocs != 0 {
+		t.Errorf("Expected rewinding not to allocate, got %v", allocs)
+	}
+}
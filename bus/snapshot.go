@@ -0,0 +1,151 @@
+package bus
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/meadori/vibemulator/snap"
+)
+
+// Snapshots are the fast in-memory counterpart of savestates for rewind and netplay:
+// the fields of State in a flat binary encoding appended into reusable buffers instead
+// of gob. They start with StateVersion as a byte but have no ROM hash, and are only
+// meant to be restored by the same build; SavestateFromSnapshot and
+// SnapshotFromSavestate convert between the two.
+
+// maxPooledSnapshots bounds how many released buffers are kept for reuse
+const maxPooledSnapshots = 64
+
+var snapshotPool struct {
+	sync.Mutex
+	free [][]byte
+}
+
+// Snapshot captures the emulator state into a pooled buffer. Hand the buffer to
+// ReleaseSnapshot once it is no longer needed so later snapshots can reuse it.
+func (b *Bus) Snapshot() []byte {
+	var buf []byte
+	snapshotPool.Lock()
+	if n := len(snapshotPool.free); n > 0 {
+		buf = snapshotPool.free[n-1]
+		snapshotPool.free = snapshotPool.free[:n-1]
+	}
+	snapshotPool.Unlock()
+	return b.AppendSnapshot(buf[:0])
+}
+
+// ReleaseSnapshot returns a buffer from Snapshot or AppendSnapshot to the pool.
+func ReleaseSnapshot(s []byte) {
+	if s == nil {
+		return
+	}
+	snapshotPool.Lock()
+	if len(snapshotPool.free) < maxPooledSnapshots {
+		snapshotPool.free = append(snapshotPool.free, s)
+	}
+	snapshotPool.Unlock()
+}
+
+// AppendSnapshot appends a snapshot of the emulator state to dst and returns the
+// extended buffer. It does not allocate when dst has room for it.
+func (b *Bus) AppendSnapshot(dst []byte) []byte {
+	w := snap.Writer{Buf: slices.Grow(dst, b.snapshotSize)}
+	w.U8(StateVersion)
+	w.Raw(b.ram[:])
+	w.Int(b.SystemClocks)
+	b.cpu.Snapshot(&w)
+	b.PPU.Snapshot(&w)
+	b.APU.Snapshot(&w)
+	b.joy1.Snapshot(&w)
+	b.joy2.Snapshot(&w)
+	w.U8(b.portReadID())
+	b.dma.snapshot(&w)
+	w.U8(b.openBus)
+	if b.cart != nil {
+		b.cart.Snapshot(&w)
+	}
+	b.snapshotSize = len(w.Buf) - len(dst)
+	return w.Buf
+}
+
+// RestoreSnapshot restores the emulator state from a snapshot taken by this build with
+// the same cartridge inserted. It waits for a running Advance to finish its frame.
+func (b *Bus) RestoreSnapshot(data []byte) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	return b.restoreSnapshot(data)
+}
+
+// restoreSnapshot is RestoreSnapshot for callers that hold the clock.
+func (b *Bus) restoreSnapshot(data []byte) error {
+	r := snap.NewReader(data)
+	if v := r.U8(); v != StateVersion {
+		return fmt.Errorf("unsupported snapshot version %d", v)
+	}
+	r.Raw(b.ram[:])
+	b.SystemClocks = r.Int()
+	b.cpu.Restore(&r)
+	b.PPU.Restore(&r)
+	b.APU.Restore(&r)
+	b.joy1.Restore(&r)
+	b.joy2.Restore(&r)
+	b.setPortRead(r.U8())
+	b.dma.restore(&r)
+	b.openBus = r.U8()
+	b.lastFrame = b.PPU.FrameCounter
+	b.prgMapChanged()
+	if b.cart != nil {
+		if err := b.cart.Restore(&r); err != nil {
+			return fmt.Errorf("failed to restore cartridge: %w", err)
+		}
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("snapshot has %d unexpected trailing bytes", r.Len())
+	}
+	return nil
+}
+
+// SavestateFromSnapshot converts a snapshot taken on b, such as a rewind buffer entry,
+// into a savestate, so a moment found by rewinding can be kept on disk. b is left as
+// it was.
+func (b *Bus) SavestateFromSnapshot(snapshot []byte) ([]byte, error) {
+	var data []byte
+	err := b.withState(func() (err error) {
+		if err = b.restoreSnapshot(snapshot); err == nil {
+			data, err = b.saveStateBytes()
+		}
+		return err
+	})
+	return data, err
+}
+
+// SnapshotFromSavestate converts a savestate for b's ROM into a snapshot appended to
+// dst, for example to seed a rewind buffer. b is left as it was.
+func (b *Bus) SnapshotFromSavestate(dst, savestate []byte) ([]byte, error) {
+	err := b.withState(func() error {
+		if err := b.loadStateBytes(savestate, true); err != nil {
+			return err
+		}
+		dst = b.AppendSnapshot(dst)
+		return nil
+	})
+	return dst, err
+}
+
+// withState runs fn, which may replace the emulator state, and then puts the state
+// back as it was. It holds the clock throughout, so fn must not take it.
+func (b *Bus) withState(fn func() error) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	saved := b.Snapshot()
+	defer ReleaseSnapshot(saved)
+	err := fn()
+	if rerr := b.restoreSnapshot(saved); rerr != nil && err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package bus
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/meadori/vibemulator/snap"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	b := newBenchBus(t)
+	b.SetController1State([8]bool{false, false, false, true})
+	for i := 0; i < 5; i++ {
+		b.RunFrame()
+	}
+	// Stop mid-frame so the snapshot catches the PPU and CPU partway through
+	for i := 0; i < 12345; i++ {
+		b.Clock()
+	}
+	s := b.Snapshot()
+	defer ReleaseSnapshot(s)
+
+	restored := newBenchBus(t)
+	if err := restored.RestoreSnapshot(s); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(b.SaveStateToMemory(), restored.SaveStateToMemory()) {
+		t.Fatal("Restored state differs from the snapshotted one")
+	}
+	if again := restored.AppendSnapshot(nil); !bytes.Equal(s, again) {
+		t.Error("Snapshot of the restored bus differs from the original snapshot")
+	}
+
+	for i := 0; i < 3; i++ {
+		b.RunFrame()
+		restored.RunFrame()
+	}
+	if b.FrameHash() != restored.FrameHash() {
+		t.Error("Runs diverge after restoring a snapshot")
+	}
+}
+
+func TestSnapshotAllocs(t *testing.T) {
+	b := newBenchBus(t)
+	ReleaseSnapshot(b.Snapshot())
+	if allocs := testing.AllocsPerRun(10, func() { ReleaseSnapshot(b.Snapshot()) }); allocs != 0 {
+		t.Errorf("Expected pooled snapshots not to allocate, got %v allocations", allocs)
+	}
+
+	s := b.Snapshot()
+	defer ReleaseSnapshot(s)
+	if allocs := testing.AllocsPerRun(10, func() { b.RestoreSnapshot(s) }); allocs != 0 {
+		t.Errorf("Expected restoring a snapshot not to allocate, got %v allocations", allocs)
+	}
+}
+
+func TestRestoreSnapshotErrors(t *testing.T) {
+	b := newBenchBus(t)
+	s := b.AppendSnapshot(nil)
+
+	if err := b.RestoreSnapshot(s[:len(s)/2]); !errors.Is(err, snap.ErrShort) {
+		t.Errorf("Expected snap.ErrShort for a truncated snapshot, got %v", err)
+	}
+	if err := b.RestoreSnapshot(append(s, 0)); err == nil {
+		t.Error("Expected an error for trailing data")
+	}
+	s[0] = StateVersion + 1
+	if err := b.RestoreSnapshot(s); err == nil {
+		t.Error("Expected an error for an unknown snapshot version")
+	}
+}
+
+func TestSnapshotSavestateConversion(t *testing.T) {
+	b := newBenchBus(t)
+	b.RunFrame()
+	snapshot := b.AppendSnapshot(nil)
+	want := b.SaveStateToMemory()
+	b.RunFrame()
+	now := b.SaveStateToMemory()
+
+	savestate, err := b.SavestateFromSnapshot(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(b.SaveStateToMemory(), now) {
+		t.Error("Expected converting a snapshot to leave the bus alone")
+	}
+	if err := b.LoadStateFromBytes(savestate); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(b.SaveStateToMemory(), want) {
+		t.Error("Expected the savestate to hold the snapshotted state")
+	}
+
+	b.RunFrame()
+	back, err := b.SnapshotFromSavestate(nil, savestate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back, snapshot) {
+		t.Error("Expected the savestate to convert back to the original snapshot")
+	}
+	if _, err := b.SnapshotFromSavestate(nil, savestate[:10]); err == nil {
+		t.Error("Expected an error converting a truncated savestate")
+	}
+}
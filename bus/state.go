@@ -1,15 +1,24 @@
 package bus
 
 import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
 	"encoding/gob"
+	"errors"
+	"fmt"
 	"os"
 
 	"github.com/meadori/vibemulator/apu"
 	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/controller"
 	"github.com/meadori/vibemulator/cpu"
 	"github.com/meadori/vibemulator/ppu"
 )
 
+// State is the canonical emulator state. Savestates gob-encode it behind a header, and
+// snapshots (see AppendSnapshot) write the same fields in a flat encoding; both carry
+// StateVersion, so either can be converted to the other.
 type State struct {
 	Ram          [2048]byte
 	SystemClocks int
@@ -17,6 +26,10 @@ type State struct {
 	PPU          ppu.State
 	APU          apu.State
 	Cartridge    cartridge.State
+	Joy1, Joy2   controller.State
+	PortRead     byte // Controller port (1 or 2) read by the running instruction, or 0
+	DMA          DMAState
+	OpenBus      byte // Last value on the CPU data bus
 }
 
 // SaveStateToMemory creates and returns a complete snapshot of the emulator state in memory.
@@ -27,6 +40,11 @@ func (b *Bus) SaveStateToMemory() State {
 		CPU:          b.cpu.SaveState(),
 		PPU:          b.PPU.SaveState(),
 		APU:          b.APU.SaveState(),
+		Joy1:         b.joy1.SaveState(),
+		Joy2:         b.joy2.SaveState(),
+		PortRead:     b.portReadID(),
+		DMA:          b.dma,
+		OpenBus:      b.openBus,
 	}
 
 	if b.cart != nil {
@@ -36,63 +54,164 @@ func (b *Bus) SaveStateToMemory() State {
 }
 
 // LoadStateFromMemory instantly overwrites the emulator state with a previously saved memory snapshot.
+// It waits for a running Advance to finish its frame.
 func (b *Bus) LoadStateFromMemory(s State) {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	b.loadState(s)
+}
+
+// loadState is LoadStateFromMemory for callers that hold the clock.
+func (b *Bus) loadState(s State) {
 	b.ram = s.Ram
 	b.SystemClocks = s.SystemClocks
 	b.cpu.LoadState(s.CPU)
 	b.PPU.LoadState(s.PPU)
 	b.APU.LoadState(s.APU)
+	b.joy1.LoadState(s.Joy1)
+	b.joy2.LoadState(s.Joy2)
+	b.setPortRead(s.PortRead)
+	b.dma = s.DMA
+	b.openBus = s.OpenBus
+	b.lastFrame = b.PPU.FrameCounter
+	b.prgMapChanged()
 
 	if b.cart != nil {
 		b.cart.LoadState(s.Cartridge)
 	}
 }
 
-// SaveState saves the entire emulator state to a file.
-func (b *Bus) SaveState(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+// Savestates start with a header: the magic, a little-endian uint16 format version and
+// the SHA-1 of the ROM they were taken on (zero without a cartridge). The gob-encoded
+// State follows. Snapshots from before the header existed are format version 0.
+const (
+	stateMagic = "VIBESAVE"
 
-	s := State{
-		Ram:          b.ram,
-		SystemClocks: b.SystemClocks,
-		CPU:          b.cpu.SaveState(),
-		PPU:          b.PPU.SaveState(),
-		APU:          b.APU.SaveState(),
+	// StateVersion is the version of State this build writes, in savestates and
+	// snapshots alike. Bump it whenever State changes; teach decodeState to migrate old
+	// savestates if gob can't absorb the change.
+	StateVersion = 8
+
+	stateHeaderSize = len(stateMagic) + 2 + sha1.Size
+)
+
+// ErrStateROMMismatch is returned when loading a savestate taken on a different ROM.
+var ErrStateROMMismatch = errors.New("savestate is for a different ROM")
+
+// romSum returns the SHA-1 of the loaded ROM image, or zero without a cartridge.
+func (b *Bus) romSum() [sha1.Size]byte {
+	if b.cart == nil || b.cart.Image() == nil {
+		return [sha1.Size]byte{}
 	}
+	return sha1.Sum(b.cart.Image())
+}
 
-	if b.cart != nil {
-		s.Cartridge = b.cart.SaveState()
+// SaveStateToBytes returns a versioned snapshot, in the same format as SaveState. It
+// waits for a running Advance to finish its frame.
+func (b *Bus) SaveStateToBytes() ([]byte, error) {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	return b.saveStateBytes()
+}
+
+// saveStateBytes is SaveStateToBytes for callers that hold the clock.
+func (b *Bus) saveStateBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(stateMagic)
+	binary.Write(&buf, binary.LittleEndian, uint16(StateVersion))
+	sum := b.romSum()
+	buf.Write(sum[:])
+	if err := gob.NewEncoder(&buf).Encode(b.SaveStateToMemory()); err != nil {
+		return nil, err
 	}
+	return buf.Bytes(), nil
+}
 
-	return gob.NewEncoder(file).Encode(s)
+// LoadStateFromBytes restores the emulator state from a snapshot written by SaveState,
+// including headerless ones from older builds. Snapshots of a different ROM are refused.
+// It waits for a running Advance to finish its frame.
+func (b *Bus) LoadStateFromBytes(data []byte) error {
+	b.exec.clock.Lock()
+	defer b.exec.clock.Unlock()
+	return b.loadStateBytes(data, true)
 }
 
-// LoadState loads the emulator state from a file.
-func (b *Bus) LoadState(filename string) error {
-	file, err := os.Open(filename)
+// loadStateBytes is LoadStateFromBytes for callers that hold the clock, checking the ROM
+// hash only if checkROM is set.
+func (b *Bus) loadStateBytes(data []byte, checkROM bool) error {
+	version, sum, payload, err := parseStateHeader(data)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	var s State
-	if err := gob.NewDecoder(file).Decode(&s); err != nil {
+	var zero [sha1.Size]byte
+	if cur := b.romSum(); checkROM && sum != zero && cur != zero && sum != cur {
+		return fmt.Errorf("%w (SHA-1 %x, loaded %x)", ErrStateROMMismatch, sum, cur)
+	}
+	s, err := decodeState(version, payload)
+	if err != nil {
 		return err
 	}
+	if version < 3 {
+		// Older savestates have no controller state, so keep the current one
+		s.Joy1, s.Joy2 = b.joy1.SaveState(), b.joy2.SaveState()
+	}
+	b.loadState(s)
+	return nil
+}
 
-	b.ram = s.Ram
-	b.SystemClocks = s.SystemClocks
-	b.cpu.LoadState(s.CPU)
-	b.PPU.LoadState(s.PPU)
-	b.APU.LoadState(s.APU)
+// parseStateHeader splits a snapshot into its version, ROM hash and gob payload.
+func parseStateHeader(data []byte) (version int, sum [sha1.Size]byte, payload []byte, err error) {
+	if !bytes.HasPrefix(data, []byte(stateMagic)) {
+		return 0, sum, data, nil
+	}
+	if len(data) < stateHeaderSize {
+		return 0, sum, nil, fmt.Errorf("savestate header is truncated")
+	}
+	version = int(binary.LittleEndian.Uint16(data[len(stateMagic):]))
+	if version > StateVersion {
+		return 0, sum, nil, fmt.Errorf("savestate format version %d is newer than this build supports (%d)", version, StateVersion)
+	}
+	copy(sum[:], data[len(stateMagic)+2:])
+	return version, sum, data[stateHeaderSize:], nil
+}
 
-	if b.cart != nil {
-		b.cart.LoadState(s.Cartridge)
+// decodeState decodes a payload of the given format version into the current State.
+func decodeState(version int, payload []byte) (State, error) {
+	var s State
+	switch version {
+	case 0, 1, 2, 3, 4, 5, 6, 7, 8:
+		// Version 1 only added the header, version 4 the shared numbering with snapshots,
+		// version 5 the CPU's progress through an instruction, version 6 the OAM DMA in
+		// progress, version 7 the open bus value and version 8 whether the CPU is jammed,
+		// so the payloads decode the same
+		if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&s); err != nil {
+			return State{}, fmt.Errorf("failed to decode savestate: %v", err)
+		}
+	default:
+		return State{}, fmt.Errorf("unsupported savestate format version %d", version)
+	}
+	if version < 2 {
+		// Version 2 moved NMI edge detection into the CPU. Without the line's level an
+		// NMI already taken this vertical blank would be taken again
+		s.CPU.NmiLine = s.PPU.Status&s.PPU.Ctrl&0x80 != 0
 	}
+	return s, nil
+}
 
-	return nil
+// SaveState saves the entire emulator state to a file.
+func (b *Bus) SaveState(filename string) error {
+	data, err := b.SaveStateToBytes()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// LoadState loads the emulator state from a file.
+func (b *Bus) LoadState(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return b.LoadStateFromBytes(data)
 }
@@ -0,0 +1,205 @@
+package bus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"testing"
+	"testing/quick"
+
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+// TestStateRoundTrip checks that Save→Load→Save reproduces the same bytes from any
+// point in a run, so a snapshot captures everything it restores.
+func TestStateRoundTrip(t *testing.T) {
+	path := writeTestROM(t, testProgram)
+	f := func(clocks uint16, buttons uint8) bool {
+		b := newTestBus(t)
+		var p1 [8]bool
+		for i := range p1 {
+			p1[i] = buttons&(1<<i) != 0
+		}
+		b.SetController1State(p1)
+		for i := 0; i < int(clocks)*8; i++ {
+			b.Clock()
+		}
+		first, err := b.SaveStateToBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cart, err := cartridge.New(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored := New()
+		if err := restored.LoadCartridge(cart); err != nil {
+			t.Fatal(err)
+		}
+		if err := restored.LoadStateFromBytes(first); err != nil {
+			t.Fatal(err)
+		}
+		second, err := restored.SaveStateToBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.Equal(first, second)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 20}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStateHeader(t *testing.T) {
+	b := newTestBus(t)
+	data, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte(stateMagic)) {
+		t.Fatalf("Expected the savestate to start with %q", stateMagic)
+	}
+	if v := binary.LittleEndian.Uint16(data[len(stateMagic):]); v != StateVersion {
+		t.Errorf("Expected format version %d, got %d", StateVersion, v)
+	}
+
+	// Future versions are refused rather than misread
+	binary.LittleEndian.PutUint16(data[len(stateMagic):], StateVersion+1)
+	if err := b.LoadStateFromBytes(data); err == nil {
+		t.Error("Expected an error loading a newer format version")
+	}
+	if err := b.LoadStateFromBytes(data[:stateHeaderSize-1]); err == nil {
+		t.Error("Expected an error loading a truncated header")
+	}
+}
+
+func TestLoadStateROMMismatch(t *testing.T) {
+	b := newTestBus(t)
+	data, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cart, err := cartridge.New(writeTestROM(t, []byte{0x4C, 0x00, 0x80}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := New()
+	if err := other.LoadCartridge(cart); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.LoadStateFromBytes(data); !errors.Is(err, ErrStateROMMismatch) {
+		t.Errorf("Expected ErrStateROMMismatch, got %v", err)
+	}
+}
+
+func TestLoadLegacyState(t *testing.T) {
+	b := newTestBus(t)
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	b.ram[0x20] = 0x99
+
+	// Snapshots from before the header were a bare gob-encoded State
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b.SaveStateToMemory()); err != nil {
+		t.Fatal(err)
+	}
+	b.ram[0x20] = 0
+	if err := b.LoadStateFromBytes(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if b.ram[0x20] != 0x99 {
+		t.Errorf("Expected RAM from the legacy savestate, got %02X", b.ram[0x20])
+	}
+}
+
+func TestDecodeStateMigratesNMILine(t *testing.T) {
+	var s State
+	s.PPU.Status, s.PPU.Ctrl = 0x80, 0x80 // In vertical blank with NMI enabled
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		version int
+		want    bool
+	}{{1, true}, {2, false}} {
+		got, err := decodeState(tt.version, buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.CPU.NmiLine != tt.want {
+			t.Errorf("Version %d: expected NmiLine %v, got %v", tt.version, tt.want, got.CPU.NmiLine)
+		}
+	}
+}
+
+func TestStateKeepsControllerShiftRegister(t *testing.T) {
+	b := newTestBus(t)
+	b.SetController1State([8]bool{false, false, false, true}) // Start
+	b.Write(0x4016, 1)
+	b.Write(0x4016, 0)
+	for i := 0; i < 3; i++ {
+		b.Read(0x4016) // A, B and Select
+	}
+
+	data, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := b.Snapshot()
+	defer ReleaseSnapshot(snapshot)
+
+	for name, load := range map[string]func() error{
+		"savestate": func() error { return b.LoadStateFromBytes(data) },
+		"snapshot":  func() error { return b.RestoreSnapshot(snapshot) },
+	} {
+		b.Write(0x4016, 1) // Restart the reads, and drop Start
+		b.SetController1State([8]bool{})
+		if err := load(); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.Read(0x4016); got&0x01 == 0 {
+			t.Errorf("Expected the %s to resume the reads at Start, got $%02X", name, got)
+		}
+	}
+}
+
+func TestStateKeepsJAM(t *testing.T) {
+	cart, err := cartridge.New(writeTestROM(t, []byte{0x02})) // JAM
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	if err := b.LoadCartridge(cart); err != nil {
+		t.Fatal(err)
+	}
+	b.RunFrame() // The PPU keeps drawing frames around the jammed CPU
+	b.RunFrame()
+	if !b.Jammed() {
+		t.Fatal("Expected the JAM to jam the CPU")
+	}
+	data, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := b.Snapshot()
+	defer ReleaseSnapshot(snapshot)
+
+	for name, load := range map[string]func() error{
+		"savestate": func() error { return b.LoadStateFromBytes(data) },
+		"snapshot":  func() error { return b.RestoreSnapshot(snapshot) },
+	} {
+		b.Reset()
+		if b.Jammed() {
+			t.Error("Expected reset to free the CPU")
+		}
+		if err := load(); err != nil {
+			t.Fatal(err)
+		}
+		if !b.Jammed() {
+			t.Errorf("Expected the %s to restore the jammed CPU", name)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package bus
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+)
+
+// ReadState decodes a savestate written by SaveState without loading it, returning the
+// state and the SHA-1 of the ROM it was taken on (zero if unknown).
+func ReadState(data []byte) (State, [sha1.Size]byte, error) {
+	version, sum, payload, err := parseStateHeader(data)
+	if err != nil {
+		return State{}, sum, err
+	}
+	s, err := decodeState(version, payload)
+	return s, sum, err
+}
+
+// ByteChange is a byte that differs between two states.
+type ByteChange struct {
+	Addr     int
+	Old, New byte
+}
+
+// RegionDiff lists the bytes of one memory that differ between two states.
+type RegionDiff struct {
+	Name    string
+	Changes []ByteChange
+}
+
+// StateDiff is what differs between two states of the same game, for finding which
+// variables an action touches.
+type StateDiff struct {
+	Regions       []RegionDiff // Only the memories with changes, CPU RAM first
+	PixelsChanged int          // Framebuffer pixels that differ
+}
+
+// DiffStates compares the memories and framebuffers of two states. Addresses are as the
+// CPU sees RAM and PRG RAM, and as the PPU sees the palette and CHR RAM; nametable RAM
+// and OAM are numbered from their start.
+func DiffStates(before, after State) StateDiff {
+	var d StateDiff
+	for _, r := range []struct {
+		name          string
+		base          int
+		before, after []byte
+	}{
+		{"RAM", 0x0000, before.Ram[:], after.Ram[:]},
+		{"PRG RAM", 0x6000, before.Cartridge.PRGRAM, after.Cartridge.PRGRAM},
+		{"Nametable RAM", 0, before.PPU.Vram[:], after.PPU.Vram[:]},
+		{"Palette", 0x3F00, before.PPU.Palette[:], after.PPU.Palette[:]},
+		{"OAM", 0, before.PPU.Oam[:], after.PPU.Oam[:]},
+		{"CHR RAM", 0x0000, before.Cartridge.CHRRAM, after.Cartridge.CHRRAM},
+	} {
+		if rd := diffBytes(r.name, r.base, r.before, r.after); rd.Changes != nil {
+			d.Regions = append(d.Regions, rd)
+		}
+	}
+	d.PixelsChanged = len(ChangedPixels(before.PPU.FrameBuffer, after.PPU.FrameBuffer))
+	return d
+}
+
+// diffBytes compares two copies of a memory. A byte only one of them has counts as
+// changed from or to zero.
+func diffBytes(name string, base int, before, after []byte) RegionDiff {
+	rd := RegionDiff{Name: name}
+	if bytes.Equal(before, after) {
+		return rd
+	}
+	for i := range max(len(before), len(after)) {
+		var o, n byte
+		if i < len(before) {
+			o = before[i]
+		}
+		if i < len(after) {
+			n = after[i]
+		}
+		if o != n {
+			rd.Changes = append(rd.Changes, ByteChange{Addr: base + i, Old: o, New: n})
+		}
+	}
+	return rd
+}
+
+// ChangedPixels returns the indexes of the pixels that differ between two RGBA
+// framebuffers, counting every pixel as changed if their sizes differ.
+func ChangedPixels(before, after []byte) []int {
+	var changed []int
+	if len(before) != len(after) {
+		for i := range max(len(before), len(after)) / 4 {
+			changed = append(changed, i)
+		}
+		return changed
+	}
+	for i := 0; i+4 <= len(before); i += 4 {
+		if !bytes.Equal(before[i:i+4], after[i:i+4]) {
+			changed = append(changed, i/4)
+		}
+	}
+	return changed
+}
+
+// Runs groups the changes into runs of consecutive addresses, for memories where a
+// change touches many bytes at once, like a redrawn nametable.
+func (rd RegionDiff) Runs() [][]ByteChange {
+	var runs [][]ByteChange
+	for i, c := range rd.Changes {
+		if i > 0 && c.Addr == rd.Changes[i-1].Addr+1 {
+			runs[len(runs)-1] = append(runs[len(runs)-1], c)
+		} else {
+			runs = append(runs, []ByteChange{c})
+		}
+	}
+	return runs
+}
+
+func (c ByteChange) String() string {
+	return fmt.Sprintf("$%04X: %02X -> %02X", c.Addr, c.Old, c.New)
+}
@@ -0,0 +1,67 @@
+package bus
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffStates(t *testing.T) {
+	b := newTestBus(t)
+	b.RunFrame()
+	data, err := b.SaveStateToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, beforeSum, err := ReadState(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if beforeSum != b.romSum() {
+		t.Errorf("Expected the ROM's SHA-1 %x, got %x", b.romSum(), beforeSum)
+	}
+
+	b.RunFrame()
+	after := b.SaveStateToMemory()
+	d := DiffStates(before, after)
+	if len(d.Regions) == 0 || d.Regions[0].Name != "RAM" {
+		t.Fatalf("Expected RAM to change, got %+v", d.Regions)
+	}
+	// The test program counts in $00
+	c := d.Regions[0].Changes[0]
+	if c.Addr != 0x0000 || c.Old != before.Ram[0] || c.New != after.Ram[0] {
+		t.Errorf("Expected $00 to change from %02X to %02X, got %s", before.Ram[0], after.Ram[0], c)
+	}
+
+	if d := DiffStates(after, after); len(d.Regions) != 0 || d.PixelsChanged != 0 {
+		t.Errorf("Expected no differences between a state and itself, got %+v", d)
+	}
+
+	if _, _, err := ReadState([]byte("VIBESAVE")); err == nil {
+		t.Error("Expected an error for a truncated savestate")
+	}
+}
+
+func TestRegionDiffRuns(t *testing.T) {
+	rd := diffBytes("Nametable RAM", 0, []byte{0, 0, 0, 0, 0}, []byte{1, 1, 0, 1, 0, 7})
+	var lengths []int
+	for _, run := range rd.Runs() {
+		lengths = append(lengths, len(run))
+	}
+	if !slices.Equal(lengths, []int{2, 1, 1}) {
+		t.Errorf("Expected runs of 2, 1 and 1 bytes, got %v", lengths)
+	}
+	if last := rd.Changes[len(rd.Changes)-1]; last.Addr != 5 || last.Old != 0 || last.New != 7 {
+		t.Errorf("Expected a byte only the second memory has to change from 0, got %s", last)
+	}
+}
+
+func TestChangedPixels(t *testing.T) {
+	before := []byte{1, 2, 3, 255, 4, 5, 6, 255}
+	after := []byte{1, 2, 3, 255, 4, 5, 7, 255}
+	if got := ChangedPixels(before, after); !slices.Equal(got, []int{1}) {
+		t.Errorf("Expected pixel 1 to change, got %v", got)
+	}
+	if got := ChangedPixels(before, nil); len(got) != 2 {
+		t.Errorf("Expected every pixel to change when the sizes differ, got %v", got)
+	}
+}
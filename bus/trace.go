@@ -0,0 +1,44 @@
+package bus
+
+import (
+	"io"
+
+	"github.com/meadori/vibemulator/cpu"
+)
+
+// traceSource gives the CPU's trace side-effect-free reads and the system's position.
+type traceSource struct{ b *Bus }
+
+func (s traceSource) Peek(addr uint16) byte { return s.b.debugRead(addr) }
+
+func (s traceSource) TracePosition() (cycle, scanline, dot int) {
+	return s.b.SystemClocks / 3, s.b.PPU.Scanline, s.b.PPU.Cycle
+}
+
+// EnableTrace has the CPU record its last n instructions, discarding any earlier
+// record, or stops recording if n is 0.
+func (b *Bus) EnableTrace(n int) {
+	b.trace = nil
+	if n > 0 {
+		b.trace = cpu.NewTrace(n, traceSource{b})
+	}
+	b.cpu.SetTrace(b.trace)
+}
+
+// Trace returns the instructions recorded since EnableTrace, oldest first, or nil if
+// tracing is off. Call it from the goroutine that clocks the bus.
+func (b *Bus) Trace() []cpu.TraceEntry {
+	if b.trace == nil {
+		return nil
+	}
+	return b.trace.Entries()
+}
+
+// WriteTrace writes the recorded instructions to w in nestest's log format, oldest
+// first. Call it from the goroutine that clocks the bus.
+func (b *Bus) WriteTrace(w io.Writer) error {
+	if b.trace == nil {
+		return nil
+	}
+	return b.trace.Dump(w)
+}
@@ -0,0 +1,48 @@
+package bus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	b := newTestBus(t)
+	b.EnableTrace(4)
+	b.RunFrame()
+
+	entries := b.Trace()
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+	// The last four instructions are one time around the loop, in some order
+	seen := map[string]bool{}
+	for i, e := range entries {
+		seen[e.Instruction.String()] = true
+		if i > 0 && e.Cycle <= entries[i-1].Cycle {
+			t.Errorf("Expected cycles to increase, got %d after %d", e.Cycle, entries[i-1].Cycle)
+		}
+	}
+	for _, want := range []string{"INC $00", "LDA $4016", "STA $01", "JMP $8005"} {
+		if !seen[want] {
+			t.Errorf("Expected %s in the trace, got %v", want, entries)
+		}
+	}
+	last := entries[len(entries)-1]
+	if cycle := b.SystemClocks / 3; last.Cycle > cycle || last.Cycle < cycle-6 {
+		t.Errorf("Expected the last instruction to start near cycle %d, got %d", cycle, last.Cycle)
+	}
+
+	var sb strings.Builder
+	if err := b.WriteTrace(&sb); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(sb.String(), "\n"); lines != 4 {
+		t.Errorf("Expected 4 lines, got %d:\n%s", lines, sb.String())
+	}
+
+	b.EnableTrace(0)
+	b.RunFrame()
+	if entries := b.Trace(); entries != nil {
+		t.Errorf("Expected no trace once disabled, got %d entries", len(entries))
+	}
+}
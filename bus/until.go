@@ -0,0 +1,93 @@
+package bus
+
+import "sync"
+
+// StopKind selects what RunUntil waits for.
+type StopKind int
+
+const (
+	// StopAtAddress stops before the instruction at Addr executes
+	StopAtAddress StopKind = iota
+	// StopAtScanline stops once the PPU starts Scanline (-1 to 260)
+	StopAtScanline
+	// StopAtVBlank stops once the PPU enters vertical blank (scanline 241, dot 1)
+	StopAtVBlank
+	// StopAtFrame stops once the frame counter reaches Frame
+	StopAtFrame
+)
+
+// StopCondition describes where RunUntil should pause the emulator.
+type StopCondition struct {
+	Kind     StopKind
+	Addr     uint16
+	Scanline int
+	Frame    int
+}
+
+// runUntil is a pending RunUntil. The PPU position is checked every clock, but the
+// emulator only pauses on the next instruction boundary so the CPU state is consistent.
+type runUntil struct {
+	cond    StopCondition
+	reached bool
+	once    sync.Once
+	done    chan bool
+}
+
+// finish reports whether the condition was reached and releases the waiter.
+func (u *runUntil) finish(reached bool) {
+	u.once.Do(func() {
+		u.done <- reached
+		close(u.done)
+	})
+}
+
+// RunUntil resumes the emulator and pauses it when cond is met. The returned channel
+// yields true when the condition was reached, or false when something else paused the
+// emulator first (a watchpoint, SetPaused or another RunUntil). The bus must be clocked
+// by its owner (e.g. the display loop) for the condition to be reached.
+func (b *Bus) RunUntil(cond StopCondition) <-chan bool {
+	u := &runUntil{cond: cond, done: make(chan bool, 1)}
+	if prev := b.until.Swap(u); prev != nil {
+		prev.finish(false)
+	}
+	b.setExecState(RunningUntil)
+	return u.done
+}
+
+// cancelUntil abandons any pending RunUntil.
+func (b *Bus) cancelUntil() {
+	if u := b.until.Swap(nil); u != nil {
+		u.finish(false)
+	}
+}
+
+// checkUntilPosition runs before each PPU clock and notes when the target position is drawn.
+func (u *runUntil) checkUntilPosition(scanline, dot, frame int) {
+	switch u.cond.Kind {
+	case StopAtFrame:
+		u.reached = u.reached || frame >= u.cond.Frame
+	case StopAtScanline:
+		u.reached = u.reached || scanline == u.cond.Scanline && dot == 0
+	case StopAtVBlank:
+		u.reached = u.reached || scanline == 241 && dot == 1
+	}
+}
+
+// checkUntilInstruction runs on every instruction boundary and pauses once the condition holds.
+func (b *Bus) checkUntilInstruction(u *runUntil) {
+	if u.cond.Kind == StopAtAddress {
+		u.reached = b.cpu.PC == u.cond.Addr
+	}
+	if !u.reached {
+		return
+	}
+	if b.until.CompareAndSwap(u, nil) {
+		b.setExecState(Paused)
+		u.finish(true)
+	}
+}
+
+// RasterPosition returns the scanline (-1 to 260) and dot the PPU will draw next.
+func (b *Bus) RasterPosition() (scanline, dot int) {
+	return b.PPU.Scanline, b.PPU.Cycle
+}
@@ -0,0 +1,78 @@
+package bus
+
+import "testing"
+
+func TestRunUntilAddress(t *testing.T) {
+	b := newTestBus(t)
+	done := b.RunUntil(StopCondition{Kind: StopAtAddress, Addr: 0x800A})
+
+	clockUntilPaused(t, b)
+	if !<-done {
+		t.Error("Expected RunUntil to report the address was reached")
+	}
+	if _, _, _, _, _, pc, _ := b.GetCPUState(); pc != 0x800A {
+		t.Errorf("Expected to stop at $800A, got $%04X", pc)
+	}
+}
+
+func TestRunUntilScanline(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cond     StopCondition
+		scanline int
+		dot      int
+	}{
+		{"scanline", StopCondition{Kind: StopAtScanline, Scanline: 100}, 100, 0},
+		{"vblank", StopCondition{Kind: StopAtVBlank}, 241, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newTestBus(t)
+			done := b.RunUntil(tc.cond)
+
+			clockUntilPaused(t, b)
+			if !<-done {
+				t.Error("Expected RunUntil to report the position was reached")
+			}
+			// The emulator stops on the first instruction boundary at or after the target
+			scanline, dot := b.RasterPosition()
+			if scanline != tc.scanline || dot < tc.dot || dot > tc.dot+3*7 {
+				t.Errorf("Expected to stop just after scanline %d dot %d, got scanline %d dot %d", tc.scanline, tc.dot, scanline, dot)
+			}
+			if !b.IsInstructionComplete() {
+				t.Error("Expected to stop on an instruction boundary")
+			}
+		})
+	}
+}
+
+func TestRunUntilFrame(t *testing.T) {
+	b := newTestBus(t)
+	target := b.PPU.FrameCounter + 2
+	done := b.RunUntil(StopCondition{Kind: StopAtFrame, Frame: target})
+
+	for i := 0; i < 3*341*262 && !b.Paused(); i++ {
+		b.Clock()
+	}
+	if !b.Paused() || !<-done {
+		t.Fatal("Expected RunUntil to report the frame was reached")
+	}
+	if b.PPU.FrameCounter != target {
+		t.Errorf("Expected to stop in frame %d, got %d", target, b.PPU.FrameCounter)
+	}
+	if !b.IsInstructionComplete() {
+		t.Error("Expected to stop on an instruction boundary")
+	}
+}
+
+func TestRunUntilCancelled(t *testing.T) {
+	b := newTestBus(t)
+	done := b.RunUntil(StopCondition{Kind: StopAtAddress, Addr: 0x9000})
+	if b.Paused() {
+		t.Error("RunUntil should resume the emulator")
+	}
+
+	b.SetPaused(true)
+	if <-done {
+		t.Error("Expected a paused RunUntil to report it was not reached")
+	}
+}
@@ -0,0 +1,144 @@
+package bus
+
+import (
+	"sort"
+	"sync"
+)
+
+// Watchpoint pauses the emulator when the CPU reads or writes an address range.
+type Watchpoint struct {
+	ID         HookID
+	Start, End uint16
+	Write      bool // false watches reads
+}
+
+// WatchHit describes the access that tripped a watchpoint.
+type WatchHit struct {
+	Watchpoint Watchpoint
+	Addr       uint16
+	Write      bool
+	Old, New   byte // Old is only meaningful when HasOld is set
+	HasOld     bool
+	PC         uint16 // Instruction that made the access
+	Frame      int
+}
+
+// watchState tracks watchpoints; it is shared between the emulation and debugger goroutines.
+type watchState struct {
+	mu     sync.Mutex
+	points map[HookID]Watchpoint
+	hit    *WatchHit
+}
+
+// AddWatchpoint installs a read or write watchpoint on [start, end].
+func (b *Bus) AddWatchpoint(start, end uint16, write bool) Watchpoint {
+	w := Watchpoint{ID: b.hooks.newID(), Start: start, End: end, Write: write}
+
+	b.watch.mu.Lock()
+	defer b.watch.mu.Unlock()
+	if b.watch.points == nil {
+		b.watch.points = make(map[HookID]Watchpoint)
+	}
+	b.watch.points[w.ID] = w
+	b.setCPUWatches(w, true)
+	return w
+}
+
+// RemoveWatchpoint deletes a watchpoint and reports whether it existed.
+func (b *Bus) RemoveWatchpoint(id HookID) bool {
+	b.watch.mu.Lock()
+	defer b.watch.mu.Unlock()
+	w, ok := b.watch.points[id]
+	if !ok {
+		return false
+	}
+	delete(b.watch.points, id)
+
+	// Clear the range, then put back what the remaining watchpoints still watch
+	b.setCPUWatches(w, false)
+	for _, other := range b.watch.points {
+		if other.Write == w.Write && other.Start <= w.End && other.End >= w.Start {
+			b.setCPUWatches(Watchpoint{Start: max(other.Start, w.Start), End: min(other.End, w.End), Write: w.Write}, true)
+		}
+	}
+	return true
+}
+
+// setCPUWatches sets or clears the CPU's watches on w's range. The caller holds watch.mu.
+func (b *Bus) setCPUWatches(w Watchpoint, on bool) {
+	for addr := int(w.Start); addr <= int(w.End); addr++ {
+		if w.Write {
+			b.cpu.SetWriteWatch(uint16(addr), on)
+		} else {
+			b.cpu.SetReadWatch(uint16(addr), on)
+		}
+	}
+}
+
+// onWatch is the CPU's read and write watch hook: it records a hit on the first
+// watchpoint covering the access. Runs on the emulation goroutine.
+func (b *Bus) onWatch(addr uint16, data byte, write bool) {
+	b.watch.mu.Lock()
+	var w *Watchpoint
+	for _, p := range b.watch.points {
+		if p.Write == write && addr >= p.Start && addr <= p.End && (w == nil || p.ID < w.ID) {
+			w = &p
+		}
+	}
+	b.watch.mu.Unlock()
+	if w == nil {
+		return
+	}
+
+	if write {
+		old, ok := b.peek(addr)
+		b.watchHit(*w, WatchHit{Addr: addr, Write: true, Old: old, New: data, HasOld: ok})
+	} else {
+		b.watchHit(*w, WatchHit{Addr: addr, New: data})
+	}
+}
+
+// Watchpoints lists the installed watchpoints in creation order.
+func (b *Bus) Watchpoints() []Watchpoint {
+	b.watch.mu.Lock()
+	defer b.watch.mu.Unlock()
+
+	list := make([]Watchpoint, 0, len(b.watch.points))
+	for _, w := range b.watch.points {
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// TakeWatchHit returns and clears the most recent watchpoint hit.
+func (b *Bus) TakeWatchHit() (WatchHit, bool) {
+	b.watch.mu.Lock()
+	defer b.watch.mu.Unlock()
+
+	if b.watch.hit == nil {
+		return WatchHit{}, false
+	}
+	hit := *b.watch.hit
+	b.watch.hit = nil
+	return hit, true
+}
+
+// watchHit records a hit and pauses the emulator once the instruction making the access
+// finishes. A read-modify-write writes twice; the hit keeps the value from before the
+// first write. Runs on the emulation goroutine.
+func (b *Bus) watchHit(w Watchpoint, hit WatchHit) {
+	hit.Watchpoint = w
+	hit.PC = b.cpu.InstructionPC()
+	hit.Frame = b.PPU.FrameCounter
+
+	b.watch.mu.Lock()
+	if prev := b.watch.hit; prev != nil && b.pauseAtBoundary.Load() && prev.Write && hit.Write && prev.Addr == hit.Addr {
+		hit.Old, hit.HasOld = prev.Old, prev.HasOld
+	}
+	b.watch.hit = &hit
+	b.watch.mu.Unlock()
+
+	b.cancelUntil()
+	b.pauseAtBoundary.Store(true)
+}
@@ -0,0 +1,96 @@
+package bus
+
+import "testing"
+
+// clockUntilPaused runs the CPU until a watchpoint pauses the bus.
+func clockUntilPaused(t *testing.T, b *Bus) {
+	t.Helper()
+	for i := 0; i < 100000 && !b.Paused(); i++ {
+		b.Clock()
+	}
+	if !b.Paused() {
+		t.Fatal("Expected a watchpoint to pause the emulator")
+	}
+}
+
+func TestWriteWatchpoint(t *testing.T) {
+	b := newTestBus(t)
+	w := b.AddWatchpoint(0x0000, 0x0000, true)
+
+	clockUntilPaused(t, b)
+	hit, ok := b.TakeWatchHit()
+	if !ok {
+		t.Fatal("Expected a watch hit")
+	}
+	if hit.Watchpoint.ID != w.ID || hit.Addr != 0x0000 || !hit.Write {
+		t.Errorf("Unexpected hit %+v", hit)
+	}
+	if !hit.HasOld || hit.New != hit.Old+1 {
+		t.Errorf("Expected INC to write old+1, got old %02X new %02X", hit.Old, hit.New)
+	}
+	if hit.PC != 0x8005 {
+		t.Errorf("Expected hit at PC $8005, got $%04X", hit.PC)
+	}
+	if _, ok := b.TakeWatchHit(); ok {
+		t.Error("Expected TakeWatchHit to clear the hit")
+	}
+}
+
+func TestReadWatchpoint(t *testing.T) {
+	b := newTestBus(t)
+	b.AddWatchpoint(0x4016, 0x4017, false)
+
+	// Debugger reads must not trip read watchpoints
+	b.GetMemoryBlock(0x4016, 2)
+	if _, ok := b.TakeWatchHit(); ok {
+		t.Error("GetMemoryBlock should not trigger watchpoints")
+	}
+
+	clockUntilPaused(t, b)
+	hit, _ := b.TakeWatchHit()
+	if hit.Addr != 0x4016 || hit.Write || hit.PC != 0x8007 {
+		t.Errorf("Unexpected hit %+v", hit)
+	}
+}
+
+func TestRemoveWatchpoint(t *testing.T) {
+	b := newTestBus(t)
+	w1 := b.AddWatchpoint(0x0000, 0x00FF, true)
+	w2 := b.AddWatchpoint(0x0300, 0x0300, false)
+
+	if list := b.Watchpoints(); len(list) != 2 || list[0].ID != w1.ID || list[1].ID != w2.ID {
+		t.Errorf("Expected both watchpoints in order, got %+v", list)
+	}
+	if !b.RemoveWatchpoint(w1.ID) {
+		t.Error("Expected RemoveWatchpoint to find the watchpoint")
+	}
+	if b.RemoveWatchpoint(w1.ID) {
+		t.Error("Expected a second remove to fail")
+	}
+
+	b.StepFrame([8]bool{}, [8]bool{}, 2)
+	if b.Paused() {
+		t.Error("Removed watchpoint should not pause the emulator")
+	}
+}
+
+func TestOverlappingWatchpoints(t *testing.T) {
+	b := newTestBus(t)
+	w1 := b.AddWatchpoint(0x0000, 0x00FF, true)
+	w2 := b.AddWatchpoint(0x0000, 0x0000, true)
+
+	// Removing the wide watchpoint leaves $00 watched by the other
+	b.RemoveWatchpoint(w1.ID)
+	clockUntilPaused(t, b)
+	if hit, ok := b.TakeWatchHit(); !ok || hit.Watchpoint.ID != w2.ID || hit.Addr != 0x0000 {
+		t.Errorf("Expected a hit on watchpoint %d at $0000, got %+v", w2.ID, hit)
+	}
+
+	// With both gone, nothing is watched
+	b.RemoveWatchpoint(w2.ID)
+	b.SetPaused(false)
+	b.StepFrame([8]bool{}, [8]bool{}, 1)
+	if _, ok := b.TakeWatchHit(); ok {
+		t.Error("Expected no hits once both watchpoints are removed")
+	}
+}
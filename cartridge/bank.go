@@ -0,0 +1,48 @@
+package cartridge
+
+import "math/bits"
+
+// bankIndex maps a bank number written to a mapper register onto one of count banks.
+// Boards only wire up the address lines their ROM needs, so high bits are masked off;
+// dumps with a bank count that isn't a power of two wrap what the mask lets through.
+// There is always a bank 0, even for memory smaller than one bank.
+func bankIndex(bank, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	bank &= 1<<bits.Len(uint(count-1)) - 1
+	if bank >= count {
+		bank %= count
+	}
+	return bank
+}
+
+// chrMemory is the pattern table memory a mapper banks: CHR ROM, CHR RAM, or CHR ROM
+// followed by CHR RAM on boards that mix the two.
+type chrMemory struct {
+	data     []byte
+	ramStart int // Writes below this offset hit ROM and are ignored
+}
+
+func newCHRMemory(cart *Cartridge) chrMemory {
+	return chrMemory{data: cart.CHRROM, ramStart: cart.chrRAMStart()}
+}
+
+// offset returns where addr falls in CHR memory while the size-byte window holding it
+// maps bank. Memory smaller than the window repeats through it.
+func (c chrMemory) offset(bank, size int, addr uint16) int {
+	return (bankIndex(bank, len(c.data)/size)*size + int(addr)&(size-1)) % len(c.data)
+}
+
+func (c chrMemory) read(offset int) byte {
+	return c.data[offset]
+}
+
+// write stores data at offset if it is in CHR RAM.
+func (c chrMemory) write(offset int, data byte) bool {
+	if offset < c.ramStart {
+		return false
+	}
+	c.data[offset] = data
+	return true
+}
@@ -0,0 +1,140 @@
+package cartridge
+
+import "testing"
+
+// newTestCart builds an iNES image with the given mapper and PRG and CHR ROM sizes in
+// 16KB and 8KB units, and an NES 2.0 CHR RAM shift count if chrRAMShift is not 0.
+func newTestCart(t *testing.T, mapperID byte, prgBanks, chrBanks int, chrRAMShift byte) *Cartridge {
+	t.Helper()
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, byte(prgBanks), byte(chrBanks), mapperID << 4, mapperID & 0xF0, 0, 0, 0, 0, 0, 0, 0, 0}
+	if chrRAMShift != 0 {
+		header[7] |= 0x08
+		header[11] = chrRAMShift
+	}
+	prg := make([]byte, prgBanks*16384)
+	for i := range prg {
+		prg[i] = byte(i / 8192) // Each 8KB of PRG ROM holds its bank number
+	}
+	chr := make([]byte, chrBanks*8192)
+	for i := range chr {
+		chr[i] = byte(i / 1024) // Each 1KB of CHR ROM holds its bank number
+	}
+	cart, err := NewFromBytes(append(append(header, prg...), chr...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cart
+}
+
+func TestBankIndex(t *testing.T) {
+	tests := []struct{ bank, count, want int }{
+		{5, 0, 0},
+		{5, 1, 0},
+		{5, 8, 5},
+		{13, 8, 5}, // Masked to 3 bits
+		{2, 3, 2},
+		{3, 3, 0}, // Within the mask but past the end, so wrapped
+		{7, 6, 1},
+		{0xFF, 12, 3},
+	}
+	for _, tt := range tests {
+		if got := bankIndex(tt.bank, tt.count); got != tt.want {
+			t.Errorf("Expected bank %d of %d to map to %d, got %d", tt.bank, tt.count, tt.want, got)
+		}
+	}
+}
+
+func TestNoPRGROM(t *testing.T) {
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x00, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, err := NewFromBytes(append(header, make([]byte, 8192)...)); err == nil {
+		t.Error("Expected an error for a ROM without PRG ROM")
+	}
+}
+
+func TestCHRROMIsReadOnly(t *testing.T) {
+	for _, id := range []byte{0, 2, 3} {
+		cart := newTestCart(t, id, 1, 1, 0)
+		if cart.Mapper.PPUMapWrite(0x0400, 0xAA) {
+			t.Errorf("Mapper %d: expected a write to 8KB of CHR ROM to be ignored", id)
+		}
+		if v, _ := cart.Mapper.PPUMapRead(0x0400); v != 1 {
+			t.Errorf("Mapper %d: expected CHR ROM to keep $01, got $%02X", id, v)
+		}
+	}
+}
+
+func TestMMC1SmallPRG(t *testing.T) {
+	// 16KB of PRG ROM in the power-on 16KB mode and in 32KB mode
+	cart := newTestCart(t, 1, 1, 0, 0)
+	m := cart.Mapper.(*mmc1)
+	for _, control := range []byte{0x0C, 0x00} {
+		m.control = control
+		m.prgBank = 0x0F
+		for _, addr := range []uint16{0x8000, 0xA000, 0xC000, 0xE000} {
+			want := byte(addr & 0x3FFF / 0x2000)
+			if v, _ := m.CPUMapRead(addr); v != want {
+				t.Errorf("Control $%02X: expected $%04X to read 8KB bank %d of the mirrored ROM, got %d", control, addr, want, v)
+			}
+		}
+	}
+}
+
+func TestMMC1CHRRAMBanks(t *testing.T) {
+	cart := newTestCart(t, 1, 2, 0, 0)
+	m := cart.Mapper.(*mmc1)
+	m.control = 0x10 // 4KB CHR mode
+	m.chrBank0, m.chrBank1 = 1, 0x1F
+	if !m.PPUMapWrite(0x0000, 0x11) || !m.PPUMapWrite(0x1000, 0x22) {
+		t.Fatal("Expected CHR RAM to take writes")
+	}
+	// Bank $1F wraps to bank 1 of the two 4KB banks, which both windows now map
+	if v, _ := m.PPUMapRead(0x1000); v != 0x22 {
+		t.Errorf("Expected $22 through the wrapped bank, got $%02X", v)
+	}
+	if v, _ := m.PPUMapRead(0x0000); v != 0x22 {
+		t.Errorf("Expected both windows to share bank 1, got $%02X", v)
+	}
+}
+
+func TestMMC3OddSizes(t *testing.T) {
+	// 48KB of PRG ROM is six 8KB banks; 24KB of CHR ROM is twenty-four 1KB banks
+	cart := newTestCart(t, 4, 3, 3, 0)
+	m := cart.Mapper.(*mmc3)
+	m.registers[6] = 7  // Masked to 7, then wrapped to 1
+	m.registers[2] = 30 // Masked to 30, then wrapped to 6
+	if v, _ := m.CPUMapRead(0x8000); v != 1 {
+		t.Errorf("Expected PRG bank 1, got %d", v)
+	}
+	if v, _ := m.CPUMapRead(0xE000); v != 5 {
+		t.Errorf("Expected the last PRG bank fixed at $E000, got %d", v)
+	}
+	if v, _ := m.PPUMapRead(0x1000); v != 6 {
+		t.Errorf("Expected CHR bank 6, got %d", v)
+	}
+}
+
+func TestMixedCHR(t *testing.T) {
+	// 8KB of CHR ROM followed by 8KB of CHR RAM (64 << 7 bytes)
+	cart := newTestCart(t, 4, 2, 1, 7)
+	if cart.CHRRAMSize != 8192 || len(cart.CHRROM) != 16384 || cart.IsCHRRAM {
+		t.Fatalf("Expected 8KB of CHR ROM and 8KB of CHR RAM, got %d bytes with %d of RAM", len(cart.CHRROM), cart.CHRRAMSize)
+	}
+	m := cart.Mapper.(*mmc3)
+	m.registers[2] = 3  // ROM at $1000
+	m.registers[3] = 12 // RAM at $1400
+	if m.PPUMapWrite(0x1000, 0xAA) {
+		t.Error("Expected a write to the CHR ROM bank to be ignored")
+	}
+	if !m.PPUMapWrite(0x1400, 0xBB) {
+		t.Error("Expected a write to the CHR RAM bank to succeed")
+	}
+	if v, _ := m.PPUMapRead(0x1400); v != 0xBB {
+		t.Errorf("Expected $BB from CHR RAM, got $%02X", v)
+	}
+
+	// Savestates carry the CHR RAM
+	s := cart.SaveState()
+	if len(s.CHRRAM) != 8192 || s.CHRRAM[12*1024-8192] != 0xBB {
+		t.Errorf("Expected the 8KB of CHR RAM in the savestate, got %d bytes", len(s.CHRRAM))
+	}
+}
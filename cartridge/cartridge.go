@@ -8,9 +8,6 @@ import (
 	"github.com/meadori/vibemulator/mapper"
 )
 
-// Declare logDebug function from main package
-var LogDebug func(format string, a ...interface{})
-
 // Mirroring types
 const (
 	MirrorHorizontal     byte = 0
@@ -23,10 +20,16 @@ const (
 // Cartridge represents an NES cartridge.
 type Cartridge struct {
 	PRGROM   []byte
-	CHRROM   []byte
+	CHRROM   []byte // CHR ROM, then any CHR RAM
 	Mapper   mapper.Mapper
 	Mirror   byte
-	IsCHRRAM bool
+	IsCHRRAM bool // All of CHRROM is RAM
+	// Bytes at the end of CHRROM that are RAM, on boards that mix CHR ROM and CHR RAM
+	CHRRAMSize int
+	Header     Header // As read from the iNES image; zero for cartridges built in code
+
+	// raw holds the original iNES image so the cartridge can be re-inserted in its power-on state.
+	raw []byte
 }
 
 // New creates a new Cartridge instance from a .nes file.
@@ -42,33 +45,70 @@ func New(path string) (*Cartridge, error) {
 		return nil, err
 	}
 
-	if len(data) < 16 {
-		return nil, fmt.Errorf("file is too small to be a valid NES ROM")
+	return parse(data)
+}
+
+// NewFromBytes creates a new Cartridge instance from an in-memory iNES image.
+func NewFromBytes(data []byte) (*Cartridge, error) {
+	// Keep a private copy so the caller is free to reuse its buffer
+	return parse(append([]byte(nil), data...))
+}
+
+// Clone returns a freshly parsed copy of the cartridge with its mapper, PRG-RAM and CHR-RAM
+// in their power-on state.
+func (c *Cartridge) Clone() (*Cartridge, error) {
+	if c.raw == nil {
+		return nil, fmt.Errorf("cartridge has no ROM image to reload")
+	}
+	return parse(c.raw)
+}
+
+// Image returns the iNES image the cartridge was loaded from, or nil if it has none.
+func (c *Cartridge) Image() []byte {
+	return c.raw
+}
+
+// chrRAMStart returns the offset in CHRROM where CHR RAM starts: 0 when it is all RAM,
+// and len(CHRROM) when there is none.
+func (c *Cartridge) chrRAMStart() int {
+	if c.IsCHRRAM {
+		return 0
+	}
+	return len(c.CHRROM) - c.CHRRAMSize
+}
+
+// chrRAM returns the part of CHRROM that is RAM, which savestates carry.
+func (c *Cartridge) chrRAM() []byte {
+	return c.CHRROM[c.chrRAMStart():]
+}
+
+// parse builds a Cartridge from an in-memory iNES image.
+func parse(data []byte) (*Cartridge, error) {
+	h, err := ParseHeader(data)
+	if err != nil {
+		return nil, err
 	}
 
-	// Verify iNES header signature
-	if data[0] != 'N' || data[1] != 'E' || data[2] != 'S' || data[3] != 0x1A {
-		return nil, fmt.Errorf("invalid NES ROM format: missing iNES signature")
+	if h.PRGSize == 0 {
+		return nil, fmt.Errorf("invalid NES ROM: header declares no PRG ROM")
 	}
 
-	c := &Cartridge{}
-	prgRomSize := int(data[4]) * 16384
-	chrRomSize := int(data[5]) * 8192
+	c := &Cartridge{Header: h, raw: data}
+	prgRomSize := h.PRGSize
+	chrRomSize := h.CHRSize
 
-	// Check for presence of a trainer (Bit 2 of Flag 6)
-	hasTrainer := (data[6] & 0x04) != 0
 	offset := 16
-	if hasTrainer {
+	if h.Trainer {
 		offset += 512
 	}
 
 	// Allocate exact expected sizes to ensure compatibility even with under-dumped ROMs
 	c.PRGROM = make([]byte, prgRomSize)
 	if chrRomSize > 0 {
-		c.CHRROM = make([]byte, chrRomSize)
-		c.IsCHRRAM = false
+		c.CHRROM = make([]byte, chrRomSize+h.CHRRAMSize)
+		c.CHRRAMSize = h.CHRRAMSize
 	} else {
-		c.CHRROM = make([]byte, 8192) // CHR RAM
+		c.CHRROM = make([]byte, max(h.CHRRAMSize, 8192)) // CHR RAM
 		c.IsCHRRAM = true
 	}
 
@@ -93,10 +133,9 @@ func New(path string) (*Cartridge, error) {
 		}
 	}
 
-	mapperID := (data[6] >> 4) | (data[7] & 0xF0)
-	c.Mirror = (data[6] & 1) | ((data[6] >> 3) & 2)
+	c.Mirror = h.Mirror
 
-	mapper, err := NewMapper(c, mapperID)
+	mapper, err := NewMapper(c, h.Mapper)
 	if err != nil {
 		return nil, err
 	}
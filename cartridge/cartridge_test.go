@@ -3,7 +3,11 @@ package cartridge
 import (
 	"io/ioutil"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/meadori/vibemulator/snap"
 )
 
 func TestNew(t *testing.T) {
@@ -44,3 +48,202 @@ func TestNew(t *testing.T) {
 		t.Errorf("Expected mirroring to be Horizontal, but got %d", cart.Mirror)
 	}
 }
+
+func TestNewFromBytes(t *testing.T) {
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x00, 0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	prg := make([]byte, 16384)
+	prg[0] = 0xEA
+	data := append(header, prg...)
+
+	cart, err := NewFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cart.Mapper.(*uxrom); !ok {
+		t.Errorf("Expected UxROM mapper, but got %T", cart.Mapper)
+	}
+	if !cart.IsCHRRAM {
+		t.Error("Expected CHR RAM when the header declares no CHR ROM")
+	}
+	if cart.Mirror != MirrorVertical {
+		t.Errorf("Expected mirroring to be Vertical, but got %d", cart.Mirror)
+	}
+
+	// The caller's buffer must not alias the cartridge image
+	data[16] = 0x00
+	clone, err := cart.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.PRGROM[0] != 0xEA {
+		t.Errorf("Expected cloned PRGROM to be unaffected by caller writes, got %02X", clone.PRGROM[0])
+	}
+
+	if _, err := NewFromBytes([]byte("NES")); err == nil {
+		t.Error("Expected an error for a truncated image")
+	}
+}
+
+func TestNROMPRGRAM(t *testing.T) {
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	data := append(header, make([]byte, 16384+8192)...)
+	cart, err := NewFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cart.Mapper.CPUMapWrite(0x6004, 0x42) {
+		t.Fatal("Expected NROM to accept a write to $6004")
+	}
+	if v, ok := cart.Mapper.CPUMapRead(0x6004); !ok || v != 0x42 {
+		t.Errorf("Expected $6004 to read back 0x42, got 0x%02X (ok=%v)", v, ok)
+	}
+}
+
+func TestBatteryRAM(t *testing.T) {
+	for _, flags6 := range []byte{0x00, 0x02} {
+		header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x01, 0x10 | flags6, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+		cart, err := NewFromBytes(append(header, make([]byte, 16384+8192)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ram := cart.BatteryRAM()
+		if battery := flags6 != 0; battery != (ram != nil) {
+			t.Errorf("Expected battery RAM only with the battery flag, got %d bytes (battery=%v)", len(ram), battery)
+		}
+		if ram != nil {
+			// It must be the mapper's own RAM, not a copy
+			cart.Mapper.CPUMapWrite(0x6010, 0x99)
+			if ram[0x10] != 0x99 {
+				t.Errorf("Expected a write to $6010 to show in the battery RAM")
+			}
+		}
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags6 byte
+		chr    int
+	}{
+		{"MMC1 with CHR RAM", 0x10, 0},
+		{"MMC3", 0x40, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x02, byte(tt.chr), tt.flags6, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+			data := append(header, make([]byte, 2*16384+tt.chr*8192)...)
+			src, err := NewFromBytes(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst, err := NewFromBytes(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Touch PRG RAM, CHR memory and the bank registers
+			src.Mapper.CPUMapWrite(0x6010, 0x5A)
+			src.Mapper.PPUMapWrite(0x0010, 0xA5)
+			for _, addr := range []uint16{0x8000, 0xA000, 0xC000, 0xE000} {
+				src.Mapper.CPUMapWrite(addr, 0x01)
+				src.Mapper.CPUMapWrite(addr+1, 0x03)
+			}
+
+			var w snap.Writer
+			src.Snapshot(&w)
+			r := snap.NewReader(w.Buf)
+			if err := dst.Restore(&r); err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+			if r.Len() != 0 {
+				t.Errorf("Expected Restore to consume the snapshot, %d bytes left", r.Len())
+			}
+			if !reflect.DeepEqual(src.SaveState(), dst.SaveState()) {
+				t.Error("Expected the restored cartridge to match the original")
+			}
+		})
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	// Mapper 69 (unsupported), vertical mirroring, battery, NES 2.0
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x08, 0x00, 0x53, 0x48, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	h, err := ParseHeader(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Header{Mapper: 69, PRGSize: 8 * 16384, Mirror: MirrorVertical, Battery: true, NES2: true}
+	if h != want {
+		t.Errorf("Expected %+v, got %+v", want, h)
+	}
+	if name := MapperName(h.Mapper); name != "" {
+		t.Errorf("Expected no name for an unsupported mapper, got %q", name)
+	}
+	if name := MapperName(4); name != "MMC3" {
+		t.Errorf("Expected MMC3 for mapper 4, got %q", name)
+	}
+
+	// The low mapper bit sits next to the four-screen bit and must not leak into mirroring
+	for flags6, want := range map[byte]byte{0x30: MirrorHorizontal, 0x31: MirrorVertical, 0x48: MirrorFourScreen} {
+		h, err := ParseHeader([]byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x01, flags6, 0x00, 0, 0, 0, 0, 0, 0, 0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Mirror != want {
+			t.Errorf("Expected mirroring %d for flags 6 = $%02X, got %d", want, flags6, h.Mirror)
+		}
+	}
+
+	// Junk in the padding makes byte 7 junk too
+	dirty := append([]byte{0x4E, 0x45, 0x53, 0x1A, 0x02, 0x01, 0x10, 'D'}, "iskDude!"...)
+	if h, err := ParseHeader(dirty); err != nil || !h.Dirty || h.Mapper != 1 {
+		t.Errorf("Expected a dirty header to use mapper 1, got %+v (err=%v)", h, err)
+	}
+
+	if _, err := ParseHeader([]byte("NES")); err == nil {
+		t.Error("Expected an error for a truncated header")
+	}
+}
+
+func TestCheckHeader(t *testing.T) {
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+	if w := CheckHeader(append(header, make([]byte, 16384+8192)...)); len(w) != 0 {
+		t.Errorf("Expected no warnings for a clean NROM image, got %q", w)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"truncated", append(header, make([]byte, 16384)...), "truncated"},
+		{"trailing data", append(header, make([]byte, 16384+8192+128)...), "128 bytes after"},
+		{"unsupported", append([]byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x01, 0x50, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 16384+8192)...), "mapper 5"},
+		{"dirty", append(append([]byte{0x4E, 0x45, 0x53, 0x1A, 0x01, 0x01, 0x00, 'D'}, "iskDude!"...), make([]byte, 16384+8192)...), "not zero"},
+	}
+	for _, tt := range tests {
+		w := CheckHeader(tt.data)
+		if len(w) != 1 || !strings.Contains(w[0], tt.want) {
+			t.Errorf("%s: expected one warning mentioning %q, got %q", tt.name, tt.want, w)
+		}
+	}
+}
+
+func TestPRGMapFollowsBanking(t *testing.T) {
+	// UxROM with four 16KB banks
+	header := []byte{0x4E, 0x45, 0x53, 0x1A, 0x04, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	cart, err := NewFromBytes(append(header, make([]byte, 4*16384)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cart.Mapper.CPUMapWrite(0x8000, 2)
+	want := []PRGWindow{
+		{Start: 0x8000, End: 0xBFFF, Offset: 2 * 16384},
+		{Start: 0xC000, End: 0xFFFF, Offset: 3 * 16384},
+	}
+	if got := cart.PRGMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected PRG map %+v, got %+v", want, got)
+	}
+}
@@ -5,22 +5,19 @@ package cartridge
 // Bank switching is done by writing to any address in $8000-$FFFF.
 type cnrom struct {
 	prgROM        []byte
-	chrROM        []byte
+	chr           chrMemory
 	mirror        byte
 	prgBanks      int
-	chrBanks      int
 	chrBankSelect int
 }
 
 func newCNROM(cart *Cartridge) *cnrom {
 	prgBanks := len(cart.PRGROM) / 16384
-	chrBanks := len(cart.CHRROM) / 8192
 	return &cnrom{
 		prgROM:        cart.PRGROM,
-		chrROM:        cart.CHRROM,
+		chr:           newCHRMemory(cart),
 		mirror:        cart.Mirror,
 		prgBanks:      prgBanks,
-		chrBanks:      chrBanks,
 		chrBankSelect: 0,
 	}
 }
@@ -41,9 +38,7 @@ func (c *cnrom) CPUMapRead(addr uint16) (byte, bool) {
 func (c *cnrom) CPUMapWrite(addr uint16, data byte) bool {
 	if addr >= 0x8000 && addr <= 0xFFFF {
 		// CHR bank select is written to $8000-$FFFF
-		if c.chrBanks > 0 {
-			c.chrBankSelect = int(data) % c.chrBanks
-		}
+		c.chrBankSelect = int(data)
 		return true
 	}
 	return false
@@ -52,8 +47,7 @@ func (c *cnrom) CPUMapWrite(addr uint16, data byte) bool {
 // PPUMapRead implements the Mapper interface for PPU reads.
 func (c *cnrom) PPUMapRead(addr uint16) (byte, bool) {
 	if addr <= 0x1FFF {
-		mappedAddr := (c.chrBankSelect * 8192) + int(addr)
-		return c.chrROM[mappedAddr], true
+		return c.chr.read(c.chr.offset(c.chrBankSelect, 8192, addr)), true
 	}
 	return 0, false
 }
@@ -62,11 +56,7 @@ func (c *cnrom) PPUMapRead(addr uint16) (byte, bool) {
 func (c *cnrom) PPUMapWrite(addr uint16, data byte) bool {
 	if addr <= 0x1FFF {
 		// CNROM is typically CHR-ROM, but handle CHR-RAM just in case
-		if len(c.chrROM) == 8192 {
-			mappedAddr := (c.chrBankSelect * 8192) + int(addr)
-			c.chrROM[mappedAddr] = data
-			return true
-		}
+		return c.chr.write(c.chr.offset(c.chrBankSelect, 8192, addr), data)
 	}
 	return false
 }
@@ -0,0 +1,109 @@
+package cartridge
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Header is the board description in an iNES header.
+type Header struct {
+	Mapper     byte
+	PRGSize    int  // PRG ROM in bytes
+	CHRSize    int  // CHR ROM in bytes; 0 means the board has 8KB of CHR RAM instead
+	CHRRAMSize int  // CHR RAM in bytes, from an NES 2.0 header; mapped after any CHR ROM
+	Mirror     byte // One of the Mirror constants
+	Battery    bool // PRG RAM is battery backed
+	Trainer    bool // A 512-byte trainer precedes PRG ROM
+	NES2       bool // The header uses the NES 2.0 extensions; only the CHR RAM size is used
+
+	// Dirty is set when bytes 12-15 of an iNES 1.0 header hold junk, as in headers
+	// stamped "DiskDude!" by old dumping tools. Byte 7 is junk too then, so only the
+	// low nibble of the mapper number is used.
+	Dirty bool
+}
+
+// ParseHeader reads the iNES header at the start of data without loading the ROM, so it
+// also works for boards whose mapper isn't supported.
+func ParseHeader(data []byte) (Header, error) {
+	if len(data) < 16 {
+		return Header{}, fmt.Errorf("file is too small to be a valid NES ROM")
+	}
+	if data[0] != 'N' || data[1] != 'E' || data[2] != 'S' || data[3] != 0x1A {
+		return Header{}, fmt.Errorf("invalid NES ROM format: missing iNES signature")
+	}
+	h := Header{
+		Mapper:  (data[6] >> 4) | (data[7] & 0xF0),
+		PRGSize: int(data[4]) * 16384,
+		CHRSize: int(data[5]) * 8192,
+		Mirror:  data[6] & 1,
+		Battery: data[6]&0x02 != 0,
+		Trainer: data[6]&0x04 != 0,
+		NES2:    data[7]&0x0C == 0x08,
+	}
+	if data[6]&0x08 != 0 {
+		h.Mirror = MirrorFourScreen
+	}
+	if shift := data[11] & 0x0F; h.NES2 && shift != 0 {
+		h.CHRRAMSize = 64 << shift
+	}
+	if !h.NES2 && (data[12] != 0 || data[13] != 0 || data[14] != 0 || data[15] != 0) {
+		h.Dirty = true
+		h.Mapper = data[6] >> 4
+	}
+	return h, nil
+}
+
+// CheckHeader returns warnings about an iNES image whose header looks wrong: junk in the
+// padding, sizes that don't match the file, or a board the emulator doesn't support.
+// There is no database of known-good headers, so these are heuristics.
+func CheckHeader(data []byte) []string {
+	h, err := ParseHeader(data)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var warnings []string
+	if h.Dirty {
+		warnings = append(warnings, fmt.Sprintf("bytes 12-15 of the header are not zero (%q); using mapper %d from the low nibble only", data[7:16], h.Mapper))
+	}
+	if h.PRGSize == 0 {
+		warnings = append(warnings, "header declares no PRG ROM")
+	}
+	want := 16 + h.PRGSize + h.CHRSize
+	if h.Trainer {
+		want += 512
+	}
+	switch {
+	case len(data) < want:
+		warnings = append(warnings, fmt.Sprintf("file is %d bytes but the header needs %d; the ROM is truncated or the sizes are wrong", len(data), want))
+	case len(data) > want:
+		warnings = append(warnings, fmt.Sprintf("file has %d bytes after the ROM data the header declares", len(data)-want))
+	}
+	if MapperName(h.Mapper) == "" {
+		warnings = append(warnings, fmt.Sprintf("mapper %d is not supported", h.Mapper))
+	}
+	return warnings
+}
+
+// LogValue describes the board in log records.
+func (h Header) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("mapper", int(h.Mapper)),
+		slog.Int("prg_kb", h.PRGSize/1024),
+		slog.Int("chr_kb", h.CHRSize/1024),
+		slog.Int("mirror", int(h.Mirror)),
+		slog.Bool("battery", h.Battery),
+	)
+}
+
+var mapperNames = map[byte]string{
+	0: "NROM",
+	1: "MMC1",
+	2: "UxROM",
+	3: "CNROM",
+	4: "MMC3",
+}
+
+// MapperName returns the board name for a supported mapper, or "" if it isn't supported.
+func MapperName(id byte) string {
+	return mapperNames[id]
+}
@@ -5,9 +5,8 @@ import "github.com/meadori/vibemulator/mapper"
 // MMC1 (Mapper 1) is a common mapper that supports bank switching.
 type mmc1 struct {
 	prgROM []byte
-	chrROM []byte
+	chr    chrMemory
 	wram   []byte
-	chrRAM bool
 	cart   *Cartridge
 
 	// Registers
@@ -28,10 +27,9 @@ type mmc1 struct {
 func newMMC1(cart *Cartridge) mapper.Mapper {
 	return &mmc1{
 		prgROM:  cart.PRGROM,
-		chrROM:  cart.CHRROM,
+		chr:     newCHRMemory(cart),
 		wram:    make([]byte, 8192),
 		control: 0x0C,
-		chrRAM:  cart.IsCHRRAM,
 		cart:    cart,
 	}
 }
@@ -44,39 +42,47 @@ func (m *mmc1) CPUMapRead(addr uint16) (byte, bool) {
 		}
 		return 0, false
 	} else if addr >= 0x8000 && addr <= 0xFFFF {
-		prgBankMode := (m.control >> 2) & 3
-		numPrgBanks := uint32(len(m.prgROM) / 16384)
-
-		var finalAddr uint32
-		switch prgBankMode {
-		case 0, 1: // switch 32 KB at $8000
-			bank := uint32(m.prgBank&0x0E) >> 1
-			bank %= (numPrgBanks / 2)
-			finalAddr = bank*32768 + uint32(addr&0x7FFF)
-		case 2: // fix first bank at $8000 and switch 16 KB bank at $C000
-			var bank uint32
-			if addr < 0xC000 {
-				bank = 0
-			} else {
-				bank = uint32(m.prgBank & 0x0F)
-				bank %= numPrgBanks
-			}
-			finalAddr = bank*16384 + uint32(addr&0x3FFF)
-		case 3: // fix last bank at $C000 and switch 16 KB bank at $8000
-			var bank uint32
-			if addr < 0xC000 {
-				bank = uint32(m.prgBank & 0x0F)
-				bank %= numPrgBanks
-			} else {
-				bank = numPrgBanks - 1
-			}
-			finalAddr = bank*16384 + uint32(addr&0x3FFF)
-		}
-		return m.prgROM[finalAddr], true
+		return m.prgROM[m.prgAddr(addr)], true
 	}
 	return 0, false
 }
 
+// prgAddr returns the offset in PRG ROM that addr in $8000-$FFFF reads.
+func (m *mmc1) prgAddr(addr uint16) uint32 {
+	prgBankMode := (m.control >> 2) & 3
+	numPrgBanks := len(m.prgROM) / 16384
+	upper := addr >= 0xC000
+
+	// Banks are counted in 16KB units; a 32KB bank is an even 16KB bank and the next
+	var bank int
+	switch {
+	case prgBankMode <= 1: // switch 32 KB at $8000
+		bank = int(m.prgBank & 0x0E)
+		if upper {
+			bank |= 1
+		}
+	case prgBankMode == 2 && !upper: // fix first bank at $8000 and switch 16 KB bank at $C000
+		bank = 0
+	case prgBankMode == 3 && upper: // fix last bank at $C000 and switch 16 KB bank at $8000
+		bank = numPrgBanks - 1
+	default:
+		bank = int(m.prgBank & 0x0F)
+	}
+	return uint32(bankIndex(bank, numPrgBanks)*16384) + uint32(addr&0x3FFF)
+}
+
+// chrAddr returns the offset in CHR memory that addr in $0000-$1FFF reads.
+func (m *mmc1) chrAddr(addr uint16) int {
+	// Banks are counted in 4KB units; in 8KB mode the low bit of CHR bank 0 is ignored
+	bank := int(m.chrBank0)
+	if (m.control>>4)&1 == 0 {
+		bank = bank&0x1E | int(addr>>12)
+	} else if addr >= 0x1000 {
+		bank = int(m.chrBank1)
+	}
+	return m.chr.offset(bank, 4096, addr)
+}
+
 // CPUMapWrite implements the Mapper interface for CPU writes.
 func (m *mmc1) CPUMapWrite(addr uint16, data byte) bool {
 	if addr >= 0x8000 && addr <= 0xFFFF {
@@ -131,27 +137,7 @@ func (m *mmc1) CPUMapWrite(addr uint16, data byte) bool {
 // PPUMapRead implements the Mapper interface for PPU reads.
 func (m *mmc1) PPUMapRead(addr uint16) (byte, bool) {
 	if addr >= 0x0000 && addr <= 0x1FFF {
-		chrBankMode := (m.control >> 4) & 1
-		numChrBanks := uint32(len(m.chrROM) / 4096)
-
-		var finalAddr uint32
-
-		if chrBankMode == 0 { // 8KB mode
-			bank := uint32(m.chrBank0&0x1E) >> 1
-			bank %= (numChrBanks / 2)
-			finalAddr = bank*8192 + uint32(addr&0x1FFF)
-		} else { // 4KB mode
-			var bank uint32
-			if addr < 0x1000 {
-				bank = uint32(m.chrBank0)
-			} else {
-				bank = uint32(m.chrBank1)
-			}
-			bank %= numChrBanks
-			finalAddr = bank*4096 + uint32(addr&0x0FFF)
-		}
-
-		return m.chrROM[finalAddr], true
+		return m.chr.read(m.chrAddr(addr)), true
 	}
 	return 0, false
 }
@@ -159,29 +145,7 @@ func (m *mmc1) PPUMapRead(addr uint16) (byte, bool) {
 // PPUMapWrite implements the Mapper interface for PPU writes.
 func (m *mmc1) PPUMapWrite(addr uint16, data byte) bool {
 	if addr >= 0x0000 && addr <= 0x1FFF {
-		if m.chrRAM {
-			chrBankMode := (m.control >> 4) & 1
-			numChrBanks := uint32(len(m.chrROM) / 4096)
-
-			var finalAddr uint32
-
-			if chrBankMode == 0 { // 8KB mode
-				bank := uint32(m.chrBank0&0x1E) >> 1
-				bank %= (numChrBanks / 2)
-				finalAddr = bank*8192 + uint32(addr&0x1FFF)
-			} else { // 4KB mode
-				var bank uint32
-				if addr < 0x1000 {
-					bank = uint32(m.chrBank0)
-				} else {
-					bank = uint32(m.chrBank1)
-				}
-				bank %= numChrBanks
-				finalAddr = bank*4096 + uint32(addr&0x0FFF)
-			}
-			m.chrROM[finalAddr] = data
-			return true
-		}
+		return m.chr.write(m.chrAddr(addr), data)
 	}
 	return false
 }
@@ -4,9 +4,8 @@ package cartridge
 // It features complex PRG and CHR bank switching and a scanline-based IRQ counter.
 type mmc3 struct {
 	prgROM []byte
-	chrROM []byte
+	chr    chrMemory
 	prgRAM []byte
-	chrRAM bool
 
 	targetRegister byte
 	prgBankMode    bool // false: $8000 is swappable, true: $C000 is swappable
@@ -14,7 +13,6 @@ type mmc3 struct {
 	registers      [8]byte
 
 	prgBanks int
-	chrBanks int
 
 	// IRQ State
 	irqCounter byte
@@ -30,7 +28,6 @@ type mmc3 struct {
 
 func newMMC3(cart *Cartridge) *mmc3 {
 	prgBanks := len(cart.PRGROM) / 8192
-	chrBanks := len(cart.CHRROM) / 1024
 
 	// Handle 4-screen mirroring flag
 	fourScreen := (cart.Mirror & 4) != 0
@@ -38,11 +35,9 @@ func newMMC3(cart *Cartridge) *mmc3 {
 
 	return &mmc3{
 		prgROM:     cart.PRGROM,
-		chrROM:     cart.CHRROM,
+		chr:        newCHRMemory(cart),
 		prgRAM:     make([]byte, 8192),
-		chrRAM:     cart.IsCHRRAM,
 		prgBanks:   prgBanks,
-		chrBanks:   chrBanks,
 		fourScreen: fourScreen,
 		mirroring:  mirroring,
 	}
@@ -68,12 +63,12 @@ func (m *mmc3) getPRGBank(addr uint16) int {
 		if m.prgBankMode {
 			return secondToLast
 		}
-		return int(m.registers[6]) % m.prgBanks
+		return bankIndex(int(m.registers[6]), m.prgBanks)
 	} else if addr >= 0xA000 && addr <= 0xBFFF {
-		return int(m.registers[7]) % m.prgBanks
+		return bankIndex(int(m.registers[7]), m.prgBanks)
 	} else if addr >= 0xC000 && addr <= 0xDFFF {
 		if m.prgBankMode {
-			return int(m.registers[6]) % m.prgBanks
+			return bankIndex(int(m.registers[6]), m.prgBanks)
 		}
 		return secondToLast
 	} else if addr >= 0xE000 && addr <= 0xFFFF {
@@ -130,9 +125,7 @@ func (m *mmc3) CPUMapWrite(addr uint16, data byte) bool {
 func (m *mmc3) PPUMapRead(addr uint16) (byte, bool) {
 	if addr <= 0x1FFF {
 		m.checkA12(addr)
-		bank := m.getCHRBank(addr)
-		mappedAddr := (bank * 1024) + int(addr&0x03FF)
-		return m.chrROM[mappedAddr], true
+		return m.chr.read(m.chrAddr(addr)), true
 	}
 	return 0, false
 }
@@ -141,54 +134,55 @@ func (m *mmc3) PPUMapRead(addr uint16) (byte, bool) {
 func (m *mmc3) PPUMapWrite(addr uint16, data byte) bool {
 	if addr <= 0x1FFF {
 		m.checkA12(addr)
-		if m.chrRAM {
-			bank := m.getCHRBank(addr)
-			mappedAddr := (bank * 1024) + int(addr&0x03FF)
-			m.chrROM[mappedAddr] = data
-			return true
-		}
+		return m.chr.write(m.chrAddr(addr), data)
 	}
 	return false
 }
 
+// chrAddr returns the offset in CHR memory that addr in $0000-$1FFF reads.
+func (m *mmc3) chrAddr(addr uint16) int {
+	return m.chr.offset(m.getCHRBank(addr), 1024, addr)
+}
+
+// getCHRBank returns the 1KB bank number selected for addr, before masking.
 func (m *mmc3) getCHRBank(addr uint16) int {
 	if m.chrInversion {
 		switch {
 		case addr <= 0x03FF:
-			return int(m.registers[2]) % m.chrBanks
+			return int(m.registers[2])
 		case addr <= 0x07FF:
-			return int(m.registers[3]) % m.chrBanks
+			return int(m.registers[3])
 		case addr <= 0x0BFF:
-			return int(m.registers[4]) % m.chrBanks
+			return int(m.registers[4])
 		case addr <= 0x0FFF:
-			return int(m.registers[5]) % m.chrBanks
+			return int(m.registers[5])
 		case addr <= 0x13FF:
-			return int(m.registers[0]&0xFE) % m.chrBanks
+			return int(m.registers[0] & 0xFE)
 		case addr <= 0x17FF:
-			return int((m.registers[0]&0xFE)|1) % m.chrBanks
+			return int((m.registers[0] & 0xFE) | 1)
 		case addr <= 0x1BFF:
-			return int(m.registers[1]&0xFE) % m.chrBanks
+			return int(m.registers[1] & 0xFE)
 		case addr <= 0x1FFF:
-			return int((m.registers[1]&0xFE)|1) % m.chrBanks
+			return int((m.registers[1] & 0xFE) | 1)
 		}
 	} else {
 		switch {
 		case addr <= 0x03FF:
-			return int(m.registers[0]&0xFE) % m.chrBanks
+			return int(m.registers[0] & 0xFE)
 		case addr <= 0x07FF:
-			return int((m.registers[0]&0xFE)|1) % m.chrBanks
+			return int((m.registers[0] & 0xFE) | 1)
 		case addr <= 0x0BFF:
-			return int(m.registers[1]&0xFE) % m.chrBanks
+			return int(m.registers[1] & 0xFE)
 		case addr <= 0x0FFF:
-			return int((m.registers[1]&0xFE)|1) % m.chrBanks
+			return int((m.registers[1] & 0xFE) | 1)
 		case addr <= 0x13FF:
-			return int(m.registers[2]) % m.chrBanks
+			return int(m.registers[2])
 		case addr <= 0x17FF:
-			return int(m.registers[3]) % m.chrBanks
+			return int(m.registers[3])
 		case addr <= 0x1BFF:
-			return int(m.registers[4]) % m.chrBanks
+			return int(m.registers[4])
 		case addr <= 0x1FFF:
-			return int(m.registers[5]) % m.chrBanks
+			return int(m.registers[5])
 		}
 	}
 	return 0
@@ -252,9 +246,7 @@ func (m *mmc3) ClearIRQ() {
 // PPUDebugRead implements a side-effect free PPU read for the PPU Debugger overlay, skipping the A12 IRQ counter update.
 func (m *mmc3) PPUDebugRead(addr uint16) (byte, bool) {
 	if addr <= 0x1FFF {
-		bank := m.getCHRBank(addr)
-		mappedAddr := (bank * 1024) + int(addr&0x03FF)
-		return m.chrROM[mappedAddr], true
+		return m.chr.read(m.chrAddr(addr)), true
 	}
 	return 0, false
 }
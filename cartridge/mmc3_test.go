@@ -0,0 +1,103 @@
+package cartridge
+
+import "testing"
+
+func newTestMMC3() *mmc3 {
+	return newMMC3(&Cartridge{PRGROM: make([]byte, 4*8192), CHRROM: make([]byte, 8*1024)})
+}
+
+// riseA12 drops A12 for lowCycles CPU cycles, then raises it as the PPU does when it
+// moves from background to sprite pattern fetches.
+func riseA12(m *mmc3, lowCycles int) {
+	m.PPUMapRead(0x0000)
+	for i := 0; i < lowCycles; i++ {
+		m.Clock()
+	}
+	m.PPUMapRead(0x1000)
+}
+
+func TestMMC3IRQCounter(t *testing.T) {
+	m := newTestMMC3()
+	m.CPUMapWrite(0xC000, 2) // Latch
+	m.CPUMapWrite(0xC001, 0) // Reload
+	m.CPUMapWrite(0xE001, 0) // Enable
+
+	riseA12(m, 3) // Reload to 2
+	riseA12(m, 3) // 1
+	if m.IRQPending() {
+		t.Fatal("Expected no IRQ before the counter reaches 0")
+	}
+	riseA12(m, 3) // 0
+	if !m.IRQPending() {
+		t.Fatal("Expected an IRQ when the counter reaches 0")
+	}
+	if m.irqCounter != 0 {
+		t.Errorf("Expected counter 0, got %d", m.irqCounter)
+	}
+
+	// Writing $E000 acknowledges and disables
+	m.CPUMapWrite(0xE000, 0)
+	if m.IRQPending() {
+		t.Error("Expected $E000 to acknowledge the IRQ")
+	}
+	riseA12(m, 3) // Reloads from 0 to 2
+	if m.irqCounter != 2 {
+		t.Errorf("Expected the counter to reload to 2 after reaching 0, got %d", m.irqCounter)
+	}
+}
+
+func TestMMC3A12Filter(t *testing.T) {
+	m := newTestMMC3()
+	m.CPUMapWrite(0xC000, 5)
+	m.CPUMapWrite(0xC001, 0)
+	riseA12(m, 3)
+	if m.irqCounter != 5 {
+		t.Fatalf("Expected counter 5, got %d", m.irqCounter)
+	}
+
+	// A12 toggling within a scanline's sprite fetches must not clock the counter
+	riseA12(m, 1)
+	riseA12(m, 0)
+	if m.irqCounter != 5 {
+		t.Errorf("Expected short A12 low periods to be filtered, got counter %d", m.irqCounter)
+	}
+	riseA12(m, 2)
+	if m.irqCounter != 4 {
+		t.Errorf("Expected a 2-cycle low period to clock the counter, got %d", m.irqCounter)
+	}
+}
+
+func TestMMC3Reload(t *testing.T) {
+	m := newTestMMC3()
+	m.CPUMapWrite(0xC000, 8)
+	m.CPUMapWrite(0xC001, 0)
+	riseA12(m, 3)
+	riseA12(m, 3)
+	if m.irqCounter != 7 {
+		t.Fatalf("Expected counter 7, got %d", m.irqCounter)
+	}
+
+	// $C001 reloads on the next clock instead of decrementing, even mid-count
+	m.CPUMapWrite(0xC000, 3)
+	m.CPUMapWrite(0xC001, 0)
+	riseA12(m, 3)
+	if m.irqCounter != 3 {
+		t.Errorf("Expected $C001 to reload the counter to 3, got %d", m.irqCounter)
+	}
+}
+
+func TestMMC3ZeroLatch(t *testing.T) {
+	m := newTestMMC3()
+	m.CPUMapWrite(0xC000, 0)
+	m.CPUMapWrite(0xC001, 0)
+	m.CPUMapWrite(0xE001, 0)
+
+	// With a latch of 0 the counter reloads to 0 and fires on every clock (MMC3B/C behaviour)
+	for i := 0; i < 3; i++ {
+		riseA12(m, 3)
+		if !m.IRQPending() {
+			t.Fatalf("Expected an IRQ on clock %d with a zero latch", i)
+		}
+		m.ClearIRQ()
+	}
+}
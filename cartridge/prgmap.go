@@ -0,0 +1,67 @@
+package cartridge
+
+// PRGWindow is a range of CPU addresses the cartridge maps to PRG ROM or RAM.
+type PRGWindow struct {
+	Start, End uint16
+	RAM        bool // PRG RAM rather than PRG ROM
+	Offset     int  // Where Start falls in the PRG ROM or RAM
+}
+
+// prgMapper is implemented by mappers that can report where their PRG windows point.
+type prgMapper interface {
+	prgWindows() []PRGWindow
+}
+
+// PRGMap returns the windows of $4020-$FFFF the cartridge currently maps, in address
+// order. It changes as the game switches banks. It is nil for mappers that can't say.
+func (c *Cartridge) PRGMap() []PRGWindow {
+	if m, ok := c.Mapper.(prgMapper); ok {
+		return m.prgWindows()
+	}
+	return nil
+}
+
+// prgRAMWindow is the usual 8KB of PRG RAM at $6000.
+var prgRAMWindow = PRGWindow{Start: 0x6000, End: 0x7FFF, RAM: true}
+
+// fixedWindows maps 16KB or 32KB of PRG ROM at $8000, mirroring 16KB into $C000.
+func fixedWindows(prgBanks int) []PRGWindow {
+	if prgBanks == 1 {
+		return []PRGWindow{{Start: 0x8000, End: 0xBFFF}, {Start: 0xC000, End: 0xFFFF}}
+	}
+	return []PRGWindow{{Start: 0x8000, End: 0xFFFF}}
+}
+
+func (n *nrom) prgWindows() []PRGWindow {
+	return append([]PRGWindow{prgRAMWindow}, fixedWindows(n.prgBanks)...)
+}
+
+func (c *cnrom) prgWindows() []PRGWindow {
+	return fixedWindows(c.prgBanks)
+}
+
+func (u *uxrom) prgWindows() []PRGWindow {
+	return []PRGWindow{
+		{Start: 0x8000, End: 0xBFFF, Offset: bankIndex(u.prgBankSelect, u.prgBanks) * 16384},
+		{Start: 0xC000, End: 0xFFFF, Offset: max(u.prgBanks-1, 0) * 16384},
+	}
+}
+
+func (m *mmc1) prgWindows() []PRGWindow {
+	var windows []PRGWindow
+	if !m.wramDisabled {
+		windows = append(windows, prgRAMWindow)
+	}
+	// A 32KB bank is reported as its two halves, which differ in a 16KB ROM
+	return append(windows,
+		PRGWindow{Start: 0x8000, End: 0xBFFF, Offset: int(m.prgAddr(0x8000))},
+		PRGWindow{Start: 0xC000, End: 0xFFFF, Offset: int(m.prgAddr(0xC000))})
+}
+
+func (m *mmc3) prgWindows() []PRGWindow {
+	windows := []PRGWindow{prgRAMWindow}
+	for start := 0x8000; start <= 0xE000; start += 0x2000 {
+		windows = append(windows, PRGWindow{Start: uint16(start), End: uint16(start + 0x1FFF), Offset: m.getPRGBank(uint16(start)) * 8192})
+	}
+	return windows
+}
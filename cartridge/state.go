@@ -3,6 +3,8 @@ package cartridge
 import (
 	"bytes"
 	"encoding/gob"
+
+	"github.com/meadori/vibemulator/snap"
 )
 
 type State struct {
@@ -11,11 +13,22 @@ type State struct {
 	MapperState []byte
 }
 
+// BatteryRAM returns the PRG RAM that the header marks as battery backed, or nil if the
+// cartridge has none. It is the cartridge's own memory, not a copy.
+func (c *Cartridge) BatteryRAM() []byte {
+	if !c.Header.Battery {
+		return nil
+	}
+	if m, ok := c.Mapper.(interface{ GetPRGRAM() []byte }); ok {
+		return m.GetPRGRAM()
+	}
+	return nil
+}
+
 func (c *Cartridge) SaveState() State {
 	s := State{}
-	if c.IsCHRRAM {
-		s.CHRRAM = make([]byte, len(c.CHRROM))
-		copy(s.CHRRAM, c.CHRROM)
+	if ram := c.chrRAM(); len(ram) > 0 {
+		s.CHRRAM = append([]byte(nil), ram...)
 	}
 
 	// Dump PRG RAM if the mapper has it
@@ -30,9 +43,7 @@ func (c *Cartridge) SaveState() State {
 }
 
 func (c *Cartridge) LoadState(s State) error {
-	if c.IsCHRRAM && len(s.CHRRAM) > 0 {
-		copy(c.CHRROM, s.CHRRAM)
-	}
+	copy(c.chrRAM(), s.CHRRAM)
 
 	// Restore PRG RAM if the mapper has it
 	if m, ok := c.Mapper.(interface{ GetPRGRAM() []byte }); ok && len(s.PRGRAM) > 0 {
@@ -43,10 +54,57 @@ func (c *Cartridge) LoadState(s State) error {
 	return c.Mapper.Load(s.MapperState)
 }
 
+// snapshotter is implemented by mappers that can write their registers in snap's flat
+// encoding. Other mappers fall back to Save and Load. The writer and reader are passed
+// by value so calling through the interface doesn't move them to the heap.
+type snapshotter interface {
+	snapshot(w snap.Writer) snap.Writer
+	restore(r snap.Reader) snap.Reader
+}
+
+// Snapshot writes the state saved by SaveState in snap's flat encoding, without the
+// intermediate copies.
+func (c *Cartridge) Snapshot(w *snap.Writer) {
+	if ram := c.chrRAM(); len(ram) > 0 {
+		w.Bytes(ram)
+	}
+	if m, ok := c.Mapper.(interface{ GetPRGRAM() []byte }); ok {
+		w.Bytes(m.GetPRGRAM())
+	}
+	if m, ok := c.Mapper.(snapshotter); ok {
+		*w = m.snapshot(*w)
+	} else {
+		w.Bytes(c.Mapper.Save())
+	}
+}
+
+// Restore loads a state written by Snapshot.
+func (c *Cartridge) Restore(r *snap.Reader) error {
+	if ram := c.chrRAM(); len(ram) > 0 {
+		copy(ram, r.Bytes())
+	}
+	if m, ok := c.Mapper.(interface{ GetPRGRAM() []byte }); ok {
+		copy(m.GetPRGRAM(), r.Bytes())
+	}
+	if m, ok := c.Mapper.(snapshotter); ok {
+		*r = m.restore(*r)
+		return r.Err()
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+	return c.Mapper.Load(r.Bytes())
+}
+
 // NROM
+func (n *nrom) GetPRGRAM() []byte { return n.prgRAM }
+
 func (n *nrom) Save() []byte        { return nil }
 func (n *nrom) Load(b []byte) error { return nil }
 
+func (n *nrom) snapshot(w snap.Writer) snap.Writer { return w }
+func (n *nrom) restore(r snap.Reader) snap.Reader  { return r }
+
 // UXROM
 func (u *uxrom) Save() []byte { return []byte{byte(u.prgBankSelect)} }
 func (u *uxrom) Load(b []byte) error {
@@ -56,6 +114,16 @@ func (u *uxrom) Load(b []byte) error {
 	return nil
 }
 
+func (u *uxrom) snapshot(w snap.Writer) snap.Writer {
+	w.Int(u.prgBankSelect)
+	return w
+}
+
+func (u *uxrom) restore(r snap.Reader) snap.Reader {
+	u.prgBankSelect = r.Int()
+	return r
+}
+
 // CNROM
 func (c *cnrom) Save() []byte { return []byte{byte(c.chrBankSelect)} }
 func (c *cnrom) Load(b []byte) error {
@@ -65,6 +133,16 @@ func (c *cnrom) Load(b []byte) error {
 	return nil
 }
 
+func (c *cnrom) snapshot(w snap.Writer) snap.Writer {
+	w.Int(c.chrBankSelect)
+	return w
+}
+
+func (c *cnrom) restore(r snap.Reader) snap.Reader {
+	c.chrBankSelect = r.Int()
+	return r
+}
+
 // MMC1
 type MMC1State struct {
 	Control, ChrBank0, ChrBank1, PrgBank, ShiftRegister, WriteCount, WramDisableCounter byte
@@ -91,6 +169,21 @@ func (m *mmc1) Load(b []byte) error {
 	return nil
 }
 
+func (m *mmc1) snapshot(w snap.Writer) snap.Writer {
+	for _, v := range [...]byte{m.control, m.chrBank0, m.chrBank1, m.prgBank, m.shiftRegister, m.writeCount, m.wramDisableCounter} {
+		w.U8(v)
+	}
+	w.Bool(m.wramDisabled)
+	return w
+}
+
+func (m *mmc1) restore(r snap.Reader) snap.Reader {
+	m.control, m.chrBank0, m.chrBank1, m.prgBank = r.U8(), r.U8(), r.U8(), r.U8()
+	m.shiftRegister, m.writeCount, m.wramDisableCounter = r.U8(), r.U8(), r.U8()
+	m.wramDisabled = r.Bool()
+	return r
+}
+
 // MMC3
 type MMC3State struct {
 	TargetRegister                                         byte
@@ -121,3 +214,26 @@ func (m *mmc3) Load(b []byte) error {
 	m.targetRegister, m.prgBankMode, m.chrInversion, m.registers, m.irqCounter, m.irqLatch, m.irqReload, m.irqEnabled, m.irqPending, m.lastA12, m.fourScreen, m.a12Delay, m.mirroring = s.TargetRegister, s.PrgBankMode, s.ChrInversion, s.Registers, s.IrqCounter, s.IrqLatch, s.IrqReload, s.IrqEnabled, s.IrqPending, s.LastA12, s.FourScreen, s.A12Delay, s.Mirroring
 	return nil
 }
+
+func (m *mmc3) snapshot(w snap.Writer) snap.Writer {
+	w.U8(m.targetRegister)
+	w.Raw(m.registers[:])
+	w.U8(m.irqCounter)
+	w.U8(m.irqLatch)
+	w.U8(m.mirroring)
+	for _, v := range [...]bool{m.prgBankMode, m.chrInversion, m.irqReload, m.irqEnabled, m.irqPending, m.lastA12, m.fourScreen} {
+		w.Bool(v)
+	}
+	w.Int(m.a12Delay)
+	return w
+}
+
+func (m *mmc3) restore(r snap.Reader) snap.Reader {
+	m.targetRegister = r.U8()
+	r.Raw(m.registers[:])
+	m.irqCounter, m.irqLatch, m.mirroring = r.U8(), r.U8(), r.U8()
+	m.prgBankMode, m.chrInversion, m.irqReload, m.irqEnabled = r.Bool(), r.Bool(), r.Bool(), r.Bool()
+	m.irqPending, m.lastA12, m.fourScreen = r.Bool(), r.Bool(), r.Bool()
+	m.a12Delay = r.Int()
+	return r
+}
@@ -6,7 +6,7 @@ package cartridge
 // It typically uses 8KB of CHR-RAM, which is unbanked.
 type uxrom struct {
 	prgROM        []byte
-	chrROM        []byte
+	chr           chrMemory
 	mirror        byte
 	prgBanks      int
 	prgBankSelect int
@@ -16,7 +16,7 @@ func newUxROM(cart *Cartridge) *uxrom {
 	prgBanks := len(cart.PRGROM) / 16384
 	return &uxrom{
 		prgROM:        cart.PRGROM,
-		chrROM:        cart.CHRROM,
+		chr:           newCHRMemory(cart),
 		mirror:        cart.Mirror,
 		prgBanks:      prgBanks,
 		prgBankSelect: 0,
@@ -27,12 +27,12 @@ func newUxROM(cart *Cartridge) *uxrom {
 func (u *uxrom) CPUMapRead(addr uint16) (byte, bool) {
 	if addr >= 0x8000 && addr <= 0xBFFF {
 		// Switchable 16KB bank
-		bank := u.prgBankSelect % u.prgBanks
+		bank := bankIndex(u.prgBankSelect, u.prgBanks)
 		mappedAddr := (bank * 16384) + int(addr-0x8000)
 		return u.prgROM[mappedAddr], true
 	} else if addr >= 0xC000 && addr <= 0xFFFF {
 		// Fixed last 16KB bank
-		bank := u.prgBanks - 1
+		bank := max(u.prgBanks-1, 0)
 		mappedAddr := (bank * 16384) + int(addr-0xC000)
 		return u.prgROM[mappedAddr], true
 	}
@@ -52,7 +52,7 @@ func (u *uxrom) CPUMapWrite(addr uint16, data byte) bool {
 // PPUMapRead implements the Mapper interface for PPU reads.
 func (u *uxrom) PPUMapRead(addr uint16) (byte, bool) {
 	if addr <= 0x1FFF {
-		return u.chrROM[addr], true
+		return u.chr.read(u.chr.offset(0, 8192, addr)), true
 	}
 	return 0, false
 }
@@ -60,11 +60,8 @@ func (u *uxrom) PPUMapRead(addr uint16) (byte, bool) {
 // PPUMapWrite implements the Mapper interface for PPU writes.
 func (u *uxrom) PPUMapWrite(addr uint16, data byte) bool {
 	if addr <= 0x1FFF {
-		// Most UxROM boards use CHR-RAM, but we check if we allocated it as RAM
-		if len(u.chrROM) == 8192 {
-			u.chrROM[addr] = data
-			return true
-		}
+		// Most UxROM boards use CHR-RAM, but a few have CHR-ROM
+		return u.chr.write(u.chr.offset(0, 8192, addr), data)
 	}
 	return false
 }
@@ -0,0 +1,117 @@
+// Package cheat decodes NES cheat codes: Game Genie codes (six or eight letters, e.g.
+// "SXIOPO") and raw codes written "AAAA:VV" or "AAAA?CC:VV" in hexadecimal.
+//
+// A code replaces the byte the CPU reads at an address. Eight-letter Game Genie codes
+// and raw codes with "?CC" only apply while the original byte equals the compare value,
+// so they leave other PRG banks alone on mappers that switch banks.
+package cheat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Code is a decoded cheat.
+type Code struct {
+	Addr       uint16
+	Value      byte
+	Compare    byte // Only meaningful when HasCompare is set
+	HasCompare bool
+}
+
+// Apply returns the byte the CPU sees when it reads data from the code's address.
+func (c Code) Apply(data byte) byte {
+	if c.HasCompare && data != c.Compare {
+		return data
+	}
+	return c.Value
+}
+
+// String formats the code in the raw notation.
+func (c Code) String() string {
+	if c.HasCompare {
+		return fmt.Sprintf("%04X?%02X:%02X", c.Addr, c.Compare, c.Value)
+	}
+	return fmt.Sprintf("%04X:%02X", c.Addr, c.Value)
+}
+
+// Parse decodes a Game Genie or raw code.
+func Parse(s string) (Code, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ":") {
+		return parseRaw(s)
+	}
+	return DecodeGameGenie(s)
+}
+
+// genieLetters maps each Game Genie letter to its 4-bit value
+const genieLetters = "APZLGITYEOXUKSVN"
+
+// DecodeGameGenie decodes a six- or eight-letter Game Genie code.
+func DecodeGameGenie(s string) (Code, error) {
+	if len(s) != 6 && len(s) != 8 {
+		return Code{}, fmt.Errorf("invalid Game Genie code %q (want 6 or 8 letters)", s)
+	}
+	var n [8]uint16
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(genieLetters, upper(s[i]))
+		if v < 0 {
+			return Code{}, fmt.Errorf("invalid Game Genie letter %q in %q", s[i], s)
+		}
+		n[i] = uint16(v)
+	}
+
+	c := Code{
+		Addr: 0x8000 | (n[3]&7)<<12 | (n[5]&7)<<8 | (n[4]&8)<<8 |
+			(n[2]&7)<<4 | (n[1]&8)<<4 | n[4]&7 | n[3]&8,
+		Value: byte((n[1]&7)<<4 | (n[0]&8)<<4 | n[0]&7),
+	}
+	if len(s) == 6 {
+		c.Value |= byte(n[5] & 8)
+		return c, nil
+	}
+	c.Value |= byte(n[7] & 8)
+	c.Compare = byte((n[7]&7)<<4 | (n[6]&8)<<4 | n[6]&7 | n[5]&8)
+	c.HasCompare = true
+	return c, nil
+}
+
+// parseRaw decodes "AAAA:VV" or "AAAA?CC:VV"
+func parseRaw(s string) (Code, error) {
+	addrPart, valuePart, _ := strings.Cut(s, ":")
+	addrPart, comparePart, hasCompare := strings.Cut(addrPart, "?")
+
+	addr, err := parseHex(addrPart, 16)
+	if err != nil {
+		return Code{}, fmt.Errorf("invalid address in %q", s)
+	}
+	value, err := parseHex(valuePart, 8)
+	if err != nil {
+		return Code{}, fmt.Errorf("invalid value in %q", s)
+	}
+	c := Code{Addr: uint16(addr), Value: byte(value), HasCompare: hasCompare}
+	if hasCompare {
+		compare, err := parseHex(comparePart, 8)
+		if err != nil {
+			return Code{}, fmt.Errorf("invalid compare value in %q", s)
+		}
+		c.Compare = byte(compare)
+	}
+	return c, nil
+}
+
+func parseHex(s string, bits int) (uint64, error) {
+	s = strings.TrimPrefix(s, "$")
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	return strconv.ParseUint(s, 16, bits)
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
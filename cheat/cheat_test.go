@@ -0,0 +1,44 @@
+package cheat
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want Code
+	}{
+		{"GOSSIP", Code{Addr: 0xD1DD, Value: 0x14}},
+		{"sxiopo", Code{Addr: 0x91D9, Value: 0xAD}},
+		{"ZEXPYGLA", Code{Addr: 0x94A7, Value: 0x02, Compare: 0x03, HasCompare: true}},
+		{"075A:09", Code{Addr: 0x075A, Value: 0x09}},
+		{"$C123?A9:EA", Code{Addr: 0xC123, Value: 0xEA, Compare: 0xA9, HasCompare: true}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.code)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.code, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, expected %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, code := range []string{"", "GOSSI", "GOSSIPQ", "GOSSIB", "10000:00", "0300:100", "0300?:01", "xyz:01"} {
+		if _, err := Parse(code); err == nil {
+			t.Errorf("Expected an error parsing %q", code)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	c := Code{Addr: 0x8000, Value: 0xEA, Compare: 0xA9, HasCompare: true}
+	if got := c.Apply(0xA9); got != 0xEA {
+		t.Errorf("Expected a matching compare to apply, got $%02X", got)
+	}
+	if got := c.Apply(0x4C); got != 0x4C {
+		t.Errorf("Expected a mismatched compare to leave the byte alone, got $%02X", got)
+	}
+}
@@ -1,78 +1,54 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"flag"
 	"log"
 	"os"
-	"strconv"
-	"strings"
-	"time"
 
 	"github.com/meadori/vibemulator/api"
+	"github.com/meadori/vibemulator/script"
+	"github.com/meadori/vibemulator/server"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-func parseButtons(fullStr string) (int, *api.InputState) {
-	parts := strings.Split(fullStr, ":")
-	if len(parts) != 2 {
-		return 0, &api.InputState{}
-	}
-
-	playerIndex := 1
-	if parts[0] == "P2" {
-		playerIndex = 2
-	}
-
-	buttonStr := parts[1]
-	state := &api.InputState{PlayerIndex: int32(playerIndex)}
-	if buttonStr == "NONE" {
-		return playerIndex, state
-	}
-
-	buttons := strings.Split(buttonStr, "+")
-	for _, b := range buttons {
-		switch strings.ToUpper(b) {
-		case "A":
-			state.A = true
-		case "B":
-			state.B = true
-		case "SELECT":
-			state.Select = true
-		case "START":
-			state.Start = true
-		case "UP":
-			state.Up = true
-		case "DOWN":
-			state.Down = true
-		case "LEFT":
-			state.Left = true
-		case "RIGHT":
-			state.Right = true
-		}
+// inputState converts one player's buttons, in controller bit order, for StreamInput.
+func inputState(player int32, buttons [8]bool) *api.InputState {
+	return &api.InputState{
+		PlayerIndex: player,
+		A:           buttons[0],
+		B:           buttons[1],
+		Select:      buttons[2],
+		Start:       buttons[3],
+		Up:          buttons[4],
+		Down:        buttons[5],
+		Left:        buttons[6],
+		Right:       buttons[7],
 	}
-	return playerIndex, state
 }
 
 func main() {
 	scriptFile := flag.String("script", "", "Path to the recorded script file to replay")
+	recordFile := flag.String("record", "", "Path to write a script of the input the emulator receives")
+	recordFrames := flag.Uint64("frames", 0, "With -record, stop after this many frames (0 records until Ctrl-C)")
+	addr := flag.String("addr", "localhost:50051", "Emulator gRPC address")
+	caFile := flag.String("tls-ca", "", "PEM CA bundle used to verify the emulator (enables TLS)")
+	certFile := flag.String("tls-cert", "", "PEM client certificate for mTLS")
+	keyFile := flag.String("tls-key", "", "PEM client key for mTLS")
+	token := flag.String("token", os.Getenv("VIBEMULATOR_TOKEN"), "Bearer token for the emulator")
 	flag.Parse()
 
-	if *scriptFile == "" {
-		log.Fatalf("Please provide a script file using -script <file.script>")
+	if (*scriptFile == "") == (*recordFile == "") {
+		log.Fatalf("Please provide either a script to replay with -script <file.script> or one to record with -record <file.script>")
 	}
 
-	file, err := os.Open(*scriptFile)
+	// 1. Connect to the emulator's gRPC server
+	log.Printf("Connecting to emulator on %s...\n", *addr)
+	opts, err := server.DialOptions(server.TLSConfig{CertFile: *certFile, KeyFile: *keyFile, CAFile: *caFile}, *token)
 	if err != nil {
-		log.Fatalf("Failed to open script file: %v", err)
+		log.Fatalf("bad connection settings: %v", err)
 	}
-	defer file.Close()
-
-	// 1. Connect to the emulator's gRPC server
-	log.Println("Connecting to emulator on localhost:50051...")
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(*addr, opts...)
 	if err != nil {
 		log.Fatalf("failed to connect: %v", err)
 	}
@@ -80,49 +56,94 @@ func main() {
 
 	client := api.NewControllerServiceClient(conn)
 
+	if *recordFile != "" {
+		if err := record(client, *recordFile, *recordFrames); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
+	file, err := os.Open(*scriptFile)
+	if err != nil {
+		log.Fatalf("Failed to open script file: %v", err)
+	}
+	s, err := script.Parse(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("Failed to read script file: %v", err)
+	}
+
 	// 2. Open a streaming connection
 	stream, err := client.StreamInput(context.Background())
 	if err != nil {
 		log.Fatalf("failed to open stream: %v", err)
 	}
 
-	log.Printf("Connected! Starting replay of %s in 2 seconds...\n", *scriptFile)
-	time.Sleep(2 * time.Second)
+	// Version 2 scripts start from a power-cycle or their savestate, which leaves the
+	// emulator paused until the first states are queued. Older scripts start about
+	// 2 seconds (120 frames) from now.
+	paused := s.Version >= 2
+	if paused {
+		anchor(client, s)
+	}
 
-	// 3. Read and execute the script
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
+	// Follow the emulator's frame counter, which paces the replay instead of the wall clock
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	progress, err := client.StreamFrames(ctx, &api.StreamFramesRequest{HashesOnly: true})
+	if err != nil {
+		log.Fatalf("failed to follow frames: %v", err)
+	}
+	current, err := progress.Recv()
+	if err != nil {
+		log.Fatalf("failed to read frame counter: %v", err)
+	}
+	base := current.Frame
+	if !paused {
+		base += 120
+	}
 
-		parts := strings.Split(line, " ")
-		if len(parts) < 2 {
-			log.Printf("Skipping invalid line: %s\n", line)
-			continue
-		}
+	log.Printf("Connected! Starting replay of %s at frame %d...\n", *scriptFile, base)
 
-		frames, err := strconv.Atoi(parts[0])
-		if err != nil {
-			log.Printf("Invalid frame count: %s\n", parts[0])
-			continue
+	// 3. Schedule every state against the emulator's frame counter so network jitter
+	// cannot shift the replay
+	for _, e := range s.Entries {
+		frame := base + uint64(e.Frame)
+
+		// Stay a bounded distance ahead so the server's queue stays short on long scripts
+		if frame > current.Frame+replayLead {
+			if paused {
+				resume(client)
+				paused = false
+			}
+			if current, err = waitForFrame(progress, current, frame-replayLead); err != nil {
+				log.Fatalf("lost the frame stream: %v", err)
+			}
+		}
+		if !paused && current.Frame >= frame {
+			log.Fatalf("fell behind the emulator (frame %d is already at %d)", frame, current.Frame)
 		}
 
-		// Replay all player states on this line
-		for i := 1; i < len(parts); i++ {
-			_, state := parseButtons(parts[i])
-			// Send state for each player
+		for player, buttons := range [][8]bool{e.P1, e.P2} {
+			state := inputState(int32(player+1), buttons)
+			target := frame
+			state.TargetFrame = &target
 			if err := stream.Send(state); err != nil {
 				log.Fatalf("failed to send state: %v", err)
 			}
 		}
+	}
 
-		// 1 frame = ~16.666ms at 60Hz
-		duration := time.Duration(float64(frames) * 16.666666 * float64(time.Millisecond))
-
-		// Wait for the duration
-		time.Sleep(duration)
+	// Release the buttons once the last entry has been held to the end of the script
+	end := base + uint64(s.End)
+	for player := int32(1); player <= 2; player++ {
+		target := end
+		if err := stream.Send(&api.InputState{PlayerIndex: player, TargetFrame: &target}); err != nil {
+			log.Fatalf("failed to send state: %v", err)
+		}
+	}
+	if paused {
+		resume(client)
 	}
 
 	// Gracefully close the send stream
@@ -130,5 +151,58 @@ func main() {
 		log.Printf("failed to close stream: %v", err)
 	}
 
-	log.Println("Replay complete. Disconnected.")
+	if current, err = waitForFrame(progress, current, end); err != nil {
+		log.Fatalf("lost the frame stream: %v", err)
+	}
+	log.Printf("Replay complete at frame %d (hash %016x). Disconnected.\n", current.Frame, current.Hash)
+}
+
+// anchor power-cycles the emulator into the state a version 2 script starts from, after
+// checking it was recorded against the same ROM and core.
+func anchor(client api.ControllerServiceClient, s *script.Script) {
+	if s.ROMHash != "" || s.Emulator != "" {
+		snap, err := client.SaveState(context.Background(), &api.Empty{})
+		if err != nil {
+			log.Fatalf("failed to identify the emulator's ROM: %v", err)
+		}
+		if s.ROMHash != "" && snap.RomSha1 != s.ROMHash {
+			log.Printf("Warning: script was recorded on ROM %s, but the emulator has %s loaded\n", s.ROMHash, snap.RomSha1)
+		}
+		if s.Emulator != "" && snap.Emulator != s.Emulator {
+			log.Printf("Warning: script was recorded with %s, but the emulator is %s; it may desync\n", s.Emulator, snap.Emulator)
+		}
+	}
+
+	req := &api.EpisodeRequest{}
+	if s.Start == script.StartFromState {
+		req.State = s.State
+	}
+	if _, err := client.ResetEpisode(context.Background(), req); err != nil {
+		log.Fatalf("failed to reset the emulator: %v", err)
+	}
+	if s.Rerecords > 0 {
+		log.Printf("Script has %d rerecords.\n", s.Rerecords)
+	}
+}
+
+func resume(client api.ControllerServiceClient) {
+	if _, err := client.Resume(context.Background(), &api.Empty{}); err != nil {
+		log.Fatalf("failed to resume the emulator: %v", err)
+	}
+}
+
+// replayLead is how many frames ahead of the emulator states are queued, enough to ride
+// out network hiccups
+const replayLead = 300
+
+// waitForFrame reads the frame stream until the emulator reaches frame.
+func waitForFrame(progress api.ControllerService_StreamFramesClient, current *api.FrameResponse, frame uint64) (*api.FrameResponse, error) {
+	for current.Frame < frame {
+		next, err := progress.Recv()
+		if err != nil {
+			return current, err
+		}
+		current = next
+	}
+	return current, nil
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/meadori/vibemulator/api"
+	"github.com/meadori/vibemulator/script"
+)
+
+// record writes the input the emulator latches at every frame to a script that -script
+// replays, until Ctrl-C, the stream ends, or limit frames (when non-zero) are in. The
+// script starts from a savestate taken when recording begins.
+func record(client api.ControllerServiceClient, path string, limit uint64) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create script file: %v", err)
+	}
+	defer file.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Subscribe before taking the savestate so no frame after it is missed
+	stream, err := client.Spectate(ctx, &api.SpectateRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to open spectator stream: %v", err)
+	}
+	snap, err := client.SaveState(ctx, &api.Empty{})
+	if err != nil {
+		return fmt.Errorf("failed to save the starting state: %v", err)
+	}
+	rec, err := script.NewRecorder(file, script.Header{
+		Emulator: snap.Emulator,
+		ROMHash:  snap.RomSha1,
+		Start:    script.StartFromState,
+		State:    snap.State,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write script file: %v", err)
+	}
+	log.Printf("Recording to %s from frame %d, press Ctrl-C to stop...\n", path, snap.Frame)
+
+	var frames uint64
+	for limit == 0 || frames < limit {
+		update, err := stream.Recv()
+		if ctx.Err() != nil || err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("spectator stream failed: %v", err)
+		}
+		if update.Frame <= snap.Frame {
+			// Latched before the savestate was taken
+			continue
+		}
+
+		// Frames that go backwards (a rewind or state load) are recorded over
+		err = rec.Record(int(update.Frame-snap.Frame), buttons(update.P1), buttons(update.P2))
+		if err != nil {
+			return fmt.Errorf("failed to write script file: %v", err)
+		}
+		frames++
+	}
+	if err := rec.Close(); err != nil {
+		return fmt.Errorf("failed to write script file: %v", err)
+	}
+
+	log.Printf("Recorded %d frames.\n", frames)
+	return nil
+}
+
+// buttons is the inverse of inputState.
+func buttons(state *api.InputState) [8]bool {
+	return [8]bool{
+		state.GetA(), state.GetB(), state.GetSelect(), state.GetStart(),
+		state.GetUp(), state.GetDown(), state.GetLeft(), state.GetRight(),
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+// conditionNames are the names the condition language reserves for registers, flags and
+// the PPU position, so symbols never shadow them.
+var conditionNames = map[string]bool{
+	"A": true, "X": true, "Y": true, "SP": true, "P": true, "PC": true,
+	"N": true, "V": true, "D": true, "I": true, "Z": true, "C": true,
+	"SCANLINE": true, "DOT": true, "FRAME": true,
+}
+
+// substituteSymbols replaces loaded symbol names in a condition with their addresses,
+// since conditions are evaluated by the emulator, which doesn't know the symbols.
+func substituteSymbols(cond string) string {
+	var out strings.Builder
+	for i := 0; i < len(cond); {
+		c := cond[i]
+		if !isWordChar(c) {
+			out.WriteByte(c)
+			i++
+			continue
+		}
+		start := i
+		for i < len(cond) && isWordChar(cond[i]) {
+			i++
+		}
+		word := cond[start:i]
+		numeric := c == '$' || c >= '0' && c <= '9'
+		if addr, ok := syms.resolve(word); ok && !numeric && !conditionNames[strings.ToUpper(word)] {
+			word = fmt.Sprintf("$%04X", addr)
+		}
+		out.WriteString(word)
+	}
+	return out.String()
+}
+
+func isWordChar(c byte) bool {
+	return c == '$' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func describeBreakpoint(bp *api.Breakpoint) string {
+	if bp.Frame != 0 {
+		return fmt.Sprintf("frame %d", bp.Frame)
+	}
+	desc := fmt.Sprintf("$%04X", bp.Address)
+	if name, ok := syms.lookup(uint16(bp.Address)); ok {
+		desc += " <" + name + ">"
+	}
+	if bp.Condition != "" {
+		desc += " if " + bp.Condition
+	}
+	return desc
+}
+
+func listBreakpoints(client api.ControllerServiceClient) {
+	list, err := client.ListBreakpoints(context.Background(), &api.Empty{})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(list.Breakpoints) == 0 {
+		fmt.Println("No breakpoints.")
+		return
+	}
+	for _, bp := range list.Breakpoints {
+		fmt.Printf("%3d  %s\n", bp.Id, describeBreakpoint(bp))
+	}
+}
+
+// printStopReason reports a pending breakpoint or watchpoint hit, if there is one.
+func printStopReason(client api.ControllerServiceClient) (bool, error) {
+	bhit, err := client.GetBreakpointHit(context.Background(), &api.Empty{})
+	if err != nil {
+		return false, err
+	}
+	if bhit.Hit && bhit.Breakpoint.Frame != 0 {
+		fmt.Printf("\nPausepoint %d, %s\n", bhit.Breakpoint.Id, describeBreakpoint(bhit.Breakpoint))
+		return true, nil
+	} else if bhit.Hit {
+		fmt.Printf("\nBreakpoint %d, %s (frame %d)\n", bhit.Breakpoint.Id, describeBreakpoint(bhit.Breakpoint), bhit.Frame)
+		return true, nil
+	}
+
+	whit, err := client.GetWatchHit(context.Background(), &api.Empty{})
+	if err != nil {
+		return false, err
+	}
+	if whit.Hit {
+		printWatchHit(whit)
+		return true, nil
+	}
+	return false, nil
+}
+
+// clearStopReason discards any pending breakpoint or watchpoint hit.
+func clearStopReason(client api.ControllerServiceClient) {
+	client.GetBreakpointHit(context.Background(), &api.Empty{})
+	client.GetWatchHit(context.Background(), &api.Empty{})
+}
+
+// waitForStop blocks until a breakpoint or watchpoint stops the emulator or the user hits
+// Ctrl-C. Without any installed it returns immediately, leaving the emulator running.
+func waitForStop(client api.ControllerServiceClient) {
+	bps, err := client.ListBreakpoints(context.Background(), &api.Empty{})
+	if err != nil {
+		return
+	}
+	wps, err := client.ListWatchpoints(context.Background(), &api.Empty{})
+	if err != nil || len(bps.Breakpoints) == 0 && len(wps.Watchpoints) == 0 {
+		return
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-interrupt:
+			if _, err := client.Pause(context.Background(), &api.Empty{}); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Println("\nInterrupted.")
+			printStop(client)
+			return
+		case <-ticker.C:
+		}
+
+		stopped, err := printStopReason(client)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if stopped {
+			printStop(client)
+			return
+		}
+	}
+}
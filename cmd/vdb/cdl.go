@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+const cdlUsage = "Usage: cdl start [file] | cdl stop [file] | cdl save <file> | cdl status"
+
+// cdlCommand runs the code/data log subcommands; args excludes the word "cdl".
+func cdlCommand(client api.ControllerServiceClient, args []string) {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Println(cdlUsage)
+		return
+	}
+	file := ""
+	if len(args) == 2 {
+		file = args[1]
+	}
+
+	var report *api.CDLReport
+	var err error
+	switch args[0] {
+	case "start":
+		// Continue an existing log, so coverage builds up over several sessions
+		req := &api.CDLRequest{}
+		if file != "" {
+			if req.Data, err = os.ReadFile(file); err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+		if _, err := client.StartCDL(context.Background(), req); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(req.Data) != 0 {
+			fmt.Printf("Code/data logging continued from %s.\n", file)
+		} else {
+			fmt.Println("Code/data logging started.")
+		}
+		return
+	case "stop":
+		report, err = client.StopCDL(context.Background(), &api.Empty{})
+	case "save":
+		if file == "" {
+			fmt.Println(cdlUsage)
+			return
+		}
+		report, err = client.GetCDL(context.Background(), &api.Empty{})
+	case "status":
+		report, err = client.GetCDL(context.Background(), &api.Empty{})
+	default:
+		fmt.Println(cdlUsage)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printCDL(report)
+	if file != "" {
+		if err := os.WriteFile(file, report.Data, 0644); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Saved %s.\n", file)
+	}
+}
+
+func printCDL(report *api.CDLReport) {
+	state := ""
+	if report.Running {
+		state = " (still running)"
+	}
+	percent := func(n uint32) float64 {
+		if report.PrgSize == 0 {
+			return 0
+		}
+		return 100 * float64(n) / float64(report.PrgSize)
+	}
+	fmt.Printf("Code/data log%s: %d of %d PRG ROM bytes are code (%.1f%%), %d are data (%.1f%%)\n",
+		state, report.CodeBytes, report.PrgSize, percent(report.CodeBytes), report.DataBytes, percent(report.DataBytes))
+}
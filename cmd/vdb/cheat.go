@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+const cheatUsage = "Usage: cheat add <code> | cheat list | cheat enable <id> | cheat disable <id>"
+
+// cheatCommand runs the cheat subcommands; args excludes the word "cheat".
+func cheatCommand(client api.ControllerServiceClient, args []string) {
+	if len(args) == 0 {
+		fmt.Println(cheatUsage)
+		return
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) != 2 {
+			fmt.Println("Usage: cheat add <code> (e.g. cheat add SXIOPO or cheat add 075A:09)")
+			return
+		}
+		c, err := client.AddCheat(context.Background(), &api.Cheat{Code: args[1]})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Cheat %d: %s\n", c.Id, describeCheat(c))
+	case "list":
+		list, err := client.ListCheats(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(list.Cheats) == 0 {
+			fmt.Println("No cheats.")
+			return
+		}
+		for _, c := range list.Cheats {
+			state := "off"
+			if c.Enabled {
+				state = "on"
+			}
+			fmt.Printf("%3d  %-3s  %s\n", c.Id, state, describeCheat(c))
+		}
+	case "enable", "disable":
+		if len(args) != 2 {
+			fmt.Printf("Usage: cheat %s <id>\n", args[0])
+			return
+		}
+		id, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			fmt.Printf("Invalid cheat id: %s\n", args[1])
+			return
+		}
+		c, err := client.SetCheatEnabled(context.Background(), &api.Cheat{Id: uint32(id), Enabled: args[0] == "enable"})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Cheat %d %sd: %s\n", c.Id, args[0], describeCheat(c))
+	default:
+		fmt.Println(cheatUsage)
+	}
+}
+
+// describeCheat shows a code with what it decodes to, e.g. "SXIOPO ($91D9 = $AD)".
+func describeCheat(c *api.Cheat) string {
+	desc := fmt.Sprintf("%s (%s = $%02X", c.Code, symbolize(uint16(c.Address), 4), c.Value)
+	if c.HasCompare {
+		desc += fmt.Sprintf(" if $%02X", c.Compare)
+	}
+	return desc + ")"
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// commands are completed as the first word of a line
+var commands = []string{
+	"apu", "backtrace", "break", "cdl", "cheat", "continue", "delete", "disas", "display", "fill", "frame",
+	"framehash", "help", "info", "log", "map", "movie", "pause", "pausepoint", "ppu", "print", "profile", "quit", "regs", "run",
+	"rwatch", "set", "source", "step", "symbols", "undisplay", "until", "watch", "x",
+}
+
+// subcommands are completed as the second word after some commands
+var subcommands = map[string][]string{
+	"cdl":        {"save", "start", "status", "stop"},
+	"cheat":      {"add", "disable", "enable", "list"},
+	"info":       {"break", "display", "r", "stack", "watch"},
+	"log":        {"bus", "cartridge", "cpu", "debug", "error", "info", "ppu", "trace", "warn"},
+	"movie":      {"insert", "load", "save", "seek", "status", "truncate"},
+	"pausepoint": {"frame"},
+	"profile":    {"report", "start", "stop"},
+	"until":      {"frame", "scanline"},
+}
+
+// registers are completed in breakpoint conditions
+var registers = []string{"A", "X", "Y", "SP", "P", "PC", "N", "V", "D", "I", "Z", "C", "SCANLINE", "DOT", "FRAME"}
+
+// historyFile returns where command history is kept, or "" to keep none.
+func historyFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".vdb_history")
+}
+
+// completer completes commands, then registers and loaded symbols.
+type completer struct{}
+
+// Do implements readline.AutoCompleter. It returns the suffixes that complete the word
+// before the cursor, and the length of that word.
+func (completer) Do(line []rune, pos int) ([][]rune, int) {
+	before := string(line[:pos])
+	start := strings.LastIndexAny(before, " \t[(!&|=<>+-*/%^~") + 1
+	word := before[start:]
+	fields := strings.Fields(before[:start])
+
+	var candidates []string
+	switch {
+	case len(fields) == 0:
+		candidates = commands
+	case len(fields) == 1 && subcommands[fields[0]] != nil:
+		candidates = subcommands[fields[0]]
+	default:
+		candidates = append(candidates, syms.namesWithPrefix(word)...)
+		switch fields[0] {
+		case "break", "b", "display", "print":
+			candidates = append(candidates, registers...)
+		}
+	}
+
+	var out [][]rune
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			out = append(out, []rune(c[len(word):]+" "))
+		}
+	}
+	return out, len([]rune(word))
+}
+
+// namesWithPrefix returns the sorted symbol names starting with prefix.
+func (t *symbolTable) namesWithPrefix(prefix string) []string {
+	var names []string
+	for name := range t.addrs {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
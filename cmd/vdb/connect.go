@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// errDisconnected replaces the transport errors of calls made while the emulator is down.
+var errDisconnected = errors.New("emulator disconnected")
+
+// reconnectParams retries quickly after the emulator restarts, backing off to a few
+// seconds if it stays down.
+var reconnectParams = grpc.ConnectParams{
+	Backoff: backoff.Config{
+		BaseDelay:  250 * time.Millisecond,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+		MaxDelay:   5 * time.Second,
+	},
+	MinConnectTimeout: 2 * time.Second,
+}
+
+// connection tracks whether the emulator is reachable so vdb reports a lost connection
+// once, rather than printing a transport error for every command.
+type connection struct {
+	addr string
+
+	mu           sync.Mutex
+	disconnected bool
+}
+
+// dialOptions returns the options that make calls report disconnects gracefully.
+func (c *connection) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithConnectParams(reconnectParams),
+		grpc.WithChainUnaryInterceptor(c.intercept),
+	}
+}
+
+func (c *connection) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if status.Code(err) == codes.Unavailable {
+		if !c.disconnected {
+			fmt.Printf("Emulator at %s disconnected; vdb will reconnect when it is back.\n", c.addr)
+			c.disconnected = true
+		}
+		return errDisconnected
+	}
+	if c.disconnected {
+		fmt.Printf("Reconnected to emulator at %s.\n", c.addr)
+		c.disconnected = false
+	}
+	return err
+}
+
+// waitForEmulator blocks until conn is ready, retrying with backoff. It returns false
+// if the user gives up with Ctrl-C.
+func waitForEmulator(conn *grpc.ClientConn, addr string) bool {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	conn.Connect()
+	waiting := false
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return true
+		}
+		if state == connectivity.TransientFailure && !waiting {
+			fmt.Printf("Waiting for emulator at %s (Ctrl-C to quit)...\n", addr)
+			waiting = true
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
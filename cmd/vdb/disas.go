@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/meadori/vibemulator/api"
+	"github.com/meadori/vibemulator/cpu"
+)
+
+// disasBefore is how many instructions disas shows ahead of the PC
+const disasBefore = 4
+
+// printDisassembly lists count instructions at addr, or around the PC when addr is nil.
+func printDisassembly(client api.ControllerServiceClient, addr *uint32, count uint32) {
+	updateBanks(client)
+	req := &api.DisassembleRequest{Address: addr, Count: count}
+	if addr == nil {
+		req.Before = disasBefore
+	}
+	res, err := client.Disassemble(context.Background(), req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	for _, instr := range res.Instructions {
+		addr := uint16(instr.Address)
+		if name, ok := syms.lookup(addr); ok {
+			fmt.Printf("%s:\n", name)
+		}
+
+		marker := "  "
+		if instr.Address == res.Pc {
+			marker = "=>"
+		}
+		raw := make([]string, len(instr.Bytes))
+		for i, b := range instr.Bytes {
+			raw[i] = fmt.Sprintf("%02X", b)
+		}
+		fmt.Printf("%s $%04X:  %-8s  %s\n", marker, addr, strings.Join(raw, " "), formatInstruction(instr))
+	}
+}
+
+// formatInstruction renders an instruction in assembler syntax, naming operand addresses
+// that have symbols.
+func formatInstruction(instr *api.Instruction) string {
+	d := cpu.Disassembly{Addr: uint16(instr.Address), Mnemonic: instr.Mnemonic, Mode: instr.Mode}
+	for i := len(instr.Bytes) - 1; i > 0; i-- {
+		d.Operand = d.Operand<<8 | uint16(instr.Bytes[i])
+	}
+	return d.Format(syms.lookup)
+}
+
+// symbolize returns the label for addr, or addr as hex with the given number of digits.
+func symbolize(addr uint16, digits int) string {
+	if name, ok := syms.lookup(addr); ok {
+		return name
+	}
+	return fmt.Sprintf("$%0*X", digits, addr)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+// displayExpr is an expression printed whenever the emulator stops.
+type displayExpr struct {
+	id  int
+	src string // As typed, for listing
+	sub string // With symbols replaced by addresses, as sent to the emulator
+}
+
+var (
+	displays      []displayExpr
+	nextDisplayID = 1
+)
+
+// addDisplay registers an expression and prints its current value.
+func addDisplay(client api.ControllerServiceClient, src string) {
+	d := displayExpr{id: nextDisplayID, src: src, sub: substituteSymbols(src)}
+	res, err := client.Evaluate(context.Background(), &api.EvaluateRequest{Expressions: []string{d.sub}})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if msg := res.Results[0].Error; msg != "" {
+		fmt.Printf("Error: %s\n", msg)
+		return
+	}
+
+	nextDisplayID++
+	displays = append(displays, d)
+	printDisplay(d, res.Results[0])
+}
+
+// removeDisplay deletes a display by number, or all of them when arg is empty.
+func removeDisplay(arg string) {
+	if arg == "" {
+		displays = nil
+		return
+	}
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Printf("Invalid display: %s\n", arg)
+		return
+	}
+	for i, d := range displays {
+		if d.id == id {
+			displays = append(displays[:i], displays[i+1:]...)
+			return
+		}
+	}
+	fmt.Printf("No display %d.\n", id)
+}
+
+func listDisplays() {
+	if len(displays) == 0 {
+		fmt.Println("No display expressions.")
+		return
+	}
+	for _, d := range displays {
+		fmt.Printf("%3d  %s\n", d.id, d.src)
+	}
+}
+
+// printDisplays evaluates every display expression in one call and prints the results.
+func printDisplays(client api.ControllerServiceClient) {
+	if len(displays) == 0 {
+		return
+	}
+	req := &api.EvaluateRequest{}
+	for _, d := range displays {
+		req.Expressions = append(req.Expressions, d.sub)
+	}
+	res, err := client.Evaluate(context.Background(), req)
+	if err != nil {
+		fmt.Printf("Error evaluating displays: %v\n", err)
+		return
+	}
+	for i, d := range displays {
+		printDisplay(d, res.Results[i])
+	}
+}
+
+func printDisplay(d displayExpr, r *api.EvaluateResult) {
+	if r.Error != "" {
+		fmt.Printf("%d: %s = <%s>\n", d.id, d.src, r.Error)
+		return
+	}
+	fmt.Printf("%d: %s = %s\n", d.id, d.src, formatValue(r.Value))
+}
+
+// formatValue shows a value in hex and decimal, like $40 (64).
+func formatValue(v int64) string {
+	if v < 0 {
+		return strconv.FormatInt(v, 10)
+	}
+	if v <= 0xFF {
+		return fmt.Sprintf("$%02X (%d)", v, v)
+	}
+	return fmt.Sprintf("$%04X (%d)", v, v)
+}
+
+// printStop shows the machine state after the emulator stops: the registers, then
+// any display expressions.
+func printStop(client api.ControllerServiceClient) {
+	printRegs(client)
+	printDisplays(client)
+}
+
+// printExpr evaluates and prints an expression once.
+func printExpr(client api.ControllerServiceClient, src string) {
+	res, err := client.Evaluate(context.Background(), &api.EvaluateRequest{Expressions: []string{substituteSymbols(src)}})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if msg := res.Results[0].Error; msg != "" {
+		fmt.Printf("Error: %s\n", msg)
+		return
+	}
+	fmt.Printf("%s = %s\n", src, formatValue(res.Results[0].Value))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+// flags names the set bits of a register, most significant bit first.
+func flags(value uint32, names [8]string) string {
+	var set []string
+	for i, name := range names {
+		if name != "" && value&(0x80>>i) != 0 {
+			set = append(set, name)
+		}
+	}
+	if len(set) == 0 {
+		return "-"
+	}
+	return strings.Join(set, " ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func printPPU(client api.ControllerServiceClient) {
+	ppu, err := client.GetPPUState(context.Background(), &api.Empty{})
+	if err != nil {
+		fmt.Printf("Error getting PPU state: %v\n", err)
+		return
+	}
+
+	increment := 1
+	if ppu.Ctrl&0x04 != 0 {
+		increment = 32
+	}
+	spriteSize := "8x8"
+	if ppu.Ctrl&0x20 != 0 {
+		spriteSize = "8x16"
+	}
+	fmt.Printf("PPUCTRL   $%02X  NMI %s, nametable $%04X, increment %d, sprites %s at $%04X, background $%04X\n",
+		ppu.Ctrl, yesNo(ppu.Ctrl&0x80 != 0), 0x2000+0x400*(ppu.Ctrl&0x03), increment,
+		spriteSize, 0x1000*(ppu.Ctrl>>3&1), 0x1000*(ppu.Ctrl>>4&1))
+	fmt.Printf("PPUMASK   $%02X  %s\n", ppu.Mask,
+		flags(ppu.Mask, [8]string{"emph-B", "emph-G", "emph-R", "sprites", "background", "sprites-left", "background-left", "greyscale"}))
+	fmt.Printf("PPUSTATUS $%02X  %s\n", ppu.Status,
+		flags(ppu.Status, [8]string{"vblank", "sprite0-hit", "sprite-overflow"}))
+	fmt.Printf("OAMADDR   $%02X\n", ppu.OamAddr)
+
+	w := 0
+	if ppu.W {
+		w = 1
+	}
+	fmt.Printf("v: $%04X  t: $%04X  x: %d  w: %d\n", ppu.V, ppu.T, ppu.FineX, w)
+	fmt.Printf("Scanline %d, dot %d (frame %d), NMI pending: %s\n", ppu.Scanline, ppu.Dot, ppu.Frame, yesNo(ppu.NmiPending))
+}
+
+func printAPU(client api.ControllerServiceClient) {
+	apu, err := client.GetAPUState(context.Background(), &api.Empty{})
+	if err != nil {
+		fmt.Printf("Error getting APU state: %v\n", err)
+		return
+	}
+
+	fmt.Println("Channel   Enabled  Period  Length  Halt  Volume")
+	for _, ch := range []struct {
+		name string
+		c    *api.APUChannel
+	}{
+		{"Pulse 1", apu.Pulse1},
+		{"Pulse 2", apu.Pulse2},
+		{"Triangle", apu.Triangle},
+		{"Noise", apu.Noise},
+		{"DMC", apu.Dmc},
+	} {
+		fmt.Printf("%-9s %-8s %6d  %6d  %-4s  %6d\n",
+			ch.name, yesNo(ch.c.Enabled), ch.c.Period, ch.c.LengthCounter, yesNo(ch.c.Halt), ch.c.Volume)
+	}
+
+	mode := "4-step"
+	if apu.FiveStep {
+		mode = "5-step"
+	}
+	fmt.Printf("DMC address $%04X, %d bytes remaining\n", apu.DmcAddress, apu.DmcBytesRemaining)
+	fmt.Printf("Frame counter %s, IRQ inhibit: %s\n", mode, yesNo(apu.IrqInhibit))
+	fmt.Printf("IRQ flags: frame %s, DMC %s\n", yesNo(apu.FrameIrq), yesNo(apu.DmcIrq))
+}
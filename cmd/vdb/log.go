@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+const logUsage = "Usage: log | log <level> | log <subsystem> <level>"
+
+// logCommand shows or changes the emulator's log levels; args excludes the word "log".
+func logCommand(client api.ControllerServiceClient, args []string) {
+	var levels *api.LogLevels
+	var err error
+	switch len(args) {
+	case 0:
+		levels, err = client.GetLogLevels(context.Background(), &api.Empty{})
+	case 1:
+		levels, err = client.SetLogLevels(context.Background(), &api.LogLevels{Levels: map[string]string{"all": args[0]}})
+	case 2:
+		levels, err = client.SetLogLevels(context.Background(), &api.LogLevels{Levels: map[string]string{args[0]: args[1]}})
+	default:
+		fmt.Println(logUsage)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	names := make([]string, 0, len(levels.Levels))
+	for name := range levels.Levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-10s %s\n", name, levels.Levels[name])
+	}
+}
@@ -1,149 +1,627 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 
+	"github.com/chzyer/readline"
 	"github.com/meadori/vibemulator/api"
+	"github.com/meadori/vibemulator/server"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
+	var addr string
+	flag.StringVar(&addr, "connect", "localhost:50051", "emulator gRPC address (host:port)")
+	flag.StringVar(&addr, "addr", "localhost:50051", "alias for -connect")
+	caFile := flag.String("tls-ca", "", "PEM CA bundle used to verify the emulator (enables TLS)")
+	certFile := flag.String("tls-cert", "", "PEM client certificate for mTLS")
+	keyFile := flag.String("tls-key", "", "PEM client key for mTLS")
+	token := flag.String("token", os.Getenv("VIBEMULATOR_TOKEN"), "bearer token for the emulator")
+	symbolFile := flag.String("symbols", "", "label file to symbolize addresses (ld65 -Ln or FCEUX .nl)")
+	script := flag.String("x", "", "script of vdb commands to run after connecting")
+	noInit := flag.Bool("nx", false, "do not run ~/.vdbinit")
+	flag.Parse()
+
+	if *symbolFile != "" {
+		if _, err := syms.load(*symbolFile); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+
 	fmt.Println("VDB - Vibemulator DeBugger")
-	fmt.Println("Connecting to emulator on localhost:50051...")
+	fmt.Printf("Connecting to emulator on %s...\n", addr)
 
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts, err := server.DialOptions(server.TLSConfig{CertFile: *certFile, KeyFile: *keyFile, CAFile: *caFile}, *token)
+	if err != nil {
+		log.Fatalf("bad connection settings: %v", err)
+	}
+	tracker := &connection{addr: addr}
+	conn, err := grpc.Dial(addr, append(opts, tracker.dialOptions()...)...)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
 
+	if !waitForEmulator(conn, addr) {
+		return
+	}
 	client := api.NewControllerServiceClient(conn)
 	fmt.Println("Connected. Type 'help' for commands.")
 
-	scanner := bufio.NewScanner(os.Stdin)
+	if path := initFile(); !*noInit && path != "" {
+		if _, err := os.Stat(path); err == nil && !source(client, path) {
+			return
+		}
+	}
+	if *script != "" && !source(client, *script) {
+		return
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:            "(vdb) ",
+		HistoryFile:       historyFile(),
+		HistorySearchFold: true,
+		AutoComplete:      completer{},
+	})
+	if err != nil {
+		log.Fatalf("failed to start line editor: %v", err)
+	}
+	defer rl.Close()
+
 	for {
-		fmt.Print("(vdb) ")
-		if !scanner.Scan() {
+		line, err := rl.Readline()
+		if err == readline.ErrInterrupt {
+			// Ctrl-C at the prompt discards the line, as in a shell
+			continue
+		} else if err != nil {
 			break
 		}
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+		if !execute(client, line) {
+			return
 		}
+	}
+}
+
+// execute runs one vdb command line and reports whether the debugger should keep going.
+func execute(client api.ControllerServiceClient, line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return true
+	}
 
-		parts := strings.Fields(line)
-		cmd := parts[0]
+	parts := strings.Fields(line)
+	cmd := parts[0]
 
-		switch cmd {
-		case "help", "h":
-			fmt.Println("Commands:")
-			fmt.Println("  run, c      - Resume execution")
-			fmt.Println("  pause, p    - Pause execution")
-			fmt.Println("  step, s     - Step one instruction")
-			fmt.Println("  regs, i r   - Print CPU registers")
-			fmt.Println("  x <addr>    - Examine memory (e.g. x 0000 or x/16 0000)")
-			fmt.Println("  quit, q     - Exit debugger")
-		case "quit", "q", "exit":
-			return
-		case "pause", "p":
-			_, err := client.Pause(context.Background(), &api.Empty{})
+	switch cmd {
+	case "help", "h":
+		fmt.Println("Commands:")
+		fmt.Println("  run, c      - Resume execution")
+		fmt.Println("  pause, p    - Pause execution")
+		fmt.Println("  step, s     - Step one instruction")
+		fmt.Println("  regs, i r   - Print CPU registers")
+		fmt.Println("  ppu         - Print PPU registers, v/t/x/w, scanline/dot and pending NMI")
+		fmt.Println("  apu         - Print APU channel enables, periods, length counters and IRQ flags")
+		fmt.Println("  x <addr>    - Examine memory (e.g. x 0000 or x/16 0000)")
+		fmt.Println("  map         - Show the memory map, with the PRG banks currently mapped")
+		fmt.Println("  until <addr>         - Run until the PC reaches an address")
+		fmt.Println("  until scanline <n>   - Run until the PPU starts scanline n (-1 to 260)")
+		fmt.Println("  until frame          - Run until the next VBlank")
+		fmt.Println("  frame [n]            - Advance n frames (default 1), stopping as the frame starts")
+		fmt.Println("  pausepoint frame <n> - Stop whenever frame n starts")
+		fmt.Println("  framehash            - Print the current frame number and hash")
+		fmt.Println("  set <addr> <byte...>       - Write bytes to memory (e.g. set $0300 A9 00)")
+		fmt.Println("  fill <addr> <len> <byte>   - Fill len bytes of memory with a value")
+		fmt.Println("  disas [addr] [count] - Disassemble around the PC or at an address")
+		fmt.Println("  symbols <file>       - Load labels (ld65 -Ln, FCEUX .nl, or FCEUX bank files like game.nes.3.nl)")
+		fmt.Println("  watch <addr>[-<end>]  - Stop when the CPU writes the address or range (e.g. watch $00D0)")
+		fmt.Println("  rwatch <addr>[-<end>] - Stop when the CPU reads the address or range (e.g. rwatch 0x2002)")
+		fmt.Println("  break <addr> [if <cond>] - Stop before the instruction at addr, e.g. break $C123 if A==0x40 && [$00D0]>3")
+		fmt.Println("  info break  - List breakpoints")
+		fmt.Println("  info watch  - List watchpoints")
+		fmt.Println("  info stack  - Dump the stack from $0100+SP upward")
+		fmt.Println("  backtrace, bt - Show the JSR call chain (best effort)")
+		fmt.Println("  delete <id> - Remove a breakpoint, pausepoint or watchpoint")
+		fmt.Println("  print <expr>   - Evaluate an expression once (e.g. print [$00D0] + 1)")
+		fmt.Println("  display <expr> - Print an expression after every step or stop (e.g. display [$00D0])")
+		fmt.Println("  undisplay [n]  - Remove display n, or all displays")
+		fmt.Println("  info display   - List display expressions")
+		fmt.Println("  cheat add <code>     - Add a Game Genie (SXIOPO) or raw (075A:09, C123?A9:EA) cheat")
+		fmt.Println("  cheat list           - List cheats")
+		fmt.Println("  cheat enable <id>    - Turn a cheat on")
+		fmt.Println("  cheat disable <id>   - Turn a cheat off")
+		fmt.Println("  profile start        - Start counting executed instructions and subroutine calls")
+		fmt.Println("  profile stop [n]     - Stop profiling and show the top n addresses (default 10)")
+		fmt.Println("  profile report [n]   - Show the running or last profile")
+		fmt.Println("  cdl start [file]     - Log PRG ROM code and data, continuing an FCEUX .cdl file")
+		fmt.Println("  cdl stop [file]      - Stop logging, show coverage and optionally save the log")
+		fmt.Println("  cdl save <file>      - Save the running or last log as an FCEUX .cdl file")
+		fmt.Println("  cdl status           - Show how much of PRG ROM has been logged")
+		fmt.Println("  movie load <file>    - Load a movie into the emulator for editing, at its first frame")
+		fmt.Println("  movie seek <frame>   - Replay the movie to the start of a frame")
+		fmt.Println("  movie truncate       - Drop the movie's input after the current frame")
+		fmt.Println("  movie insert <p1> [p2] [n] - Insert n frames of input at the current frame (e.g. A+RIGHT)")
+		fmt.Println("  movie save <file>    - Save the edited movie")
+		fmt.Println("  movie status         - Show the movie's length and the current frame")
+		fmt.Println("  log [subsystem] [level] - Show log levels, or set one subsystem or all (trace, debug, info, warn, error)")
+		fmt.Println("  source <file> - Run the vdb commands in a file")
+		fmt.Println("  quit, q     - Exit debugger")
+	case "quit", "q", "exit":
+		return false
+	case "pause", "p":
+		_, err := client.Pause(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Println("Emulator paused.")
+			printStop(client)
+		}
+	case "run", "c", "continue":
+		// Forget hits from stepping, so only hits after resuming are reported
+		clearStopReason(client)
+		_, err := client.Resume(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Println("Emulator running...")
+			waitForStop(client)
+		}
+	case "step", "s":
+		_, err := client.Step(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			printStop(client)
+		}
+	case "regs", "i", "info":
+		if len(parts) > 1 && parts[1] == "r" || cmd == "regs" {
+			printRegs(client)
+		} else if len(parts) > 1 && strings.HasPrefix(parts[1], "watch") {
+			listWatchpoints(client)
+		} else if len(parts) > 1 && strings.HasPrefix(parts[1], "b") {
+			listBreakpoints(client)
+		} else if len(parts) > 1 && parts[1] == "stack" {
+			printStack(client)
+		} else if len(parts) > 1 && parts[1] == "display" {
+			listDisplays()
+		} else {
+			fmt.Println("Unknown command. Did you mean 'i r', 'info break', 'info watch', 'info stack' or 'info display'?")
+		}
+	case "cheat":
+		cheatCommand(client, parts[1:])
+	case "profile":
+		profileCommand(client, parts[1:])
+	case "cdl":
+		cdlCommand(client, parts[1:])
+	case "log":
+		logCommand(client, parts[1:])
+	case "movie":
+		movieCommand(client, parts[1:])
+	case "map":
+		printMemoryMap(client)
+	case "ppu":
+		printPPU(client)
+	case "apu":
+		printAPU(client)
+	case "backtrace", "bt":
+		printBacktrace(client)
+	case "break", "b":
+		if len(parts) < 2 {
+			fmt.Println("Usage: break <addr> [if <condition>]")
+			return true
+		}
+		addr, err := parseAddr(parts[1])
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		condition := ""
+		if len(parts) > 2 {
+			if parts[2] != "if" || len(parts) == 3 {
+				fmt.Println("Usage: break <addr> [if <condition>]")
+				return true
+			}
+			condition = substituteSymbols(strings.Join(parts[3:], " "))
+		}
+		bp, err := client.AddBreakpoint(context.Background(), &api.Breakpoint{Address: uint32(addr), Condition: condition})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Printf("Breakpoint %d: %s\n", bp.Id, describeBreakpoint(bp))
+		}
+	case "watch", "rwatch":
+		if len(parts) < 2 {
+			fmt.Printf("Usage: %s <addr>[-<end>]\n", cmd)
+			return true
+		}
+		start, end, err := parseRange(parts[1])
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		w, err := client.AddWatchpoint(context.Background(), &api.Watchpoint{
+			Start: uint32(start),
+			End:   uint32(end),
+			Write: cmd == "watch",
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Printf("Watchpoint %d: %s\n", w.Id, describeWatchpoint(w))
+		}
+	case "until", "u":
+		if len(parts) < 2 {
+			fmt.Println("Usage: until <addr> | until scanline <n> | until frame")
+			return true
+		}
+		req := &api.RunUntilRequest{}
+		switch parts[1] {
+		case "frame", "vblank":
+			req.Condition = api.StopCondition_STOP_AT_VBLANK
+		case "scanline":
+			if len(parts) < 3 {
+				fmt.Println("Usage: until scanline <n>")
+				return true
+			}
+			n, err := strconv.ParseInt(parts[2], 10, 32)
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				fmt.Println("Emulator paused.")
-				printRegs(client)
+				fmt.Printf("Invalid scanline: %s\n", parts[2])
+				return true
 			}
-		case "run", "c", "continue":
-			_, err := client.Resume(context.Background(), &api.Empty{})
+			req.Condition = api.StopCondition_STOP_AT_SCANLINE
+			req.Scanline = int32(n)
+		default:
+			addr, err := parseAddr(parts[1])
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				fmt.Println("Emulator running...")
+				fmt.Println(err)
+				return true
+			}
+			req.Condition = api.StopCondition_STOP_AT_ADDRESS
+			req.Address = uint32(addr)
+		}
+		runUntil(client, req)
+	case "frame":
+		n := uint64(1)
+		if len(parts) > 1 {
+			v, err := strconv.ParseUint(parts[1], 10, 32)
+			if err != nil || v == 0 {
+				fmt.Printf("Invalid frame count: %s\n", parts[1])
+				return true
 			}
-		case "step", "s":
-			_, err := client.Step(context.Background(), &api.Empty{})
+			n = v
+		}
+		hash, err := client.GetFrameHash(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return true
+		}
+		runUntil(client, &api.RunUntilRequest{Condition: api.StopCondition_STOP_AT_FRAME, Frame: hash.Frame + n})
+	case "pausepoint":
+		if len(parts) != 3 || parts[1] != "frame" {
+			fmt.Println("Usage: pausepoint frame <n>")
+			return true
+		}
+		frame, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil || frame == 0 {
+			fmt.Printf("Invalid frame: %s\n", parts[2])
+			return true
+		}
+		bp, err := client.AddBreakpoint(context.Background(), &api.Breakpoint{Frame: frame})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Printf("Pausepoint %d: %s\n", bp.Id, describeBreakpoint(bp))
+		}
+	case "framehash":
+		hash, err := client.GetFrameHash(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Printf("Frame %d: %016x\n", hash.Frame, hash.Hash)
+		}
+	case "set":
+		if len(parts) < 3 {
+			fmt.Println("Usage: set <addr> <byte...>")
+			return true
+		}
+		addr, err := parseAddr(parts[1])
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		data, err := parseBytes(parts[2:])
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		writeMemory(client, addr, data)
+	case "fill":
+		if len(parts) != 4 {
+			fmt.Println("Usage: fill <addr> <len> <byte>")
+			return true
+		}
+		addr, err := parseAddr(parts[1])
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		n, err := strconv.ParseUint(parts[2], 0, 32)
+		if err != nil || n == 0 || n > 0x10000 {
+			fmt.Printf("Invalid length: %s\n", parts[2])
+			return true
+		}
+		v, err := parseByte(parts[3])
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		writeMemory(client, addr, bytes.Repeat([]byte{v}, int(n)))
+	case "disas", "disassemble":
+		var addr *uint32
+		count := uint64(10)
+		if len(parts) > 1 {
+			a, err := parseAddr(parts[1])
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				printRegs(client)
+				fmt.Println(err)
+				return true
 			}
-		case "regs", "i":
-			if len(parts) > 1 && parts[1] == "r" || cmd == "regs" {
-				printRegs(client)
-			} else {
-				fmt.Println("Unknown command. Did you mean 'i r'?")
+			a32 := uint32(a)
+			addr = &a32
+		}
+		if len(parts) > 2 {
+			n, err := strconv.ParseUint(parts[2], 10, 32)
+			if err != nil || n == 0 {
+				fmt.Printf("Invalid count: %s\n", parts[2])
+				return true
 			}
-		case "x":
-			count := 1
-			addrStr := ""
-			if len(parts) == 1 {
-				fmt.Println("Usage: x <addr> or x/<count> <addr>")
-				continue
-			} else if strings.HasPrefix(parts[0], "x/") {
-				countStr := strings.TrimPrefix(parts[0], "x/")
-				parsedCount, err := strconv.ParseInt(countStr, 10, 32)
-				if err == nil {
-					count = int(parsedCount)
-				}
-				addrStr = parts[1]
-			} else {
-				addrStr = parts[1]
+			count = n
+		}
+		printDisassembly(client, addr, uint32(count))
+	case "symbols":
+		if len(parts) < 2 {
+			fmt.Println("Usage: symbols <file>")
+			return true
+		}
+		n, err := syms.load(parts[1])
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Printf("Loaded %d symbols.\n", n)
+		}
+	case "display":
+		if len(parts) < 2 {
+			printDisplays(client)
+			return true
+		}
+		addDisplay(client, strings.Join(parts[1:], " "))
+	case "undisplay":
+		removeDisplay(strings.Join(parts[1:], " "))
+	case "print":
+		if len(parts) < 2 {
+			fmt.Println("Usage: print <expr>")
+			return true
+		}
+		printExpr(client, strings.Join(parts[1:], " "))
+	case "source":
+		if len(parts) < 2 {
+			fmt.Println("Usage: source <file>")
+			return true
+		}
+		return source(client, parts[1])
+	case "delete", "d":
+		if len(parts) < 2 {
+			fmt.Println("Usage: delete <id>")
+			return true
+		}
+		id, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			fmt.Printf("Invalid breakpoint: %s\n", parts[1])
+			return true
+		}
+		// Breakpoints and watchpoints share one numbering, so try both
+		if _, err := client.RemoveBreakpoint(context.Background(), &api.Breakpoint{Id: uint32(id)}); err == nil {
+			fmt.Printf("Deleted breakpoint %d.\n", id)
+		} else if _, err := client.RemoveWatchpoint(context.Background(), &api.Watchpoint{Id: uint32(id)}); err == nil {
+			fmt.Printf("Deleted watchpoint %d.\n", id)
+		} else {
+			fmt.Printf("No breakpoint or watchpoint %d.\n", id)
+		}
+	case "x":
+		count := 1
+		addrStr := ""
+		if len(parts) == 1 {
+			fmt.Println("Usage: x <addr> or x/<count> <addr>")
+			return true
+		} else if strings.HasPrefix(parts[0], "x/") {
+			countStr := strings.TrimPrefix(parts[0], "x/")
+			parsedCount, err := strconv.ParseInt(countStr, 10, 32)
+			if err == nil {
+				count = int(parsedCount)
 			}
+			addrStr = parts[1]
+		} else {
+			addrStr = parts[1]
+		}
 
-			// Clean up address (e.g., remove 0x prefix if present)
-			addrStr = strings.TrimPrefix(addrStr, "0x")
-			addr, err := strconv.ParseUint(addrStr, 16, 32)
-			if err != nil {
-				fmt.Printf("Invalid address: %s\n", parts[1])
-				continue
-			}
+		addr, err := parseAddr(addrStr)
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
 
-			res, err := client.ReadMemoryBlock(context.Background(), &api.MemoryBlockRequest{
-				Address: uint32(addr),
-				Size:    uint32(count),
-			})
-			if err != nil {
-				fmt.Printf("Error reading memory: %v\n", err)
-			} else {
-				printHexDump(uint16(addr), res.Data)
+		examine(client, addr, count)
+	default:
+		// check for x/count without space like x/10 0x0000
+		if strings.HasPrefix(cmd, "x/") {
+			countStr := strings.TrimPrefix(cmd, "x/")
+			count, _ := strconv.ParseInt(countStr, 10, 32)
+			if count <= 0 {
+				count = 1
 			}
-		default:
-			// check for x/count without space like x/10 0x0000
-			if strings.HasPrefix(cmd, "x/") {
-				countStr := strings.TrimPrefix(cmd, "x/")
-				count, _ := strconv.ParseInt(countStr, 10, 32)
-				if count <= 0 {
-					count = 1
-				}
-				if len(parts) > 1 {
-					addrStr := strings.TrimPrefix(parts[1], "0x")
-					addr, err := strconv.ParseUint(addrStr, 16, 32)
-					if err != nil {
-						fmt.Printf("Invalid address: %s\n", parts[1])
-						continue
-					}
-					res, err := client.ReadMemoryBlock(context.Background(), &api.MemoryBlockRequest{
-						Address: uint32(addr),
-						Size:    uint32(count),
-					})
-					if err != nil {
-						fmt.Printf("Error: %v\n", err)
-					} else {
-						printHexDump(uint16(addr), res.Data)
-					}
+			if len(parts) > 1 {
+				addr, err := parseAddr(parts[1])
+				if err != nil {
+					fmt.Println(err)
+					return true
 				}
-			} else {
-				fmt.Printf("Unknown command: %s\n", cmd)
+				examine(client, addr, int(count))
 			}
+		} else {
+			fmt.Printf("Unknown command: %s\n", cmd)
+		}
+	}
+	return true
+}
+
+// parseAddr accepts a symbol name, $00D0, 0x00D0 or bare hex
+func parseAddr(s string) (uint16, error) {
+	if addr, ok := syms.resolve(s); ok {
+		return addr, nil
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "$"), "0x")
+	addr, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid address: %s", s)
+	}
+	return uint16(addr), nil
+}
+
+// parseByte accepts $FF, 0xFF or bare hex
+func parseByte(s string) (byte, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(s, "$"), "0x"), 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid byte: %s", s)
+	}
+	return byte(v), nil
+}
+
+// parseBytes parses each argument with parseByte
+func parseBytes(args []string) ([]byte, error) {
+	data := make([]byte, len(args))
+	for i, arg := range args {
+		v, err := parseByte(arg)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = v
+	}
+	return data, nil
+}
+
+// writeMemory writes data and dumps the memory back so the effect is visible
+// (ROM and register addresses may not read back what was written)
+func writeMemory(client api.ControllerServiceClient, addr uint16, data []byte) {
+	_, err := client.WriteMemoryBlock(context.Background(), &api.MemoryWriteRequest{
+		Address: uint32(addr),
+		Data:    data,
+	})
+	if err != nil {
+		fmt.Printf("Error writing memory: %v\n", err)
+		return
+	}
+
+	res, err := client.ReadMemoryBlock(context.Background(), &api.MemoryBlockRequest{
+		Address: uint32(addr),
+		Size:    uint32(min(len(data), 256)),
+	})
+	if err != nil {
+		fmt.Printf("Error reading memory: %v\n", err)
+	} else {
+		fmt.Printf("Wrote %d bytes.\n", len(data))
+		printHexDump(addr, res.Data)
+	}
+}
+
+// parseRange accepts a single address or an inclusive start-end range
+func parseRange(s string) (uint16, uint16, error) {
+	startStr, endStr, isRange := strings.Cut(s, "-")
+	start, err := parseAddr(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	end := start
+	if isRange {
+		if end, err = parseAddr(endStr); err != nil {
+			return 0, 0, err
+		}
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("Invalid range: %s", s)
+	}
+	return start, end, nil
+}
+
+func describeWatchpoint(w *api.Watchpoint) string {
+	kind := "read"
+	if w.Write {
+		kind = "write"
+	}
+	if w.Start == w.End {
+		return fmt.Sprintf("%s $%04X", kind, w.Start)
+	}
+	return fmt.Sprintf("%s $%04X-$%04X", kind, w.Start, w.End)
+}
+
+func listWatchpoints(client api.ControllerServiceClient) {
+	list, err := client.ListWatchpoints(context.Background(), &api.Empty{})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(list.Watchpoints) == 0 {
+		fmt.Println("No watchpoints.")
+		return
+	}
+	for _, w := range list.Watchpoints {
+		fmt.Printf("%3d  %s\n", w.Id, describeWatchpoint(w))
+	}
+}
+
+// runUntil blocks until the server reports the condition met; Ctrl-C cancels the call,
+// which pauses the emulator.
+func runUntil(client api.ControllerServiceClient, req *api.RunUntilRequest) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	clearStopReason(client)
+	fmt.Println("Emulator running...")
+	res, err := client.RunUntil(ctx, req)
+	if ctx.Err() != nil {
+		fmt.Println("\nInterrupted.")
+		printStop(client)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if !res.Reached {
+		if stopped, err := printStopReason(client); err != nil || !stopped {
+			fmt.Println("Stopped before the condition was reached.")
 		}
 	}
+	updateBanks(client)
+	fmt.Printf("Stopped at PC %s, scanline %d dot %d (frame %d)\n", symbolize(uint16(res.Pc), 4), res.Scanline, res.Dot, res.Frame)
+	printStop(client)
+}
+
+func printWatchHit(hit *api.WatchHit) {
+	fmt.Printf("\nWatchpoint %d (%s) hit at PC $%04X, frame %d\n", hit.Watchpoint.Id, describeWatchpoint(hit.Watchpoint), hit.Pc, hit.Frame)
+	if hit.Write && hit.HasOld {
+		fmt.Printf("$%04X: Old value = $%02X, New value = $%02X\n", hit.Address, hit.OldValue, hit.NewValue)
+	} else if hit.Write {
+		fmt.Printf("$%04X: New value = $%02X\n", hit.Address, hit.NewValue)
+	} else {
+		fmt.Printf("$%04X: Value = $%02X\n", hit.Address, hit.NewValue)
+	}
 }
 
 func printRegs(client api.ControllerServiceClient) {
@@ -154,6 +632,14 @@ func printRegs(client api.ControllerServiceClient) {
 	}
 	fmt.Printf("A: %02X  X: %02X  Y: %02X  SP: %02X  PC: %04X  Status: %02b\n",
 		state.A, state.X, state.Y, state.Sp, state.Pc, state.Status)
+	fmt.Printf("Frame: %d  Scanline: %d  Dot: %d  CPU cycles: %d  PPU dots: %d\n",
+		state.Frame, state.Scanline, state.Dot, state.CpuCycles, state.PpuCycles)
+	if state.Jammed {
+		fmt.Println("CPU is jammed on the JAM at PC; only a reset frees it")
+	}
+	if r := regionAt(client, uint16(state.Pc)); r != nil && r.Kind == "prg-rom" && r.Offset >= 0 {
+		fmt.Printf("PC is in PRG ROM bank %d (offset $%05X)\n", r.Bank, r.Offset+int32(state.Pc-r.Start))
+	}
 }
 
 func printHexDump(startAddr uint16, data []byte) {
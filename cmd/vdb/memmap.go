@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+var regionNames = map[string]string{
+	"ram":     "RAM",
+	"ppu":     "PPU registers",
+	"io":      "APU and I/O registers",
+	"prg-ram": "PRG RAM",
+	"prg-rom": "PRG ROM",
+	"cart":    "Cartridge",
+	"open":    "Open bus",
+}
+
+// describeRegion names a region and, for PRG windows, the bank mapped into it.
+func describeRegion(r *api.MemoryRegion) string {
+	desc := regionNames[r.Kind]
+	if desc == "" {
+		desc = r.Kind
+	}
+	switch {
+	case r.Mirror != 0:
+		desc += fmt.Sprintf(", %d bytes mirrored", r.Mirror)
+	case r.Kind == "prg-rom" && r.Offset >= 0:
+		desc += fmt.Sprintf(" bank %d (offset $%05X)", r.Bank, r.Offset)
+	case r.Kind == "prg-ram" && r.Offset > 0:
+		desc += fmt.Sprintf(" bank %d", r.Bank)
+	}
+	return desc
+}
+
+func printMemoryMap(client api.ControllerServiceClient) {
+	m, err := client.GetMemoryMap(context.Background(), &api.Empty{})
+	if err != nil {
+		fmt.Printf("Error getting memory map: %v\n", err)
+		return
+	}
+	for _, r := range m.Regions {
+		fmt.Printf("$%04X-$%04X  %s\n", r.Start, r.End, describeRegion(r))
+	}
+}
+
+// regionAt returns the region holding addr, or nil if the map can't be read.
+func regionAt(client api.ControllerServiceClient, addr uint16) *api.MemoryRegion {
+	m, err := client.GetMemoryMap(context.Background(), &api.Empty{})
+	if err != nil {
+		return nil
+	}
+	syms.regions = m.Regions
+	for _, r := range m.Regions {
+		if uint32(addr) >= r.Start && uint32(addr) <= r.End {
+			return r
+		}
+	}
+	return nil
+}
+
+// updateBanks reads which banks are mapped, when there are bank-aware labels to choose.
+func updateBanks(client api.ControllerServiceClient) {
+	if !syms.hasBanks() {
+		return
+	}
+	if m, err := client.GetMemoryMap(context.Background(), &api.Empty{}); err == nil {
+		syms.regions = m.Regions
+	}
+}
+
+// examine dumps count bytes from addr, headed by the region they start in.
+func examine(client api.ControllerServiceClient, addr uint16, count int) {
+	res, err := client.ReadMemoryBlock(context.Background(), &api.MemoryBlockRequest{
+		Address: uint32(addr),
+		Size:    uint32(count),
+	})
+	if err != nil {
+		fmt.Printf("Error reading memory: %v\n", err)
+		return
+	}
+	if r := regionAt(client, addr); r != nil {
+		fmt.Printf("$%04X-$%04X: %s\n", r.Start, r.End, describeRegion(r))
+	}
+	printHexDump(addr, res.Data)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/meadori/vibemulator/api"
+	"github.com/meadori/vibemulator/script"
+)
+
+const movieUsage = "Usage: movie load <file> | movie seek <frame> | movie truncate | movie insert <p1> [p2] [frames] | movie save <file> | movie status"
+
+// movieCommand runs the movie editing subcommands; args excludes the word "movie".
+// The movie lives on the server, so edits replay it there to keep the emulator on the
+// frame being edited.
+func movieCommand(client api.ControllerServiceClient, args []string) {
+	if len(args) == 0 {
+		fmt.Println(movieUsage)
+		return
+	}
+
+	var res *api.MovieResponse
+	var err error
+	switch args[0] {
+	case "load":
+		if len(args) != 2 {
+			fmt.Println("Usage: movie load <file>")
+			return
+		}
+		data, rerr := os.ReadFile(args[1])
+		if rerr != nil {
+			fmt.Printf("Error: %v\n", rerr)
+			return
+		}
+		res, err = client.EditMovie(context.Background(), &api.MovieRequest{Movie: data})
+	case "seek":
+		if len(args) != 2 {
+			fmt.Println("Usage: movie seek <frame>")
+			return
+		}
+		frame, perr := strconv.ParseUint(args[1], 10, 32)
+		if perr != nil {
+			fmt.Printf("Invalid frame: %s\n", args[1])
+			return
+		}
+		res, err = client.SeekMovie(context.Background(), &api.MovieSeekRequest{Frame: uint32(frame)})
+	case "truncate":
+		res, err = client.TruncateMovie(context.Background(), &api.Empty{})
+	case "insert":
+		req, perr := parseMovieInsert(args[1:])
+		if perr != nil {
+			fmt.Printf("Error: %v\n", perr)
+			fmt.Println("Usage: movie insert <p1> [p2] [frames], e.g. movie insert A+RIGHT NONE 3")
+			return
+		}
+		res, err = client.InsertMovieInput(context.Background(), req)
+	case "save":
+		if len(args) != 2 {
+			fmt.Println("Usage: movie save <file>")
+			return
+		}
+		if res, err = client.SaveMovie(context.Background(), &api.MovieRequest{}); err == nil {
+			if err = os.WriteFile(args[1], res.Movie, 0644); err == nil {
+				fmt.Printf("Saved %s.\n", args[1])
+			}
+		}
+	case "status":
+		res, err = client.MovieStatus(context.Background(), &api.Empty{})
+	default:
+		fmt.Println(movieUsage)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Movie frame %d of %d, frame hash %016X\n", res.Position, res.Frames+1, res.FrameHash)
+}
+
+// parseMovieInsert reads "<p1> [p2] [frames]", with buttons written as in input scripts,
+// e.g. "A+RIGHT" or "NONE".
+func parseMovieInsert(args []string) (*api.StepRequest, error) {
+	req := &api.StepRequest{Frames: 1}
+	if n := len(args); n > 0 {
+		if frames, err := strconv.ParseUint(args[n-1], 10, 32); err == nil {
+			req.Frames = uint32(max(frames, 1))
+			args = args[:n-1]
+		}
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return nil, fmt.Errorf("expected buttons for one or two players")
+	}
+	players := []**api.InputState{&req.P1, &req.P2}
+	for i, s := range args {
+		b, err := script.ParseButtons(s)
+		if err != nil {
+			return nil, err
+		}
+		*players[i] = &api.InputState{PlayerIndex: int32(i + 1), A: b[0], B: b[1], Select: b[2], Start: b[3], Up: b[4], Down: b[5], Left: b[6], Right: b[7]}
+	}
+	return req, nil
+}
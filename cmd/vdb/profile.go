@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+const profileUsage = "Usage: profile start | profile stop [n] | profile report [n]"
+
+// profileTop is how many addresses a report lists by default
+const profileTop = 10
+
+// profileCommand runs the profile subcommands; args excludes the word "profile".
+func profileCommand(client api.ControllerServiceClient, args []string) {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Println(profileUsage)
+		return
+	}
+	top := profileTop
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			fmt.Printf("Invalid count: %s\n", args[1])
+			return
+		}
+		top = n
+	}
+
+	var report *api.ProfileReport
+	var err error
+	switch args[0] {
+	case "start":
+		if _, err := client.StartProfile(context.Background(), &api.Empty{}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Println("Profiling started.")
+		return
+	case "stop":
+		report, err = client.StopProfile(context.Background(), &api.Empty{})
+	case "report":
+		report, err = client.GetProfile(context.Background(), &api.Empty{})
+	default:
+		fmt.Println(profileUsage)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printProfile(report, top)
+}
+
+func printProfile(report *api.ProfileReport, top int) {
+	state := ""
+	if report.Running {
+		state = " (still running)"
+	}
+	fmt.Printf("%d instructions over %d frames%s\n", report.Instructions, report.Frames, state)
+	if report.Instructions == 0 {
+		return
+	}
+
+	fmt.Println("\nHottest instructions:")
+	fmt.Println("       count       %  address")
+	for _, e := range report.Pcs[:min(top, len(report.Pcs))] {
+		pct := 100 * float64(e.Count) / float64(report.Instructions)
+		fmt.Printf("%12d  %5.1f%%  %s\n", e.Count, pct, profileLocation(uint16(e.Address)))
+	}
+
+	if len(report.Subroutines) == 0 {
+		return
+	}
+	fmt.Println("\nMost called subroutines:")
+	fmt.Println("       calls  address")
+	for _, e := range report.Subroutines[:min(top, len(report.Subroutines))] {
+		fmt.Printf("%12d  %s\n", e.Count, profileLocation(uint16(e.Address)))
+	}
+}
+
+// profileLocation formats an address with its label, or the nearest one before it.
+func profileLocation(addr uint16) string {
+	loc := fmt.Sprintf("$%04X", addr)
+	if name, offset, ok := syms.nearest(addr); ok && offset == 0 {
+		loc += " <" + name + ">"
+	} else if ok {
+		loc += fmt.Sprintf(" <%s+%d>", name, offset)
+	}
+	return loc
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+// maxSourceDepth stops scripts that source themselves from recursing forever
+const maxSourceDepth = 8
+
+var sourceDepth int
+
+// initFile returns the path of the script run at startup, or "" if there is no home directory.
+func initFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".vdbinit")
+}
+
+// expandHome replaces a leading ~/ with the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// source runs each line of a script as a vdb command. Blank lines and lines starting
+// with # are skipped. It reports whether the debugger should keep going, which is false
+// once the script runs quit.
+func source(client api.ControllerServiceClient, path string) bool {
+	if sourceDepth >= maxSourceDepth {
+		fmt.Printf("Scripts nested too deeply, not sourcing %s\n", path)
+		return true
+	}
+	f, err := os.Open(expandHome(path))
+	if err != nil {
+		fmt.Printf("Error: failed to open script: %v\n", err)
+		return true
+	}
+	defer f.Close()
+
+	sourceDepth++
+	defer func() { sourceDepth-- }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if !execute(client, scanner.Text()) {
+			return false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Printf("Error: failed to read script: %v\n", err)
+	}
+	return true
+}
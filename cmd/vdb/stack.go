@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+// jsrOpcode is the 6502 JSR absolute instruction
+const jsrOpcode = 0x20
+
+// readStack returns the CPU state and the bytes in use on the stack, from $0100+SP+1 up to $01FF.
+func readStack(client api.ControllerServiceClient) (*api.CPUStateResponse, []byte, error) {
+	state, err := client.GetCPUState(context.Background(), &api.Empty{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error getting CPU state: %v", err)
+	}
+	top := 0x0100 + state.Sp + 1
+	if top > 0x01FF {
+		return state, nil, nil
+	}
+	res, err := client.ReadMemoryBlock(context.Background(), &api.MemoryBlockRequest{
+		Address: top,
+		Size:    0x0200 - top,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error reading stack: %v", err)
+	}
+	return state, res.Data, nil
+}
+
+func printStack(client api.ControllerServiceClient) {
+	state, stack, err := readStack(client)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if len(stack) == 0 {
+		fmt.Printf("Stack is empty (SP: %02X).\n", state.Sp)
+		return
+	}
+	printHexDump(uint16(0x0100+state.Sp+1), stack)
+}
+
+// printBacktrace walks the stack looking for JSR return addresses. Anything pushed with PHA
+// or by an interrupt can hide or fake a frame, so this is only a best effort.
+func printBacktrace(client api.ControllerServiceClient) {
+	state, stack, err := readStack(client)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	updateBanks(client)
+	pc := uint16(state.Pc)
+	frame := 0
+	for i := 0; i+1 < len(stack); i++ {
+		// JSR pushes the address of its own last byte, high byte first
+		ret := uint16(stack[i+1])<<8 | uint16(stack[i])
+		site := ret - 2
+		target, ok := jsrTarget(client, site)
+		if !ok {
+			continue
+		}
+		fmt.Printf("#%-2d $%04X in %s\n", frame, pc, symbolize(target, 4))
+		pc = site
+		frame++
+		i++
+	}
+	fmt.Printf("#%-2d $%04X\n", frame, pc)
+}
+
+// jsrTarget returns the subroutine called by the JSR at addr, if there is one. Only RAM and
+// cartridge space are read, since reading PPU or APU registers has side effects.
+func jsrTarget(client api.ControllerServiceClient, addr uint16) (uint16, bool) {
+	if addr >= 0x2000 && addr < 0x4020 || addr > 0xFFFD {
+		return 0, false
+	}
+	res, err := client.ReadMemoryBlock(context.Background(), &api.MemoryBlockRequest{
+		Address: uint32(addr),
+		Size:    3,
+	})
+	if err != nil || len(res.Data) != 3 || res.Data[0] != jsrOpcode {
+		return 0, false
+	}
+	return uint16(res.Data[2])<<8 | uint16(res.Data[1]), true
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/meadori/vibemulator/api"
+)
+
+// hardwareSymbols names the PPU, APU and I/O registers so they are symbolized without a label file.
+var hardwareSymbols = map[uint16]string{
+	0x2000: "PPUCTRL", 0x2001: "PPUMASK", 0x2002: "PPUSTATUS", 0x2003: "OAMADDR",
+	0x2004: "OAMDATA", 0x2005: "PPUSCROLL", 0x2006: "PPUADDR", 0x2007: "PPUDATA",
+	0x4000: "SQ1_VOL", 0x4001: "SQ1_SWEEP", 0x4002: "SQ1_LO", 0x4003: "SQ1_HI",
+	0x4004: "SQ2_VOL", 0x4005: "SQ2_SWEEP", 0x4006: "SQ2_LO", 0x4007: "SQ2_HI",
+	0x4008: "TRI_LINEAR", 0x400A: "TRI_LO", 0x400B: "TRI_HI",
+	0x400C: "NOISE_VOL", 0x400E: "NOISE_LO", 0x400F: "NOISE_HI",
+	0x4010: "DMC_FREQ", 0x4011: "DMC_RAW", 0x4012: "DMC_START", 0x4013: "DMC_LEN",
+	0x4014: "OAMDMA", 0x4015: "SND_CHN", 0x4016: "JOY1", 0x4017: "JOY2",
+}
+
+// fceuxBankSize is the size of the PRG ROM banks FCEUX numbers its .nl files by.
+const fceuxBankSize = 0x4000
+
+// symbolTable maps addresses to labels and back. Labels from an FCEUX bank file
+// (game.nes.3.nl) apply only while that bank of PRG ROM is mapped.
+type symbolTable struct {
+	names  map[uint16]string
+	banked map[bankedAddr]string
+	addrs  map[string]uint16
+
+	// The memory map as of the last stop, to tell which banks are mapped
+	regions []*api.MemoryRegion
+}
+
+// bankedAddr is a CPU address within a bank of PRG ROM.
+type bankedAddr struct {
+	bank int
+	addr uint16
+}
+
+// syms holds the hardware registers plus any labels loaded with -symbols or the symbols command.
+var syms = newSymbolTable()
+
+func newSymbolTable() *symbolTable {
+	t := &symbolTable{names: make(map[uint16]string), banked: make(map[bankedAddr]string), addrs: make(map[string]uint16)}
+	for addr, name := range hardwareSymbols {
+		t.add(addr, name)
+	}
+	return t
+}
+
+func (t *symbolTable) add(addr uint16, name string) {
+	if old, ok := t.names[addr]; ok {
+		delete(t.addrs, old)
+	}
+	t.names[addr] = name
+	t.addrs[name] = addr
+}
+
+func (t *symbolTable) addBanked(bank int, addr uint16, name string) {
+	t.banked[bankedAddr{bank, addr}] = name
+	t.addrs[name] = addr
+}
+
+// lookup returns the label at addr, preferring one for the bank mapped there.
+func (t *symbolTable) lookup(addr uint16) (string, bool) {
+	if bank, ok := t.bankAt(addr); ok {
+		if name, ok := t.banked[bankedAddr{bank, addr}]; ok {
+			return name, true
+		}
+	}
+	name, ok := t.names[addr]
+	return name, ok
+}
+
+// bankAt returns the bank of PRG ROM mapped at addr, as FCEUX numbers them.
+func (t *symbolTable) bankAt(addr uint16) (int, bool) {
+	if len(t.banked) == 0 {
+		return 0, false
+	}
+	for _, r := range t.regions {
+		if r.Kind == "prg-rom" && r.Offset >= 0 && uint32(addr) >= r.Start && uint32(addr) <= r.End {
+			return (int(r.Offset) + int(uint32(addr)-r.Start)) / fceuxBankSize, true
+		}
+	}
+	return 0, false
+}
+
+// hasBanks reports whether any labels depend on the banks mapped.
+func (t *symbolTable) hasBanks() bool {
+	return len(t.banked) != 0
+}
+
+// nearest returns the closest label at or below addr within 256 bytes, and the offset
+// from it, to place addresses inside routines that have no label of their own.
+func (t *symbolTable) nearest(addr uint16) (string, uint16, bool) {
+	for offset := uint16(0); offset <= 0xFF && offset <= addr; offset++ {
+		if name, ok := t.lookup(addr - offset); ok {
+			return name, offset, true
+		}
+	}
+	return "", 0, false
+}
+
+// resolve returns the address of a label.
+func (t *symbolTable) resolve(name string) (uint16, bool) {
+	addr, ok := t.addrs[name]
+	return addr, ok
+}
+
+// load reads a label file and returns the number of labels added. It understands the
+// VICE format written by ld65 -Ln ("al 00C000 .reset") and FCEUX .nl files ("$C000#reset#").
+// The labels in an FCEUX bank file, named for the bank in hex (game.nes.1F.nl), are
+// bank-aware.
+func (t *symbolTable) load(path string) (int, error) {
+	bank, banked := fceuxBank(path)
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open symbols: %v", err)
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var addrStr, name string
+		if fields := strings.Fields(line); fields[0] == "al" && len(fields) == 3 {
+			addrStr, name = fields[1], strings.TrimPrefix(fields[2], ".")
+		} else if nl := strings.Split(line, "#"); strings.HasPrefix(line, "$") && len(nl) >= 2 {
+			addrStr, name = strings.TrimPrefix(nl[0], "$"), nl[1]
+		} else {
+			return n, fmt.Errorf("failed to parse %s:%d: %q", path, lineNo, line)
+		}
+
+		addr, err := strconv.ParseUint(addrStr, 16, 32)
+		if err != nil || name == "" {
+			return n, fmt.Errorf("failed to parse %s:%d: %q", path, lineNo, line)
+		}
+		// ld65 writes 24-bit addresses; only the CPU address matters here
+		if banked {
+			t.addBanked(bank, uint16(addr), name)
+		} else {
+			t.add(uint16(addr), name)
+		}
+		n++
+	}
+	if err := scanner.Err(); err != nil {
+		return n, fmt.Errorf("failed to read symbols: %v", err)
+	}
+	return n, nil
+}
+
+// fceuxBank returns the bank an FCEUX bank file such as game.nes.1F.nl labels.
+func fceuxBank(path string) (int, bool) {
+	stem, ok := strings.CutSuffix(filepath.Base(path), ".nl")
+	if !ok {
+		return 0, false
+	}
+	bank, err := strconv.ParseUint(strings.TrimPrefix(filepath.Ext(stem), "."), 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return int(bank), true
+}
@@ -0,0 +1,121 @@
+// Package compat records how well ROMs run in the emulator, keyed by the ROM's SHA-1
+// like the storage folders. The emulator ships a curated list, compat.json, and players
+// add reports to a local list in the same format, which overrides it for their ROMs.
+// Contributing reports means merging a local list into compat.json:
+//
+//	{
+//	  "<sha1>": {"title": "Burger Time", "status": "minor", "notes": "Drops inputs with DPCM on"}
+//	}
+package compat
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/meadori/vibemulator/storage"
+)
+
+// Status is how well a ROM runs.
+type Status string
+
+const (
+	Playable Status = "playable"
+	Minor    Status = "minor" // Playable, with minor glitches
+	Broken   Status = "broken"
+)
+
+// Valid reports whether s is one of the known statuses.
+func (s Status) Valid() bool {
+	return s == Playable || s == Minor || s == Broken
+}
+
+// Describe returns s as the emulator shows it.
+func (s Status) Describe() string {
+	if s == Minor {
+		return "minor issues"
+	}
+	return string(s)
+}
+
+// Entry is the report for one ROM.
+type Entry struct {
+	Title  string `json:"title,omitempty"`
+	Status Status `json:"status"`
+	Notes  string `json:"notes,omitempty"`
+}
+
+// List maps ROM SHA-1s, in lower-case hex, to their reports.
+type List map[string]Entry
+
+//go:embed compat.json
+var builtinJSON []byte
+
+// Builtin returns the list shipped with the emulator. Don't modify it.
+var Builtin = sync.OnceValue(func() List {
+	l, err := parse(builtinJSON)
+	if err != nil {
+		panic(fmt.Sprintf("compat.json: %v", err))
+	}
+	return l
+})
+
+// Load reads the list at path. A missing file is an empty list.
+func Load(path string) (List, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return List{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return l, nil
+}
+
+func parse(data []byte) (List, error) {
+	l := List{}
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	for hash, e := range l {
+		if !e.Status.Valid() {
+			return nil, fmt.Errorf("%s has unknown status %q", hash, e.Status)
+		}
+	}
+	return l, nil
+}
+
+// Lookup returns the report for hash from local, or else from the shipped list.
+func Lookup(local List, hash string) (Entry, bool) {
+	if e, ok := local[hash]; ok {
+		return e, true
+	}
+	e, ok := Builtin()[hash]
+	return e, ok
+}
+
+// Report records e for hash in the local list at path, replacing any earlier report
+// for the ROM.
+func Report(path, hash string, e Entry) error {
+	if !e.Status.Valid() {
+		return fmt.Errorf("unknown status %q", e.Status)
+	}
+	l, err := Load(path)
+	if err != nil {
+		return err
+	}
+	l[hash] = e
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode reports: %v", err)
+	}
+	return storage.WriteFile(path, append(data, '\n'))
+}
@@ -0,0 +1,55 @@
+package compat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compat.json")
+	if l, err := Load(path); err != nil || len(l) != 0 {
+		t.Fatalf("Expected a missing list to be empty, got %v, %v", l, err)
+	}
+
+	if err := Report(path, "aaaa", Entry{Title: "Game A", Status: Broken}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Report(path, "bbbb", Entry{Title: "Game B", Status: Minor}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Report(path, "aaaa", Entry{Title: "Game A", Status: Playable}); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := Lookup(l, "aaaa"); !ok || e.Status != Playable {
+		t.Errorf("Expected the later report for Game A to replace the first, got %+v", e)
+	}
+	if e, ok := Lookup(l, "bbbb"); !ok || e.Status != Minor || e.Title != "Game B" {
+		t.Errorf("Expected Game B's report, got %+v", e)
+	}
+	if _, ok := Lookup(l, "cccc"); ok {
+		t.Error("Expected no report for an unknown ROM")
+	}
+}
+
+func TestLoadRejectsUnknownStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compat.json")
+	if err := os.WriteFile(path, []byte(`{"aaaa": {"status": "great"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an unknown status")
+	}
+	if err := Report(path, "aaaa", Entry{Status: "great"}); err == nil {
+		t.Error("Expected Report to refuse an unknown status")
+	}
+}
+
+func TestBuiltinParses(t *testing.T) {
+	Builtin()
+}
@@ -0,0 +1,285 @@
+// Package config loads and saves the vibemulator settings file, by default
+// ~/.config/vibemulator/config.toml. A missing file or key takes its value from Default,
+// so the file only needs the settings that differ:
+//
+//	[video]
+//	scale = 2.0
+//	scanlines = false
+//
+//	[input.p1]
+//	a = "J"
+//	b = "K"
+//
+//	[grpc]
+//	addr = "localhost:50051"
+//
+// Command-line flags override the file for a single run; the in-game Settings screen
+// writes its changes back to it.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/meadori/vibemulator/macro"
+)
+
+// Config holds every user-adjustable setting.
+type Config struct {
+	Video     Video     `toml:"video"`
+	Audio     Audio     `toml:"audio"`
+	Input     Input     `toml:"input"`
+	Paths     Paths     `toml:"paths"`
+	GRPC      GRPC      `toml:"grpc"`
+	Rewind    Rewind    `toml:"rewind"`
+	Autosave  Autosave  `toml:"autosave"`
+	Emulation Emulation `toml:"emulation"`
+	Macros    []Macro   `toml:"macro"`
+}
+
+type Video struct {
+	Scale      float64 `toml:"scale"` // Window size relative to the 1024x1024 bezel
+	Scanlines  bool    `toml:"scanlines"`
+	Fullscreen bool    `toml:"fullscreen"`
+	Blend      string  `toml:"blend"`      // One of Blends, to smooth sprite flicker
+	FrameRate  float64 `toml:"frame_rate"` // Emulated frames a second, e.g. 60 or 50; 0 is uncapped
+	VSync      bool    `toml:"vsync"`      // Off, with frame_rate 0, runs as fast as the host can
+}
+
+// Frame blending modes: none, an even mix of each frame with the one before, or a
+// phosphor glow that fades over a few frames.
+const (
+	BlendOff      = "off"
+	BlendMix      = "mix"
+	BlendPhosphor = "phosphor"
+)
+
+// Blends lists the frame blending modes in the order the Settings screen cycles them.
+var Blends = []string{BlendOff, BlendMix, BlendPhosphor}
+
+type Audio struct {
+	SampleRate int     `toml:"sample_rate"`
+	Volume     float64 `toml:"volume"` // 0 (muted) to 1
+}
+
+// Input maps each controller's buttons to keyboard keys.
+type Input struct {
+	P1 Buttons `toml:"p1"`
+	P2 Buttons `toml:"p2"`
+}
+
+// Macro binds a key to a sequence of inputs for one controller, in the step format of
+// package macro, e.g. ["DOWN", "DOWN+RIGHT", "RIGHT+B 2"].
+type Macro struct {
+	Key    string   `toml:"key"`
+	Player int      `toml:"player"`
+	Steps  []string `toml:"steps"`
+}
+
+// Buttons names the key for each button, using ebiten key names such as "Z", "Shift"
+// or "ArrowUp".
+type Buttons struct {
+	A      string `toml:"a"`
+	B      string `toml:"b"`
+	Select string `toml:"select"`
+	Start  string `toml:"start"`
+	Up     string `toml:"up"`
+	Down   string `toml:"down"`
+	Left   string `toml:"left"`
+	Right  string `toml:"right"`
+}
+
+// Keys returns the key names in controller order: A, B, Select, Start, Up, Down, Left, Right.
+func (b Buttons) Keys() [8]string {
+	return [8]string{b.A, b.B, b.Select, b.Start, b.Up, b.Down, b.Left, b.Right}
+}
+
+// Paths locates the emulator's files. Empty paths use the per-ROM folders of the
+// storage package.
+type Paths struct {
+	DataDir   string `toml:"data_dir"`   // Saves and screenshots; empty for the platform's data directory
+	SaveState string `toml:"save_state"` // One savestate file for F5 and F7 shared by every ROM
+	ROMDir    string `toml:"rom_dir"`    // Directory the Load dialog opens in
+}
+
+// GRPC configures the remote-control servers. An empty HTTPAddr disables the HTTP gateway.
+type GRPC struct {
+	Addr     string `toml:"addr"`
+	HTTPAddr string `toml:"http_addr"`
+	TLSCert  string `toml:"tls_cert"`
+	TLSKey   string `toml:"tls_key"`
+	ClientCA string `toml:"client_ca"`
+	Token    string `toml:"token"`
+
+	// Pages on other origins, e.g. "https://example.com", that may open the /ws WebSocket
+	AllowedOrigins []string `toml:"allowed_origins"`
+
+	MaxSessions int `toml:"max_sessions"` // Headless sessions CreateSession may keep; 0 for no limit
+	SessionIdle int `toml:"session_idle"` // Seconds an unused session lives; 0 keeps it until destroyed
+}
+
+type Rewind struct {
+	Enabled bool `toml:"enabled"`
+	Seconds int  `toml:"seconds"` // History kept, at one snapshot per frame
+}
+
+// Autosave periodically saves the game to the ROM's autosave file, which the next launch
+// of the ROM offers to resume from.
+type Autosave struct {
+	Enabled bool `toml:"enabled"`
+	Seconds int  `toml:"seconds"` // Time between autosaves
+}
+
+// Emulation turns off hardware quirks that get in the way. Every option defaults to
+// behaving like the console.
+type Emulation struct {
+	FilterDPCMConflicts bool `toml:"filter_dpcm_conflicts"` // Stop DMC sample fetches corrupting controller reads
+	SkipPPUWarmup       bool `toml:"skip_ppu_warmup"`       // Accept PPU writes straight after power-on
+}
+
+// Frames returns how many snapshots the rewind history holds.
+func (r Rewind) Frames() int {
+	return r.Seconds * 60
+}
+
+// Default returns the settings used when the file doesn't set them.
+func Default() Config {
+	return Config{
+		Video: Video{Scale: 1.5, Scanlines: true, Blend: BlendOff, FrameRate: 60, VSync: true},
+		Audio: Audio{SampleRate: 44100, Volume: 1},
+		Input: Input{
+			P1: Buttons{A: "Z", B: "X", Select: "Shift", Start: "Enter", Up: "ArrowUp", Down: "ArrowDown", Left: "ArrowLeft", Right: "ArrowRight"},
+			P2: Buttons{A: "I", B: "U", Select: "Y", Start: "H", Up: "W", Down: "S", Left: "A", Right: "D"},
+		},
+		GRPC:     GRPC{Addr: ":50051", MaxSessions: 16, SessionIdle: 600},
+		Rewind:   Rewind{Enabled: true, Seconds: 20},
+		Autosave: Autosave{Enabled: true, Seconds: 30},
+	}
+}
+
+// DefaultPath returns the settings file in the user's configuration directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vibemulator", "config.toml"), nil
+}
+
+// Load reads the settings file at path over the defaults. A missing file is not an
+// error, but unknown keys are, so typos don't go unnoticed.
+func Load(path string) (Config, error) {
+	c := Default()
+	md, err := toml.DecodeFile(path, &c)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return c, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return c, fmt.Errorf("unknown settings in %s: %s", path, strings.Join(keys, ", "))
+	}
+	if err := c.Validate(); err != nil {
+		return c, fmt.Errorf("invalid settings in %s: %v", path, err)
+	}
+	return c, nil
+}
+
+// Save writes c to path, creating its directory. The file is replaced atomically so a
+// crash never leaves it half written.
+func Save(path string, c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return fmt.Errorf("failed to encode settings: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create settings directory: %v", err)
+	}
+	// The file may hold the gRPC token, so keep it private
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.toml")
+	if err != nil {
+		return fmt.Errorf("failed to write settings: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write settings: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write settings: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write settings: %v", err)
+	}
+	return nil
+}
+
+// Update applies change to the settings stored at path and saves them. It rereads the
+// file so that values overridden for this run by flags aren't written back.
+func Update(path string, change func(*Config)) error {
+	c, err := Load(path)
+	if err != nil {
+		return err
+	}
+	change(&c)
+	return Save(path, c)
+}
+
+// Validate reports the first setting that is out of range.
+func (c Config) Validate() error {
+	switch {
+	case c.Video.Scale < 0.25 || c.Video.Scale > 4:
+		return fmt.Errorf("video.scale %v is outside 0.25-4", c.Video.Scale)
+	case !slices.Contains(Blends, c.Video.Blend):
+		return fmt.Errorf("video.blend %q is not one of %s", c.Video.Blend, strings.Join(Blends, ", "))
+	case c.Video.FrameRate != 0 && (c.Video.FrameRate < 10 || c.Video.FrameRate > 1000):
+		return fmt.Errorf("video.frame_rate %v is outside 10-1000, or 0 for uncapped", c.Video.FrameRate)
+	case c.Audio.SampleRate < 8000 || c.Audio.SampleRate > 192000:
+		return fmt.Errorf("audio.sample_rate %d is outside 8000-192000", c.Audio.SampleRate)
+	case c.Audio.Volume < 0 || c.Audio.Volume > 1:
+		return fmt.Errorf("audio.volume %v is outside 0-1", c.Audio.Volume)
+	case c.Rewind.Seconds < 1 || c.Rewind.Seconds > 600:
+		return fmt.Errorf("rewind.seconds %d is outside 1-600", c.Rewind.Seconds)
+	case c.Autosave.Seconds < 5 || c.Autosave.Seconds > 3600:
+		return fmt.Errorf("autosave.seconds %d is outside 5-3600", c.Autosave.Seconds)
+	case c.GRPC.Addr == "":
+		return errors.New("grpc.addr is empty")
+	case (c.GRPC.TLSCert == "") != (c.GRPC.TLSKey == ""):
+		return errors.New("grpc.tls_cert and grpc.tls_key must be set together")
+	}
+	for i, m := range c.Macros {
+		switch {
+		case m.Key == "":
+			return fmt.Errorf("macro %d has no key", i+1)
+		case m.Player != 1 && m.Player != 2:
+			return fmt.Errorf("macro %d is for player %d, not 1 or 2", i+1, m.Player)
+		}
+		if _, err := macro.ParseSteps(m.Steps); err != nil {
+			return fmt.Errorf("macro %d: %v", i+1, err)
+		}
+	}
+	for i, b := range []Buttons{c.Input.P1, c.Input.P2} {
+		for _, k := range b.Keys() {
+			if k == "" {
+				return fmt.Errorf("input.p%d has a button with no key", i+1)
+			}
+		}
+	}
+	return nil
+}
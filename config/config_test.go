@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(text), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("Expected a missing file to load the defaults, got %v", err)
+	}
+	if !reflect.DeepEqual(c, Default()) {
+		t.Errorf("Expected the defaults, got %+v", c)
+	}
+}
+
+func TestLoadOverridesDefaults(t *testing.T) {
+	path := writeConfig(t, `
+[video]
+scale = 2.0
+
+[input.p1]
+a = "J"
+
+[rewind]
+seconds = 30
+`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Default()
+	want.Video.Scale = 2
+	want.Input.P1.A = "J"
+	want.Rewind.Seconds = 30
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("Expected %+v, got %+v", want, c)
+	}
+	if c.Rewind.Frames() != 1800 {
+		t.Errorf("Expected 1800 rewind frames, got %d", c.Rewind.Frames())
+	}
+}
+
+func TestLoadRejectsBadSettings(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"unknown key", "[video]\nscael = 2.0\n", "video.scael"},
+		{"out of range", "[audio]\nvolume = 2.0\n", "audio.volume"},
+		{"blend mode", "[video]\nblend = \"smear\"\n", "video.blend"},
+		{"frame rate", "[video]\nframe_rate = 5\n", "video.frame_rate"},
+		{"autosave too often", "[autosave]\nseconds = 1\n", "autosave.seconds"},
+		{"half of a TLS pair", "[grpc]\ntls_cert = \"cert.pem\"\n", "tls_key"},
+		{"empty key", "[input.p2]\nstart = \"\"\n", "input.p2"},
+		{"macro player", "[[macro]]\nkey = \"Q\"\nplayer = 3\nsteps = [\"A\"]\n", "macro 1"},
+		{"macro step", "[[macro]]\nkey = \"Q\"\nplayer = 1\nsteps = [\"JUMP\"]\n", "macro 1"},
+		{"syntax", "[video\n", "failed to read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.text))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vibemulator", "config.toml")
+	c := Default()
+	c.Video.Scanlines = false
+	c.Video.Blend = BlendPhosphor
+	c.Video.FrameRate = 50
+	c.Paths.ROMDir = "/roms"
+	c.Macros = []Macro{{Key: "Q", Player: 2, Steps: []string{"DOWN", "B+RIGHT 2"}}}
+	if err := Save(path, c); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("Expected %+v after a round trip, got %+v", c, got)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0600 {
+		t.Errorf("Expected a private settings file, got %v (err=%v)", fi.Mode(), err)
+	}
+}
+
+func TestUpdateKeepsOtherSettings(t *testing.T) {
+	path := writeConfig(t, "[grpc]\naddr = \"localhost:6000\"\n")
+	if err := Update(path, func(c *Config) { c.Audio.Volume = 0.5 }); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Audio.Volume != 0.5 || c.GRPC.Addr != "localhost:6000" {
+		t.Errorf("Expected volume 0.5 and the stored gRPC address, got %v and %q", c.Audio.Volume, c.GRPC.Addr)
+	}
+}
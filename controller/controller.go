@@ -17,6 +17,11 @@ func (c *Controller) SetButtons(buttons [8]bool) {
 	c.buttons = buttons
 }
 
+// Buttons returns the current state of the controller's buttons.
+func (c *Controller) Buttons() [8]bool {
+	return c.buttons
+}
+
 // Write handles CPU writes to the controller register ($4016 or $4017).
 func (c *Controller) Write(data byte) {
 	c.strobe = data & 1
@@ -25,21 +30,32 @@ func (c *Controller) Write(data byte) {
 	}
 }
 
-// Read handles CPU reads from the controller register.
+// Read handles CPU reads from the controller register. Only bit 0 is driven; the bus
+// supplies the other bits.
 func (c *Controller) Read() byte {
-	if c.index >= 8 {
-		return 1 // After the 8 main buttons, standard controllers return 1.
+	value := c.DebugRead()
+	// If strobe is low, the shift register is advanced on each read.
+	if c.strobe == 0 && c.index < 8 {
+		c.index++
 	}
+	return value
+}
 
-	value := byte(0)
-	if c.buttons[c.index] {
-		value = 1
+// DebugRead returns the bit the next Read would, without advancing the shift register.
+func (c *Controller) DebugRead() byte {
+	switch {
+	case c.strobe == 1:
+		// The shift register keeps reloading, so every read sees A
+		return boolToBit(c.buttons[0])
+	case c.index >= 8:
+		return 1 // After the 8 main buttons, standard controllers return 1.
 	}
+	return boolToBit(c.buttons[c.index])
+}
 
-	// If strobe is low, the shift register is advanced on each read.
-	if c.strobe == 0 {
-		c.index++
+func boolToBit(b bool) byte {
+	if b {
+		return 1
 	}
-
-	return value
+	return 0
 }
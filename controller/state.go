@@ -0,0 +1,36 @@
+package controller
+
+import "github.com/meadori/vibemulator/snap"
+
+type State struct {
+	Buttons       [8]bool
+	Index, Strobe byte
+}
+
+func (c *Controller) SaveState() State {
+	return State{c.buttons, c.index, c.strobe}
+}
+
+func (c *Controller) LoadState(s State) {
+	c.buttons, c.index, c.strobe = s.Buttons, s.Index, s.Strobe
+}
+
+// Snapshot writes the state saved by SaveState in snap's flat encoding.
+func (c *Controller) Snapshot(w *snap.Writer) {
+	s := c.SaveState()
+	for _, v := range s.Buttons {
+		w.Bool(v)
+	}
+	w.U8(s.Index)
+	w.U8(s.Strobe)
+}
+
+// Restore loads a state written by Snapshot.
+func (c *Controller) Restore(r *snap.Reader) {
+	var s State
+	for i := range s.Buttons {
+		s.Buttons[i] = r.Bool()
+	}
+	s.Index, s.Strobe = r.U8(), r.U8()
+	c.LoadState(s)
+}
@@ -0,0 +1,47 @@
+package cpu
+
+import "testing"
+
+// benchProgram mixes loads, stores, arithmetic, indexed addressing and branches.
+var benchProgram = []byte{
+	0xA2, 0x00, // loop: LDX #$00
+	0xBD, 0x00, 0x02, // inner: LDA $0200,X
+	0x69, 0x01, // ADC #$01
+	0x9D, 0x00, 0x03, // STA $0300,X
+	0x91, 0x10, // STA ($10),Y
+	0xE8,       // INX
+	0xD0, 0xF4, // BNE inner
+	0x4C, 0x00, 0x80, // JMP loop
+}
+
+func BenchmarkInstruction(b *testing.B) {
+	c := New(nil)
+	bus := &mockBus{}
+	copy(bus.ram[0x8000:], benchProgram)
+	bus.ram[0xFFFC], bus.ram[0xFFFD] = 0x00, 0x80
+	c.ConnectBus(bus)
+	c.Reset()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		// Clock through exactly one instruction
+		c.Clock()
+		for c.Cycles > 0 {
+			c.Clock()
+		}
+	}
+}
+
+func TestInstructionAllocs(t *testing.T) {
+	c, bus := setupCPU(t)
+	copy(bus.ram[0x8000:], benchProgram)
+	step := func() {
+		c.Clock()
+		for c.Cycles > 0 {
+			c.Clock()
+		}
+	}
+	if allocs := testing.AllocsPerRun(1000, step); allocs != 0 {
+		t.Errorf("Expected instruction dispatch not to allocate, got %v allocations per instruction", allocs)
+	}
+}
@@ -1,18 +1,13 @@
 package cpu
 
 import (
+	"context"
 	"fmt"
-)
-
-// Declare logDebug function from main package
-var LogDebug func(format string, a ...interface{})
+	"log/slog"
+	"sync/atomic"
 
-// safeLogDebug calls LogDebug if it's not nil
-func safeLogDebug(format string, a ...interface{}) {
-	if LogDebug != nil {
-		LogDebug(format, a...)
-	}
-}
+	"github.com/meadori/vibemulator/logging"
+)
 
 // Bus defines the interface for the CPU to interact with the bus.
 type Bus interface {
@@ -41,17 +36,36 @@ type CPU struct {
 	P byte
 
 	bus Bus
+	log *slog.Logger
 
 	opcode byte
-	Cycles int // Exported
+	opPC   uint16 // Address of the instruction being executed
+	Cycles int    // Exported
+	Jammed bool   // A JAM has locked the CPU up until reset
 	Lookup [256]Instruction
 
 	fetched uint8
 	addrAbs uint16
 	addrRel uint16
 
-	nmiPending bool
-	irqPending bool
+	nmiLine    bool // Level last seen on the NMI line, for edge detection
+	nmiPending bool // NMI latched on a rising edge, taken before the next instruction
+	irqPending bool // Level of the IRQ line this cycle
+
+	// Cycle-stepped execution (see cycle.go)
+	instructionStepping bool
+	ops                 [256]cycleOp
+	step                int    // Cycle of the instruction or interrupt in progress, 0 between them
+	ptr                 uint16 // Pointer or base address an addressing mode is working from
+	poll                bool   // Whether an interrupt was asserted when last polled
+	interrupt           bool   // Whether the sequence in progress is an IRQ or NMI rather than BRK
+	latched             bool   // Whether fetched already holds the operand
+
+	trace *Trace // Optional record of the last instructions executed
+
+	// Breakpoints and watches (see debug.go)
+	debugHooks DebugHooks
+	debug      atomic.Pointer[debugStops]
 }
 
 // GetState returns the current values of the CPU registers for the VDB debugger.
@@ -59,15 +73,24 @@ func (c *CPU) GetState() (a, x, y, sp, p byte, pc uint16, cycles int) {
 	return c.A, c.X, c.Y, c.SP, c.P, c.PC, c.Cycles
 }
 
+// InstructionPC returns the address of the instruction currently (or most recently) executed.
+func (c *CPU) InstructionPC() uint16 {
+	return c.opPC
+}
+
 // IsInstructionComplete returns true if the CPU has finished executing the current instruction.
 func (c *CPU) IsInstructionComplete() bool {
-	return c.Cycles == 0
+	return c.Cycles == 0 && c.step == 0
 }
 
-// New creates a new CPU instance.
-func New() *CPU {
-	c := &CPU{}
+// New creates a new CPU instance that logs to log. A nil log discards everything.
+func New(log *slog.Logger) *CPU {
+	if log == nil {
+		log = slog.New(slog.DiscardHandler)
+	}
+	c := &CPU{log: log}
 	c.Lookup = c.createLookupTable()
+	c.ops = cycleOps(&c.Lookup)
 	return c
 }
 
@@ -79,10 +102,10 @@ func (c *CPU) ConnectBus(bus Bus) {
 // Reset resets the CPU to its initial state.
 func (c *CPU) Reset() {
 	c.addrAbs = 0xFFFC
-	lo := uint16(c.bus.Read(c.addrAbs))
-	hi := uint16(c.bus.Read(c.addrAbs + 1))
+	lo := uint16(c.read(c.addrAbs))
+	hi := uint16(c.read(c.addrAbs + 1))
 	c.PC = (hi << 8) | lo
-	safeLogDebug("CPU Reset: PC = %04X", c.PC)
+	c.log.Debug("reset", "pc", fmt.Sprintf("%04X", c.PC))
 
 	c.A = 0
 	c.X = 0
@@ -91,87 +114,144 @@ func (c *CPU) Reset() {
 	c.P = 0x00 | U
 	c.setFlag('I', true) // This sets the I flag
 	c.Cycles = 8         // Updated
+	c.nmiLine = false
 	c.nmiPending = false
 	c.irqPending = false
+	c.step = 0
+	c.poll = false
+	c.interrupt = false
+	c.Jammed = false
+}
+
+// AssertNMI pulls the non-maskable interrupt line active. The CPU latches an NMI when
+// the line goes active and takes it before its next instruction, however briefly the
+// line was held; it has to be released and asserted again for another. It returns true
+// when an NMI was latched.
+func (c *CPU) AssertNMI() bool {
+	edge := !c.nmiLine
+	c.nmiLine = true
+	if edge {
+		c.nmiPending = true
+	}
+	return edge
+}
+
+// ReleaseNMI lets the non-maskable interrupt line go inactive. An NMI already latched is
+// still taken.
+func (c *CPU) ReleaseNMI() {
+	c.nmiLine = false
 }
 
-// NMI is a non-maskable interrupt.
-func (c *CPU) NMI() {
-	c.nmiPending = true
+// NMIPending reports whether an NMI is latched but not yet taken.
+func (c *CPU) NMIPending() bool {
+	return c.nmiPending
 }
 
 func (c *CPU) processNMI() {
 	c.push(byte((c.PC >> 8) & 0x00FF))
 	c.push(byte(c.PC & 0x00FF))
 
-	c.setFlag('B', false)
-	c.setFlag('U', true)
+	// Push P as it was, with B clear, and only then mask IRQs
+	c.push(c.P&^B | U)
 	c.setFlag('I', true)
-	c.push(c.P)
 
 	c.addrAbs = 0xFFFA
-	lo := uint16(c.bus.Read(c.addrAbs))
-	hi := uint16(c.bus.Read(c.addrAbs + 1))
+	lo := uint16(c.read(c.addrAbs))
+	hi := uint16(c.read(c.addrAbs + 1))
 	c.PC = (hi << 8) | lo
 
 	c.Cycles = 8 // NMI takes 8 cycles
 	c.nmiPending = false
 }
 
-// IRQ is a maskable interrupt.
-func (c *CPU) IRQ() {
+// AssertIRQ pulls the maskable interrupt line active. It is level triggered: the CPU
+// samples it before each instruction and takes an IRQ while it is held and I is clear.
+func (c *CPU) AssertIRQ() {
 	c.irqPending = true
 }
 
+// ReleaseIRQ lets the maskable interrupt line go inactive, as when its source is
+// acknowledged. An IRQ released before the CPU samples the line is never taken.
+func (c *CPU) ReleaseIRQ() {
+	c.irqPending = false
+}
+
 func (c *CPU) processIRQ() {
 	// Push PC to stack
 	c.push(byte((c.PC >> 8) & 0x00FF))
 	c.push(byte(c.PC & 0x00FF))
 
 	// Push P to stack with B (Break) flag cleared and U (Unused) flag set
-	c.setFlag('B', false) // B flag should be 0 for IRQ
-	c.setFlag('U', true)  // U flag should be 1
-	c.push(c.P)
+	c.push(c.P&^B | U)
 
 	// Set Interrupt Disable flag
 	c.setFlag('I', true)
 
 	// Load PC from IRQ vector
 	c.addrAbs = 0xFFFE
-	lo := uint16(c.bus.Read(c.addrAbs))
-	hi := uint16(c.bus.Read(c.addrAbs + 1))
+	lo := uint16(c.read(c.addrAbs))
+	hi := uint16(c.read(c.addrAbs + 1))
 	c.PC = (hi << 8) | lo
 
 	c.Cycles = 7 // IRQ takes 7 cycles
-	c.irqPending = false
 }
 
 // LogState prints the current CPU state in a nestest-like format.
 func (c *CPU) LogState() string {
 	// PPU cycle count and total cycles are omitted for now as they are not directly available in CPU struct.
 	// P-register flags are displayed as a hex value.
-	return fmt.Sprintf("%04X A:%02X X:%02X Y:%02X P:%02X SP:%02X",
+	s := fmt.Sprintf("%04X A:%02X X:%02X Y:%02X P:%02X SP:%02X",
 		c.PC, c.A, c.X, c.Y, c.P, c.SP)
+	if c.Jammed {
+		s += " JAMMED"
+	}
+	return s
 }
 
 // Clock performs one clock cycle.
 func (c *CPU) Clock() {
-	safeLogDebug("CPU Clock")
+	// A jammed CPU fetches nothing and takes no interrupts; only a reset frees it
+	if c.Jammed && c.IsInstructionComplete() {
+		return
+	}
+	// Cycles left over from a reset, or from an instruction run all at once, are idled
+	// away the same in either mode
+	if c.step == 0 && (c.instructionStepping || c.Cycles > 0) {
+		c.clockInstruction()
+	} else {
+		c.clockCycle()
+	}
+	if c.IsInstructionComplete() {
+		c.checkBreakpoint()
+	}
+}
+
+// clockInstruction runs a whole instruction on its first cycle and idles for the rest.
+func (c *CPU) clockInstruction() {
 	if c.Cycles == 0 {
 		if c.nmiPending {
 			c.processNMI()
 		} else if c.irqPending && c.getFlag('I') == 0 {
 			c.processIRQ()
 		} else {
-			c.opcode = c.bus.Read(c.PC)
+			c.opPC = c.PC
+			c.opcode = c.read(c.PC)
 			c.PC++
-			safeLogDebug("CPU Clock: PC = %04X, Opcode = %02X", c.PC, c.opcode)
+			if c.log.Enabled(context.Background(), logging.LevelTrace) {
+				// Checked here so formatting the arguments doesn't allocate on every instruction
+				c.log.Log(context.Background(), logging.LevelTrace, "instruction",
+					"pc", fmt.Sprintf("%04X", c.opPC), "opcode", fmt.Sprintf("%02X", c.opcode))
+			}
+			if c.trace != nil {
+				c.trace.record(c)
+			}
 
 			instr := c.Lookup[c.opcode]
 			c.Cycles = instr.Cycles
 			addedCycle1 := instr.AddrMode()
 			addedCycle2 := instr.Operate()
-			c.Cycles += int(addedCycle1 + addedCycle2)
+			// Only reads pay for crossing a page; stores and read-modify-writes always take the long path
+			c.Cycles += int(addedCycle1 & addedCycle2)
 		}
 	}
 	if c.Cycles > 0 {
@@ -180,13 +260,13 @@ func (c *CPU) Clock() {
 }
 
 func (c *CPU) push(data byte) {
-	c.bus.Write(0x0100+uint16(c.SP), data)
+	c.write(0x0100+uint16(c.SP), data)
 	c.SP--
 }
 
 func (c *CPU) pop() byte {
 	c.SP++
-	return c.bus.Read(0x0100 + uint16(c.SP))
+	return c.read(0x0100 + uint16(c.SP))
 }
 
 // createLookupTable creates and returns the 6502 instruction lookup table.
@@ -225,8 +305,8 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0xBF: {"LAX", c.lax, c.aby, "aby", 4},
 		0xA3: {"LAX", c.lax, c.izx, "izx", 6},
 		0xB3: {"LAX", c.lax, c.izy, "izy", 5},
-		// Unofficial Load (ATX / LXA)
-		0xAB: {"ATX", c.atx, c.imm, "imm", 2},
+		// Unofficial Load (LXA / ATX)
+		0xAB: {"LXA", c.lxa, c.imm, "imm", 2},
 		// LDX
 		0xA2: {"LDX", c.ldx, c.imm, "imm", 2},
 		0xA6: {"LDX", c.ldx, c.zp0, "zp0", 3},
@@ -250,8 +330,8 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0x81: {"STA", c.sta, c.izx, "izx", 6},
 		0x91: {"STA", c.sta, c.izy, "izy", 6},
 
-		// Unofficial SYA (SHY) - absolute,X
-		0x9C: {"SYA", c.sya, c.abx, "abx", 5},
+		// Unofficial SHY (SYA) - absolute,X
+		0x9C: {"SHY", c.shy, c.abx, "abx", 5},
 
 		// STX
 		0x86: {"STX", c.stx, c.zp0, "zp0", 3},
@@ -269,8 +349,13 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0x8F: {"SAX", c.sax, c.abs, "abs", 4},
 		0x83: {"SAX", c.sax, c.izx, "izx", 6},
 
-		// Unofficial SXA (SHX) - absolute,Y
-		0x9E: {"SXA", c.sxa, c.aby, "aby", 5},
+		// Unofficial SHX (SXA) - absolute,Y
+		0x9E: {"SHX", c.shx, c.aby, "aby", 5},
+
+		// Unofficial SHA (AHX) and TAS (SHS)
+		0x9F: {"SHA", c.sha, c.aby, "aby", 5},
+		0x93: {"SHA", c.sha, c.izy, "izy", 6},
+		0x9B: {"TAS", c.tas, c.aby, "aby", 5},
 
 		// Arithmetic
 		0x69: {"ADC", c.adc, c.imm, "imm", 2},
@@ -325,20 +410,21 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0xE3: {"ISC", c.isc, c.izx, "izx", 8},
 		0xF3: {"ISC", c.isc, c.izy, "izy", 8},
 
-		// Unofficial NOPs (DOP - Double OPeration, immediate)
-		0x04: {"DOP", c.dope, c.zp0, "zp0", 3},
-		0x14: {"DOP", c.dope, c.zpx, "zpx", 4},
-		0x34: {"DOP", c.dope, c.zpx, "zpx", 4},
-		0x44: {"DOP", c.dope, c.zp0, "zp0", 3},
-		0x54: {"DOP", c.dope, c.zpx, "zpx", 4},
-		0x74: {"DOP", c.dope, c.zpx, "zpx", 4},
-		0xD4: {"DOP", c.dope, c.zpx, "zpx", 4},
-		0xF4: {"DOP", c.dope, c.zpx, "zpx", 4},
-		0x80: {"DOP", c.dope, c.imm, "imm", 3},
-		0x82: {"DOP", c.dope, c.imm, "imm", 3},
-		0x89: {"DOP", c.dope, c.imm, "imm", 3},
-		0xC2: {"DOP", c.dope, c.imm, "imm", 3},
-		0xE2: {"DOP", c.dope, c.imm, "imm", 3},
+		// Unofficial two-byte NOPs (DOP)
+		0x04: {"NOP", c.dope, c.zp0, "zp0", 3},
+		0x14: {"NOP", c.dope, c.zpx, "zpx", 4},
+		0x34: {"NOP", c.dope, c.zpx, "zpx", 4},
+		0x44: {"NOP", c.dope, c.zp0, "zp0", 3},
+		0x64: {"NOP", c.dope, c.zp0, "zp0", 3},
+		0x54: {"NOP", c.dope, c.zpx, "zpx", 4},
+		0x74: {"NOP", c.dope, c.zpx, "zpx", 4},
+		0xD4: {"NOP", c.dope, c.zpx, "zpx", 4},
+		0xF4: {"NOP", c.dope, c.zpx, "zpx", 4},
+		0x80: {"NOP", c.dope, c.imm, "imm", 2},
+		0x82: {"NOP", c.dope, c.imm, "imm", 2},
+		0x89: {"NOP", c.dope, c.imm, "imm", 2},
+		0xC2: {"NOP", c.dope, c.imm, "imm", 2},
+		0xE2: {"NOP", c.dope, c.imm, "imm", 2},
 
 		// Logical
 		0x29: {"AND", c.and, c.imm, "imm", 2},
@@ -370,7 +456,7 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0x0B: {"ANC", c.anc, c.imm, "imm", 2}, // ANC
 		0x2B: {"ANC", c.anc, c.imm, "imm", 2}, // ANC2
 		0x4B: {"ALR", c.alr, c.imm, "imm", 2}, // ALR (ASR)
-		0x8B: {"ANE", c.nop, c.imm, "imm", 2}, // ANE (XAA) - Unstable, treat as NOP
+		0x8B: {"ANE", c.ane, c.imm, "imm", 2}, // ANE (XAA)
 		0x6B: {"ARR", c.arr, c.imm, "imm", 2}, // ARR
 
 		// Unofficial Shift/Rotate (RLA)
@@ -460,15 +546,15 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		// Unofficial AXS (SBX)
 		0xCB: {"AXS", c.axs, c.imm, "imm", 2},
 
-		// Unofficial NOP (TOP) - absolute
-		0x0C: {"TOP", c.dope, c.abs, "abs", 4},
-		// Unofficial NOP (TOP) - absolute,X
-		0x1C: {"TOP", c.dope, c.abx, "abx", 4},
-		0x3C: {"TOP", c.dope, c.abx, "abx", 4},
-		0x5C: {"TOP", c.dope, c.abx, "abx", 4},
-		0x7C: {"TOP", c.dope, c.abx, "abx", 4},
-		0xDC: {"TOP", c.dope, c.abx, "abx", 4},
-		0xFC: {"TOP", c.dope, c.abx, "abx", 4},
+		// Unofficial three-byte NOPs (TOP) - absolute
+		0x0C: {"NOP", c.dope, c.abs, "abs", 4},
+		// Unofficial three-byte NOPs (TOP) - absolute,X
+		0x1C: {"NOP", c.dope, c.abx, "abx", 4},
+		0x3C: {"NOP", c.dope, c.abx, "abx", 4},
+		0x5C: {"NOP", c.dope, c.abx, "abx", 4},
+		0x7C: {"NOP", c.dope, c.abx, "abx", 4},
+		0xDC: {"NOP", c.dope, c.abx, "abx", 4},
+		0xFC: {"NOP", c.dope, c.abx, "abx", 4},
 
 		// Jump
 		0x4C: {"JMP", c.jmp, c.abs, "abs", 3},
@@ -482,6 +568,28 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0x2C: {"BIT", c.bit, c.abs, "abs", 4},
 		0xEA: {"NOP", c.nop, c.imp, "imp", 2},
 
+		// Unofficial one-byte NOPs
+		0x1A: {"NOP", c.nop, c.imp, "imp", 2},
+		0x3A: {"NOP", c.nop, c.imp, "imp", 2},
+		0x5A: {"NOP", c.nop, c.imp, "imp", 2},
+		0x7A: {"NOP", c.nop, c.imp, "imp", 2},
+		0xDA: {"NOP", c.nop, c.imp, "imp", 2},
+		0xFA: {"NOP", c.nop, c.imp, "imp", 2},
+
+		// Unofficial JAM (KIL) - halts the CPU
+		0x02: {"JAM", c.jam, c.imp, "imp", 2},
+		0x12: {"JAM", c.jam, c.imp, "imp", 2},
+		0x22: {"JAM", c.jam, c.imp, "imp", 2},
+		0x32: {"JAM", c.jam, c.imp, "imp", 2},
+		0x42: {"JAM", c.jam, c.imp, "imp", 2},
+		0x52: {"JAM", c.jam, c.imp, "imp", 2},
+		0x62: {"JAM", c.jam, c.imp, "imp", 2},
+		0x72: {"JAM", c.jam, c.imp, "imp", 2},
+		0x92: {"JAM", c.jam, c.imp, "imp", 2},
+		0xB2: {"JAM", c.jam, c.imp, "imp", 2},
+		0xD2: {"JAM", c.jam, c.imp, "imp", 2},
+		0xF2: {"JAM", c.jam, c.imp, "imp", 2},
+
 		// Stack
 		0x48: {"PHA", c.pha, c.imp, "imp", 3},
 		0x68: {"PLA", c.pla, c.imp, "imp", 4},
@@ -496,12 +604,6 @@ func (c *CPU) createLookupTable() [256]Instruction {
 		0xBA: {"TSX", c.tsx, c.imp, "imp", 2},
 		0x9A: {"TXS", c.txs, c.imp, "imp", 2},
 	}
-
-	for i := 0; i < 256; i++ {
-		if lookup[i].Operate == nil {
-			lookup[i] = Instruction{"XXX", c.nop, c.imp, "imp", 2}
-		}
-	}
 	return lookup
 }
 
@@ -519,27 +621,27 @@ func (c *CPU) imm() byte {
 }
 
 func (c *CPU) zp0() byte {
-	c.addrAbs = uint16(c.bus.Read(c.PC))
+	c.addrAbs = uint16(c.read(c.PC))
 	c.PC++
 	return 0
 }
 
 func (c *CPU) zpx() byte {
-	c.addrAbs = uint16(c.bus.Read(c.PC) + c.X)
+	c.addrAbs = uint16(c.read(c.PC) + c.X)
 	c.PC++
 	c.addrAbs &= 0x00FF
 	return 0
 }
 
 func (c *CPU) zpy() byte {
-	c.addrAbs = uint16(c.bus.Read(c.PC) + c.Y)
+	c.addrAbs = uint16(c.read(c.PC) + c.Y)
 	c.PC++
 	c.addrAbs &= 0x00FF
 	return 0
 }
 
 func (c *CPU) rel() byte {
-	c.addrRel = uint16(c.bus.Read(c.PC))
+	c.addrRel = uint16(c.read(c.PC))
 	c.PC++
 	if c.addrRel&0x80 != 0 {
 		c.addrRel |= 0xFF00
@@ -548,18 +650,18 @@ func (c *CPU) rel() byte {
 }
 
 func (c *CPU) abs() byte {
-	lo := uint16(c.bus.Read(c.PC))
+	lo := uint16(c.read(c.PC))
 	c.PC++
-	hi := uint16(c.bus.Read(c.PC))
+	hi := uint16(c.read(c.PC))
 	c.PC++
 	c.addrAbs = (hi << 8) | lo
 	return 0
 }
 
 func (c *CPU) abx() byte {
-	lo := uint16(c.bus.Read(c.PC))
+	lo := uint16(c.read(c.PC))
 	c.PC++
-	hi := uint16(c.bus.Read(c.PC))
+	hi := uint16(c.read(c.PC))
 	c.PC++
 	c.addrAbs = (hi << 8) | lo
 	c.addrAbs += uint16(c.X)
@@ -571,9 +673,9 @@ func (c *CPU) abx() byte {
 }
 
 func (c *CPU) aby() byte {
-	lo := uint16(c.bus.Read(c.PC))
+	lo := uint16(c.read(c.PC))
 	c.PC++
-	hi := uint16(c.bus.Read(c.PC))
+	hi := uint16(c.read(c.PC))
 	c.PC++
 	c.addrAbs = (hi << 8) | lo
 	c.addrAbs += uint16(c.Y)
@@ -585,34 +687,34 @@ func (c *CPU) aby() byte {
 }
 
 func (c *CPU) ind() byte {
-	ptrLo := uint16(c.bus.Read(c.PC))
+	ptrLo := uint16(c.read(c.PC))
 	c.PC++
-	ptrHi := uint16(c.bus.Read(c.PC))
+	ptrHi := uint16(c.read(c.PC))
 	c.PC++
 	ptr := (ptrHi << 8) | ptrLo
 
 	if ptrLo == 0x00FF { // Simulate page boundary hardware bug
-		c.addrAbs = (uint16(c.bus.Read(ptr&0xFF00)) << 8) | uint16(c.bus.Read(ptr))
+		c.addrAbs = (uint16(c.read(ptr&0xFF00)) << 8) | uint16(c.read(ptr))
 	} else {
-		c.addrAbs = (uint16(c.bus.Read(ptr+1)) << 8) | uint16(c.bus.Read(ptr))
+		c.addrAbs = (uint16(c.read(ptr+1)) << 8) | uint16(c.read(ptr))
 	}
 	return 0
 }
 
 func (c *CPU) izx() byte {
-	t := uint16(c.bus.Read(c.PC))
+	t := uint16(c.read(c.PC))
 	c.PC++
-	lo := uint16(c.bus.Read((t + uint16(c.X)) & 0x00FF))
-	hi := uint16(c.bus.Read((t + uint16(c.X) + 1) & 0x00FF))
+	lo := uint16(c.read((t + uint16(c.X)) & 0x00FF))
+	hi := uint16(c.read((t + uint16(c.X) + 1) & 0x00FF))
 	c.addrAbs = (hi << 8) | lo
 	return 0
 }
 
 func (c *CPU) izy() byte {
-	t := uint16(c.bus.Read(c.PC))
+	t := uint16(c.read(c.PC))
 	c.PC++
-	lo := uint16(c.bus.Read(t & 0x00FF))
-	hi := uint16(c.bus.Read((t + 1) & 0x00FF))
+	lo := uint16(c.read(t & 0x00FF))
+	hi := uint16(c.read((t + 1) & 0x00FF))
 	c.addrAbs = (hi << 8) | lo
 	c.addrAbs += uint16(c.Y)
 
@@ -629,7 +731,7 @@ func (c *CPU) ldy() byte {
 	c.Y = c.fetched
 	c.setFlag('Z', c.Y == 0)
 	c.setFlag('N', c.Y&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) ldx() byte {
@@ -637,50 +739,72 @@ func (c *CPU) ldx() byte {
 	c.X = c.fetched
 	c.setFlag('Z', c.X == 0)
 	c.setFlag('N', c.X&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) sty() byte {
-	c.bus.Write(c.addrAbs, c.Y)
+	c.write(c.addrAbs, c.Y)
 	return 0
 }
 
 func (c *CPU) stx() byte {
-	c.bus.Write(c.addrAbs, c.X)
+	c.write(c.addrAbs, c.X)
 	return 0
 }
 
 // Unofficial SYA (SHY)
 // M = Y AND (high_byte_of_operand + 1)
-func (c *CPU) sya() byte {
-	// The high byte of the absolute address operand is at PC-1 (since PC was incremented twice by abx)
-	hi_operand := c.bus.Read(c.PC - 1)
-	val := c.Y & (hi_operand + 1) // Y AND (high_byte_of_operand + 1)
-	c.bus.Write(c.addrAbs, val)
+func (c *CPU) shy() byte {
+	c.storeHigh(c.Y, c.X)
 	return 0
 }
 
 func (c *CPU) sta() byte {
-	c.bus.Write(c.addrAbs, c.A)
+	c.write(c.addrAbs, c.A)
 	return 0
 }
 
 func (c *CPU) sax() byte {
 	val := c.A & c.X
-	c.bus.Write(c.addrAbs, val)
+	c.write(c.addrAbs, val)
 	return 0
 }
 
-// Unofficial SXA (SHX)
-// M = X AND (high_byte_of_operand + 1)
-func (c *CPU) sxa() byte {
-	// The high byte of the absolute address operand is at PC-1 (since PC was incremented twice by aby)
-	hi_operand := c.bus.Read(c.PC - 1)
-	val := c.X & (hi_operand + 1) // X AND (high_byte_of_operand + 1)
-	c.bus.Write(c.addrAbs, val)
+// Unofficial SHX (SXA)
+// M = X AND (high_byte_of_base + 1)
+func (c *CPU) shx() byte {
+	c.storeHigh(c.X, c.Y)
 	return 0
 }
 
+// Unofficial SHA (AHX)
+// M = A AND X AND (high_byte_of_base + 1)
+func (c *CPU) sha() byte {
+	c.storeHigh(c.A&c.X, c.Y)
+	return 0
+}
+
+// Unofficial TAS (SHS)
+// SP = A AND X, M = SP AND (high_byte_of_base + 1)
+func (c *CPU) tas() byte {
+	c.SP = c.A & c.X
+	c.storeHigh(c.SP, c.Y)
+	return 0
+}
+
+// storeHigh stores data ANDed with one more than the high byte of the base address the
+// index was added to, as SHA, SHX, SHY and TAS do. When indexing crosses a page, the
+// stored value also replaces the high byte of the address.
+func (c *CPU) storeHigh(data, index byte) {
+	base := c.addrAbs - uint16(index)
+	data &= byte(base>>8) + 1
+	addr := c.addrAbs
+	if addr&0xFF00 != base&0xFF00 {
+		addr = uint16(data)<<8 | addr&0x00FF
+	}
+	c.write(addr, data)
+}
+
 func (c *CPU) plp() byte {
 	popped := c.pop() // Value popped from stack
 	// Load bits 7,6,3,2,1,0 directly from popped value.
@@ -753,7 +877,7 @@ func (c *CPU) lda() byte {
 	c.A = c.fetched
 	c.setFlag('Z', c.A == 0)
 	c.setFlag('N', c.A&0x80 != 0)
-	return 0
+	return 1
 }
 
 // Unofficial SLO (ASL and ORA)
@@ -766,7 +890,7 @@ func (c *CPU) slo() byte {
 	c.setFlag('C', temp > 0xFF) // Set C from bit 7 of M
 
 	shiftedM := byte(temp & 0x00FF)
-	c.bus.Write(c.addrAbs, shiftedM) // Write shifted M back to memory
+	c.write(c.addrAbs, shiftedM) // Write shifted M back to memory
 
 	// ORA operation with A
 	c.A = c.A | shiftedM
@@ -785,29 +909,38 @@ func (c *CPU) las() byte {
 	c.SP = val
 	c.setFlag('Z', val == 0)
 	c.setFlag('N', val&0x80 != 0)
-	return 0
+	return 1
 }
 
-// Unofficial ATX (OAL/AXA)
-// X = (A OR 0xEE) AND M
-func (c *CPU) atx() byte {
-	c.fetch()                       // c.fetched will contain M (the immediate operand)
-	val := (c.A | 0xEE) & c.fetched // Calculate (A OR 0xEE) AND M
-	c.X = val                       // Store result in X
-	// A is unchanged according to this interpretation.
-
+// Unofficial LXA (ATX/OAL)
+// A = X = (A OR 0xEE) AND M. The constant varies between chips; 0xEE is common.
+func (c *CPU) lxa() byte {
+	c.fetch()
+	val := (c.A | 0xEE) & c.fetched
+	c.A = val
+	c.X = val
 	c.setFlag('Z', val == 0)
 	c.setFlag('N', val&0x80 != 0)
 	return 0
 }
 
+// Unofficial ANE (XAA)
+// A = (A OR 0xEE) AND X AND M, with the same unstable constant as LXA.
+func (c *CPU) ane() byte {
+	c.fetch()
+	c.A = (c.A | 0xEE) & c.X & c.fetched
+	c.setFlag('Z', c.A == 0)
+	c.setFlag('N', c.A&0x80 != 0)
+	return 0
+}
+
 func (c *CPU) lax() byte {
 	c.fetch()
 	c.A = c.fetched
 	c.X = c.A // TAX operation
 	c.setFlag('Z', c.A == 0)
 	c.setFlag('N', c.A&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) sbc() byte {
@@ -819,7 +952,7 @@ func (c *CPU) sbc() byte {
 	c.setFlag('V', ((uint16(c.A)^temp)&(0x00FF^uint16(c.fetched)^temp))&0x0080 != 0)
 	c.setFlag('N', temp&0x0080 != 0)
 	c.A = byte(temp & 0x00FF)
-	return 0
+	return 1
 }
 func (c *CPU) adc() byte {
 	c.fetch()
@@ -829,7 +962,7 @@ func (c *CPU) adc() byte {
 	c.setFlag('V', ((uint16(c.A)^temp)&(uint16(c.fetched)^temp))&0x0080 != 0)
 	c.setFlag('N', temp&0x80 != 0)
 	c.A = byte(temp & 0x00FF)
-	return 0
+	return 1
 }
 
 func (c *CPU) dey() byte {
@@ -849,7 +982,7 @@ func (c *CPU) dex() byte {
 func (c *CPU) dec() byte {
 	c.fetch()
 	temp := c.fetched - 1
-	c.bus.Write(c.addrAbs, temp)
+	c.write(c.addrAbs, temp)
 	c.setFlag('Z', temp == 0)
 	c.setFlag('N', temp&0x80 != 0)
 	return 0
@@ -872,7 +1005,7 @@ func (c *CPU) inx() byte {
 func (c *CPU) inc() byte {
 	c.fetch()
 	temp := c.fetched + 1
-	c.bus.Write(c.addrAbs, temp)
+	c.write(c.addrAbs, temp)
 	c.setFlag('Z', temp == 0)
 	c.setFlag('N', temp&0x80 != 0)
 	return 0
@@ -882,7 +1015,7 @@ func (c *CPU) dcp() byte {
 	c.fetch()
 	// DEC operation
 	temp := c.fetched - 1
-	c.bus.Write(c.addrAbs, temp)
+	c.write(c.addrAbs, temp)
 
 	// CMP operation
 	res := c.A - temp
@@ -897,7 +1030,7 @@ func (c *CPU) isc() byte {
 
 	// INC operation
 	temp := c.fetched + 1 // Use temp as the incremented value for consistency with SBC
-	c.bus.Write(c.addrAbs, temp)
+	c.write(c.addrAbs, temp)
 
 	// SBC operation (similar to regular SBC, but with the incremented value)
 	sbcVal := uint16(temp)
@@ -920,7 +1053,7 @@ func (c *CPU) sre() byte {
 	c.setFlag('C', c.fetched&1 != 0) // Bit 0 of M to Carry
 	shiftedM := c.fetched >> 1
 
-	c.bus.Write(c.addrAbs, shiftedM) // Write shifted M back to memory
+	c.write(c.addrAbs, shiftedM) // Write shifted M back to memory
 
 	// EOR operation with A
 	c.A = c.A ^ shiftedM
@@ -955,7 +1088,7 @@ func (c *CPU) eor() byte {
 	c.A = c.A ^ c.fetched
 	c.setFlag('Z', c.A == 0)
 	c.setFlag('N', c.A&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) anc() byte {
@@ -972,7 +1105,7 @@ func (c *CPU) and() byte {
 	c.A = c.A & c.fetched
 	c.setFlag('Z', c.A == 0)
 	c.setFlag('N', c.A&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) ora() byte {
@@ -980,7 +1113,7 @@ func (c *CPU) ora() byte {
 	c.A = c.A | c.fetched
 	c.setFlag('Z', c.A == 0)
 	c.setFlag('N', c.A&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) alr() byte {
@@ -1005,7 +1138,7 @@ func (c *CPU) ror() byte {
 	if c.Lookup[c.opcode].AddrModeName == "imp" {
 		c.A = byte(temp & 0x00FF)
 	} else {
-		c.bus.Write(c.addrAbs, byte(temp&0x00FF))
+		c.write(c.addrAbs, byte(temp&0x00FF))
 	}
 	return 0
 }
@@ -1083,7 +1216,7 @@ func (c *CPU) rol() byte {
 	if c.Lookup[c.opcode].AddrModeName == "imp" {
 		c.A = byte(temp & 0x00FF)
 	} else {
-		c.bus.Write(c.addrAbs, byte(temp&0x00FF))
+		c.write(c.addrAbs, byte(temp&0x00FF))
 	}
 	return 0
 }
@@ -1097,7 +1230,7 @@ func (c *CPU) lsr() byte {
 	if c.Lookup[c.opcode].AddrModeName == "imp" {
 		c.A = temp
 	} else {
-		c.bus.Write(c.addrAbs, temp)
+		c.write(c.addrAbs, temp)
 	}
 	return 0
 }
@@ -1111,7 +1244,7 @@ func (c *CPU) asl() byte {
 	if c.Lookup[c.opcode].AddrModeName == "imp" {
 		c.A = byte(temp & 0x00FF)
 	} else {
-		c.bus.Write(c.addrAbs, byte(temp&0x00FF))
+		c.write(c.addrAbs, byte(temp&0x00FF))
 	}
 	return 0
 }
@@ -1125,7 +1258,7 @@ func (c *CPU) rla() byte {
 	c.setFlag('C', val&0x80 != 0)
 	val = (val << 1) | oldC
 
-	c.bus.Write(c.addrAbs, val) // Write back rotated value
+	c.write(c.addrAbs, val) // Write back rotated value
 
 	// AND operation
 	c.A = c.A & val
@@ -1143,7 +1276,7 @@ func (c *CPU) rra() byte {
 	c.setFlag('C', val&1 != 0)
 	val = (val >> 1) | (oldC << 7)
 
-	c.bus.Write(c.addrAbs, val) // Write back rotated value
+	c.write(c.addrAbs, val) // Write back rotated value
 
 	// ADC operation (similar to regular ADC, but with the rotated value)
 	adcVal := uint16(val)
@@ -1272,15 +1405,14 @@ func (c *CPU) cmp() byte {
 	c.setFlag('C', c.A >= c.fetched)
 	c.setFlag('Z', temp == 0)
 	c.setFlag('N', temp&0x80 != 0)
-	return 0
+	return 1
 }
 
 func (c *CPU) rti() byte {
 	popped := c.pop()
-	// RTI loads all flags from stack, but forces U (bit 5) to 1.
-	// B flag (bit 4) is loaded from stack.
-	// This means we take all bits from 'popped' except U, and then force U to 1.
-	c.P = (popped & ^U) | U
+	// RTI loads all flags from stack like PLP: B (bit 4) only exists on the stack, to
+	// tell BRK from IRQ, so it is dropped, and U (bit 5) is forced to 1.
+	c.P = (popped & ^(B | U)) | U
 	c.PC = uint16(c.pop())
 	c.PC |= uint16(c.pop()) << 8
 	return 0
@@ -1312,8 +1444,8 @@ func (c *CPU) brk() byte {
 	c.setFlag('I', true) // Set Interrupt Disable flag
 
 	c.addrAbs = 0xFFFE // IRQ vector
-	lo := uint16(c.bus.Read(c.addrAbs))
-	hi := uint16(c.bus.Read(c.addrAbs + 1))
+	lo := uint16(c.read(c.addrAbs))
+	hi := uint16(c.read(c.addrAbs + 1))
 	c.PC = (hi << 8) | lo
 	return 0
 }
@@ -1328,9 +1460,19 @@ func (c *CPU) nop() byte {
 	return 0
 }
 
+// Unofficial JAM (KIL/STP)
+// The CPU locks up until reset, with PC left on the JAM. Games only get here by jumping
+// somewhere they shouldn't, so it is logged.
+func (c *CPU) jam() byte {
+	c.PC--
+	c.Jammed = true
+	c.log.Warn("jammed", "pc", fmt.Sprintf("%04X", c.PC), "opcode", fmt.Sprintf("%02X", c.opcode))
+	return 0
+}
+
 func (c *CPU) dope() byte {
 	c.fetch() // Fetch the operand, but do nothing with it
-	return 0
+	return 1
 }
 
 func (c *CPU) bit() byte {
@@ -1343,8 +1485,8 @@ func (c *CPU) bit() byte {
 }
 
 func (c *CPU) fetch() byte {
-	if c.Lookup[c.opcode].AddrModeName != "imp" {
-		c.fetched = c.bus.Read(c.addrAbs)
+	if !c.latched && c.Lookup[c.opcode].AddrModeName != "imp" {
+		c.fetched = c.read(c.addrAbs)
 	}
 	return 0
 }
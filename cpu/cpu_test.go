@@ -1,6 +1,7 @@
 package cpu
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -22,23 +23,12 @@ func executeOneInstruction(c *CPU) {
 		c.Clock()
 	}
 
-	// Now c.Cycles is 0, the next Clock() call will fetch and process the instruction.
-	// We need to determine the total cycles this instruction will consume *after* it's fetched.
-	// This requires peeking at the opcode at c.PC before Clock() consumes it.
-	opcode := c.bus.Read(c.PC)
-	instr := c.Lookup[opcode]
-	cyclesToConsume := instr.Cycles
-
-	// Clock the CPU until this instruction is fully executed.
-	// The first Clock() call when c.cycles == 0 will fetch and update c.cycles.
-	// The subsequent calls will decrement c.cycles.
-	for i := 0; i < cyclesToConsume; i++ {
-		c.Clock()
-	}
+	// Clock the CPU until this instruction is fully executed
+	runInstruction(c)
 }
 
 func setupCPU(t *testing.T) (*CPU, *mockBus) {
-	c := New()
+	c := New(nil)
 	bus := &mockBus{}
 	c.ConnectBus(bus)
 	c.Reset()
@@ -177,3 +167,269 @@ func TestBranch(t *testing.T) {
 		t.Error("BEQ (taken) failed")
 	}
 }
+
+// runInstruction clocks the CPU through the next instruction or interrupt.
+func runInstruction(c *CPU) {
+	c.Clock()
+	for c.Cycles > 0 {
+		c.Clock()
+	}
+}
+
+func TestNMIEdge(t *testing.T) {
+	c, bus := setupCPU(t)
+	// Taken at the next instruction boundary, as an instruction-stepped CPU polls
+	c.SetInstructionStepping(true)
+	bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90 // NMI vector -> $9000
+	bus.ram[0x8000] = 0xEA                        // NOP
+	bus.ram[0x9000] = 0xEA
+
+	if !c.AssertNMI() {
+		t.Fatal("Expected raising the NMI line to latch an NMI")
+	}
+	if c.AssertNMI() {
+		t.Error("Expected holding the NMI line not to latch another")
+	}
+	runInstruction(c)
+	if c.PC != 0x9000 {
+		t.Fatalf("Expected the NMI to be taken, PC = $%04X", c.PC)
+	}
+
+	// Still held: the next instruction runs normally
+	c.AssertNMI()
+	runInstruction(c)
+	if c.PC != 0x9001 {
+		t.Errorf("Expected a held NMI line to fire once, PC = $%04X", c.PC)
+	}
+
+	// Saved and loaded while held, the line still doesn't fire again
+	s := c.SaveState()
+	c.ReleaseNMI()
+	c.LoadState(s)
+	if c.AssertNMI() || c.NMIPending() {
+		t.Error("Expected the NMI line level to survive LoadState")
+	}
+}
+
+func TestIRQLevel(t *testing.T) {
+	c, bus := setupCPU(t)
+	// Taken at the next instruction boundary, as an instruction-stepped CPU polls
+	c.SetInstructionStepping(true)
+	bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0x90 // IRQ vector -> $9000
+	bus.ram[0x8000] = 0x58                        // CLI
+	bus.ram[0x8001] = 0xEA                        // NOP
+	bus.ram[0x8002] = 0xEA
+
+	// Raised and released while I is set, the IRQ is never taken
+	c.AssertIRQ()
+	c.ReleaseIRQ()
+	runInstruction(c) // CLI
+	runInstruction(c) // NOP
+	if c.PC != 0x8002 {
+		t.Fatalf("Expected an IRQ released before the CPU sampled it to be lost, PC = $%04X", c.PC)
+	}
+
+	c.AssertIRQ()
+	runInstruction(c)
+	if c.PC != 0x9000 {
+		t.Errorf("Expected a held IRQ line to be taken, PC = $%04X", c.PC)
+	}
+}
+
+func TestIRQHeld(t *testing.T) {
+	for _, instructionStepping := range []bool{false, true} {
+		c, bus := setupCPU(t)
+		c.SetInstructionStepping(instructionStepping)
+		bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0x90 // IRQ vector -> $9000
+		bus.ram[0x8000] = 0xEA                        // NOP
+		bus.ram[0x8001] = 0xEA
+		copy(bus.ram[0x9000:], []byte{0xEA, 0xEA, 0x40}) // NOP, NOP, RTI
+		c.SP, c.P = 0xFD, U
+
+		// A held line is taken once, and not again while the handler runs with I set
+		// (an instruction-stepped CPU polls a cycle later, so takes it before the NOP)
+		c.AssertIRQ()
+		for i := 0; i < 2 && c.PC != 0x9000; i++ {
+			runInstruction(c)
+		}
+		if c.PC != 0x9000 {
+			t.Fatalf("Stepping %v: Expected the IRQ to be taken, PC = $%04X", instructionStepping, c.PC)
+		}
+		runInstruction(c)
+		runInstruction(c)
+		if c.PC != 0x9002 {
+			t.Fatalf("Stepping %v: Expected the handler to run undisturbed, PC = $%04X", instructionStepping, c.PC)
+		}
+
+		// RTI clears I, so a line the handler didn't acknowledge is taken straight away
+		runInstruction(c) // RTI
+		runInstruction(c)
+		if c.PC != 0x9000 {
+			t.Fatalf("Stepping %v: Expected the unacknowledged IRQ to be taken again, PC = $%04X", instructionStepping, c.PC)
+		}
+
+		// Acknowledged in the handler, it isn't
+		c.ReleaseIRQ()
+		for range 4 {
+			runInstruction(c) // NOP, NOP, RTI and the NOP returned to
+		}
+		want := uint16(0x8002)
+		if instructionStepping {
+			want = 0x8001
+		}
+		if c.PC != want {
+			t.Errorf("Stepping %v: Expected the acknowledged IRQ to return to the program, PC = $%04X", instructionStepping, c.PC)
+		}
+	}
+}
+
+func TestBRK(t *testing.T) {
+	c, bus := setupCPU(t)
+	bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0x90 // IRQ/BRK vector -> $9000
+	bus.ram[0x8000] = 0x00                        // BRK (and its padding byte)
+	bus.ram[0x9000] = 0x40                        // RTI
+	c.P = U | C
+	sp := c.SP
+
+	runInstruction(c)
+	if c.PC != 0x9000 || c.getFlag('I') == 0 {
+		t.Fatalf("Expected BRK to vector through $FFFE with I set, PC = $%04X P = $%02X", c.PC, c.P)
+	}
+	if c.SP != sp-3 {
+		t.Errorf("Expected BRK to push 3 bytes, SP = $%02X", c.SP)
+	}
+	if got := bus.ram[0x0100+uint16(sp)-2]; got != U|C|B {
+		t.Errorf("Expected P pushed with B set and I as it was, got $%02X", got)
+	}
+	if ret := uint16(bus.ram[0x0100+uint16(sp)])<<8 | uint16(bus.ram[0x0100+uint16(sp)-1]); ret != 0x8002 {
+		t.Errorf("Expected BRK to push the address past its padding byte, got $%04X", ret)
+	}
+
+	runInstruction(c) // RTI
+	if c.PC != 0x8002 || c.P != U|C {
+		t.Errorf("Expected RTI to return to $8002 with P = $%02X, got PC = $%04X P = $%02X", U|C, c.PC, c.P)
+	}
+}
+
+func TestInterruptPushesFlags(t *testing.T) {
+	c, bus := setupCPU(t)
+	c.SetInstructionStepping(true)
+	bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90 // NMI vector -> $9000
+	bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0xA0 // IRQ vector -> $A000
+	bus.ram[0x8000] = 0xEA                        // NOP
+	c.P = U | Z
+	sp := c.SP
+
+	c.AssertIRQ()
+	runInstruction(c)
+	if c.PC != 0xA000 || c.getFlag('I') == 0 {
+		t.Fatalf("Expected the IRQ to be taken with I set, PC = $%04X", c.PC)
+	}
+	if got := bus.ram[0x0100+uint16(sp)-2]; got != U|Z {
+		t.Errorf("Expected the IRQ to push P with B and I clear, got $%02X", got)
+	}
+
+	// The NMI pushes I as it was before it was taken
+	c, bus = setupCPU(t)
+	c.SetInstructionStepping(true)
+	bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90 // NMI vector -> $9000
+	c.P = U | Z
+	sp = c.SP
+	c.AssertNMI()
+	runInstruction(c)
+	if c.PC != 0x9000 || c.getFlag('I') == 0 {
+		t.Fatalf("Expected the NMI to be taken with I set, PC = $%04X", c.PC)
+	}
+	if got := bus.ram[0x0100+uint16(sp)-2]; got != U|Z {
+		t.Errorf("Expected the NMI to push P with B and I clear, got $%02X", got)
+	}
+}
+
+func TestOpcodeTableComplete(t *testing.T) {
+	c := New(nil)
+	for op, instr := range c.Lookup {
+		if instr.Operate == nil || instr.AddrMode == nil || instr.Name == "" || instr.Cycles == 0 {
+			t.Errorf("Opcode %02X: expected an instruction, got %+v", op, instr)
+		}
+	}
+}
+
+func TestUnofficialStores(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		x, y    byte
+		addr    uint16
+		want    byte
+	}{
+		{"SHX abs,Y", []byte{0x9E, 0x10, 0x12}, 0xFF, 0x01, 0x1211, 0x13},
+		{"SHY abs,X", []byte{0x9C, 0x10, 0x12}, 0x01, 0xFF, 0x1211, 0x13},
+		{"SHA abs,Y", []byte{0x9F, 0x10, 0x12}, 0x0F, 0x01, 0x1211, 0x03},
+		{"SHA (zp),Y", []byte{0x93, 0x20}, 0x0F, 0x01, 0x1211, 0x03},
+		// Crossing a page stores to the page the value names
+		{"SHX abs,Y across a page", []byte{0x9E, 0xFF, 0x02}, 0x02, 0x01, 0x0200, 0x02},
+	}
+	for _, tt := range tests {
+		c, bus := setupCPU(t)
+		copy(bus.ram[0x8000:], tt.program)
+		bus.ram[0x20], bus.ram[0x21] = 0x10, 0x12
+		c.A, c.X, c.Y = 0x33, tt.x, tt.y
+		clocksToComplete(c)
+		if got := bus.ram[tt.addr]; got != tt.want {
+			t.Errorf("%s: Expected $%02X at $%04X, got $%02X", tt.name, tt.want, tt.addr, got)
+		}
+	}
+
+	c, bus := setupCPU(t)
+	copy(bus.ram[0x8000:], []byte{0x9B, 0x10, 0x12}) // TAS $1210,Y
+	c.A, c.X, c.Y = 0xF3, 0x3F, 0x01
+	clocksToComplete(c)
+	if c.SP != 0x33 || bus.ram[0x1211] != 0x13 {
+		t.Errorf("TAS: Expected SP $33 and $13 at $1211, got SP $%02X and $%02X", c.SP, bus.ram[0x1211])
+	}
+}
+
+func TestUnofficialImmediates(t *testing.T) {
+	c, bus := setupCPU(t)
+	copy(bus.ram[0x8000:], []byte{0xAB, 0x5A}) // LXA #$5A
+	c.A = 0x01
+	clocksToComplete(c)
+	if c.A != 0x4A || c.X != 0x4A {
+		t.Errorf("LXA: Expected A and X $4A, got A $%02X X $%02X", c.A, c.X)
+	}
+
+	c, bus = setupCPU(t)
+	copy(bus.ram[0x8000:], []byte{0x8B, 0xFF}) // ANE #$FF
+	c.A, c.X = 0x01, 0x0F
+	clocksToComplete(c)
+	if c.A != 0x0F {
+		t.Errorf("ANE: Expected A $0F, got $%02X", c.A)
+	}
+}
+
+func TestJAM(t *testing.T) {
+	for _, instructionStepping := range []bool{false, true} {
+		c, bus := setupCPU(t)
+		c.SetInstructionStepping(instructionStepping)
+		bus.ram[0x8000] = 0x02
+		bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90 // NMI vector -> $9000
+		clocksToComplete(c)
+		if !c.Jammed || !strings.HasSuffix(c.LogState(), " JAMMED") {
+			t.Errorf("Expected the JAM to jam the CPU, got %s", c.LogState())
+		}
+
+		// Not even an NMI gets it going again
+		c.AssertNMI()
+		for range 20 {
+			c.Clock()
+		}
+		if c.PC != 0x8000 || c.SP != 0xFD {
+			t.Errorf("Expected the CPU to stay on the JAM, PC = $%04X, SP = $%02X", c.PC, c.SP)
+		}
+
+		c.Reset()
+		if c.Jammed {
+			t.Error("Expected reset to free the CPU")
+		}
+	}
+}
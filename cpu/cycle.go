@@ -0,0 +1,510 @@
+package cpu
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/meadori/vibemulator/logging"
+)
+
+// sequence is the series of bus accesses an instruction makes, one per cycle, as
+// described in the 6502 hardware manual's cycle-by-cycle tables.
+type sequence byte
+
+const (
+	seqImplied sequence = iota // Also accumulator mode and undocumented opcodes
+	seqImmediate
+	seqZeroPage
+	seqZeroPageIndexed
+	seqAbsolute
+	seqAbsoluteIndexed
+	seqIndexedIndirect
+	seqIndirectIndexed
+	seqBranch
+	seqJMP
+	seqJMPIndirect
+	seqJSR
+	seqRTS
+	seqRTI
+	seqBRK // Also hardware interrupts
+	seqPush
+	seqPull
+)
+
+// access is what an instruction does with the memory its addressing mode points at.
+type access byte
+
+const (
+	accessRead access = iota
+	accessWrite
+	accessModify // Read, write back unchanged, then write the result
+)
+
+// cycleOp is how the cycle-stepped core runs an opcode.
+type cycleOp struct {
+	seq    sequence
+	access access
+}
+
+// cycleOps derives how each opcode in lookup runs from its name and addressing mode.
+func cycleOps(lookup *[256]Instruction) [256]cycleOp {
+	var ops [256]cycleOp
+	for i, instr := range lookup {
+		op := &ops[i]
+		switch instr.Name {
+		case "STA", "STX", "STY", "SAX", "SHA", "SHX", "SHY", "TAS":
+			op.access = accessWrite
+		case "ASL", "LSR", "ROL", "ROR", "INC", "DEC", "SLO", "SRE", "RLA", "RRA", "DCP", "ISC":
+			op.access = accessModify
+		}
+		switch instr.Name {
+		case "BRK":
+			op.seq = seqBRK
+			continue
+		case "JSR":
+			op.seq = seqJSR
+			continue
+		case "RTS":
+			op.seq = seqRTS
+			continue
+		case "RTI":
+			op.seq = seqRTI
+			continue
+		case "PHA", "PHP":
+			op.seq = seqPush
+			continue
+		case "PLA", "PLP":
+			op.seq = seqPull
+			continue
+		case "JMP":
+			op.seq = seqJMP
+			if instr.AddrModeName == "ind" {
+				op.seq = seqJMPIndirect
+			}
+			continue
+		}
+		switch instr.AddrModeName {
+		case "imm":
+			op.seq = seqImmediate
+		case "zp0":
+			op.seq = seqZeroPage
+		case "zpx", "zpy":
+			op.seq = seqZeroPageIndexed
+		case "abs":
+			op.seq = seqAbsolute
+		case "abx", "aby":
+			op.seq = seqAbsoluteIndexed
+		case "izx":
+			op.seq = seqIndexedIndirect
+		case "izy":
+			op.seq = seqIndirectIndexed
+		case "rel":
+			op.seq = seqBranch
+		default:
+			op.seq = seqImplied
+		}
+	}
+	return ops
+}
+
+// SetInstructionStepping switches between running each instruction a cycle at a time
+// (the default) and running all of it on its first cycle, then idling for the rest,
+// as the CPU did before it was cycle-stepped. The switch takes effect at the next
+// instruction boundary. Instruction-stepped interrupts and BRKs can't be hijacked by an
+// NMI, since their vector is read before it can arrive.
+func (c *CPU) SetInstructionStepping(on bool) {
+	c.instructionStepping = on
+}
+
+// InstructionStepping reports whether the CPU runs an instruction at a time.
+func (c *CPU) InstructionStepping() bool {
+	return c.instructionStepping
+}
+
+// clockCycle performs the bus access of one cycle of the current instruction, starting
+// the next instruction or interrupt when the last one is done.
+func (c *CPU) clockCycle() {
+	if c.step == 0 {
+		c.begin()
+	} else {
+		c.step++
+		op := c.ops[c.opcode]
+		switch op.seq {
+		case seqImplied:
+			c.read(c.PC)
+			c.Lookup[c.opcode].AddrMode()
+			c.Lookup[c.opcode].Operate()
+			c.done()
+		case seqImmediate:
+			c.addrAbs = c.PC
+			c.PC++
+			c.Lookup[c.opcode].Operate()
+			c.done()
+		case seqZeroPage:
+			if c.step == 2 {
+				c.addrAbs = uint16(c.read(c.PC))
+				c.PC++
+			} else {
+				c.access(op.access, c.step-2)
+			}
+		case seqZeroPageIndexed:
+			c.zeroPageIndexed(op.access)
+		case seqAbsolute:
+			c.absolute(op.access)
+		case seqAbsoluteIndexed:
+			c.absoluteIndexed(op.access)
+		case seqIndexedIndirect:
+			c.indexedIndirect(op.access)
+		case seqIndirectIndexed:
+			c.indirectIndexed(op.access)
+		case seqBranch:
+			c.branchCycle()
+		case seqJMP:
+			c.jmpCycle()
+		case seqJMPIndirect:
+			c.jmpIndirectCycle()
+		case seqJSR:
+			c.jsrCycle()
+		case seqRTS:
+			c.rtsCycle()
+		case seqRTI:
+			c.rtiCycle()
+		case seqBRK:
+			c.brkCycle()
+		case seqPush:
+			if c.step == 2 {
+				c.read(c.PC)
+			} else {
+				c.Lookup[c.opcode].Operate()
+				c.done()
+			}
+		case seqPull:
+			switch c.step {
+			case 2:
+				c.read(c.PC)
+			case 3:
+				c.read(0x0100 + uint16(c.SP))
+			default:
+				c.Lookup[c.opcode].Operate()
+				c.done()
+			}
+		}
+	}
+	if c.Cycles > 0 {
+		c.Cycles--
+	}
+	// Interrupts are polled at the end of every cycle but the last, so the poll that
+	// counts is the one before an instruction's final cycle. BRK and interrupts don't poll.
+	if c.step != 0 && c.ops[c.opcode].seq != seqBRK {
+		c.poll = c.nmiPending || c.irqPending && c.getFlag('I') == 0
+	}
+}
+
+// begin fetches the next opcode, or starts an interrupt if one was polled.
+func (c *CPU) begin() {
+	c.step = 1
+	if c.poll {
+		// An interrupt runs BRK's sequence without fetching an opcode
+		c.read(c.PC)
+		c.opcode = 0x00
+		c.interrupt = true
+		c.Cycles = 7
+		return
+	}
+	c.opPC = c.PC
+	c.opcode = c.read(c.PC)
+	c.PC++
+	if c.log.Enabled(context.Background(), logging.LevelTrace) {
+		// Checked here so formatting the arguments doesn't allocate on every instruction
+		c.log.Log(context.Background(), logging.LevelTrace, "instruction",
+			"pc", fmt.Sprintf("%04X", c.opPC), "opcode", fmt.Sprintf("%02X", c.opcode))
+	}
+	if c.trace != nil {
+		c.trace.record(c)
+	}
+	c.Cycles = c.Lookup[c.opcode].Cycles
+}
+
+// done ends the current instruction on this cycle.
+func (c *CPU) done() {
+	c.step = 0
+	c.Cycles = 1 // Clocked away at the end of this cycle
+}
+
+// access performs cycle n (from 1) of reading, writing or modifying the operand at addrAbs.
+func (c *CPU) access(a access, n int) {
+	if a != accessModify {
+		c.Lookup[c.opcode].Operate()
+		c.done()
+		return
+	}
+	switch n {
+	case 1:
+		c.fetched = c.read(c.addrAbs)
+	case 2:
+		c.write(c.addrAbs, c.fetched)
+	default:
+		c.latched = true
+		c.Lookup[c.opcode].Operate()
+		c.latched = false
+		c.done()
+	}
+}
+
+func (c *CPU) zeroPageIndexed(a access) {
+	switch c.step {
+	case 2:
+		c.ptr = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.read(c.ptr)
+		index := c.X
+		if c.Lookup[c.opcode].AddrModeName == "zpy" {
+			index = c.Y
+		}
+		c.addrAbs = (c.ptr + uint16(index)) & 0x00FF
+	default:
+		c.access(a, c.step-3)
+	}
+}
+
+func (c *CPU) absolute(a access) {
+	switch c.step {
+	case 2:
+		c.addrAbs = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.addrAbs |= uint16(c.read(c.PC)) << 8
+		c.PC++
+	default:
+		c.access(a, c.step-3)
+	}
+}
+
+func (c *CPU) absoluteIndexed(a access) {
+	switch c.step {
+	case 2:
+		c.ptr = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.ptr |= uint16(c.read(c.PC)) << 8
+		c.PC++
+		index := c.X
+		if c.Lookup[c.opcode].AddrModeName == "aby" {
+			index = c.Y
+		}
+		c.index(a, index)
+	case 4:
+		c.indexed(a)
+	default:
+		c.access(a, c.step-4)
+	}
+}
+
+func (c *CPU) indexedIndirect(a access) {
+	switch c.step {
+	case 2:
+		c.ptr = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.read(c.ptr)
+		c.ptr = (c.ptr + uint16(c.X)) & 0x00FF
+	case 4:
+		c.addrAbs = uint16(c.read(c.ptr))
+	case 5:
+		c.addrAbs |= uint16(c.read((c.ptr+1)&0x00FF)) << 8
+	default:
+		c.access(a, c.step-5)
+	}
+}
+
+func (c *CPU) indirectIndexed(a access) {
+	switch c.step {
+	case 2:
+		c.ptr = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.addrAbs = uint16(c.read(c.ptr))
+	case 4:
+		c.ptr = uint16(c.read((c.ptr+1)&0x00FF))<<8 | c.addrAbs
+		c.index(a, c.Y)
+	case 5:
+		c.indexed(a)
+	default:
+		c.access(a, c.step-5)
+	}
+}
+
+// index adds index to the base address in ptr. Reads take an extra cycle to carry into
+// the high byte if they cross a page.
+func (c *CPU) index(a access, index byte) {
+	c.addrAbs = c.ptr + uint16(index)
+	if a == accessRead && c.addrAbs&0xFF00 != c.ptr&0xFF00 {
+		c.Cycles++
+	}
+}
+
+// indexed makes the access to the indexed address before its high byte is carried
+// into, from the base address in ptr. It's the operand read if there was nothing to
+// carry; otherwise, and always before writes, it's a dummy read.
+func (c *CPU) indexed(a access) {
+	crossed := c.addrAbs&0xFF00 != c.ptr&0xFF00
+	if a == accessRead && !crossed {
+		c.Lookup[c.opcode].Operate()
+		c.done()
+		return
+	}
+	c.read(c.ptr&0xFF00 | c.addrAbs&0x00FF)
+}
+
+// branchTaken decodes a branch opcode: bits 7-6 select N, V, C or Z, and bit 5 is the
+// value the flag must have.
+func (c *CPU) branchTaken() bool {
+	flag := [4]byte{'N', 'V', 'C', 'Z'}[c.opcode>>6]
+	return c.getFlag(flag) == c.opcode>>5&1
+}
+
+func (c *CPU) branchCycle() {
+	switch c.step {
+	case 2:
+		c.addrRel = uint16(c.read(c.PC))
+		c.PC++
+		if c.addrRel&0x80 != 0 {
+			c.addrRel |= 0xFF00
+		}
+		if !c.branchTaken() {
+			c.done()
+			return
+		}
+		c.Cycles++
+	case 3:
+		c.read(c.PC)
+		c.addrAbs = c.PC + c.addrRel
+		if c.addrAbs&0xFF00 == c.PC&0xFF00 {
+			c.PC = c.addrAbs
+			c.done()
+			return
+		}
+		c.PC = c.PC&0xFF00 | c.addrAbs&0x00FF
+		c.Cycles++
+	default:
+		c.read(c.PC)
+		c.PC = c.addrAbs
+		c.done()
+	}
+}
+
+func (c *CPU) jmpCycle() {
+	if c.step == 2 {
+		c.addrAbs = uint16(c.read(c.PC))
+		c.PC++
+		return
+	}
+	c.PC = uint16(c.read(c.PC))<<8 | c.addrAbs
+	c.done()
+}
+
+func (c *CPU) jmpIndirectCycle() {
+	switch c.step {
+	case 2:
+		c.ptr = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.ptr |= uint16(c.read(c.PC)) << 8
+		c.PC++
+	case 4:
+		c.addrAbs = uint16(c.read(c.ptr))
+	default:
+		// The high byte comes from the same page, even if the pointer is at its end
+		c.PC = uint16(c.read(c.ptr&0xFF00|(c.ptr+1)&0x00FF))<<8 | c.addrAbs
+		c.done()
+	}
+}
+
+func (c *CPU) jsrCycle() {
+	switch c.step {
+	case 2:
+		c.addrAbs = uint16(c.read(c.PC))
+		c.PC++
+	case 3:
+		c.read(0x0100 + uint16(c.SP))
+	case 4:
+		c.push(byte(c.PC >> 8))
+	case 5:
+		c.push(byte(c.PC))
+	default:
+		c.PC = uint16(c.read(c.PC))<<8 | c.addrAbs
+		c.done()
+	}
+}
+
+func (c *CPU) rtsCycle() {
+	switch c.step {
+	case 2:
+		c.read(c.PC)
+	case 3:
+		c.read(0x0100 + uint16(c.SP))
+	case 4:
+		c.addrAbs = uint16(c.pop())
+	case 5:
+		c.PC = uint16(c.pop())<<8 | c.addrAbs
+	default:
+		c.read(c.PC)
+		c.PC++
+		c.done()
+	}
+}
+
+func (c *CPU) rtiCycle() {
+	switch c.step {
+	case 2:
+		c.read(c.PC)
+	case 3:
+		c.read(0x0100 + uint16(c.SP))
+	case 4:
+		c.P = c.pop()&^(B|U) | U
+	case 5:
+		c.addrAbs = uint16(c.pop())
+	default:
+		c.PC = uint16(c.pop())<<8 | c.addrAbs
+		c.done()
+	}
+}
+
+// brkCycle runs BRK, and IRQs and NMIs, which differ only in not skipping a byte after
+// the opcode and pushing P with B clear. An NMI that arrives before the vector is read
+// takes it over, whatever started the sequence.
+func (c *CPU) brkCycle() {
+	switch c.step {
+	case 2:
+		c.read(c.PC)
+		if !c.interrupt {
+			c.PC++
+		}
+	case 3:
+		c.push(byte(c.PC >> 8))
+	case 4:
+		c.push(byte(c.PC))
+	case 5:
+		if c.interrupt {
+			c.push(c.P&^B | U)
+		} else {
+			c.push(c.P | B | U)
+		}
+		c.setFlag('I', true)
+		// An NMI that arrived by now hijacks the vector of a BRK or IRQ, which is then
+		// never taken on its own. BRK's B flag is already on the stack
+		c.ptr = 0xFFFE
+		if c.nmiPending {
+			c.ptr = 0xFFFA
+			c.nmiPending = false
+		}
+	case 6:
+		c.addrAbs = uint16(c.read(c.ptr))
+	default:
+		c.PC = uint16(c.read(c.ptr+1))<<8 | c.addrAbs
+		c.interrupt = false
+		c.poll = false
+		c.done()
+	}
+}
@@ -0,0 +1,190 @@
+package cpu
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// recordingBus logs every access the CPU makes.
+type recordingBus struct {
+	mockBus
+	accesses []string
+}
+
+func (b *recordingBus) Read(addr uint16) byte {
+	b.accesses = append(b.accesses, fmt.Sprintf("R %04X", addr))
+	return b.ram[addr]
+}
+
+func (b *recordingBus) Write(addr uint16, data byte) {
+	b.accesses = append(b.accesses, fmt.Sprintf("W %04X %02X", addr, data))
+	b.ram[addr] = data
+}
+
+// clocksToComplete clocks the CPU through the next instruction and counts the cycles.
+func clocksToComplete(c *CPU) int {
+	n, _ := clocksLeft(c)
+	return n
+}
+
+// clocksLeft clocks the CPU through the next instruction, noting Cycles after each clock.
+func clocksLeft(c *CPU) (int, []int) {
+	var left []int
+	for {
+		c.Clock()
+		left = append(left, c.Cycles)
+		if c.IsInstructionComplete() {
+			return len(left), left
+		}
+	}
+}
+
+func TestCycleSteppingMatchesInstructionStepping(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for op := 0; op < 256; op++ {
+		for trial := 0; trial < 8; trial++ {
+			var mem mockBus
+			rng.Read(mem.ram[:])
+			mem.ram[0x8000] = byte(op)
+			regs := make([]byte, 5)
+			rng.Read(regs)
+
+			run := func(instructionStepping bool) (*CPU, *mockBus, []int) {
+				bus := mem
+				c := New(nil)
+				c.ConnectBus(&bus)
+				c.SetInstructionStepping(instructionStepping)
+				c.PC = 0x8000
+				c.A, c.X, c.Y, c.SP, c.P = regs[0], regs[1], regs[2], regs[3], regs[4]|U
+				_, left := clocksLeft(c)
+				return c, &bus, left
+			}
+			want, wantBus, wantLeft := run(true)
+			got, gotBus, gotLeft := run(false)
+
+			name := fmt.Sprintf("%02X %s %s", op, got.Lookup[op].Name, got.Lookup[op].AddrModeName)
+			if len(gotLeft) != len(wantLeft) {
+				t.Errorf("%s: Expected %d cycles, got %d", name, len(wantLeft), len(gotLeft))
+			}
+			// Cycles counts down the cycles left, though an extra one for crossing a page
+			// or taking a branch is only added once the CPU finds it needs it
+			for i, n := range gotLeft {
+				if left := len(gotLeft) - 1 - i; n > left || n == 0 && left > 0 || i >= len(gotLeft)-2 && n != left {
+					t.Errorf("%s: Expected Cycles to count down the cycles left, got %v", name, gotLeft)
+					break
+				}
+			}
+			if g, w := got.LogState(), want.LogState(); g != w {
+				t.Errorf("%s: Expected %s, got %s", name, w, g)
+			}
+			if gotBus.ram != wantBus.ram {
+				t.Errorf("%s: Expected the same memory after both modes", name)
+			}
+		}
+	}
+}
+
+func TestCycleAccesses(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		want    []string
+	}{
+		{"LDA abs,X", []byte{0xBD, 0x10, 0x12}, []string{"R 8000", "R 8001", "R 8002", "R 1211"}},
+		{"LDA abs,X across a page", []byte{0xBD, 0xFF, 0x12}, []string{"R 8000", "R 8001", "R 8002", "R 1200", "R 1300"}},
+		{"STA abs,X", []byte{0x9D, 0x10, 0x12}, []string{"R 8000", "R 8001", "R 8002", "R 1211", "W 1211 00"}},
+		{"INC zp", []byte{0xE6, 0x10}, []string{"R 8000", "R 8001", "R 0010", "W 0010 41", "W 0010 42"}},
+		{"JSR", []byte{0x20, 0x34, 0x12}, []string{"R 8000", "R 8001", "R 01FD", "W 01FD 80", "W 01FC 02", "R 8002"}},
+		{"BNE taken", []byte{0xD0, 0x02}, []string{"R 8000", "R 8001", "R 8002"}},
+	}
+	for _, tt := range tests {
+		bus := &recordingBus{}
+		copy(bus.ram[0x8000:], tt.program)
+		bus.ram[0x0010] = 0x41
+		c := New(nil)
+		c.ConnectBus(bus)
+		c.PC, c.SP, c.X, c.P = 0x8000, 0xFD, 0x01, U
+
+		if n := clocksToComplete(c); n != len(tt.want) {
+			t.Errorf("%s: Expected %d cycles, got %d", tt.name, len(tt.want), n)
+		}
+		if !slices.Equal(bus.accesses, tt.want) {
+			t.Errorf("%s: Expected accesses %v, got %v", tt.name, tt.want, bus.accesses)
+		}
+	}
+}
+
+func TestInterruptPolling(t *testing.T) {
+	c, bus := setupCPU(t)
+	bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90 // NMI vector -> $9000
+	bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0xA0 // IRQ vector -> $A000
+	bus.ram[0x8000] = 0x58                        // CLI
+	bus.ram[0x8001] = 0xEA                        // NOP
+
+	// I is only cleared on CLI's last cycle, after the poll, so the IRQ waits for the NOP
+	c.AssertIRQ()
+	runInstruction(c)
+	runInstruction(c)
+	if c.PC != 0x8002 {
+		t.Fatalf("Expected the IRQ to wait until after the instruction following CLI, PC = $%04X", c.PC)
+	}
+	runInstruction(c)
+	if c.PC != 0xA000 {
+		t.Fatalf("Expected the IRQ to be taken, PC = $%04X", c.PC)
+	}
+
+	// An NMI that arrives while an IRQ is pushing the return address takes it over
+	c, bus = setupCPU(t)
+	bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90
+	bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0xA0
+	bus.ram[0x8000] = 0xEA // NOP
+	c.P = U
+	c.AssertIRQ()
+	runInstruction(c)
+	c.Clock() // The first cycle of the IRQ
+	c.AssertNMI()
+	for !c.IsInstructionComplete() {
+		c.Clock()
+	}
+	if c.PC != 0x9000 || c.NMIPending() {
+		t.Errorf("Expected the NMI to hijack the IRQ, PC = $%04X", c.PC)
+	}
+}
+
+func TestBRKHijack(t *testing.T) {
+	tests := []struct {
+		name    string
+		nmiAt   int // Cycles of BRK run before the NMI arrives
+		wantPC  uint16
+		pending bool
+	}{
+		{"during the return address pushes", 3, 0x9000, false},
+		{"before P is pushed", 4, 0x9000, false},
+		{"after P is pushed", 5, 0xA000, true},
+	}
+	for _, tt := range tests {
+		c, bus := setupCPU(t)
+		bus.ram[0xFFFA], bus.ram[0xFFFB] = 0x00, 0x90 // NMI vector -> $9000
+		bus.ram[0xFFFE], bus.ram[0xFFFF] = 0x00, 0xA0 // IRQ vector -> $A000
+		bus.ram[0x8000] = 0x00                        // BRK
+		c.SP, c.P = 0xFD, U
+
+		for range tt.nmiAt {
+			c.Clock()
+		}
+		c.AssertNMI()
+		for !c.IsInstructionComplete() {
+			c.Clock()
+		}
+
+		if c.PC != tt.wantPC || c.NMIPending() != tt.pending {
+			t.Errorf("%s: Expected PC = $%04X with the NMI pending %v, got $%04X and %v", tt.name, tt.wantPC, tt.pending, c.PC, c.NMIPending())
+		}
+		// Either way the stack holds BRK's return address and P with B set
+		if ret := uint16(bus.ram[0x01FD])<<8 | uint16(bus.ram[0x01FC]); ret != 0x8002 || bus.ram[0x01FB]&B == 0 {
+			t.Errorf("%s: Expected BRK's frame on the stack, got return address $%04X and P %02X", tt.name, ret, bus.ram[0x01FB])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cpu
+
+import "sync/atomic"
+
+// DebugHooks are called when the CPU reaches a breakpoint or accesses a watched address.
+// They run on the goroutine clocking the CPU, so they must not block.
+type DebugHooks struct {
+	// Break runs on the instruction boundary before the instruction at a breakpoint
+	// executes, so an owner that stops there has PC on the breakpoint
+	Break func(pc uint16)
+	// Read runs after a watched address is read, with the value read
+	Read func(addr uint16, data byte)
+	// Write runs before a watched address is written
+	Write func(addr uint16, data byte)
+}
+
+// addrSet is a set of addresses that a debugger goroutine can change while the CPU
+// checks it on every access.
+type addrSet [0x10000 / 64]atomic.Uint64
+
+func (s *addrSet) has(addr uint16) bool {
+	return s[addr>>6].Load()&(1<<(addr&63)) != 0
+}
+
+func (s *addrSet) set(addr uint16, on bool) {
+	if on {
+		s[addr>>6].Or(1 << (addr & 63))
+	} else {
+		s[addr>>6].And(^uint64(1 << (addr & 63)))
+	}
+}
+
+// debugStops are the addresses the debug hooks fire for.
+type debugStops struct {
+	breaks, reads, writes addrSet
+}
+
+// SetDebugHooks sets the callbacks for breakpoints and watches. Set them before the CPU
+// runs; the stops themselves can change at any time.
+func (c *CPU) SetDebugHooks(h DebugHooks) {
+	c.debugHooks = h
+}
+
+// SetBreakpoint sets or clears a breakpoint on the instruction at addr.
+func (c *CPU) SetBreakpoint(addr uint16, on bool) {
+	c.stops().breaks.set(addr, on)
+}
+
+// SetReadWatch sets or clears a watch on reads from addr, including opcode fetches.
+func (c *CPU) SetReadWatch(addr uint16, on bool) {
+	c.stops().reads.set(addr, on)
+}
+
+// SetWriteWatch sets or clears a watch on writes to addr.
+func (c *CPU) SetWriteWatch(addr uint16, on bool) {
+	c.stops().writes.set(addr, on)
+}
+
+// stops returns the debug stops, allocating them the first time a debugger sets one so
+// that a CPU without a debugger doesn't carry them.
+func (c *CPU) stops() *debugStops {
+	if d := c.debug.Load(); d != nil {
+		return d
+	}
+	c.debug.CompareAndSwap(nil, new(debugStops))
+	return c.debug.Load()
+}
+
+// read reads the bus, running the read watch hook for watched addresses.
+func (c *CPU) read(addr uint16) byte {
+	data := c.bus.Read(addr)
+	if d := c.debug.Load(); d != nil && d.reads.has(addr) && c.debugHooks.Read != nil {
+		c.debugHooks.Read(addr, data)
+	}
+	return data
+}
+
+// write writes the bus, running the write watch hook for watched addresses first.
+func (c *CPU) write(addr uint16, data byte) {
+	if d := c.debug.Load(); d != nil && d.writes.has(addr) && c.debugHooks.Write != nil {
+		c.debugHooks.Write(addr, data)
+	}
+	c.bus.Write(addr, data)
+}
+
+// checkBreakpoint runs the break hook if the instruction at PC, which the CPU is about to
+// start, has a breakpoint. Call it on instruction boundaries.
+func (c *CPU) checkBreakpoint() {
+	if d := c.debug.Load(); d != nil && d.breaks.has(c.PC) && c.debugHooks.Break != nil {
+		c.debugHooks.Break(c.PC)
+	}
+}
@@ -0,0 +1,58 @@
+package cpu
+
+import "testing"
+
+func TestDebugHooks(t *testing.T) {
+	for _, instructionStepping := range []bool{false, true} {
+		c, bus := setupCPU(t)
+		c.SetInstructionStepping(instructionStepping)
+		copy(bus.ram[0x8000:], []byte{0xAD, 0x00, 0x02, 0x8D, 0x01, 0x02, 0xEA}) // LDA $0200, STA $0201, NOP
+		bus.ram[0x0200] = 0x42
+
+		var breaks []uint16
+		var reads, writes []uint16
+		c.SetDebugHooks(DebugHooks{
+			Break: func(pc uint16) {
+				breaks = append(breaks, pc)
+				if bus.ram[0x0201] != 0 {
+					t.Errorf("Stepping %v: Expected the break before STA runs", instructionStepping)
+				}
+			},
+			Read: func(addr uint16, data byte) {
+				reads = append(reads, addr)
+				if data != 0x42 {
+					t.Errorf("Stepping %v: Expected the watched read to see $42, got $%02X", instructionStepping, data)
+				}
+			},
+			Write: func(addr uint16, data byte) {
+				writes = append(writes, addr)
+				if bus.ram[addr] != 0 || data != 0x42 {
+					t.Errorf("Stepping %v: Expected the write hook before $42 is written, memory holds $%02X", instructionStepping, bus.ram[addr])
+				}
+			},
+		})
+		c.SetBreakpoint(0x8003, true)
+		c.SetReadWatch(0x0200, true)
+		c.SetWriteWatch(0x0201, true)
+
+		runInstruction(c) // LDA
+		if len(reads) != 1 || len(breaks) != 1 || breaks[0] != 0x8003 {
+			t.Errorf("Stepping %v: Expected a read watch hit and a break at $8003, got reads %v and breaks %v", instructionStepping, reads, breaks)
+		}
+		runInstruction(c) // STA
+		if len(writes) != 1 || writes[0] != 0x0201 {
+			t.Errorf("Stepping %v: Expected a write watch hit at $0201, got %v", instructionStepping, writes)
+		}
+
+		// Cleared, they stay quiet
+		c.SetBreakpoint(0x8003, false)
+		c.SetReadWatch(0x0200, false)
+		c.SetWriteWatch(0x0201, false)
+		c.PC = 0x8000
+		runInstruction(c)
+		runInstruction(c)
+		if len(breaks) != 1 || len(reads) != 1 || len(writes) != 1 {
+			t.Errorf("Stepping %v: Expected cleared stops not to fire, got breaks %v, reads %v, writes %v", instructionStepping, breaks, reads, writes)
+		}
+	}
+}
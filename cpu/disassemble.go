@@ -0,0 +1,114 @@
+package cpu
+
+import (
+	"fmt"
+	"sync"
+)
+
+// opcodes is the lookup table's names and addressing modes, for decoding without a CPU.
+var opcodes = sync.OnceValue(func() [256]Instruction {
+	return New(nil).Lookup
+})
+
+// Disassembly is one decoded instruction.
+type Disassembly struct {
+	Addr     uint16
+	Opcode   byte
+	Operand  uint16 // The operand bytes, little-endian; zero-page addresses and immediates fit in the low byte
+	Mnemonic string
+	Mode     string // As in Instruction.AddrModeName
+}
+
+// OperandSize returns the number of operand bytes following an opcode in the given mode.
+func OperandSize(mode string) int {
+	switch mode {
+	case "imm", "zp0", "zpx", "zpy", "rel", "izx", "izy":
+		return 1
+	case "abs", "abx", "aby", "ind":
+		return 2
+	}
+	return 0
+}
+
+// Decode decodes the instruction at addr, reading memory through read.
+func Decode(read func(uint16) byte, addr uint16) Disassembly {
+	op := read(addr)
+	d := Disassembly{Addr: addr, Opcode: op, Mnemonic: opcodes()[op].Name, Mode: opcodes()[op].AddrModeName}
+	for i := OperandSize(d.Mode); i > 0; i-- {
+		d.Operand = d.Operand<<8 | uint16(read(addr+uint16(i)))
+	}
+	return d
+}
+
+// Disassemble decodes the instructions from start up to the last one starting at or
+// before end, reading memory through read. Data between instructions is decoded as
+// code, so start should be an address execution reaches.
+func Disassemble(read func(uint16) byte, start, end uint16) []Disassembly {
+	var out []Disassembly
+	for addr := int(start); addr <= int(end); {
+		d := Decode(read, uint16(addr))
+		out = append(out, d)
+		addr += d.Size()
+	}
+	return out
+}
+
+// Size returns the instruction's length in bytes.
+func (d Disassembly) Size() int {
+	return 1 + OperandSize(d.Mode)
+}
+
+// Bytes returns the instruction's opcode and operand bytes.
+func (d Disassembly) Bytes() []byte {
+	b := []byte{d.Opcode, byte(d.Operand), byte(d.Operand >> 8)}
+	return b[:d.Size()]
+}
+
+// Target returns the address a branch goes to, from the instruction's own address.
+func (d Disassembly) Target() uint16 {
+	return d.Addr + 2 + uint16(int8(d.Operand))
+}
+
+// String renders the instruction in assembler syntax, e.g. "LDA ($10),Y".
+func (d Disassembly) String() string {
+	return d.Format(nil)
+}
+
+// Format renders the instruction in assembler syntax, naming the addresses label has
+// names for. label may be nil.
+func (d Disassembly) Format(label func(addr uint16) (string, bool)) string {
+	addr := func(a uint16, digits int) string {
+		if label != nil {
+			if name, ok := label(a); ok {
+				return name
+			}
+		}
+		return fmt.Sprintf("$%0*X", digits, a)
+	}
+
+	switch d.Mode {
+	case "imm":
+		return fmt.Sprintf("%s #$%02X", d.Mnemonic, d.Operand)
+	case "zp0":
+		return d.Mnemonic + " " + addr(d.Operand, 2)
+	case "zpx":
+		return d.Mnemonic + " " + addr(d.Operand, 2) + ",X"
+	case "zpy":
+		return d.Mnemonic + " " + addr(d.Operand, 2) + ",Y"
+	case "rel":
+		return d.Mnemonic + " " + addr(d.Target(), 4)
+	case "abs":
+		return d.Mnemonic + " " + addr(d.Operand, 4)
+	case "abx":
+		return d.Mnemonic + " " + addr(d.Operand, 4) + ",X"
+	case "aby":
+		return d.Mnemonic + " " + addr(d.Operand, 4) + ",Y"
+	case "ind":
+		return d.Mnemonic + " (" + addr(d.Operand, 4) + ")"
+	case "izx":
+		return d.Mnemonic + " (" + addr(d.Operand, 2) + ",X)"
+	case "izy":
+		return d.Mnemonic + " (" + addr(d.Operand, 2) + "),Y"
+	}
+	return d.Mnemonic
+}
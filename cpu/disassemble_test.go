@@ -0,0 +1,53 @@
+package cpu
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDisassemble(t *testing.T) {
+	var mem [0x10000]byte
+	copy(mem[0x8000:], []byte{
+		0xA9, 0x1E, // $8000 LDA #$1E
+		0x8D, 0x01, 0x20, // $8002 STA $2001
+		0xB1, 0x10, // $8005 LDA ($10),Y
+		0x6C, 0xFC, 0xFF, // $8007 JMP ($FFFC)
+		0xD0, 0xF4, // $800A BNE $8000
+		0xEA, // $800C NOP
+	})
+	read := func(addr uint16) byte { return mem[addr] }
+
+	want := []string{"LDA #$1E", "STA $2001", "LDA ($10),Y", "JMP ($FFFC)", "BNE $8000"}
+	got := Disassemble(read, 0x8000, 0x800B)
+	var text []string
+	for _, d := range got {
+		text = append(text, d.String())
+	}
+	if !slices.Equal(text, want) {
+		t.Errorf("Expected %v, got %v", want, text)
+	}
+	if d := got[1]; d.Addr != 0x8002 || d.Opcode != 0x8D || d.Operand != 0x2001 || d.Mode != "abs" {
+		t.Errorf("Expected STA abs $2001 at $8002, got %+v", d)
+	}
+	if b := got[3].Bytes(); !slices.Equal(b, []byte{0x6C, 0xFC, 0xFF}) {
+		t.Errorf("Expected the JMP's bytes, got % X", b)
+	}
+
+	labels := map[uint16]string{0x8000: "start", 0x2001: "PPUMASK"}
+	label := func(addr uint16) (string, bool) {
+		name, ok := labels[addr]
+		return name, ok
+	}
+	if s := got[1].Format(label); s != "STA PPUMASK" {
+		t.Errorf("Expected STA PPUMASK, got %s", s)
+	}
+	if s := got[4].Format(label); s != "BNE start" {
+		t.Errorf("Expected BNE start, got %s", s)
+	}
+
+	// The end of the address space ends the listing instead of wrapping
+	mem[0xFFFF] = 0xEA
+	if got := Disassemble(read, 0xFFFF, 0xFFFF); len(got) != 1 || got[0].Mnemonic != "NOP" {
+		t.Errorf("Expected a single NOP at $FFFF, got %v", got)
+	}
+}
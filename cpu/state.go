@@ -1,16 +1,56 @@
 package cpu
 
+import "github.com/meadori/vibemulator/snap"
+
 type State struct {
 	PC, AddrAbs, AddrRel            uint16
 	SP, A, X, Y, P, Opcode, Fetched byte
 	Cycles                          int
-	NmiPending, IrqPending          bool
+	NmiLine, NmiPending, IrqPending bool
+	// Progress through the instruction in progress when cycle-stepped
+	Step            int
+	Ptr             uint16
+	Poll, Interrupt bool
+	Jammed          bool
 }
 
 func (c *CPU) SaveState() State {
-	return State{c.PC, c.addrAbs, c.addrRel, c.SP, c.A, c.X, c.Y, c.P, c.opcode, c.fetched, c.Cycles, c.nmiPending, c.irqPending}
+	return State{c.PC, c.addrAbs, c.addrRel, c.SP, c.A, c.X, c.Y, c.P, c.opcode, c.fetched, c.Cycles, c.nmiLine, c.nmiPending, c.irqPending, c.step, c.ptr, c.poll, c.interrupt, c.Jammed}
 }
 
 func (c *CPU) LoadState(s State) {
-	c.PC, c.addrAbs, c.addrRel, c.SP, c.A, c.X, c.Y, c.P, c.opcode, c.fetched, c.Cycles, c.nmiPending, c.irqPending = s.PC, s.AddrAbs, s.AddrRel, s.SP, s.A, s.X, s.Y, s.P, s.Opcode, s.Fetched, s.Cycles, s.NmiPending, s.IrqPending
+	c.PC, c.addrAbs, c.addrRel, c.SP, c.A, c.X, c.Y, c.P, c.opcode, c.fetched, c.Cycles, c.nmiLine, c.nmiPending, c.irqPending, c.step, c.ptr, c.poll, c.interrupt, c.Jammed = s.PC, s.AddrAbs, s.AddrRel, s.SP, s.A, s.X, s.Y, s.P, s.Opcode, s.Fetched, s.Cycles, s.NmiLine, s.NmiPending, s.IrqPending, s.Step, s.Ptr, s.Poll, s.Interrupt, s.Jammed
+}
+
+// Snapshot writes the state saved by SaveState in snap's flat encoding.
+func (c *CPU) Snapshot(w *snap.Writer) {
+	s := c.SaveState()
+	w.U16(s.PC)
+	w.U16(s.AddrAbs)
+	w.U16(s.AddrRel)
+	for _, v := range [...]byte{s.SP, s.A, s.X, s.Y, s.P, s.Opcode, s.Fetched} {
+		w.U8(v)
+	}
+	w.Int(s.Cycles)
+	w.Bool(s.NmiLine)
+	w.Bool(s.NmiPending)
+	w.Bool(s.IrqPending)
+	w.Int(s.Step)
+	w.U16(s.Ptr)
+	w.Bool(s.Poll)
+	w.Bool(s.Interrupt)
+	w.Bool(s.Jammed)
+}
+
+// Restore loads a state written by Snapshot.
+func (c *CPU) Restore(r *snap.Reader) {
+	var s State
+	s.PC, s.AddrAbs, s.AddrRel = r.U16(), r.U16(), r.U16()
+	s.SP, s.A, s.X, s.Y, s.P, s.Opcode, s.Fetched = r.U8(), r.U8(), r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+	s.Cycles = r.Int()
+	s.NmiLine, s.NmiPending, s.IrqPending = r.Bool(), r.Bool(), r.Bool()
+	s.Step, s.Ptr = r.Int(), r.U16()
+	s.Poll, s.Interrupt = r.Bool(), r.Bool()
+	s.Jammed = r.Bool()
+	c.LoadState(s)
 }
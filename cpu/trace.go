@@ -0,0 +1,86 @@
+package cpu
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TraceSource is what a Trace reads besides the CPU's registers.
+type TraceSource interface {
+	// Peek reads memory without side effects, to decode the instruction
+	Peek(addr uint16) byte
+	// TracePosition returns the CPU cycles since power-on and where the PPU is
+	TracePosition() (cycle, scanline, dot int)
+}
+
+// TraceEntry is the CPU's state as an instruction began.
+type TraceEntry struct {
+	Instruction    Disassembly
+	A, X, Y, P, SP byte
+	Cycle          int
+	Scanline, Dot  int
+}
+
+// String formats the entry as a line of nestest's golden log.
+func (e TraceEntry) String() string {
+	raw := make([]string, 0, 3)
+	for _, b := range e.Instruction.Bytes() {
+		raw = append(raw, fmt.Sprintf("%02X", b))
+	}
+	return fmt.Sprintf("%04X  %-8s  %-32sA:%02X X:%02X Y:%02X P:%02X SP:%02X PPU:%3d,%3d CYC:%d",
+		e.Instruction.Addr, strings.Join(raw, " "), e.Instruction,
+		e.A, e.X, e.Y, e.P, e.SP, e.Scanline, e.Dot, e.Cycle)
+}
+
+// Trace keeps the last instructions a CPU executed in a ring buffer, for finding out
+// how it got somewhere long after the fact. Interrupts aren't recorded, but the first
+// instruction of their handler is.
+type Trace struct {
+	src     TraceSource
+	entries []TraceEntry
+	next    int  // Where the next entry goes
+	full    bool // Whether next has wrapped around
+}
+
+// NewTrace returns a trace of the last size instructions, which must be at least one,
+// reading memory and positions from src.
+func NewTrace(size int, src TraceSource) *Trace {
+	return &Trace{src: src, entries: make([]TraceEntry, size)}
+}
+
+// SetTrace has the CPU record each instruction it starts into t, or stops recording if
+// t is nil.
+func (c *CPU) SetTrace(t *Trace) {
+	c.trace = t
+}
+
+// record notes the instruction the CPU has just fetched the opcode of.
+func (t *Trace) record(c *CPU) {
+	e := &t.entries[t.next]
+	e.Instruction = Decode(t.src.Peek, c.opPC)
+	e.A, e.X, e.Y, e.P, e.SP = c.A, c.X, c.Y, c.P, c.SP
+	e.Cycle, e.Scanline, e.Dot = t.src.TracePosition()
+	t.next++
+	if t.next == len(t.entries) {
+		t.next, t.full = 0, true
+	}
+}
+
+// Entries returns the recorded instructions, oldest first.
+func (t *Trace) Entries() []TraceEntry {
+	if !t.full {
+		return append([]TraceEntry(nil), t.entries[:t.next]...)
+	}
+	return append(append([]TraceEntry(nil), t.entries[t.next:]...), t.entries[:t.next]...)
+}
+
+// Dump writes the recorded instructions to w in nestest's log format, oldest first.
+func (t *Trace) Dump(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range t.Entries() {
+		fmt.Fprintln(bw, e)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,79 @@
+package cpu
+
+import (
+	"strings"
+	"testing"
+)
+
+// traceBus is a mockBus that counts clocks for a Trace.
+type traceBus struct {
+	*mockBus
+	clocks int
+}
+
+func (b *traceBus) Peek(addr uint16) byte { return b.ram[addr] }
+
+func (b *traceBus) TracePosition() (cycle, scanline, dot int) {
+	return b.clocks, 0, b.clocks * 3
+}
+
+func TestTrace(t *testing.T) {
+	for _, instructionStepping := range []bool{false, true} {
+		c, bus := setupCPU(t)
+		c.SetInstructionStepping(instructionStepping)
+		copy(bus.ram[0x8000:], []byte{
+			0xA9, 0x1E, // LDA #$1E
+			0xAA,             // TAX
+			0x8D, 0x00, 0x02, // STA $0200
+			0xE8, // INX
+		})
+		src := &traceBus{mockBus: bus}
+		trace := NewTrace(3, src)
+		c.SetTrace(trace)
+
+		if got := trace.Entries(); len(got) != 0 {
+			t.Errorf("Expected an empty trace, got %v", got)
+		}
+		for range 4 {
+			for {
+				c.Clock()
+				src.clocks++
+				if c.IsInstructionComplete() {
+					break
+				}
+			}
+		}
+
+		// The oldest of the four instructions has been overwritten
+		got := trace.Entries()
+		want := []struct {
+			addr   uint16
+			text   string
+			a, x   byte
+			cycles int
+		}{
+			{0x8002, "TAX", 0x1E, 0x00, 2},
+			{0x8003, "STA $0200", 0x1E, 0x1E, 4},
+			{0x8006, "INX", 0x1E, 0x1E, 8},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Expected %d entries, got %d", len(want), len(got))
+		}
+		for i, w := range want {
+			e := got[i]
+			if e.Instruction.Addr != w.addr || e.Instruction.String() != w.text || e.A != w.a || e.X != w.x || e.Cycle != w.cycles {
+				t.Errorf("Entry %d: expected %04X %s A:%02X X:%02X CYC:%d, got %s", i, w.addr, w.text, w.a, w.x, w.cycles, e)
+			}
+		}
+
+		var sb strings.Builder
+		if err := trace.Dump(&sb); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+		wantLine := "8003  8D 00 02  STA $0200                       A:1E X:1E Y:00 P:24 SP:FD PPU:  0, 12 CYC:4"
+		if len(lines) != 3 || lines[1] != wantLine {
+			t.Errorf("Expected the dump's second line to be\n%s\ngot\n%s", wantLine, sb.String())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package display
+
+import (
+	"image/color"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/meadori/vibemulator/storage"
+)
+
+// autosave is the rolling crash-recovery savestate, and the prompt that offers to
+// resume from it when its ROM is loaded or powered back on.
+type autosave struct {
+	last      time.Time     // When the last autosave was taken
+	writing   chan struct{} // Holds a token while an autosave is written in the background
+	offer     string        // The autosave the prompt offers to resume from, while it is open
+	offerTime time.Time
+}
+
+// offerResume opens the resume prompt if the loaded ROM has an autosave. The game waits
+// until the player answers, so nothing overwrites the autosave first.
+func (d *Display) offerResume() {
+	d.autosave.offer = ""
+	d.autosave.last = time.Now()
+	if !d.cfg.Autosave.Enabled || d.bus.IsPlaying() {
+		return
+	}
+	folder, err := d.romFolder()
+	if err != nil {
+		return
+	}
+	if fi, err := os.Stat(folder.Autosave()); err == nil {
+		d.autosave.offer, d.autosave.offerTime = folder.Autosave(), fi.ModTime()
+	}
+}
+
+func (d *Display) updateResumePrompt() {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyY):
+		if err := d.bus.LoadState(d.autosave.offer); err != nil {
+			d.showError("Error resuming: %v", err)
+		} else {
+			d.rewindBuffer.Clear()
+			d.showMessage("Resumed from the autosave of %s", d.autosave.offerTime.Format(time.Stamp))
+		}
+		d.autosave.offer = ""
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape), inpututil.IsKeyJustPressed(ebiten.KeyN):
+		d.autosave.offer = ""
+	}
+}
+
+// autosaveTick starts an autosave in the background when one is due.
+func (d *Display) autosaveTick() {
+	if time.Since(d.autosave.last) < time.Duration(d.cfg.Autosave.Seconds)*time.Second {
+		return
+	}
+	d.autosave.last = time.Now()
+	path, data, ok := d.takeAutosave()
+	if !ok {
+		return
+	}
+	select {
+	case d.autosave.writing <- struct{}{}:
+		go func() {
+			defer func() { <-d.autosave.writing }()
+			if err := storage.WriteFile(path, data); err != nil {
+				// The game loop owns the message bar, so this one only reaches the log
+				log.Printf("Error writing autosave: %v", err)
+			}
+		}()
+	default:
+		// The previous autosave is still being written; skip this one
+	}
+}
+
+// WriteAutosave saves the game to its autosave now, waiting for the write. The display
+// calls it when the power is switched off; call it once more after the game loop exits.
+func (d *Display) WriteAutosave() error {
+	path, data, ok := d.takeAutosave()
+	if !ok {
+		return nil
+	}
+	d.autosave.writing <- struct{}{} // Wait for a background write so it can't land last
+	defer func() { <-d.autosave.writing }()
+	return storage.WriteFile(path, data)
+}
+
+// takeAutosave returns the state to autosave and where to write it. It reports false
+// when there is nothing to save, or while the prompt still offers the current autosave.
+func (d *Display) takeAutosave() (string, []byte, bool) {
+	if !d.cfg.Autosave.Enabled || !d.powerOn || d.autosave.offer != "" || !d.bus.HasCartridge() {
+		return "", nil, false
+	}
+	folder, err := d.romFolder()
+	if err != nil {
+		return "", nil, false
+	}
+	data, err := d.bus.SaveStateToBytes()
+	if err != nil {
+		d.showError("Error taking autosave: %v", err)
+		return "", nil, false
+	}
+	return folder.Autosave(), data, true
+}
+
+func (d *Display) drawResumePrompt(screen *ebiten.Image) {
+	w, h := float32(360), float32(90)
+	x, y := float32(ScaledWidth())/2-w/2, float32(ScaledHeight())/2-h/2
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{0, 0, 0, 220}, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, h-2, 2, color.White, false)
+
+	ebitenutil.DebugPrintAt(screen, "RESUME?", int(x)+12, int(y)+10)
+	ebitenutil.DebugPrintAt(screen, "Autosave from "+d.autosave.offerTime.Format(time.Stamp), int(x)+12, int(y)+34)
+	ebitenutil.DebugPrintAt(screen, "ENTER RESUME  ESC START OVER", int(x)+12, int(y+h)-22)
+}
@@ -0,0 +1,54 @@
+package display
+
+import (
+	"image"
+
+	"github.com/meadori/vibemulator/config"
+)
+
+// phosphorDecay is how much of a pixel's brightness survives into the next frame with
+// phosphor blending: a sprite drawn every other frame stays at three quarters.
+const phosphorDecay = 0.75
+
+// frameBlender smooths the flicker of games that multiplex sprites over alternate
+// frames, by combining each finished PPU frame with the ones before it.
+type frameBlender struct {
+	mode  string
+	frame int    // PPU frame the output was made from
+	prev  []byte // The previous PPU frame, for mixing
+	out   image.RGBA
+}
+
+// blend returns frame number n, cur, blended with the frames before it according to
+// mode. Drawing the same frame again returns the same output, so a phosphor glow fades
+// with the game rather than the display.
+func (f *frameBlender) blend(mode string, n int, cur *image.RGBA) *image.RGBA {
+	if mode == config.BlendOff {
+		f.mode = mode
+		return cur
+	}
+	if f.mode != mode || len(f.prev) != len(cur.Pix) {
+		// Start from the current frame, with nothing to blend in
+		f.mode, f.frame = mode, n
+		f.prev = append(f.prev[:0], cur.Pix...)
+		f.out = image.RGBA{Pix: append([]byte(nil), cur.Pix...), Stride: cur.Stride, Rect: cur.Rect}
+		return &f.out
+	}
+	if n == f.frame {
+		return &f.out
+	}
+	f.frame = n
+
+	switch mode {
+	case config.BlendMix:
+		for i, c := range cur.Pix {
+			f.out.Pix[i] = byte((int(c) + int(f.prev[i]) + 1) / 2)
+		}
+	case config.BlendPhosphor:
+		for i, c := range cur.Pix {
+			f.out.Pix[i] = max(c, byte(float64(f.out.Pix[i])*phosphorDecay))
+		}
+	}
+	copy(f.prev, cur.Pix)
+	return &f.out
+}
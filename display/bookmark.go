@@ -0,0 +1,222 @@
+package display
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/meadori/vibemulator/bookmark"
+)
+
+const (
+	maxNoteLength    = 40
+	bookmarkListRows = 10
+)
+
+// bookmarks holds the F6 note prompt and the F2 browser. The game pauses while either
+// is open.
+type bookmarks struct {
+	// A bookmark waiting for its note, captured when F6 was pressed
+	pending    *bookmark.Info
+	screenshot *image.RGBA
+	state      []byte
+	note       []rune
+
+	// The browser's list of the loaded ROM's bookmarks, and the selected one's screenshot
+	list    []bookmark.Entry
+	row     int
+	browse  bool
+	preview *ebiten.Image
+}
+
+// updateBookmarkKeys handles F6 and F2, reporting whether one opened a prompt.
+func (d *Display) updateBookmarkKeys() bool {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyF6):
+		d.captureBookmark()
+	case inpututil.IsKeyJustPressed(ebiten.KeyF2):
+		d.openBookmarks()
+	default:
+		return false
+	}
+	return d.bookmarks.pending != nil || d.bookmarks.browse
+}
+
+// captureBookmark records the current frame and state, then asks for a note.
+func (d *Display) captureBookmark() {
+	if _, err := d.romFolder(); err != nil {
+		d.showError("Error adding bookmark: %v", err)
+		return
+	}
+	state, err := d.bus.SaveStateToBytes()
+	if err != nil {
+		d.showError("Error adding bookmark: %v", err)
+		return
+	}
+	frame := d.bus.PPU.GetFrame()
+	b := &d.bookmarks
+	b.pending = &bookmark.Info{ROMHash: d.bus.ROMHash(), ROMName: d.romName, Frame: d.bus.PPU.FrameCounter}
+	b.screenshot = &image.RGBA{Pix: append([]byte(nil), frame.Pix...), Stride: frame.Stride, Rect: frame.Rect}
+	b.state = state
+	b.note = b.note[:0]
+}
+
+// updateNotePrompt edits the note of a captured bookmark. Enter saves it and Esc
+// discards it.
+func (d *Display) updateNotePrompt() {
+	b := &d.bookmarks
+	b.note = editText(b.note, maxNoteLength)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		b.pending = nil
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		d.saveBookmark()
+	}
+}
+
+func (d *Display) saveBookmark() {
+	b := &d.bookmarks
+	info := *b.pending
+	b.pending = nil
+	info.Note = string(b.note)
+	info.Created = time.Now()
+
+	folder, err := d.romFolder()
+	if err == nil {
+		path := filepath.Join(folder.Bookmarks(), info.Created.Format("20060102-150405.000")+bookmark.Ext)
+		if err = bookmark.Save(path, info, b.screenshot, b.state); err == nil {
+			d.showMessage("Bookmark saved to %s", path)
+		}
+	}
+	if err != nil {
+		d.showError("Error saving bookmark: %v", err)
+	}
+	b.screenshot, b.state = nil, nil
+}
+
+// openBookmarks lists the loaded ROM's bookmarks in the browser.
+func (d *Display) openBookmarks() {
+	folder, err := d.romFolder()
+	if err != nil {
+		d.showError("Error listing bookmarks: %v", err)
+		return
+	}
+	list, err := bookmark.List(folder.Bookmarks())
+	if err != nil {
+		d.showError("Error listing bookmarks: %v", err)
+		return
+	}
+	if len(list) == 0 {
+		d.showMessage("No bookmarks yet: press F6 to add one")
+		return
+	}
+	b := &d.bookmarks
+	b.list, b.row, b.browse = list, 0, true
+	d.loadPreview()
+}
+
+// updateBookmarks handles the keyboard while the browser is open: Enter loads the
+// selected bookmark's state and Delete removes it.
+func (d *Display) updateBookmarks() {
+	b := &d.bookmarks
+	n := len(b.list)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		b.row = (b.row + n - 1) % n
+		d.loadPreview()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		b.row = (b.row + 1) % n
+		d.loadPreview()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		b.browse = false
+		e := b.list[b.row]
+		state, err := bookmark.ReadState(e.Path)
+		if err == nil {
+			err = d.bus.LoadStateFromBytes(state)
+		}
+		if err != nil {
+			d.showError("Error loading bookmark: %v", err)
+			return
+		}
+		d.showMessage("Loaded bookmark %s", bookmarkLabel(e.Info))
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete):
+		if err := os.Remove(b.list[b.row].Path); err != nil {
+			d.showError("Error deleting bookmark: %v", err)
+			return
+		}
+		b.list = append(b.list[:b.row], b.list[b.row+1:]...)
+		if len(b.list) == 0 {
+			b.browse = false
+			return
+		}
+		b.row = min(b.row, len(b.list)-1)
+		d.loadPreview()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape), inpututil.IsKeyJustPressed(ebiten.KeyF2):
+		b.browse = false
+	}
+}
+
+// loadPreview reads the selected bookmark's screenshot. One that can't be read shows
+// no preview.
+func (d *Display) loadPreview() {
+	b := &d.bookmarks
+	b.preview = nil
+	if img, err := bookmark.ReadScreenshot(b.list[b.row].Path); err == nil {
+		b.preview = ebiten.NewImageFromImage(img)
+	}
+}
+
+func bookmarkLabel(info bookmark.Info) string {
+	if info.Note != "" {
+		return fmt.Sprintf("%q", info.Note)
+	}
+	return info.Created.Format("2006-01-02 15:04:05")
+}
+
+func (d *Display) drawNotePrompt(screen *ebiten.Image) {
+	w, h := float32(360), float32(90)
+	x, y := float32(ScaledWidth())/2-w/2, float32(ScaledHeight())/2-h/2
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{0, 0, 0, 220}, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, h-2, 2, color.White, false)
+
+	ebitenutil.DebugPrintAt(screen, "BOOKMARK NOTE", int(x)+12, int(y)+10)
+	ebitenutil.DebugPrintAt(screen, string(d.bookmarks.note)+"_", int(x)+12, int(y)+34)
+	ebitenutil.DebugPrintAt(screen, "ENTER SAVE  ESC CANCEL", int(x)+12, int(y+h)-22)
+}
+
+func (d *Display) drawBookmarks(screen *ebiten.Image) {
+	b := &d.bookmarks
+	w, h := float32(680), float32(60+bookmarkListRows*20)
+	x, y := float32(ScaledWidth())/2-w/2, float32(ScaledHeight())/2-h/2
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{0, 0, 0, 220}, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, h-2, 2, color.White, false)
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("BOOKMARKS (%d)", len(b.list)), int(x)+12, int(y)+10)
+	// Scroll so the selected row is always on screen
+	first := max(0, b.row-bookmarkListRows+1)
+	for i := first; i < min(len(b.list), first+bookmarkListRows); i++ {
+		cursor := "  "
+		if i == b.row {
+			cursor = "> "
+		}
+		info := b.list[i].Info
+		line := fmt.Sprintf("%s%s  F%-7d %s", cursor, info.Created.Format("01-02 15:04"), info.Frame, info.Note)
+		ebitenutil.DebugPrintAt(screen, line, int(x)+12, int(y)+34+(i-first)*20)
+	}
+	ebitenutil.DebugPrintAt(screen, "ENTER LOAD  DEL DELETE  ESC CLOSE", int(x)+12, int(y+h)-22)
+
+	// The selected bookmark's screenshot, at its native size, on the right
+	if b.preview != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x+w)-float64(b.preview.Bounds().Dx())-12, float64(y)+10)
+		screen.DrawImage(b.preview, op)
+	}
+}
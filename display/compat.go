@@ -0,0 +1,109 @@
+package display
+
+import (
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/meadori/vibemulator/compat"
+	"github.com/meadori/vibemulator/storage"
+)
+
+// localCompat reads the player's compatibility reports. Without a data directory there
+// are none.
+func (d *Display) localCompat() (compat.List, error) {
+	if d.dataDir == "" {
+		return compat.List{}, nil
+	}
+	return compat.Load(storage.Compat(d.dataDir))
+}
+
+// showCompat shows how well the loaded ROM runs, if it has been reported.
+func (d *Display) showCompat() {
+	hash := d.bus.ROMHash()
+	if hash == "" {
+		return
+	}
+	local, err := d.localCompat()
+	if err != nil {
+		d.showError("Error reading compatibility reports: %v", err)
+		return
+	}
+	e, ok := compat.Lookup(local, hash)
+	if !ok {
+		return
+	}
+	msg := "Compatibility: " + e.Status.Describe()
+	if e.Notes != "" {
+		msg += " - " + e.Notes
+	}
+	if e.Status == compat.Broken {
+		d.showError("%s", msg)
+	} else {
+		d.showMessage("%s", msg)
+	}
+}
+
+// openReportPrompt asks how well the loaded ROM runs. Nothing is recorded unless the
+// player answers.
+func (d *Display) openReportPrompt() {
+	switch {
+	case !d.bus.HasCartridge():
+		d.showError("Load a ROM to report its compatibility")
+	case d.dataDir == "":
+		d.showError("Compatibility reports are off: no data directory")
+	default:
+		d.reportOpen = true
+	}
+}
+
+func (d *Display) updateReportPrompt() {
+	var status compat.Status
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.Key1):
+		status = compat.Playable
+	case inpututil.IsKeyJustPressed(ebiten.Key2):
+		status = compat.Minor
+	case inpututil.IsKeyJustPressed(ebiten.Key3):
+		status = compat.Broken
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape), inpututil.IsKeyJustPressed(ebiten.KeyF9):
+		d.reportOpen = false
+		return
+	default:
+		return
+	}
+	d.reportOpen = false
+
+	hash := d.bus.ROMHash()
+	local, err := d.localCompat()
+	if err != nil {
+		d.showError("Error reading compatibility reports: %v", err)
+		return
+	}
+	// Keep any notes added to the file by hand
+	e := local[hash]
+	e.Title = strings.TrimSuffix(d.romName, filepath.Ext(d.romName))
+	e.Status = status
+	path := storage.Compat(d.dataDir)
+	if err := compat.Report(path, hash, e); err != nil {
+		d.showError("Error saving compatibility report: %v", err)
+		return
+	}
+	d.showMessage("Reported %s as %s in %s", e.Title, status.Describe(), path)
+}
+
+func (d *Display) drawReportPrompt(screen *ebiten.Image) {
+	w, h := float32(360), float32(90)
+	x, y := float32(ScaledWidth())/2-w/2, float32(ScaledHeight())/2-h/2
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{0, 0, 0, 220}, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, h-2, 2, color.White, false)
+
+	ebitenutil.DebugPrintAt(screen, "HOW WELL DOES IT RUN?", int(x)+12, int(y)+10)
+	ebitenutil.DebugPrintAt(screen, "1 PLAYABLE  2 MINOR ISSUES  3 BROKEN", int(x)+12, int(y)+34)
+	ebitenutil.DebugPrintAt(screen, "ESC CANCEL", int(x)+12, int(y+h)-22)
+}
@@ -2,15 +2,16 @@ package display
 
 import (
 	"bytes"
+	_ "embed"
 	"fmt"
 	"image"
 	"image/color"
 	_ "image/png" // Required for PNG decoding
 	"log"
-	"math/rand"
 	"os"
 	"path/filepath"
-	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio"
@@ -21,12 +22,15 @@ import (
 
 	"github.com/meadori/vibemulator/bus"
 	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/config"
+	"github.com/meadori/vibemulator/pacing"
+	"github.com/meadori/vibemulator/script"
 	"github.com/meadori/vibemulator/server"
+	"github.com/meadori/vibemulator/storage"
 )
 
 const (
-	sampleRate       = 44100
-	scalingFactor    = 1.5
+	scalingFactor    = 1.5 // Layout scale; the window itself is sized by the video.scale setting
 	bezelWidth       = 1024
 	bezelHeight      = 1024
 	gameScreenX      = 318
@@ -36,12 +40,20 @@ const (
 	menuBarHeight    = 50
 )
 
+// bezelPNG is built in so the emulator can run from any directory.
+//
+//go:embed assets/tv-bezel.png
+var bezelPNG []byte
+
 type soundStream struct {
-	bus *bus.Bus
+	bus   *bus.Bus
+	meter *pacing.Meter
 }
 
 func (s *soundStream) Read(p []byte) (n int, err error) {
-	return s.bus.APU.ReadSamples(p)
+	n, err = s.bus.APU.ReadSamples(p)
+	s.meter.AudioRead(len(p), n)
+	return n, err
 }
 
 // Display represents the emulator's display.
@@ -54,19 +66,19 @@ type Display struct {
 	grpcServer      *server.GRPCServer
 
 	// Recording fields
-	recordFile      *os.File
-	lastButtonsP1   [8]bool
-	lastButtonsP2   [8]bool
-	buttonHoldCount int
-	firstFrame      bool
+	recordFile  *os.File
+	recorder    *script.Recorder
+	recordStart int // Frame counter when recording began
 
 	romLoadChan chan string
 	romName     string
+	osd         osd
+	hotReload   hotReload // Set by WatchROM
 
 	// UI Additions
-	staticImage      *ebiten.Image
-	staticPix        []byte
+	static           *tvStatic
 	scanlineImage    *ebiten.Image
+	blender          frameBlender
 	currentButtons   [8]bool
 	currentButtonsP2 [8]bool
 
@@ -79,39 +91,57 @@ type Display struct {
 	pt1Pix       []byte
 
 	// Rewind Engine
-	rewindBuffer []bus.State
+	rewindBuffer *bus.RewindBuffer
 	frameCount   int
-	frameRate    int
+	pacing       *pacing.Meter
+	limiter      *pacing.Limiter // Runs the emulator at video.frame_rate
+	uiClock      *pacing.Limiter // Runs on-screen timers at 60 Hz whatever the frame rate
 	isRewinding  bool
 	powerOn      bool
+
+	// Settings, and the file the Settings screen writes them back to
+	cfg          config.Config
+	cfgPath      string
+	keysP1       [8]ebiten.Key
+	keysP2       [8]ebiten.Key
+	settingsOpen bool
+	settingsRow  int
+	reportOpen   bool // The F9 compatibility report prompt
+	macros       macros
+	bookmarks    bookmarks
+	practice     practice
+
+	// Saves: the data directory, the loaded ROM's battery save and when it was last
+	// written, and Quit's request to stop
+	dataDir        string
+	battery        *storage.Battery
+	batteryFlushed time.Time
+	autosave       autosave
+	quit           atomic.Bool
 }
 
-// New creates a new Display instance.
-func New(b *bus.Bus, srv *server.GRPCServer, recFile *os.File, initialRomPath string) *Display {
-	audioContext := audio.NewContext(sampleRate)
-	stream := &soundStream{bus: b}
+// New creates a new Display instance using cfg. The Settings screen saves its changes
+// to cfgPath, or nowhere if it is empty.
+func New(b *bus.Bus, srv *server.GRPCServer, recFile *os.File, initialRomPath string, cfg config.Config, cfgPath string) *Display {
+	audioContext := audio.NewContext(cfg.Audio.SampleRate)
+	b.APU.SetSampleRate(float64(cfg.Audio.SampleRate))
+	meter := pacing.NewMeter()
+	srv.SetPacing(meter)
+	stream := &soundStream{bus: b, meter: meter}
 	player, err := audioContext.NewPlayer(stream)
 	if err != nil {
 		log.Printf("Error creating audio player: %v", err)
 	} else {
+		player.SetVolume(cfg.Audio.Volume)
 		player.Play()
 	}
 
-	// Load the bezel image
-	bezelFile, err := os.ReadFile("display/assets/tv-bezel.png")
-	if err != nil {
-		log.Fatalf("Error reading bezel image: %v", err)
-	}
-
-	img, _, err := image.Decode(bytes.NewReader(bezelFile))
-	if err != nil {
-		log.Fatalf("Error decoding bezel image: %v", err)
+	// Without the bezel the TV is drawn on a plain background
+	var bezelImage *ebiten.Image
+	img, _, bezelErr := image.Decode(bytes.NewReader(bezelPNG))
+	if bezelErr == nil {
+		bezelImage = ebiten.NewImageFromImage(img)
 	}
-	bezelImage := ebiten.NewImageFromImage(img)
-
-	// Create TV Static assets
-	staticImg := ebiten.NewImage(256, 240)
-	staticPix := make([]byte, 256*240*4)
 
 	// Create CRT Scanlines overlay (black line every other row)
 	scanImg := ebiten.NewImage(256, 240)
@@ -124,85 +154,225 @@ func New(b *bus.Bus, srv *server.GRPCServer, recFile *os.File, initialRomPath st
 		romBaseName = filepath.Base(initialRomPath)
 	}
 
-	return &Display{
+	d := &Display{
 		bus:           b,
 		audioPlayer:   player,
 		bezelImage:    bezelImage,
 		grpcServer:    srv,
 		recordFile:    recFile,
-		firstFrame:    true,
 		romLoadChan:   make(chan string, 1),
 		romName:       romBaseName,
-		staticImage:   staticImg,
-		staticPix:     staticPix,
+		static:        newTVStatic(),
 		scanlineImage: scanImg,
 		pt0Image:      ebiten.NewImage(128, 128),
 		pt1Image:      ebiten.NewImage(128, 128),
 		pt0Pix:        make([]byte, 128*128*4),
 		pt1Pix:        make([]byte, 128*128*4),
-		rewindBuffer:  make([]bus.State, 0, 1200), // Pre-allocate up to 1200 states (~20 seconds of rewind if sampled every frame)
+		rewindBuffer:  bus.NewRewindBuffer(cfg.Rewind.Frames()),
+		pacing:        meter,
+		limiter:       pacing.NewLimiter(cfg.Video.FrameRate),
+		uiClock:       pacing.NewLimiter(60),
 		powerOn:       true,
+		cfg:           cfg,
+		cfgPath:       cfgPath,
+		keysP1:        parseKeys(cfg.Input.P1, config.Default().Input.P1),
+		keysP2:        parseKeys(cfg.Input.P2, config.Default().Input.P2),
+	}
+	if bezelErr != nil {
+		d.showError("Error decoding bezel image: %v", bezelErr)
 	}
+	if d.dataDir = cfg.Paths.DataDir; d.dataDir == "" {
+		if d.dataDir, err = storage.DataDir(); err != nil {
+			d.showError("No data directory, so battery saves are off: %v", err)
+		}
+	}
+	d.applyFrameRate()
+	d.autosave.writing = make(chan struct{}, 1)
+	d.bindMacros()
+	d.syncBattery()
+	d.showCompat()
+	d.offerResume()
+	return d
+}
+
+// parseKeys resolves the key names for a controller, falling back to the default key
+// for any name ebiten doesn't know.
+func parseKeys(buttons, defaults config.Buttons) [8]ebiten.Key {
+	var keys [8]ebiten.Key
+	fallback := defaults.Keys()
+	for i, name := range buttons.Keys() {
+		if err := keys[i].UnmarshalText([]byte(name)); err != nil {
+			log.Printf("Unknown key %q, using %q: %v", name, fallback[i], err)
+			keys[i].UnmarshalText([]byte(fallback[i]))
+		}
+	}
+	return keys
+}
+
+// WindowSize returns the window size for a scale relative to the bezel image.
+func WindowSize(scale float64) (width, height int) {
+	return int(bezelWidth * scale), int(bezelHeight * scale)
 }
 
-func (d *Display) loadROM(path string) {
+// loadROM inserts the ROM at path. On error the current game, or the static, keeps running.
+func (d *Display) loadROM(path string) error {
 	cart, err := cartridge.New(path)
 	if err != nil {
-		log.Fatalf("Error loading ROM: %v", err)
+		return err
 	}
-	d.bus.LoadCartridge(cart)
+	if err := d.FlushBattery(); err != nil {
+		d.showError("Error writing battery save: %v", err)
+	}
+	if err := d.bus.LoadCartridge(cart); err != nil {
+		return err
+	}
+	d.syncBattery()
 	d.romName = filepath.Base(path)
 	d.powerOn = true
+	d.rewindBuffer.Clear() // Snapshots only restore onto the cartridge they were taken from
+	d.practice.active = nil
+	d.showCompat()
+	d.offerResume()
+	return nil
 }
 
-func (d *Display) writeRecord(frames int, p1, p2 [8]bool) {
-	formatBtns := func(b [8]bool) string {
-		var names []string
-		if b[0] {
-			names = append(names, "A")
-		}
-		if b[1] {
-			names = append(names, "B")
-		}
-		if b[2] {
-			names = append(names, "SELECT")
-		}
-		if b[3] {
-			names = append(names, "START")
-		}
-		if b[4] {
-			names = append(names, "UP")
-		}
-		if b[5] {
-			names = append(names, "DOWN")
-		}
-		if b[6] {
-			names = append(names, "LEFT")
-		}
-		if b[7] {
-			names = append(names, "RIGHT")
+// recordInput logs the buttons for the frame about to run. The script starts from reset
+// when recording begins at power-on, and from a savestate otherwise.
+func (d *Display) recordInput(p1, p2 [8]bool) {
+	if d.recorder == nil {
+		h := script.Header{Emulator: bus.Version, ROMHash: d.bus.ROMHash()}
+		if d.bus.GetFrameNumber() != 0 {
+			state, err := d.bus.SaveStateToBytes()
+			if err != nil {
+				d.showError("Error starting recording: %v", err)
+				d.recordFile = nil
+				return
+			}
+			h.Start, h.State = script.StartFromState, state
 		}
-		if len(names) == 0 {
-			return "NONE"
+		r, err := script.NewRecorder(d.recordFile, h)
+		if err != nil {
+			d.showError("Error starting recording: %v", err)
+			d.recordFile = nil
+			return
 		}
-		return strings.Join(names, "+")
+		d.recorder, d.recordStart = r, d.bus.GetFrameNumber()
+	}
+
+	if err := d.recorder.Record(d.bus.GetFrameNumber()-d.recordStart, p1, p2); err != nil {
+		log.Printf("Error writing recording: %v", err)
+	}
+}
+
+// FinishRecording writes the end of the script; call it once the game loop exits.
+func (d *Display) FinishRecording() error {
+	if d.recorder == nil {
+		return nil
 	}
+	return d.recorder.Close()
+}
+
+// Quit ends the game loop at the next tick. It is safe to call from any goroutine, such
+// as a signal handler.
+func (d *Display) Quit() {
+	d.quit.Store(true)
+}
 
-	fmt.Fprintf(d.recordFile, "%d P1:%s P2:%s\n", frames, formatBtns(p1), formatBtns(p2))
+// applyFrameRate sets the game loop ticking once per display refresh, leaving the
+// emulator's own pace to the limiter.
+func (d *Display) applyFrameRate() {
+	ebiten.SetTPS(ebiten.SyncWithFPS)
+	ebiten.SetVsyncEnabled(d.cfg.Video.VSync)
+	d.limiter.SetRate(d.cfg.Video.FrameRate)
+	d.pacing.SetInterval(d.limiter.Interval())
 }
 
 // Update proceeds the game state.
-// Update is called every tick (1/60 [s] by default).
+// Update is called every display refresh, and runs the frames that are due.
 func (d *Display) Update() error {
+	if d.quit.Load() {
+		return ebiten.Termination
+	}
+	// Time spent paused below is skipped rather than caught up
+	now := time.Now()
+	frames := d.limiter.Due(now)
+	ticks := d.uiClock.Due(now)
+
 	d.menuBarVisible = true
-	d.frameRate = int(ebiten.ActualFPS())
 
 	// Check if a ROM was selected via the async dialog
 	select {
 	case filename := <-d.romLoadChan:
-		d.loadROM(filename)
+		if err := d.loadROM(filename); err != nil {
+			d.showError("Error loading %s: %v", filepath.Base(filename), err)
+		}
+	case <-d.hotReload.changed:
+		if err := d.reloadROM(); err != nil {
+			d.showError("Error reloading %s: %v", filepath.Base(d.hotReload.path), err)
+		}
 	default:
 	}
+	d.osd.frames = max(d.osd.frames-ticks, 0)
+
+	// Keep battery saves current so a crash loses at most a few seconds
+	d.syncBattery()
+	if time.Since(d.batteryFlushed) >= batteryFlushInterval {
+		if err := d.FlushBattery(); err != nil {
+			d.showError("Error writing battery save: %v", err)
+		}
+	}
+
+	// The game waits for an answer to the resume prompt
+	if d.autosave.offer != "" {
+		d.updateResumePrompt()
+		return nil
+	}
+	d.autosaveTick()
+
+	// The game is paused while the Settings screen is open
+	if d.settingsOpen {
+		d.updateSettings()
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
+		d.settingsOpen = true
+		return nil
+	}
+
+	// So is it while the compatibility report prompt is open
+	if d.reportOpen {
+		d.updateReportPrompt()
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		d.openReportPrompt()
+		return nil
+	}
+	// And while a recorded macro waits for its key
+	if d.macros.binding != nil {
+		d.updateMacroBinding()
+		return nil
+	}
+	// And while a bookmark waits for its note, or the bookmark browser is open
+	if d.bookmarks.pending != nil {
+		d.updateNotePrompt()
+		return nil
+	}
+	if d.bookmarks.browse {
+		d.updateBookmarks()
+		return nil
+	}
+	if d.updateBookmarkKeys() {
+		return nil
+	}
+	// And while practice mode asks for its condition
+	if d.practice.prompt {
+		d.updatePracticePrompt()
+		return nil
+	}
+	if d.updatePracticeKey() {
+		return nil
+	}
 
 	// Handle menu clicks
 	if d.menuBarVisible && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
@@ -213,12 +383,20 @@ func (d *Display) Update() error {
 			if x >= 60 && x <= 140 {
 				// POWER Toggle
 				if d.powerOn {
+					// An accidental click can be undone from the autosave at power on
+					if err := d.WriteAutosave(); err != nil {
+						d.showError("Error writing autosave: %v", err)
+					}
 					d.powerOn = false
+					if err := d.FlushBattery(); err != nil {
+						d.showError("Error writing battery save: %v", err)
+					}
 					d.bus.PowerOff()
-					d.rewindBuffer = d.rewindBuffer[:0] // Clear history
+					d.rewindBuffer.Clear() // Clear history
 				} else {
 					d.powerOn = true
 					d.bus.PowerOn()
+					d.offerResume()
 				}
 			} else if x >= 150 && x <= 230 {
 				// RESET
@@ -227,38 +405,39 @@ func (d *Display) Update() error {
 			} else if x >= 240 && x <= 320 {
 				// LOAD
 				go func() {
-					filename, err := dialog.File().Load()
+					dlg := dialog.File()
+					if d.cfg.Paths.ROMDir != "" {
+						dlg = dlg.SetStartDir(d.cfg.Paths.ROMDir)
+					}
+					filename, err := dlg.Load()
 					if err != nil {
-						log.Println(err)
+						if err != dialog.ErrCancelled {
+							log.Printf("Error opening file dialog: %v", err)
+						}
 					} else {
 						d.romLoadChan <- filename
 					}
 				}()
+			} else if x >= 330 && x <= 410 {
+				// SETTINGS
+				d.settingsOpen = true
 			}
 		}
 	}
 
-	if d.resetBlinkTimer > 0 {
-		d.resetBlinkTimer--
-	}
+	d.resetBlinkTimer = max(d.resetBlinkTimer-ticks, 0)
 
 	// Save States
 	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
-		log.Println("Saving State to vibemulator.sav...")
-		if err := d.bus.SaveState("vibemulator.sav"); err != nil {
-			log.Printf("Error saving state: %v\n", err)
-		} else {
-			log.Println("State saved successfully.")
-		}
+		d.saveState()
 	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyF7) {
-		log.Println("Loading State from vibemulator.sav...")
-		if err := d.bus.LoadState("vibemulator.sav"); err != nil {
-			log.Printf("Error loading state: %v\n", err)
-		} else {
-			log.Println("State loaded successfully.")
-		}
+		d.loadState()
 	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF12) {
+		d.screenshot()
+	}
+	d.updateMacroKeys()
 
 	// Debugger Toggles
 	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
@@ -268,134 +447,109 @@ func (d *Display) Update() error {
 		d.debugPalette = (d.debugPalette + 1) % 8
 	}
 
+	// Poll controller input (Logical OR local input and remote network input)
+	remoteState := d.grpcServer.GetP1State()
+	buttons := [8]bool{}
+	for i, key := range d.keysP1 { // A, B, Select, Start, Up, Down, Left, Right
+		buttons[i] = ebiten.IsKeyPressed(key) || remoteState[i]
+	}
+	d.currentButtons = buttons
+
+	// Player 2
+	remoteStateP2 := d.grpcServer.GetP2State()
+	buttonsP2 := [8]bool{}
+	for i, key := range d.keysP2 {
+		buttonsP2[i] = ebiten.IsKeyPressed(key) || remoteStateP2[i]
+	}
+	d.currentButtonsP2 = buttonsP2
+
 	// Rewind Engine (Prince of Persia style)
 	// If holding Backspace, reverse time. Otherwise, record time.
-	d.isRewinding = ebiten.IsKeyPressed(ebiten.KeyBackspace)
-
-	if d.isRewinding && len(d.rewindBuffer) > 0 {
-		// Pop the last saved state off the end of the buffer
-		lastState := d.rewindBuffer[len(d.rewindBuffer)-1]
-		d.rewindBuffer = d.rewindBuffer[:len(d.rewindBuffer)-1]
+	d.isRewinding = d.cfg.Rewind.Enabled && ebiten.IsKeyPressed(ebiten.KeyBackspace)
+	for range frames {
+		d.runFrame(buttons, buttonsP2)
+	}
+	return nil
+}
 
-		// Load it instantly into the bus
-		d.bus.LoadStateFromMemory(lastState)
+// runFrame runs one emulated frame with the buttons held, or rewinds one.
+func (d *Display) runFrame(buttons, buttonsP2 [8]bool) {
+	if d.isRewinding && d.rewindBuffer.Len() > 0 {
+		// Pop the last saved state and load it instantly into the bus
+		if _, err := d.rewindBuffer.Pop(d.bus); err != nil {
+			d.showError("Failed to rewind: %v", err)
+		}
 
 		// We DO NOT run the emulator clock loop below, so time moves backward.
 	} else if !d.isRewinding && d.bus.HasCartridge() {
-		// Capture a snapshot every single frame for butter-smooth 1x rewind
-		state := d.bus.SaveStateToMemory()
-		d.rewindBuffer = append(d.rewindBuffer, state)
-
-		// Cap the rewind buffer to 1200 states (exactly 20 seconds of 60fps gameplay history)
-		if len(d.rewindBuffer) > 1200 {
-			// Shift the slice left, discarding the oldest state
-			copy(d.rewindBuffer, d.rewindBuffer[1:])
-			d.rewindBuffer = d.rewindBuffer[:len(d.rewindBuffer)-1]
+		// Capture a snapshot every single frame for butter-smooth 1x rewind. Once full, the
+		// ring overwrites the oldest state in place.
+		if d.cfg.Rewind.Enabled {
+			d.rewindBuffer.Push(d.bus)
 		}
 
 		d.frameCount++
 	}
 
-	// Poll controller input (Logical OR local input and remote network input)
-	remoteState := d.grpcServer.GetP1State()
-	buttons := [8]bool{}
-	buttons[0] = ebiten.IsKeyPressed(ebiten.KeyZ) || remoteState[0]          // A
-	buttons[1] = ebiten.IsKeyPressed(ebiten.KeyX) || remoteState[1]          // B
-	buttons[2] = ebiten.IsKeyPressed(ebiten.KeyShift) || remoteState[2]      // Select
-	buttons[3] = ebiten.IsKeyPressed(ebiten.KeyEnter) || remoteState[3]      // Start
-	buttons[4] = ebiten.IsKeyPressed(ebiten.KeyArrowUp) || remoteState[4]    // Up
-	buttons[5] = ebiten.IsKeyPressed(ebiten.KeyArrowDown) || remoteState[5]  // Down
-	buttons[6] = ebiten.IsKeyPressed(ebiten.KeyArrowLeft) || remoteState[6]  // Left
-	buttons[7] = ebiten.IsKeyPressed(ebiten.KeyArrowRight) || remoteState[7] // Right
-	d.bus.SetController1State(buttons)
-	d.currentButtons = buttons
-
-	// Player 2
-	remoteStateP2 := d.grpcServer.GetP2State()
-	buttonsP2 := [8]bool{}
-	buttonsP2[0] = ebiten.IsKeyPressed(ebiten.KeyI) || remoteStateP2[0] // A
-	buttonsP2[1] = ebiten.IsKeyPressed(ebiten.KeyU) || remoteStateP2[1] // B
-	buttonsP2[2] = ebiten.IsKeyPressed(ebiten.KeyY) || remoteStateP2[2] // Select
-	buttonsP2[3] = ebiten.IsKeyPressed(ebiten.KeyH) || remoteStateP2[3] // Start
-	buttonsP2[4] = ebiten.IsKeyPressed(ebiten.KeyW) || remoteStateP2[4] // Up
-	buttonsP2[5] = ebiten.IsKeyPressed(ebiten.KeyS) || remoteStateP2[5] // Down
-	buttonsP2[6] = ebiten.IsKeyPressed(ebiten.KeyA) || remoteStateP2[6] // Left
-	buttonsP2[7] = ebiten.IsKeyPressed(ebiten.KeyD) || remoteStateP2[7] // Right
-	d.bus.SetController2State(buttonsP2)
-	d.currentButtonsP2 = buttonsP2
+	// While paused, inputs belong to whoever is stepping the emulator (e.g. StepFrame over gRPC),
+	// and during movie playback to the movie
+	if d.bus.Running() && !d.bus.IsPlaying() {
+		if d.powerOn && !d.isRewinding {
+			d.applyMacros(&buttons, &buttonsP2)
+		}
+		d.bus.SetController1State(buttons)
+		d.bus.SetController2State(buttonsP2)
+	}
 
 	// Generate TV Static if no cartridge is loaded or power is off
 	if !d.powerOn || !d.bus.HasCartridge() {
-		for i := 0; i < len(d.staticPix); i += 4 {
-			val := byte(rand.Intn(256))
-			d.staticPix[i] = val
-			d.staticPix[i+1] = val
-			d.staticPix[i+2] = val
-			d.staticPix[i+3] = 255
-		}
-		d.staticImage.WritePixels(d.staticPix)
+		d.static.next()
 	}
 
-	// Record inputs if recording is enabled
-	if d.recordFile != nil && !d.isRewinding {
-		if d.firstFrame {
-			d.lastButtonsP1 = buttons
-			d.lastButtonsP2 = buttonsP2
-			d.buttonHoldCount = 1
-			d.firstFrame = false
-		} else {
-			if buttons == d.lastButtonsP1 && buttonsP2 == d.lastButtonsP2 {
-				d.buttonHoldCount++
-			} else {
-				d.writeRecord(d.buttonHoldCount, d.lastButtonsP1, d.lastButtonsP2)
-				d.lastButtonsP1 = buttons
-				d.lastButtonsP2 = buttonsP2
-				d.buttonHoldCount = 1
-			}
-		}
+	// Record inputs if recording is enabled. Rewinding and then playing on records over
+	// the rewound frames, which the script counts as a rerecord.
+	if d.recordFile != nil && !d.isRewinding && d.powerOn && d.bus.HasCartridge() {
+		d.recordInput(buttons, buttonsP2)
 	}
 
-	// Run the emulator for one frame's worth of PPU cycles.
-	// 89342 PPU cycles per frame.
+	// Run a frame, or whatever the debugger asked for. Only frames run at full speed
+	// count toward pacing and practice.
+	running := d.powerOn && !d.isRewinding && d.bus.Running() && d.bus.HasCartridge()
 	if d.powerOn && !d.isRewinding {
-		if d.bus.IsPaused {
-			if d.bus.StepRequested {
-				// Clock until one full instruction completes (cycles == 0)
-				for {
-					d.bus.Clock()
-					// Since the CPU clocks every 3 system clocks, we need to make sure we hit the cycle boundary correctly
-					if d.bus.SystemClocks%3 == 0 && d.bus.IsInstructionComplete() {
-						break
-					}
-				}
-				d.bus.StepRequested = false
-			}
-		} else {
-			for i := 0; i < 89342; i++ {
-				d.bus.Clock()
-			}
-		}
+		d.bus.Advance()
+	}
+	if running {
+		d.checkPractice()
+		queued := time.Duration(d.bus.APU.QueuedSamples()) * time.Second / time.Duration(d.cfg.Audio.SampleRate)
+		d.pacing.Frame(time.Now(), queued)
+	} else {
+		d.pacing.Idle()
 	}
-
-	return nil
 }
 
 // Draw draws the game screen.
 // Draw is called every frame (typically 1/60[s] for 60Hz display).
 func (d *Display) Draw(screen *ebiten.Image) {
 	// Draw the bezel first, scaled
-	opBezel := &ebiten.DrawImageOptions{}
-	opBezel.GeoM.Scale(scalingFactor, scalingFactor)
-	screen.DrawImage(d.bezelImage, opBezel)
+	if d.bezelImage != nil {
+		opBezel := &ebiten.DrawImageOptions{}
+		opBezel.GeoM.Scale(scalingFactor, scalingFactor)
+		screen.DrawImage(d.bezelImage, opBezel)
+	} else {
+		screen.Fill(color.RGBA{40, 40, 40, 255})
+	}
 
 	// Determine what to show on the TV
 	var rawScreen *ebiten.Image
 	if d.powerOn && d.bus.HasCartridge() {
-		rawScreen = ebiten.NewImageFromImage(d.bus.PPU.GetFrame())
+		d.pacing.Present(d.bus.PPU.FrameCounter)
+		rawScreen = ebiten.NewImageFromImage(d.blender.blend(d.cfg.Video.Blend, d.bus.PPU.FrameCounter, d.bus.PPU.GetFrame()))
 		// Apply CRT Scanlines directly over the game frame before scaling
-		rawScreen.DrawImage(d.scanlineImage, nil)
+		if d.cfg.Video.Scanlines {
+			rawScreen.DrawImage(d.scanlineImage, nil)
+		}
 	} else {
-		rawScreen = d.staticImage
+		rawScreen = d.static.image
 	}
 
 	// Scale the game screen to its target size within the bezel
@@ -412,6 +566,7 @@ func (d *Display) Draw(screen *ebiten.Image) {
 	opGame.GeoM.Translate(gameScreenX*scalingFactor, gameScreenY*scalingFactor)
 
 	screen.DrawImage(rawScreen, opGame)
+	d.drawOSD(screen)
 
 	// Draw the live controller HUDs below the TV screen
 	d.drawControllerHUD(screen, -160, d.currentButtons, "P1")
@@ -459,7 +614,11 @@ func (d *Display) Draw(screen *ebiten.Image) {
 		loadHover := mouseX >= 240 && mouseX <= 320 && mouseY >= 5 && mouseY <= 45
 		drawNESButton(screen, "LOAD", 240, 5, 80, 40, loadHover, loadHover && isMouseDown)
 
-		// VIBEMULATOR Logo (X: 350+)
+		// SETTINGS button (X: 330 to 410)
+		settingsHover := mouseX >= 330 && mouseX <= 410 && mouseY >= 5 && mouseY <= 45
+		drawNESButton(screen, "SETTINGS", 330, 5, 80, 40, settingsHover, settingsHover && isMouseDown)
+
+		// VIBEMULATOR Logo (centered)
 		logoText := "VIBEMULATOR"
 		logoImg := ebiten.NewImage((len(logoText)*6)+10, 16)
 		ebitenutil.DebugPrintAt(logoImg, logoText, 0, 0)
@@ -493,9 +652,28 @@ func (d *Display) Draw(screen *ebiten.Image) {
 	if d.showDebug {
 		d.drawPPUDebugOverlay(screen)
 	}
+	if d.settingsOpen {
+		d.drawSettings(screen)
+	}
+	if d.autosave.offer != "" {
+		d.drawResumePrompt(screen)
+	}
+	if d.reportOpen {
+		d.drawReportPrompt(screen)
+	}
+	if d.bookmarks.pending != nil {
+		d.drawNotePrompt(screen)
+	}
+	if d.bookmarks.browse {
+		d.drawBookmarks(screen)
+	}
+	if d.practice.prompt {
+		d.drawPracticePrompt(screen)
+	}
 }
 
 func (d *Display) drawVCRStatus(screen *ebiten.Image) {
+	pace := d.pacing.Stats()
 	var vcrState string
 	if d.isRewinding {
 		if (d.frameCount/8)%2 == 0 {
@@ -506,7 +684,11 @@ func (d *Display) drawVCRStatus(screen *ebiten.Image) {
 	} else if !d.powerOn {
 		vcrState = "POWER OFF"
 	} else {
-		vcrState = fmt.Sprintf("PLAY > %d FPS", d.frameRate)
+		fps := 0
+		if pace.MeanInterval > 0 {
+			fps = int(time.Second / pace.MeanInterval)
+		}
+		vcrState = fmt.Sprintf("PLAY > %d FPS", fps)
 	}
 
 	uptimeSecs := d.frameCount / 60
@@ -521,11 +703,20 @@ func (d *Display) drawVCRStatus(screen *ebiten.Image) {
 		rom = rom[:19] + "..."
 	}
 
+	system := "NTSC / UNCAPPED"
+	if r := d.cfg.Video.FrameRate; r > 0 {
+		system = fmt.Sprintf("NTSC / %gHz", r)
+	}
+	paceText := fmt.Sprintf("%.1fMS ~%.1f D%d R%d", msec(pace.MeanInterval), msec(pace.Jitter), pace.Dropped, pace.Duplicated)
+	audioText := fmt.Sprintf("%dMS %+dMS U%d", pace.AudioQueued.Milliseconds(), pace.AudioDrift.Milliseconds(), pace.AudioUnderruns)
+
 	statsText := fmt.Sprintf(
 		" VCR    : %-22s \n"+
 			" ROM    : %-22s \n"+
 			" UPTIME : %02d:%02d:%02d               \n"+
-			" SYSTEM : NTSC / 60Hz            ", vcrState, rom, h, m, s)
+			" SYSTEM : %-22s \n"+
+			" PACING : %-22s \n"+
+			" AUDIO  : %-22s ", vcrState, rom, h, m, s, system, paceText, audioText)
 
 	// Draw the text
 	op := &ebiten.DrawImageOptions{}
@@ -538,7 +729,7 @@ func (d *Display) drawVCRStatus(screen *ebiten.Image) {
 	op.ColorScale.ScaleWithColor(color.RGBA{50, 255, 50, 255})
 
 	// Box dimensions: 33 chars wide * 6px = 198, plus some padding
-	w, h_box := float32(210), float32(75)
+	w, h_box := float32(210), float32(107)
 	img := ebiten.NewImage(int(w), int(h_box))
 
 	// Fill background slightly dark for readability
@@ -550,6 +741,12 @@ func (d *Display) drawVCRStatus(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(img, statsText, 6, 6)
 	screen.DrawImage(img, op)
 }
+
+// msec returns d in fractional milliseconds.
+func msec(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 func (d *Display) drawPPUDebugOverlay(screen *ebiten.Image) {
 	// Darken background
 	vector.DrawFilledRect(screen, 0, 0, float32(ScaledWidth()), float32(ScaledHeight()), color.RGBA{0, 0, 0, 220}, false)
@@ -0,0 +1,85 @@
+package display
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/cartridge"
+)
+
+// romPollInterval is how often WatchROM checks the ROM file. A change is only picked up
+// once the file has stopped changing for a poll, so the assembler has finished writing it.
+const romPollInterval = 250 * time.Millisecond
+
+// hotReload is the ROM file WatchROM reloads, and what each reload keeps.
+type hotReload struct {
+	path      string
+	statePath string
+	keepRAM   bool
+	changed   chan struct{}
+}
+
+// WatchROM reloads the ROM at path whenever the file changes, such as when ca65 and ld65
+// rebuild it. The new build starts from power-on, keeping CPU and PRG RAM if keepRAM is
+// set, or from the savestate at statePath, read on each reload, if it isn't empty.
+func (d *Display) WatchROM(path, statePath string, keepRAM bool) {
+	d.hotReload = hotReload{path: path, statePath: statePath, keepRAM: keepRAM, changed: make(chan struct{}, 1)}
+	go watchFile(path, romPollInterval, d.hotReload.changed)
+}
+
+// watchFile signals changed whenever the file at path settles after changing.
+func watchFile(path string, interval time.Duration, changed chan<- struct{}) {
+	stat := func() (time.Time, int64) {
+		fi, err := os.Stat(path)
+		if err != nil {
+			// Missing while it is rewritten; it counts as changed when it reappears
+			return time.Time{}, -1
+		}
+		return fi.ModTime(), fi.Size()
+	}
+	lastTime, lastSize := stat()
+	pending := false
+	for range time.Tick(interval) {
+		t, size := stat()
+		if !t.Equal(lastTime) || size != lastSize {
+			lastTime, lastSize = t, size
+			pending = true
+			continue
+		}
+		if pending && size >= 0 {
+			pending = false
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// reloadROM swaps in the rebuilt ROM WatchROM saw change. On error the old build keeps running.
+func (d *Display) reloadROM() error {
+	cart, err := cartridge.New(d.hotReload.path)
+	if err != nil {
+		return err
+	}
+	opts := bus.ReloadOptions{KeepRAM: d.hotReload.keepRAM}
+	if d.hotReload.statePath != "" {
+		if opts.State, err = os.ReadFile(d.hotReload.statePath); err != nil {
+			return err
+		}
+	}
+	if err := d.FlushBattery(); err != nil {
+		d.showError("Error writing battery save: %v", err)
+	}
+	if err := d.bus.Reload(cart, opts); err != nil {
+		return err
+	}
+	d.syncBattery()
+	d.powerOn = true
+	d.rewindBuffer.Clear() // Snapshots only restore onto the cartridge they were taken from
+	d.practice.active = nil
+	d.showMessage("Reloaded %s", filepath.Base(d.hotReload.path))
+	return nil
+}
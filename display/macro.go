@@ -0,0 +1,116 @@
+package display
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/meadori/vibemulator/config"
+	"github.com/meadori/vibemulator/macro"
+)
+
+// boundMacro is a macro from the settings, ready to play.
+type boundMacro struct {
+	key    ebiten.Key
+	player int
+	steps  []macro.Step
+}
+
+// macros plays the macros bound to keys, and records new ones with F8: player 1's
+// input is recorded until F8 is pressed again, then the next key pressed is bound to it.
+type macros struct {
+	bound     []boundMacro
+	runner    macro.Runner
+	recording *macro.Recorder
+	binding   []macro.Step // Recorded steps waiting for a key
+}
+
+// bindMacros resolves the macros in the settings. Settings validation has already
+// checked their steps.
+func (d *Display) bindMacros() {
+	d.macros.bound = nil
+	for _, m := range d.cfg.Macros {
+		var key ebiten.Key
+		if err := key.UnmarshalText([]byte(m.Key)); err != nil {
+			d.showError("Macro key %q is unknown: %v", m.Key, err)
+			continue
+		}
+		steps, _ := macro.ParseSteps(m.Steps)
+		d.macros.bound = append(d.macros.bound, boundMacro{key, m.Player, steps})
+	}
+}
+
+// updateMacroKeys starts the macros whose keys were pressed, and starts or stops
+// recording on F8.
+func (d *Display) updateMacroKeys() {
+	for _, m := range d.macros.bound {
+		if inpututil.IsKeyJustPressed(m.key) {
+			d.macros.runner.Start(m.player, m.steps)
+		}
+	}
+	if !inpututil.IsKeyJustPressed(ebiten.KeyF8) {
+		return
+	}
+	if d.macros.recording == nil {
+		d.macros.recording = &macro.Recorder{}
+		d.showMessage("Recording a macro from player 1; press F8 to stop")
+		return
+	}
+	steps := d.macros.recording.Steps()
+	d.macros.recording = nil
+	if len(steps) == 0 {
+		d.showMessage("No buttons were pressed, so there is no macro to bind")
+		return
+	}
+	d.macros.binding = steps
+	d.showMessage("Press the key to bind the macro to, or Esc to discard it")
+}
+
+// applyMacros adds the macros' buttons to the controllers for the frame about to run,
+// and records player 1's input if a recording is going.
+func (d *Display) applyMacros(p1, p2 *[8]bool) {
+	m1, m2 := d.macros.runner.Next()
+	for i := range p1 {
+		p1[i] = p1[i] || m1[i]
+		p2[i] = p2[i] || m2[i]
+	}
+	if d.macros.recording != nil {
+		d.macros.recording.Add(*p1)
+	}
+}
+
+// updateMacroBinding waits for the key to bind a recorded macro to, and saves it to
+// the settings file, replacing any macro on that key.
+func (d *Display) updateMacroBinding() {
+	keys := inpututil.AppendJustPressedKeys(nil)
+	if len(keys) == 0 {
+		return
+	}
+	steps := d.macros.binding
+	d.macros.binding = nil
+	if keys[0] == ebiten.KeyEscape {
+		d.showMessage("Macro discarded")
+		return
+	}
+
+	name, err := keys[0].MarshalText()
+	if err != nil {
+		d.showError("Error binding macro: %v", err)
+		return
+	}
+	m := config.Macro{Key: string(name), Player: 1, Steps: macro.FormatSteps(steps)}
+	replace := func(ms []config.Macro) []config.Macro {
+		ms = slices.DeleteFunc(slices.Clone(ms), func(old config.Macro) bool { return old.Key == m.Key })
+		return append(ms, m)
+	}
+	d.cfg.Macros = replace(d.cfg.Macros)
+	d.bindMacros()
+	if d.cfgPath != "" {
+		if err := config.Update(d.cfgPath, func(c *config.Config) { c.Macros = replace(c.Macros) }); err != nil {
+			d.showError("Error saving macro: %v", err)
+			return
+		}
+	}
+	d.showMessage("Bound a %d-step macro to %s", len(steps), m.Key)
+}
@@ -0,0 +1,57 @@
+package display
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	osdFrames   = 240 // How long a message stays up: four seconds
+	osdMaxChars = 100 // What fits across the TV screen
+)
+
+// osd is the on-screen message shown over the bottom of the TV, for errors and for
+// confirmations that would otherwise only reach the log.
+type osd struct {
+	text   string
+	frames int // Frames left before the message disappears
+	isErr  bool
+}
+
+// showMessage puts a message on screen, replacing any shown, and logs it.
+func (d *Display) showMessage(format string, a ...interface{}) {
+	d.osd = osd{text: fmt.Sprintf(format, a...), frames: osdFrames}
+	log.Print(d.osd.text)
+}
+
+// showError is showMessage for failures, drawn in red.
+func (d *Display) showError(format string, a ...interface{}) {
+	d.showMessage(format, a...)
+	d.osd.isErr = true
+}
+
+func (d *Display) drawOSD(screen *ebiten.Image) {
+	if d.osd.frames == 0 {
+		return
+	}
+	text := d.osd.text
+	if len(text) > osdMaxChars {
+		text = text[:osdMaxChars-3] + "..."
+	}
+
+	x := float32(gameScreenX*scalingFactor) + 8
+	y := float32((gameScreenY+gameScreenHeight)*scalingFactor) - 36
+	w := float32(gameScreenWidth*scalingFactor) - 16
+	background := color.RGBA{0, 0, 0, 200}
+	if d.osd.isErr {
+		background = color.RGBA{140, 0, 0, 220}
+	}
+	vector.DrawFilledRect(screen, x, y, w, 28, background, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, 26, 1, color.White, false)
+	ebitenutil.DebugPrintAt(screen, text, int(x)+8, int(y)+6)
+}
@@ -0,0 +1,89 @@
+package display
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/meadori/vibemulator/bus"
+)
+
+const maxConditionLength = 60
+
+// practice is practice mode: F3 asks for a condition, then reloads the state from that
+// moment whenever the condition becomes true.
+type practice struct {
+	prompt    bool
+	condition []rune // Kept after practice stops, to edit next time
+	active    *bus.Practice
+}
+
+// updatePracticeKey handles F3, which starts or stops practice, reporting whether it
+// opened the prompt.
+func (d *Display) updatePracticeKey() bool {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		return false
+	}
+	p := &d.practice
+	switch {
+	case p.active != nil:
+		d.showMessage("Practice stopped after %d loops", p.active.Loops)
+		p.active = nil
+	case !d.bus.HasCartridge():
+		d.showError("Load a ROM to practice")
+	default:
+		p.prompt = true
+	}
+	return p.prompt
+}
+
+// updatePracticePrompt edits the condition. Enter starts practice from the current
+// state and Esc cancels.
+func (d *Display) updatePracticePrompt() {
+	p := &d.practice
+	p.condition = editText(p.condition, maxConditionLength)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		p.prompt = false
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		active, err := d.bus.StartPractice(string(p.condition))
+		if err != nil {
+			// Leave the prompt open to fix the condition
+			d.showError("Error starting practice: %v", err)
+			return
+		}
+		p.prompt, p.active = false, active
+		d.showMessage("Practicing: reloading when %s", active.Condition())
+	}
+}
+
+// checkPractice reloads the start of the section if the condition has become true. The
+// game loop calls it after each frame.
+func (d *Display) checkPractice() {
+	p := &d.practice
+	if p.active == nil {
+		return
+	}
+	reloaded, err := p.active.Check(d.bus)
+	switch {
+	case err != nil:
+		d.showError("Practice stopped: %v", err)
+		p.active = nil
+	case reloaded:
+		d.showMessage("Practice loop %d", p.active.Loops+1)
+	}
+}
+
+func (d *Display) drawPracticePrompt(screen *ebiten.Image) {
+	w, h := float32(420), float32(90)
+	x, y := float32(ScaledWidth())/2-w/2, float32(ScaledHeight())/2-h/2
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{0, 0, 0, 220}, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, h-2, 2, color.White, false)
+
+	ebitenutil.DebugPrintAt(screen, "PRACTICE: RELOAD FROM HERE WHEN", int(x)+12, int(y)+10)
+	ebitenutil.DebugPrintAt(screen, string(d.practice.condition)+"_", int(x)+12, int(y)+34)
+	ebitenutil.DebugPrintAt(screen, "ENTER START  ESC CANCEL", int(x)+12, int(y+h)-22)
+}
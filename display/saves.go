@@ -0,0 +1,134 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/meadori/vibemulator/storage"
+)
+
+// batteryFlushInterval bounds how much progress a crash can lose from battery saves.
+const batteryFlushInterval = 5 * time.Second
+
+// romFolder returns the storage folder for the loaded ROM.
+func (d *Display) romFolder() (storage.ROM, error) {
+	hash := d.bus.ROMHash()
+	switch {
+	case hash == "":
+		return "", errors.New("no cartridge is loaded")
+	case d.dataDir == "":
+		return "", errors.New("no data directory")
+	}
+	return storage.ForROM(d.dataDir, hash), nil
+}
+
+// syncBattery attaches to the battery RAM of the cartridge in the bus, loading its save,
+// whenever the cartridge has been replaced since the last call, for example by the LOAD
+// button or a gRPC LoadROM. The RAM of the cartridge it replaced is flushed first.
+func (d *Display) syncBattery() {
+	ram := d.bus.BatteryRAM()
+	if d.battery.Holds(ram) || (ram == nil && d.battery == nil) {
+		return
+	}
+	if err := d.FlushBattery(); err != nil {
+		d.showError("Error writing battery save: %v", err)
+	}
+	d.battery = nil
+	if ram == nil {
+		return
+	}
+	folder, err := d.romFolder()
+	if err != nil {
+		d.showError("Battery saves are off: %v", err)
+		return
+	}
+	// A battery with a bad save still flushes, replacing the bad file
+	if d.battery, err = storage.OpenBattery(folder.Battery(), ram); err != nil {
+		d.showError("Error loading battery save: %v", err)
+	}
+	d.batteryFlushed = time.Now()
+}
+
+// FlushBattery writes the battery RAM if it changed. The game loop calls it every few
+// seconds; call it once more after the loop exits.
+func (d *Display) FlushBattery() error {
+	d.batteryFlushed = time.Now()
+	if d.battery == nil {
+		return nil
+	}
+	return d.battery.Flush()
+}
+
+// statePath returns the file F5 and F7 use: the configured one, or the ROM's own.
+func (d *Display) statePath() (string, error) {
+	if d.cfg.Paths.SaveState != "" {
+		return d.cfg.Paths.SaveState, nil
+	}
+	folder, err := d.romFolder()
+	if err != nil {
+		return "", err
+	}
+	return folder.State(), nil
+}
+
+func (d *Display) saveState() {
+	path, err := d.statePath()
+	if err == nil {
+		var data []byte
+		if data, err = d.bus.SaveStateToBytes(); err == nil {
+			err = storage.WriteFile(path, data)
+		}
+	}
+	if err != nil {
+		d.showError("Error saving state: %v", err)
+		return
+	}
+	d.showMessage("State saved to %s", path)
+}
+
+func (d *Display) loadState() {
+	path, err := d.statePath()
+	if err == nil {
+		err = d.bus.LoadState(path)
+	}
+	if err != nil {
+		d.showError("Error loading state: %v", err)
+		return
+	}
+	d.showMessage("State loaded from %s", path)
+}
+
+// screenshot writes the current frame as a PNG into the ROM's screenshots folder.
+func (d *Display) screenshot() {
+	folder, err := d.romFolder()
+	if err != nil {
+		d.showError("Error taking screenshot: %v", err)
+		return
+	}
+	path := filepath.Join(folder.Screenshots(), time.Now().Format("20060102-150405.000")+".png")
+	if err := writePNG(path, d.bus.PPU.GetFrame()); err != nil {
+		d.showError("Error taking screenshot: %v", err)
+		return
+	}
+	d.showMessage("Screenshot saved to %s", path)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to encode %s: %v", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,191 @@
+package display
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/config"
+)
+
+// settingItem is one line of the Settings screen, changed with Left and Right
+type settingItem struct {
+	label  string
+	value  func(c *config.Config) string
+	adjust func(c *config.Config, dir int)
+}
+
+var settingItems = []settingItem{
+	{
+		label:  "Window scale",
+		value:  func(c *config.Config) string { return fmt.Sprintf("%.2fx", c.Video.Scale) },
+		adjust: func(c *config.Config, dir int) { c.Video.Scale = clamp(c.Video.Scale+0.25*float64(dir), 0.5, 3) },
+	},
+	{
+		label:  "Fullscreen",
+		value:  func(c *config.Config) string { return onOff(c.Video.Fullscreen) },
+		adjust: func(c *config.Config, dir int) { c.Video.Fullscreen = !c.Video.Fullscreen },
+	},
+	{
+		label:  "CRT scanlines",
+		value:  func(c *config.Config) string { return onOff(c.Video.Scanlines) },
+		adjust: func(c *config.Config, dir int) { c.Video.Scanlines = !c.Video.Scanlines },
+	},
+	{
+		label:  "Frame blending",
+		value:  func(c *config.Config) string { return strings.ToUpper(c.Video.Blend) },
+		adjust: func(c *config.Config, dir int) { c.Video.Blend = cycle(config.Blends, c.Video.Blend, dir) },
+	},
+	{
+		label:  "Frame rate",
+		value:  func(c *config.Config) string { return frameRateName(c.Video.FrameRate) },
+		adjust: func(c *config.Config, dir int) { c.Video.FrameRate = cycleFrameRate(c.Video.FrameRate, dir) },
+	},
+	{
+		label:  "VSync",
+		value:  func(c *config.Config) string { return onOff(c.Video.VSync) },
+		adjust: func(c *config.Config, dir int) { c.Video.VSync = !c.Video.VSync },
+	},
+	{
+		label: "Volume",
+		value: func(c *config.Config) string { return fmt.Sprintf("%d%%", int(math.Round(c.Audio.Volume*100))) },
+		adjust: func(c *config.Config, dir int) {
+			c.Audio.Volume = math.Round(clamp(c.Audio.Volume+0.1*float64(dir), 0, 1)*10) / 10
+		},
+	},
+	{
+		label:  "Rewind",
+		value:  func(c *config.Config) string { return onOff(c.Rewind.Enabled) },
+		adjust: func(c *config.Config, dir int) { c.Rewind.Enabled = !c.Rewind.Enabled },
+	},
+	{
+		label: "Rewind length",
+		value: func(c *config.Config) string { return fmt.Sprintf("%ds", c.Rewind.Seconds) },
+		adjust: func(c *config.Config, dir int) {
+			c.Rewind.Seconds = int(clamp(float64(c.Rewind.Seconds+5*dir), 5, 120))
+		},
+	},
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// cycle returns the value dir steps from v in values, wrapping around.
+func cycle(values []string, v string, dir int) string {
+	i := max(slices.Index(values, v), 0)
+	return values[(i+dir+len(values))%len(values)]
+}
+
+// frameRates are the rates the Settings screen offers: NTSC, PAL and uncapped. Other
+// rates can be set in the settings file.
+var frameRates = []float64{60, 50, 0}
+
+func frameRateName(hz float64) string {
+	if hz == 0 {
+		return "UNCAPPED"
+	}
+	return fmt.Sprintf("%gHZ", hz)
+}
+
+// cycleFrameRate returns the rate dir steps from hz in frameRates, starting from the
+// first for a custom rate.
+func cycleFrameRate(hz float64, dir int) float64 {
+	i := slices.Index(frameRates, hz)
+	if i < 0 {
+		return frameRates[0]
+	}
+	return frameRates[(i+dir+len(frameRates))%len(frameRates)]
+}
+
+func onOff(b bool) string {
+	if b {
+		return "ON"
+	}
+	return "OFF"
+}
+
+// updateSettings handles the keyboard while the Settings screen is open. Changes apply
+// immediately and are written to the settings file when the screen closes.
+func (d *Display) updateSettings() {
+	n := len(settingItems)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		d.settingsRow = (d.settingsRow + n - 1) % n
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		d.settingsRow = (d.settingsRow + 1) % n
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		d.changeSetting(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowRight), inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		d.changeSetting(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyF1), inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		d.closeSettings()
+	}
+}
+
+func (d *Display) changeSetting(dir int) {
+	prev := d.cfg
+	settingItems[d.settingsRow].adjust(&d.cfg, dir)
+	d.applySettings(prev)
+}
+
+// applySettings puts the settings the Settings screen can change into effect.
+func (d *Display) applySettings(prev config.Config) {
+	if d.cfg.Video.Scale != prev.Video.Scale {
+		ebiten.SetWindowSize(WindowSize(d.cfg.Video.Scale))
+	}
+	ebiten.SetFullscreen(d.cfg.Video.Fullscreen)
+	if d.cfg.Video.FrameRate != prev.Video.FrameRate || d.cfg.Video.VSync != prev.Video.VSync {
+		d.applyFrameRate()
+	}
+	if d.audioPlayer != nil {
+		d.audioPlayer.SetVolume(d.cfg.Audio.Volume)
+	}
+	if d.cfg.Rewind != prev.Rewind {
+		// A new length starts a new history
+		d.rewindBuffer = bus.NewRewindBuffer(d.cfg.Rewind.Frames())
+	}
+}
+
+// closeSettings writes what the Settings screen changed back to the settings file,
+// leaving settings given on the command line for this run out of it.
+func (d *Display) closeSettings() {
+	d.settingsOpen = false
+	if d.cfgPath == "" {
+		return
+	}
+	err := config.Update(d.cfgPath, func(c *config.Config) {
+		c.Video = d.cfg.Video
+		c.Audio.Volume = d.cfg.Audio.Volume
+		c.Rewind = d.cfg.Rewind
+	})
+	if err != nil {
+		d.showError("Error saving settings: %v", err)
+	}
+}
+
+func (d *Display) drawSettings(screen *ebiten.Image) {
+	w, h := float32(320), float32(60+len(settingItems)*20)
+	x, y := float32(ScaledWidth())/2-w/2, float32(ScaledHeight())/2-h/2
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{0, 0, 0, 220}, false)
+	vector.StrokeRect(screen, x+1, y+1, w-2, h-2, 2, color.White, false)
+
+	ebitenutil.DebugPrintAt(screen, "SETTINGS", int(x)+12, int(y)+10)
+	for i, item := range settingItems {
+		cursor := "  "
+		if i == d.settingsRow {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-16s %s", cursor, item.label, item.value(&d.cfg))
+		ebitenutil.DebugPrintAt(screen, line, int(x)+12, int(y)+34+i*20)
+	}
+	ebitenutil.DebugPrintAt(screen, "UP/DOWN SELECT  LEFT/RIGHT CHANGE  F1 CLOSE", int(x)+12, int(y+h)-22)
+}
@@ -0,0 +1,37 @@
+package display
+
+import (
+	"math/rand/v2"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// tvStatic is the snow shown while the power is off or no cartridge is in. It is the
+// only randomness in the emulator, and it stays on this side of the frontend: it draws
+// from its own generator, seeded the same every run, so nothing reaches emulation state
+// and the snow itself is reproducible.
+type tvStatic struct {
+	image *ebiten.Image
+	pix   []byte
+	rng   *rand.Rand
+}
+
+func newTVStatic() *tvStatic {
+	return &tvStatic{
+		image: ebiten.NewImage(256, 240),
+		pix:   make([]byte, 256*240*4),
+		rng:   rand.New(rand.NewPCG(0x5EED, 0x7E1E)),
+	}
+}
+
+// next draws a new frame of snow.
+func (s *tvStatic) next() {
+	for i := 0; i < len(s.pix); i += 4 {
+		val := byte(s.rng.IntN(256))
+		s.pix[i] = val
+		s.pix[i+1] = val
+		s.pix[i+2] = val
+		s.pix[i+3] = 255
+	}
+	s.image.WritePixels(s.pix)
+}
@@ -0,0 +1,20 @@
+package display
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// editText applies this tick's typing to text: printable characters append, up to limit,
+// and Backspace deletes the last one.
+func editText(text []rune, limit int) []rune {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if len(text) < limit {
+			text = append(text, r)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(text) > 0 {
+		text = text[:len(text)-1]
+	}
+	return text
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/meadori/vibemulator/bus"
+)
+
+// dumpFrames runs n frames headlessly as fast as possible, writing each finished frame
+// as a PNG into dir and/or its hash as a "<frame> <hash>" line to hashPath, then exits.
+// Frames are numbered from 0 so dumps of different builds line up. Input comes from the
+// movie when there is one, and is otherwise left released.
+func dumpFrames(b *bus.Bus, n int, dir, hashPath string, movie *bus.Movie) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			log.Fatalf("Failed to create frame directory: %v", err)
+		}
+	}
+	var hashes *bufio.Writer
+	if hashPath != "" {
+		f, err := os.Create(hashPath)
+		if err != nil {
+			log.Fatalf("Failed to create hash file: %v", err)
+		}
+		defer f.Close()
+		hashes = bufio.NewWriter(f)
+	}
+
+	if movie != nil {
+		if err := b.StartPlayback(movie); err != nil {
+			log.Fatalf("Error starting playback: %v", err)
+		}
+	}
+	for i := 0; i < n; i++ {
+		b.RunFrame()
+		if dir != "" {
+			if err := writePNG(filepath.Join(dir, fmt.Sprintf("%06d.png", i)), b); err != nil {
+				log.Fatalf("Failed to write frame %d: %v", i, err)
+			}
+		}
+		if hashes != nil {
+			fmt.Fprintf(hashes, "%d %016x\n", i, b.FrameHash())
+		}
+	}
+
+	if hashes != nil {
+		if err := hashes.Flush(); err != nil {
+			log.Fatalf("Failed to write hash file: %v", err)
+		}
+	}
+	log.Printf("Dumped %d frames", n)
+	os.Exit(0)
+}
+
+// readFrameHashes reads a hash file written by dumpFrames. Lines may be left out, e.g.
+// to check only the final frame.
+func readFrameHashes(path string) (map[int]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	hashes := map[int]uint64{}
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		var frame int
+		var hash uint64
+		if _, err := fmt.Sscanf(s.Text(), "%d %x", &frame, &hash); err != nil || frame < 0 {
+			return nil, fmt.Errorf("line %d: expected \"<frame> <hash>\", got %q", line, s.Text())
+		}
+		hashes[frame] = hash
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("no frame hashes in %s", path)
+	}
+	return hashes, nil
+}
+
+func writePNG(path string, b *bus.Bus) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, b.PPU.GetFrame()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,369 @@
+// Package expr implements the small expression language used by debugger breakpoint
+// conditions, e.g. "A == 0x40 && [$00D0] > 3".
+//
+// Operands are numbers ($C123, 0xC123 or decimal), the CPU registers A, X, Y, SP, P
+// and PC, the status flags N, V, D, I, Z and C (0 or 1), the PPU position SCANLINE,
+// DOT and FRAME, and memory reads written [addr]. Operators follow C precedence:
+// unary ! - ~, then * / %, + -, < <= > >=, == !=, &, ^, |, && and ||. Names are
+// case-insensitive. Comparisons and logical operators yield 0 or 1.
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Env is the machine state an expression is evaluated against.
+type Env struct {
+	A, X, Y, SP, P       byte
+	PC                   uint16
+	Scanline, Dot, Frame int
+
+	// Read returns the byte at a CPU address; it must not have side effects that
+	// disturb the emulated program.
+	Read func(addr uint16) byte
+}
+
+// Expr is a parsed expression.
+type Expr struct {
+	src  string
+	root node
+}
+
+// String returns the source the expression was parsed from.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression.
+func (e *Expr) Eval(env *Env) int64 {
+	return e.root.eval(env)
+}
+
+// True reports whether the expression evaluates to a non-zero value.
+func (e *Expr) True(env *Env) bool {
+	return e.Eval(env) != 0
+}
+
+// Parse parses an expression.
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+type node interface {
+	eval(env *Env) int64
+}
+
+type number int64
+
+func (n number) eval(*Env) int64 { return int64(n) }
+
+// variable reads a register, flag or PPU position
+type variable func(env *Env) int64
+
+func (v variable) eval(env *Env) int64 { return v(env) }
+
+// memory reads the byte at the address its operand evaluates to
+type memory struct{ addr node }
+
+func (m memory) eval(env *Env) int64 {
+	if env.Read == nil {
+		return 0
+	}
+	return int64(env.Read(uint16(m.addr.eval(env))))
+}
+
+type unary struct {
+	op      string
+	operand node
+}
+
+func (u unary) eval(env *Env) int64 {
+	v := u.operand.eval(env)
+	switch u.op {
+	case "!":
+		return boolean(v == 0)
+	case "-":
+		return -v
+	default: // "~"
+		return ^v
+	}
+}
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (b binary) eval(env *Env) int64 {
+	l := b.left.eval(env)
+	// && and || short-circuit, so a condition can guard a memory read
+	switch b.op {
+	case "&&":
+		return boolean(l != 0 && b.right.eval(env) != 0)
+	case "||":
+		return boolean(l != 0 || b.right.eval(env) != 0)
+	}
+
+	r := b.right.eval(env)
+	switch b.op {
+	case "*":
+		return l * r
+	case "/":
+		if r == 0 {
+			return 0
+		}
+		return l / r
+	case "%":
+		if r == 0 {
+			return 0
+		}
+		return l % r
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "<":
+		return boolean(l < r)
+	case "<=":
+		return boolean(l <= r)
+	case ">":
+		return boolean(l > r)
+	case ">=":
+		return boolean(l >= r)
+	case "==":
+		return boolean(l == r)
+	case "!=":
+		return boolean(l != r)
+	case "&":
+		return l & r
+	case "^":
+		return l ^ r
+	default: // "|"
+		return l | r
+	}
+}
+
+func boolean(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func flag(mask byte) variable {
+	return func(env *Env) int64 { return boolean(env.P&mask != 0) }
+}
+
+var variables = map[string]variable{
+	"A":        func(env *Env) int64 { return int64(env.A) },
+	"X":        func(env *Env) int64 { return int64(env.X) },
+	"Y":        func(env *Env) int64 { return int64(env.Y) },
+	"SP":       func(env *Env) int64 { return int64(env.SP) },
+	"P":        func(env *Env) int64 { return int64(env.P) },
+	"PC":       func(env *Env) int64 { return int64(env.PC) },
+	"N":        flag(0x80),
+	"V":        flag(0x40),
+	"D":        flag(0x08),
+	"I":        flag(0x04),
+	"Z":        flag(0x02),
+	"C":        flag(0x01),
+	"SCANLINE": func(env *Env) int64 { return int64(env.Scanline) },
+	"DOT":      func(env *Env) int64 { return int64(env.Dot) },
+	"FRAME":    func(env *Env) int64 { return int64(env.Frame) },
+}
+
+// precedence lists the binary operators from loosest to tightest binding
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"|"},
+	{"^"},
+	{"&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	next   int
+}
+
+// operators is ordered so two-character operators match first
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "&", "|", "^", "!", "~", "(", ")", "[", "]"}
+
+func (p *parser) lex() error {
+	for i := 0; i < len(p.src); {
+		c := p.src[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '$' || isDigit(c):
+			start := i
+			i++
+			for i < len(p.src) && isAlnum(p.src[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, token{tokNumber, p.src[start:i], start})
+		case isAlpha(c):
+			start := i
+			for i < len(p.src) && isAlnum(p.src[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, token{tokIdent, p.src[start:i], start})
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(p.src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return fmt.Errorf("unexpected %q at offset %d", c, i)
+			}
+			p.tokens = append(p.tokens, token{tokOp, op, i})
+			i += len(op)
+		}
+	}
+	p.tokens = append(p.tokens, token{tokEOF, "end of expression", len(p.src)})
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) expect(op string) error {
+	if tok := p.take(); tok.kind != tokOp || tok.text != op {
+		return fmt.Errorf("expected %q at offset %d, got %q", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+// parseBinary parses operators at the given precedence level and tighter
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(precedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokOp || !contains(precedence[level], tok.text) {
+			return left, nil
+		}
+		p.take()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{tok.text, left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if tok := p.peek(); tok.kind == tokOp && (tok.text == "!" || tok.text == "-" || tok.text == "~") {
+		p.take()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{tok.text, operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.take()
+	switch {
+	case tok.kind == tokNumber:
+		return parseNumber(tok)
+	case tok.kind == tokIdent:
+		if v, ok := variables[strings.ToUpper(tok.text)]; ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("unknown name %q at offset %d", tok.text, tok.pos)
+	case tok.kind == tokOp && tok.text == "(":
+		inner, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case tok.kind == tokOp && tok.text == "[":
+		addr, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		return memory{addr}, p.expect("]")
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+}
+
+func parseNumber(tok token) (node, error) {
+	text, base := tok.text, 10
+	switch {
+	case strings.HasPrefix(text, "$"):
+		text, base = text[1:], 16
+	case strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X"):
+		text, base = text[2:], 16
+	}
+	v, err := strconv.ParseInt(text, base, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q at offset %d", tok.text, tok.pos)
+	}
+	return number(v), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+func isAlpha(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' }
+func isAlnum(c byte) bool { return isDigit(c) || isAlpha(c) }
@@ -0,0 +1,74 @@
+package expr
+
+import "testing"
+
+func TestEval(t *testing.T) {
+	mem := map[uint16]byte{0x00D0: 5, 0x0010: 0xD0}
+	env := &Env{
+		A: 0x40, X: 2, Y: 0xFF, SP: 0xFD, P: 0x81, PC: 0xC123,
+		Scanline: 241, Dot: 3, Frame: 60,
+		Read: func(addr uint16) byte { return mem[addr] },
+	}
+
+	tests := []struct {
+		src  string
+		want int64
+	}{
+		{"A==0x40 && [$00D0]>3", 1},
+		{"A == $41 || [$00D0] > 5", 0},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"PC", 0xC123},
+		{"[[$10]]", 5},
+		{"[$CE + X]", 5},
+		{"c && n && !z", 1},
+		{"P & 0x80", 0x80},
+		{"scanline >= 241 && dot < 10", 1},
+		{"frame % 2 == 0", 1},
+		{"-1 < 0", 1},
+		{"~0 & $FF", 0xFF},
+		{"10 / 0", 0},
+		{"1 | 2 ^ 3 & 6", 1 | (2 ^ (3 & 6))},
+		{"1 == 1 == 1", 1},
+	}
+	for _, tt := range tests {
+		e, err := Parse(tt.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.src, err)
+			continue
+		}
+		if got := e.Eval(env); got != tt.want {
+			t.Errorf("Eval(%q) = %d, expected %d", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestShortCircuit(t *testing.T) {
+	reads := 0
+	env := &Env{Read: func(addr uint16) byte { reads++; return 0 }}
+
+	e, err := Parse("A != 0 && [$2002] == 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.True(env) || reads != 0 {
+		t.Errorf("Expected the memory read to be skipped, got %d reads", reads)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"A ==",
+		"(A",
+		"[$00",
+		"Q == 1",
+		"$XYZ",
+		"A @ 1",
+		"1 2",
+	} {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Expected an error parsing %q", src)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/meadori/vibemulator/bus"
+)
+
+// headlessCmd runs the emulator without a window. With -frames it dumps that many frames
+// and exits; otherwise it serves gRPC and HTTP until interrupted. The served emulator
+// starts paused and advances through StepFrame, or through the debugger's Resume and
+// step calls; sessions advance only through StepFrame.
+func headlessCmd(args []string) {
+	fs := newFlagSet("headless")
+	settings := addSettingsFlags(fs, false)
+	frameCount := fs.Int("frames", 0, "run this many frames, writing them out with -dump-frames and -dump-hashes, then exit")
+	dumpDir := fs.String("dump-frames", "", "write each frame as a PNG into this directory")
+	dumpHashes := fs.String("dump-hashes", "", "write each frame's hash to this file")
+	movieFile := fs.String("movie", "", "with -frames, drive the controllers from this script")
+	shmPath := addSharedMemoryFlag(fs)
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cfg, _ := settings.load()
+	b := newBus(fs.Arg(0))
+	configureBus(b, cfg)
+	if *shmPath != "" {
+		defer publishFrames(b, *shmPath)()
+	}
+
+	dumping := *dumpDir != "" || *dumpHashes != "" || *movieFile != ""
+	if dumping && *frameCount <= 0 {
+		log.Fatalf("-dump-frames, -dump-hashes and -movie need -frames N")
+	}
+	if *frameCount > 0 {
+		if !b.HasCartridge() {
+			log.Fatalf("-frames needs a ROM")
+		}
+		var movie *bus.Movie
+		if *movieFile != "" {
+			var err error
+			if movie, err = loadPlayback(b, *movieFile); err != nil {
+				log.Fatalf("Error loading playback: %v", err)
+			}
+		}
+		dumpFrames(b, *frameCount, *dumpDir, *dumpHashes, movie)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	b.SetPaused(true)
+	grpcServer := startServers(b, cfg)
+	log.Printf("Serving headlessly on %s; press Ctrl-C to stop", cfg.GRPC.Addr)
+	// Stand in for the display loop, so the debugger's requests are carried out
+	ticker := time.NewTicker(time.Second / 60)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.Advance()
+		case <-ctx.Done():
+			grpcServer.Stop()
+			return
+		}
+	}
+}
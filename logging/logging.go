@@ -0,0 +1,169 @@
+// Package logging gives each emulator subsystem its own slog.Logger. Every subsystem has
+// a level that can be changed while the emulator runs, from the command line with
+// -log-level or over gRPC with SetLogLevels:
+//
+//	-log-level debug              every subsystem at debug
+//	-log-level info,cpu=trace     cpu at trace, the rest at info
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// LevelTrace is below slog.LevelDebug, for per-instruction logging that is far too
+// verbose for debugging anything but the core itself.
+const LevelTrace = slog.LevelDebug - 4
+
+var (
+	mu      sync.Mutex
+	loggers = map[string]*subsystem{}
+
+	output = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: LevelTrace, // Each subsystem filters by its own level
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && len(groups) == 0 {
+				a.Value = slog.StringValue(LevelName(a.Value.Any().(slog.Level)))
+			}
+			return a
+		},
+	})
+)
+
+type subsystem struct {
+	level  slog.LevelVar
+	logger *slog.Logger
+}
+
+// The emulator's subsystems, registered up front so -log-level can name them before
+// their first logger is created.
+func init() {
+	for _, name := range []string{"bus", "cartridge", "cpu", "ppu"} {
+		register(name)
+	}
+}
+
+// register returns the named subsystem, adding it at slog.LevelInfo if it is new.
+// Callers hold mu, except init.
+func register(name string) *subsystem {
+	if s, ok := loggers[name]; ok {
+		return s
+	}
+	s := &subsystem{}
+	s.logger = slog.New(&levelHandler{level: &s.level, next: output.WithAttrs([]slog.Attr{slog.String("subsystem", name)})})
+	loggers[name] = s
+	return s
+}
+
+// Logger returns the logger for a subsystem. Loggers are shared, so every emulator
+// instance in the process logs at the same levels.
+func Logger(name string) *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return register(name).logger
+}
+
+// SetLevel changes the level of a subsystem, or of all of them for "all".
+func SetLevel(name string, level slog.Level) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if name == "all" {
+		for _, s := range loggers {
+			s.level.Set(level)
+		}
+		return nil
+	}
+	s, ok := loggers[name]
+	if !ok {
+		return fmt.Errorf("unknown subsystem %q (have %s)", name, strings.Join(names(), ", "))
+	}
+	s.level.Set(level)
+	return nil
+}
+
+// SetLevels applies a comma-separated list of levels, each either "subsystem=level" or
+// a bare level for every subsystem, e.g. "info,cpu=trace". Entries apply in order.
+func SetLevels(spec string) error {
+	for _, entry := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			name, value = "all", name
+		}
+		level, err := ParseLevel(value)
+		if err != nil {
+			return err
+		}
+		if err := SetLevel(name, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Levels returns the current level of every subsystem.
+func Levels() map[string]slog.Level {
+	mu.Lock()
+	defer mu.Unlock()
+	levels := make(map[string]slog.Level, len(loggers))
+	for name, s := range loggers {
+		levels[name] = s.level.Level()
+	}
+	return levels
+}
+
+// names returns the registered subsystems in order. Callers hold mu.
+func names() []string {
+	var list []string
+	for name := range loggers {
+		list = append(list, name)
+	}
+	slices.Sort(list)
+	return list
+}
+
+// ParseLevel accepts "trace" as well as the slog level names, in any case.
+func ParseLevel(s string) (slog.Level, error) {
+	if strings.EqualFold(s, "trace") {
+		return LevelTrace, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: want trace, debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// LevelName is slog.Level.String with a name for LevelTrace.
+func LevelName(level slog.Level) string {
+	if level == LevelTrace {
+		return "TRACE"
+	}
+	return level.String()
+}
+
+// levelHandler drops records below a subsystem's level before they reach the output.
+type levelHandler struct {
+	level *slog.LevelVar
+	next  slog.Handler
+}
+
+func (h *levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, next: h.next.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, next: h.next.WithGroup(name)}
+}
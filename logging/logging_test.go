@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// restoreLevels puts every subsystem back at its level before the test.
+func restoreLevels(t *testing.T) {
+	saved := Levels()
+	t.Cleanup(func() {
+		for name, level := range saved {
+			SetLevel(name, level)
+		}
+	})
+}
+
+func TestSetLevels(t *testing.T) {
+	restoreLevels(t)
+	if err := SetLevels("warn,cpu=trace, ppu=DEBUG"); err != nil {
+		t.Fatalf("SetLevels failed: %v", err)
+	}
+	want := map[string]slog.Level{"bus": slog.LevelWarn, "cartridge": slog.LevelWarn, "cpu": LevelTrace, "ppu": slog.LevelDebug}
+	for name, level := range want {
+		if got := Levels()[name]; got != level {
+			t.Errorf("Expected %s at %s, got %s", name, LevelName(level), LevelName(got))
+		}
+	}
+
+	for _, spec := range []string{"verbose", "gpu=debug", "cpu="} {
+		if err := SetLevels(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
+
+func TestLoggerFollowsLevel(t *testing.T) {
+	restoreLevels(t)
+	log := Logger("cpu")
+	if log != Logger("cpu") {
+		t.Errorf("Expected loggers to be shared")
+	}
+	ctx := context.Background()
+
+	SetLevel("cpu", slog.LevelInfo)
+	if log.Enabled(ctx, slog.LevelDebug) || !log.Enabled(ctx, slog.LevelInfo) {
+		t.Errorf("Expected only info and above to be enabled at info")
+	}
+	SetLevel("cpu", LevelTrace)
+	if !log.Enabled(ctx, LevelTrace) {
+		t.Errorf("Expected trace to be enabled after SetLevel")
+	}
+	SetLevel("ppu", slog.LevelError)
+	if Logger("ppu").Enabled(ctx, slog.LevelWarn) || !log.Enabled(ctx, LevelTrace) {
+		t.Errorf("Expected each subsystem to keep its own level")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]slog.Level{"trace": LevelTrace, "TRACE": LevelTrace, "debug": slog.LevelDebug, "Warn": slog.LevelWarn} {
+		got, err := ParseLevel(s)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; expected %v", s, got, err, want)
+		}
+	}
+	if name := LevelName(LevelTrace); name != "TRACE" {
+		t.Errorf("Expected TRACE, got %s", name)
+	}
+}
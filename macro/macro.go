@@ -0,0 +1,121 @@
+// Package macro plays short input sequences bound to keys, such as a fighting game
+// combo or a trip through a menu, over whatever the player is pressing. Unlike a movie
+// script a macro has no start point: it runs from the frame its key is pressed.
+//
+// A macro is a list of steps, each holding buttons for a number of frames. The settings
+// file writes a step as "<buttons> [frames]", with buttons as in scripts:
+//
+//	[[macro]]
+//	key = "Q"
+//	player = 1
+//	steps = ["DOWN", "DOWN+RIGHT", "RIGHT+B 2"]
+package macro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/meadori/vibemulator/script"
+)
+
+// maxFrames bounds a step, so a typo can't lock up a controller for hours.
+const maxFrames = 600
+
+// Step holds Buttons for Frames frames.
+type Step struct {
+	Buttons [8]bool
+	Frames  int
+}
+
+// ParseSteps reads steps written as "<buttons> [frames]". Frames default to 1.
+func ParseSteps(steps []string) ([]Step, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no steps")
+	}
+	parsed := make([]Step, len(steps))
+	for i, s := range steps {
+		fields := strings.Fields(s)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid step %q", s)
+		}
+		buttons, err := script.ParseButtons(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		frames := 1
+		if len(fields) == 2 {
+			if frames, err = strconv.Atoi(fields[1]); err != nil || frames < 1 || frames > maxFrames {
+				return nil, fmt.Errorf("invalid frame count in step %q (1-%d)", s, maxFrames)
+			}
+		}
+		parsed[i] = Step{buttons, frames}
+	}
+	return parsed, nil
+}
+
+// FormatSteps writes steps the way ParseSteps reads them.
+func FormatSteps(steps []Step) []string {
+	formatted := make([]string, len(steps))
+	for i, s := range steps {
+		formatted[i] = script.FormatButtons(s.Buttons)
+		if s.Frames != 1 {
+			formatted[i] += " " + strconv.Itoa(s.Frames)
+		}
+	}
+	return formatted
+}
+
+// Runner plays macros a frame at a time. Each controller plays one macro at once.
+type Runner struct {
+	playing [2][]Step // What is left of each controller's macro
+	frame   [2]int    // Frames already played of the first step left
+}
+
+// Start plays steps on player 1 or 2's controller from the next frame, cutting short
+// any macro it was playing.
+func (r *Runner) Start(player int, steps []Step) {
+	r.playing[player-1], r.frame[player-1] = steps, 0
+}
+
+// Next returns the buttons the macros press on the next frame, and moves them on.
+func (r *Runner) Next() (p1, p2 [8]bool) {
+	var buttons [2][8]bool
+	for i, steps := range r.playing {
+		if len(steps) == 0 {
+			continue
+		}
+		buttons[i] = steps[0].Buttons
+		if r.frame[i]++; r.frame[i] == steps[0].Frames {
+			r.playing[i], r.frame[i] = steps[1:], 0
+		}
+	}
+	return buttons[0], buttons[1]
+}
+
+// Recorder turns a controller's input, a frame at a time, into steps.
+type Recorder struct {
+	steps []Step
+}
+
+// Add records the buttons held for a frame.
+func (r *Recorder) Add(buttons [8]bool) {
+	if n := len(r.steps); n > 0 && r.steps[n-1].Buttons == buttons && r.steps[n-1].Frames < maxFrames {
+		r.steps[n-1].Frames++
+		return
+	}
+	r.steps = append(r.steps, Step{buttons, 1})
+}
+
+// Steps returns what was recorded, without the frames before the first button was
+// pressed and after the last was released.
+func (r *Recorder) Steps() []Step {
+	steps := r.steps
+	for len(steps) > 0 && steps[0].Buttons == [8]bool{} {
+		steps = steps[1:]
+	}
+	for len(steps) > 0 && steps[len(steps)-1].Buttons == [8]bool{} {
+		steps = steps[:len(steps)-1]
+	}
+	return steps
+}
@@ -0,0 +1,55 @@
+package macro
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	down      = [8]bool{5: true}
+	downRight = [8]bool{5: true, 7: true}
+	rightB    = [8]bool{1: true, 7: true}
+)
+
+func TestParseSteps(t *testing.T) {
+	steps, err := ParseSteps([]string{"DOWN", "DOWN+RIGHT", "RIGHT+B 2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Step{{down, 1}, {downRight, 1}, {rightB, 2}}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("Expected %v, got %v", want, steps)
+	}
+	if got := FormatSteps(steps); !reflect.DeepEqual(got, []string{"DOWN", "DOWN+RIGHT", "B+RIGHT 2"}) {
+		t.Errorf("Expected the steps to format back, got %q", got)
+	}
+
+	for _, bad := range [][]string{nil, {""}, {"JUMP"}, {"A 0"}, {"A 601"}, {"A 2 3"}} {
+		if _, err := ParseSteps(bad); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
+
+func TestRunner(t *testing.T) {
+	var r Runner
+	r.Start(2, []Step{{down, 1}, {rightB, 2}})
+	want := [][8]bool{down, rightB, rightB, {}}
+	for i, w := range want {
+		p1, p2 := r.Next()
+		if p1 != [8]bool{} || p2 != w {
+			t.Errorf("Frame %d: expected P1 %v and P2 %v, got %v and %v", i, [8]bool{}, w, p1, p2)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var r Recorder
+	for _, b := range [][8]bool{{}, {}, down, downRight, downRight, {}, {}} {
+		r.Add(b)
+	}
+	want := []Step{{down, 1}, {downRight, 2}}
+	if got := r.Steps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -1,88 +1,234 @@
 package main
 
 import (
-	"flag" // Import the flag package
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
-
-	"github.com/hajimehoshi/ebiten/v2"
+	"strings"
+	"time"
 
 	"github.com/meadori/vibemulator/bus"
 	"github.com/meadori/vibemulator/cartridge"
-	"github.com/meadori/vibemulator/display"
+	"github.com/meadori/vibemulator/config"
+	"github.com/meadori/vibemulator/logging"
 	"github.com/meadori/vibemulator/server"
+	"github.com/meadori/vibemulator/storage"
 )
 
-var (
-	debugMode  = flag.Bool("debug", false, "enable debug logging")
-	recordFile = flag.String("record", "", "Record gameplay to script file")
-)
+// command is a vibemulator subcommand. Each parses its own flags from args.
+type command struct {
+	name, args, summary string
+	run                 func(args []string)
+}
+
+// commands is built in init because the commands' usage refers back to it.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"run", "[flags] [rom.nes]", "open the emulator window (the default when no command is given)", runCmd},
+		{"headless", "[flags] [rom.nes]", "serve gRPC without a window, or dump frames with -frames", headlessCmd},
+		{"play", "[flags] <rom.nes> <movie.script>", "play back a recorded script", playCmd},
+		{"bench", "[flags] <rom.nes>", "measure emulation speed", benchCmd},
+		{"test", "[flags] <rom.nes>", "run a test ROM headlessly until it writes a result, and exit with its status", testCmd},
+		{"rominfo", "[flags] <rom.nes>...", "print the header, hashes and header problems of ROMs, and check they boot with -verify", rominfoCmd},
+		{"statediff", "[flags] <before.sav> <after.sav>", "list the RAM, VRAM and pixels that differ between two savestates", stateDiffCmd},
+	}
+}
+
+// debugMode enables logDebug; the commands that run the emulator set it with -debug.
+var debugMode bool
 
 // logDebug prints messages if debugMode is enabled.
 func logDebug(format string, a ...interface{}) {
-	if *debugMode {
+	if debugMode {
 		log.Printf(format, a...)
 	}
 }
 
-func main() {
-	flag.Parse() // Parse command-line flags
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: vibemulator <command> [arguments]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun \"vibemulator <command> -h\" for a command's flags.\n")
+}
 
-	var romFilePath string
-	if len(flag.Args()) > 0 {
-		romFilePath = flag.Args()[0]
+func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && (args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+		usage()
+		return
 	}
+	for _, c := range commands {
+		if len(args) > 0 && args[0] == c.name {
+			c.run(args[1:])
+			return
+		}
+	}
+	// "vibemulator [flags] rom.nes" keeps working as "vibemulator run"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") && !strings.HasSuffix(strings.ToLower(args[0]), ".nes") {
+		fmt.Fprintf(os.Stderr, "vibemulator: unknown command %q\n\n", args[0])
+		usage()
+		os.Exit(2)
+	}
+	runCmd(args)
+}
 
-	logDebug("Starting emulator...")
-	if romFilePath != "" {
-		logDebug("ROM file: %s", romFilePath)
+// newFlagSet returns the flags for a command, with usage that shows its arguments.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		for _, c := range commands {
+			if c.name == name {
+				fmt.Fprintf(fs.Output(), "usage: vibemulator %s %s\n\n%s.\n\nflags:\n", c.name, c.args, c.summary)
+			}
+		}
+		fs.PrintDefaults()
 	}
+	return fs
+}
 
-	b := bus.New()
-	logDebug("Bus created.")
+// settingsFlags are the flags that override the settings file, shared by the commands
+// that run the emulator interactively or as a server.
+type settingsFlags struct {
+	fs         *flag.FlagSet
+	configFile *string
+	scale      *float64 // Only for commands that open a window
+	logLevel   *string
 
-	if romFilePath != "" {
-		cart, err := cartridge.New(romFilePath)
-		if err != nil {
-			log.Fatalf("Error loading ROM: %v", err)
-		}
-		logDebug("Cartridge loaded successfully.")
+	grpcAddr, grpcCert, grpcKey, grpcCA, grpcToken, httpAddr *string
+}
+
+func addSettingsFlags(fs *flag.FlagSet, window bool) *settingsFlags {
+	defaults := config.Default()
+	f := &settingsFlags{fs: fs}
+	f.configFile = fs.String("config", "", "settings file (defaults to ~/.config/vibemulator/config.toml); the flags below override it")
+	if window {
+		f.scale = fs.Float64("scale", defaults.Video.Scale, "window size relative to the 1024x1024 bezel")
+	}
+	fs.BoolVar(&debugMode, "debug", false, "enable debug logging")
+	f.logLevel = fs.String("log-level", "", "log levels, either one for every subsystem or per subsystem, e.g. info,cpu=trace (bus, cartridge, cpu, ppu)")
+	f.grpcAddr = fs.String("grpc-addr", defaults.GRPC.Addr, "address the gRPC server binds to (use localhost:50051 to refuse remote clients)")
+	f.grpcCert = fs.String("grpc-tls-cert", "", "PEM certificate enabling TLS on the gRPC server")
+	f.grpcKey = fs.String("grpc-tls-key", "", "PEM private key for -grpc-tls-cert")
+	f.grpcCA = fs.String("grpc-client-ca", "", "PEM CA bundle; when set, clients must present a certificate signed by it (mTLS)")
+	f.grpcToken = fs.String("grpc-token", "", "bearer token required on every gRPC and HTTP call (defaults to $VIBEMULATOR_TOKEN)")
+	f.httpAddr = fs.String("http-addr", "", "address for the optional HTTP/JSON gateway, e.g. localhost:8080 (disabled when empty)")
+	return f
+}
 
-		err = b.LoadCartridge(cart)
-		if err != nil {
-			log.Fatalf("Error loading cartridge into bus: %v", err)
+// load reads the settings file and applies the flags given on the command line over
+// it. It returns the path the Settings screen should save to.
+func (f *settingsFlags) load() (config.Config, string) {
+	if debugMode {
+		logging.SetLevel("all", slog.LevelDebug)
+	}
+	if *f.logLevel != "" {
+		if err := logging.SetLevels(*f.logLevel); err != nil {
+			log.Fatalf("Invalid -log-level: %v", err)
 		}
-		logDebug("Cartridge loaded into bus.")
 	}
 
-	// Setup recording file if requested
-	var recFile *os.File
-	if *recordFile != "" {
+	path := *f.configFile
+	if path == "" {
 		var err error
-		recFile, err = os.Create(*recordFile)
-		if err != nil {
-			log.Fatalf("Failed to create record file: %v", err)
+		if path, err = config.DefaultPath(); err != nil {
+			log.Printf("No settings file: %v", err)
+			return config.Default(), ""
 		}
-		defer recFile.Close()
-		log.Printf("Recording gameplay to %s\n", *recordFile)
 	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Fatalf("Error loading settings: %v", err)
+	}
+
+	if token := os.Getenv("VIBEMULATOR_TOKEN"); token != "" {
+		cfg.GRPC.Token = token
+	}
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "scale":
+			cfg.Video.Scale = *f.scale
+		case "grpc-addr":
+			cfg.GRPC.Addr = *f.grpcAddr
+		case "grpc-tls-cert":
+			cfg.GRPC.TLSCert = *f.grpcCert
+		case "grpc-tls-key":
+			cfg.GRPC.TLSKey = *f.grpcKey
+		case "grpc-client-ca":
+			cfg.GRPC.ClientCA = *f.grpcCA
+		case "grpc-token":
+			cfg.GRPC.Token = *f.grpcToken
+		case "http-addr":
+			cfg.GRPC.HTTPAddr = *f.httpAddr
+		}
+	})
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid settings: %v", err)
+	}
+	return cfg, path
+}
 
-	// Start the gRPC Controller Server
+// newBus creates the emulator and inserts the ROM at path, if any.
+func newBus(path string) *bus.Bus {
+	b := bus.New()
+	logDebug("Bus created.")
+	if path == "" {
+		return b
+	}
+
+	logDebug("ROM file: %s", path)
+	cart, err := cartridge.New(path)
+	if err != nil {
+		log.Fatalf("Error loading ROM: %v", err)
+	}
+	if err := b.LoadCartridge(cart); err != nil {
+		log.Fatalf("Error loading cartridge into bus: %v", err)
+	}
+	logDebug("Cartridge loaded into bus.")
+	return b
+}
+
+// configureBus applies the emulation settings to b.
+func configureBus(b *bus.Bus, cfg config.Config) {
+	b.SetDPCMConflictFilter(cfg.Emulation.FilterDPCMConflicts)
+	b.APU.SetSampleRate(float64(cfg.Audio.SampleRate))
+	b.PPU.SetWarmup(!cfg.Emulation.SkipPPUWarmup)
+}
+
+// startServers starts the gRPC server, and the HTTP gateway if configured, for b.
+func startServers(b *bus.Bus, cfg config.Config) *server.GRPCServer {
 	grpcServer := server.NewGRPCServer()
 	grpcServer.SetBus(b) // Connect the emulator bus for RL state extraction
-	if err := grpcServer.Start(50051); err != nil {
+	// Extra sessions are headless and only advance through StepFrame
+	grpcServer.SetSessionFactory(func() server.EmuInterface { return bus.New() })
+	dataDir := cfg.Paths.DataDir
+	if dataDir == "" {
+		var err error
+		if dataDir, err = storage.DataDir(); err != nil {
+			log.Printf("No data directory, so gRPC clients can't save movies by name: %v", err)
+		}
+	}
+	if dataDir != "" {
+		grpcServer.SetMovieDir(storage.Movies(dataDir))
+	}
+	grpcServer.SetAllowedOrigins(cfg.GRPC.AllowedOrigins)
+	grpcServer.SetSessionLimits(cfg.GRPC.MaxSessions, time.Duration(cfg.GRPC.SessionIdle)*time.Second)
+	srvCfg := server.Config{
+		Addr:  cfg.GRPC.Addr,
+		TLS:   server.TLSConfig{CertFile: cfg.GRPC.TLSCert, KeyFile: cfg.GRPC.TLSKey, CAFile: cfg.GRPC.ClientCA},
+		Token: cfg.GRPC.Token,
+	}
+	if err := grpcServer.Start(srvCfg); err != nil {
 		log.Fatalf("Failed to start gRPC server: %v", err)
 	}
-	defer grpcServer.Stop()
-
-	d := display.New(b, grpcServer, recFile, romFilePath)
-	logDebug("Display created.")
-	ebiten.SetWindowSize(display.ScaledWidth(), display.ScaledHeight())
-	ebiten.SetWindowTitle("Vibemulator")
-	ebiten.SetWindowResizable(true)
-
-	logDebug("Starting Ebiten game loop...")
-	if err := ebiten.RunGame(d); err != nil {
-		log.Fatal(err)
+	if cfg.GRPC.HTTPAddr != "" {
+		if err := grpcServer.StartHTTP(cfg.GRPC.HTTPAddr, srvCfg); err != nil {
+			log.Fatalf("Failed to start HTTP gateway: %v", err)
+		}
 	}
+	return grpcServer
 }
@@ -0,0 +1,3 @@
+// Package nestest runs kevtris' nestest ROM on the real bus and diffs the CPU trace
+// against the reference log from Nintendulator. See nestest_test.go.
+package nestest
@@ -0,0 +1,140 @@
+package nestest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/cpu"
+)
+
+const (
+	romPath    = "testdata/nestest.nes"
+	goldenPath = "testdata/nestest.log"
+
+	// The golden log starts after the 7-cycle reset sequence
+	resetCycles = 7
+
+	// Columns 14-47 of a log line hold the unofficial-opcode marker and the
+	// disassembly, which Nintendulator annotates with the memory it touches.
+	// Only the address, the instruction bytes and the registers are compared.
+	disasmStart = 14
+	disasmEnd   = 48
+
+	contextLines = 5
+)
+
+// TestNestest runs nestest in automation mode and compares every logged instruction,
+// with the CPU cycle-stepped and running an instruction at a time.
+func TestNestest(t *testing.T) {
+	for _, instructionStepping := range []bool{false, true} {
+		t.Run(fmt.Sprintf("instructionStepping=%v", instructionStepping), func(t *testing.T) {
+			runNestest(t, instructionStepping)
+		})
+	}
+}
+
+func runNestest(t *testing.T, instructionStepping bool) {
+	golden := readGolden(t)
+	b := newNestestBus(t)
+	b.SetInstructionStepping(instructionStepping)
+
+	var trace []string
+	offset := 0
+	for len(trace) < len(golden) {
+		b.Clock()
+		// Only a CPU clock can finish an instruction, and the CPU is clocked on every third system clock
+		if (b.SystemClocks-1)%3 != 0 || !b.IsInstructionComplete() {
+			continue
+		}
+		cycles := (b.SystemClocks + 2) / 3
+		if len(trace) == 0 {
+			// Align the cycle count with the log at the first instruction
+			offset = cycles - resetCycles
+		}
+		trace = append(trace, traceLine(b, cycles-offset))
+
+		i := len(trace) - 1
+		if compareKey(trace[i]) != compareKey(golden[i]) {
+			t.Fatalf("Trace diverges from %s at line %d:\n%s", goldenPath, i+1, contextDiff(golden, trace, i))
+		}
+	}
+
+	// nestest leaves the number of the first failing test at $02 (official) and $03 (unofficial)
+	if res := b.GetMemoryBlock(0x0002, 2); res[0] != 0 || res[1] != 0 {
+		t.Errorf("Expected nestest results $02=00 $03=00, got $02=%02X $03=%02X", res[0], res[1])
+	}
+}
+
+// newNestestBus loads nestest with its reset vector pointed at $C000, which starts the
+// ROM's automation mode instead of its on-screen menu.
+func newNestestBus(t *testing.T) *bus.Bus {
+	t.Helper()
+	data, err := os.ReadFile(romPath)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", romPath, err)
+	}
+	// 16-byte header followed by 16KB of PRG ROM mirrored at $C000
+	data[16+0x3FFC] = 0x00
+	data[16+0x3FFD] = 0xC0
+
+	cart, err := cartridge.NewFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", romPath, err)
+	}
+	b := bus.New()
+	if err := b.LoadCartridge(cart); err != nil {
+		t.Fatalf("failed to insert cartridge: %v", err)
+	}
+	return b
+}
+
+func readGolden(t *testing.T) []string {
+	t.Helper()
+	f, err := os.Open(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", goldenPath, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		lines = append(lines, strings.TrimRight(s.Text(), "\r"))
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("failed to read %s: %v", goldenPath, err)
+	}
+	return lines
+}
+
+// traceLine formats the CPU state at an instruction boundary in the golden log's layout.
+func traceLine(b *bus.Bus, cycles int) string {
+	a, x, y, sp, p, pc, _ := b.GetCPUState()
+	ppu := cycles * 3
+	e := cpu.TraceEntry{A: a, X: x, Y: y, P: p, SP: sp, Cycle: cycles, Scanline: ppu / 341, Dot: ppu % 341}
+	e.Instruction = cpu.Decode(func(addr uint16) byte { return b.GetMemoryBlock(addr, 1)[0] }, pc)
+	return e.String()
+}
+
+// compareKey drops the disassembly column, which is only there for humans.
+func compareKey(line string) string {
+	if len(line) < disasmEnd {
+		return line
+	}
+	return line[:disasmStart] + line[disasmEnd:]
+}
+
+// contextDiff shows the lines leading up to a mismatch followed by the expected and actual line.
+func contextDiff(golden, trace []string, i int) string {
+	var sb strings.Builder
+	for j := max(0, i-contextLines); j < i; j++ {
+		fmt.Fprintf(&sb, "  %s\n", golden[j])
+	}
+	fmt.Fprintf(&sb, "- %s\n+ %s", golden[i], trace[i])
+	return sb.String()
+}
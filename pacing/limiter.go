@@ -0,0 +1,62 @@
+package pacing
+
+import "time"
+
+// maxCatchUp caps the frames one tick runs after a stall, such as the window being
+// dragged; any more due are skipped rather than run in a burst.
+const maxCatchUp = 4
+
+// Limiter paces emulation at its own frame rate, whatever rate the game loop ticks at:
+// each tick runs the frames that have come due since the last, so a 144 Hz display runs
+// none on most ticks and a 50 Hz one sometimes runs two. A rate of 0 is uncapped, one
+// frame a tick, leaving the pace to the display; with vsync off that is as fast as the
+// host can go, and a variable refresh rate monitor shows each frame as it is ready.
+type Limiter struct {
+	interval time.Duration // Zero when uncapped
+	next     time.Time     // When the next frame is due; zero before the first tick
+}
+
+// NewLimiter returns a limiter running hz frames a second.
+func NewLimiter(hz float64) *Limiter {
+	l := &Limiter{}
+	l.SetRate(hz)
+	return l
+}
+
+// SetRate changes the frame rate, starting the schedule afresh at the next tick.
+func (l *Limiter) SetRate(hz float64) {
+	l.interval = 0
+	if hz > 0 {
+		l.interval = time.Duration(float64(time.Second) / hz)
+	}
+	l.next = time.Time{}
+}
+
+// Interval returns the time between frames, or 0 when uncapped.
+func (l *Limiter) Interval() time.Duration {
+	return l.interval
+}
+
+// Due returns how many frames to run on the tick at now. A tick up to half a frame
+// early still counts, so a display at the emulator's own rate runs one frame every tick
+// however its timing wobbles.
+func (l *Limiter) Due(now time.Time) int {
+	if l.interval == 0 {
+		return 1
+	}
+	if l.next.IsZero() {
+		l.next = now
+	}
+	ahead := now.Add(l.interval / 2).Sub(l.next)
+	if ahead < 0 {
+		return 0
+	}
+	n := int(ahead/l.interval) + 1
+	if n > maxCatchUp {
+		n = maxCatchUp
+		l.next = now.Add(l.interval)
+		return n
+	}
+	l.next = l.next.Add(time.Duration(n) * l.interval)
+	return n
+}
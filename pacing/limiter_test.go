@@ -0,0 +1,61 @@
+package pacing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		hz      float64
+		tick    time.Duration
+		seconds int
+		want    int // Frames run over the whole time
+	}{
+		{"60 Hz on a 60 Hz display", 60, FrameInterval, 10, 600},
+		{"50 Hz on a 60 Hz display", 50, FrameInterval, 10, 500},
+		{"60 Hz on a 144 Hz display", 60, time.Second / 144, 10, 600},
+		{"60 Hz on a 30 Hz display", 60, time.Second / 30, 10, 600},
+		{"Uncapped on a 144 Hz display", 0, time.Second / 144, 10, 1440},
+	}
+	for _, tt := range tests {
+		l := NewLimiter(tt.hz)
+		now := time.Unix(0, 0)
+		total := 0
+		for range tt.seconds * int(time.Second/tt.tick) {
+			total += l.Due(now)
+			now = now.Add(tt.tick)
+		}
+		if total < tt.want-1 || total > tt.want+1 {
+			t.Errorf("%s: expected %d frames, got %d", tt.name, tt.want, total)
+		}
+	}
+}
+
+func TestLimiterJitter(t *testing.T) {
+	l := NewLimiter(60)
+	now := time.Unix(0, 0)
+	for i := range 600 {
+		wobble := time.Duration(i%3-1) * 3 * time.Millisecond
+		if got := l.Due(now.Add(wobble)); got != 1 {
+			t.Fatalf("Tick %d: expected 1 frame, got %d", i, got)
+		}
+		now = now.Add(FrameInterval)
+	}
+}
+
+func TestLimiterStall(t *testing.T) {
+	l := NewLimiter(60)
+	now := time.Unix(0, 0)
+	l.Due(now)
+
+	// A second-long stall runs a few frames, not sixty
+	now = now.Add(time.Second)
+	if got := l.Due(now); got != maxCatchUp {
+		t.Errorf("Expected %d frames after a stall, got %d", maxCatchUp, got)
+	}
+	if got := l.Due(now.Add(FrameInterval)); got != 1 {
+		t.Errorf("Expected 1 frame on the tick after, got %d", got)
+	}
+}
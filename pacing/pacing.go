@@ -0,0 +1,140 @@
+// Package pacing measures how evenly the emulator delivers frames and audio, so pacing
+// problems can be quantified rather than described as stutter. The game loop reports
+// each emulated frame and each presented one, and the audio stream reports its reads.
+package pacing
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// FrameInterval is the time a frame takes at the default 60 frames a second.
+const FrameInterval = time.Second / 60
+
+// window is how many frame intervals the timing statistics cover: ten seconds.
+const window = 600
+
+// Stats summarizes frame delivery over the last ten seconds of running, and counts
+// problems since the meter was created.
+type Stats struct {
+	Frames       int           // Emulated frames in the window
+	MeanInterval time.Duration // Average time between emulated frames
+	Jitter       time.Duration // Standard deviation of the time between frames
+	MaxInterval  time.Duration
+
+	Dropped    int // Frames the loop fell behind by: each interval over 1.5 frames counts the whole frames missed
+	Duplicated int // Presentations that showed the same emulated frame again
+
+	AudioQueued    time.Duration // Audio generated but not yet played
+	AudioDrift     time.Duration // Change in AudioQueued over the window; positive when emulation outpaces playback
+	AudioUnderruns int           // Reads by the audio device that found no samples waiting
+}
+
+// Meter collects the measurements. It is safe for concurrent use.
+type Meter struct {
+	mu        sync.Mutex
+	interval  time.Duration   // Expected time between frames; zero when uncapped
+	last      time.Time       // When the previous frame ran; zero after Idle
+	intervals []time.Duration // Ring of the last window intervals
+	next      int
+	queued    []time.Duration // Queued audio after each frame in the window, in the same ring order
+	stats     Stats
+	presented int // Emulated frame shown by the last Present
+}
+
+// NewMeter returns an empty meter.
+func NewMeter() *Meter {
+	return &Meter{interval: FrameInterval, presented: -1}
+}
+
+// SetInterval sets the expected time between frames, which a frame must overrun by
+// half to count as dropped. Zero, for uncapped emulation, counts no drops.
+func (m *Meter) SetInterval(d time.Duration) {
+	m.mu.Lock()
+	m.interval = d
+	m.mu.Unlock()
+}
+
+// Frame records that an emulated frame ran at now, with audioQueued of audio waiting
+// to be played.
+func (m *Meter) Frame(now time.Time, audioQueued time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats.AudioQueued = audioQueued
+	if !m.last.IsZero() {
+		d := now.Sub(m.last)
+		if m.interval > 0 && d >= m.interval*3/2 {
+			m.stats.Dropped += int(d/m.interval) - 1
+		}
+		if len(m.intervals) < window {
+			m.intervals = append(m.intervals, d)
+			m.queued = append(m.queued, audioQueued)
+		} else {
+			m.intervals[m.next] = d
+			m.queued[m.next] = audioQueued
+			m.next = (m.next + 1) % window
+		}
+	}
+	m.last = now
+}
+
+// Idle records that the loop ran no frame, because the emulator is paused or powered
+// off, so the gap before the next frame isn't counted against pacing.
+func (m *Meter) Idle() {
+	m.mu.Lock()
+	m.last = time.Time{}
+	m.mu.Unlock()
+}
+
+// Present records that the display showed emulated frame number frame.
+func (m *Meter) Present(frame int) {
+	m.mu.Lock()
+	if frame == m.presented && !m.last.IsZero() {
+		m.stats.Duplicated++
+	}
+	m.presented = frame
+	m.mu.Unlock()
+}
+
+// AudioRead records a read by the audio device that wanted want bytes and got got. Short
+// reads are normal while streaming; a read that gets nothing means playback ran dry.
+func (m *Meter) AudioRead(want, got int) {
+	if want == 0 || got > 0 {
+		return
+	}
+	m.mu.Lock()
+	m.stats.AudioUnderruns++
+	m.mu.Unlock()
+}
+
+// Stats returns the statistics so far.
+func (m *Meter) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.stats
+	s.Frames = len(m.intervals)
+	if s.Frames == 0 {
+		return s
+	}
+	var sum time.Duration
+	for _, d := range m.intervals {
+		sum += d
+		s.MaxInterval = max(s.MaxInterval, d)
+	}
+	s.MeanInterval = sum / time.Duration(s.Frames)
+	var variance float64
+	for _, d := range m.intervals {
+		diff := float64(d - s.MeanInterval)
+		variance += diff * diff
+	}
+	s.Jitter = time.Duration(math.Sqrt(variance / float64(s.Frames)))
+
+	// The oldest entry in the ring is at next once it has wrapped
+	oldest := 0
+	if len(m.intervals) == window {
+		oldest = m.next
+	}
+	s.AudioDrift = s.AudioQueued - m.queued[oldest]
+	return s
+}
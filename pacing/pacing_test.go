@@ -0,0 +1,76 @@
+package pacing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeter(t *testing.T) {
+	m := NewMeter()
+	now := time.Unix(0, 0)
+	step := func(d time.Duration, queued time.Duration) {
+		now = now.Add(d)
+		m.Frame(now, queued)
+		m.Present(m.Stats().Frames)
+	}
+
+	step(0, 50*time.Millisecond)
+	for i := 0; i < 9; i++ {
+		step(FrameInterval, 50*time.Millisecond)
+	}
+	step(3*FrameInterval, 80*time.Millisecond) // Two frames late
+	m.Present(m.Stats().Frames)                // Shown twice
+
+	// A pause isn't a stall
+	m.Idle()
+	now = now.Add(time.Minute)
+	m.Frame(now, 80*time.Millisecond)
+
+	m.AudioRead(4096, 4096)
+	m.AudioRead(4096, 1024)
+	m.AudioRead(4096, 0)
+
+	s := m.Stats()
+	if s.Frames != 10 {
+		t.Errorf("Expected 10 intervals, got %d", s.Frames)
+	}
+	if s.Dropped != 2 {
+		t.Errorf("Expected 2 dropped frames, got %d", s.Dropped)
+	}
+	if s.Duplicated != 1 {
+		t.Errorf("Expected 1 duplicated frame, got %d", s.Duplicated)
+	}
+	if s.MaxInterval != 3*FrameInterval {
+		t.Errorf("Expected the longest interval to be 3 frames, got %v", s.MaxInterval)
+	}
+	if want := (12 * FrameInterval) / 10; s.MeanInterval != want {
+		t.Errorf("Expected a mean interval of %v, got %v", want, s.MeanInterval)
+	}
+	if s.Jitter == 0 {
+		t.Error("Expected jitter from the late frame")
+	}
+	if s.AudioQueued != 80*time.Millisecond || s.AudioDrift != 30*time.Millisecond {
+		t.Errorf("Expected 80ms of audio queued, up 30ms, got %v and %v", s.AudioQueued, s.AudioDrift)
+	}
+	if s.AudioUnderruns != 1 {
+		t.Errorf("Expected 1 audio underrun, got %d", s.AudioUnderruns)
+	}
+}
+
+func TestMeterInterval(t *testing.T) {
+	m := NewMeter()
+	m.SetInterval(time.Second / 50)
+	now := time.Unix(0, 0)
+	m.Frame(now, 0)
+	m.Frame(now.Add(time.Second/50), 0)
+	m.Frame(now.Add(3*time.Second/50), 0) // One frame late
+	if s := m.Stats(); s.Dropped != 1 {
+		t.Errorf("Expected 1 dropped frame at 50 Hz, got %d", s.Dropped)
+	}
+
+	m.SetInterval(0)
+	m.Frame(now.Add(time.Minute), 0)
+	if s := m.Stats(); s.Dropped != 1 {
+		t.Errorf("Expected uncapped frames never to drop, got %d dropped", s.Dropped)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/script"
+)
+
+// loadPlayback reads a script and power-cycles the emulator into the state it starts
+// from, returning the movie to play. Version 1 scripts start from reset.
+func loadPlayback(b *bus.Bus, path string) (*bus.Movie, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	s, err := script.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	if s.ROMHash != "" && s.ROMHash != b.ROMHash() {
+		log.Printf("Warning: %s was recorded on ROM %s, not the loaded %s", path, s.ROMHash, b.ROMHash())
+	}
+	if s.Emulator != "" && s.Emulator != bus.Version {
+		log.Printf("Warning: %s was recorded with %s, not %s; it may desync", path, s.Emulator, bus.Version)
+	}
+
+	// Power-cycle first so nothing from before playback (e.g. mapper state) leaks in
+	if err := b.ResetEpisode(nil); err != nil {
+		return nil, err
+	}
+	state := s.State
+	if s.Start == script.StartFromReset {
+		if state, err = b.SaveStateToBytes(); err != nil {
+			return nil, err
+		}
+	}
+	return bus.ScriptMovie(s, state), nil
+}
+
+// playAndExit runs a movie headlessly as fast as possible, prints the final frame and
+// its hash, and exits. finish is called once playback ends.
+func playAndExit(b *bus.Bus, m *bus.Movie, finish func()) {
+	hash, err := b.PlayMovie(m)
+	finish()
+	if err != nil {
+		log.Fatalf("Playback failed: %v", err)
+	}
+	fmt.Printf("frame %d hash %016x\n", b.GetFrameNumber(), hash)
+	os.Exit(0)
+}
+
+// verifyAndExit plays a movie headlessly as fast as possible, compares its frames with
+// the hashes in hashPath and exits 1 at the first that differs.
+func verifyAndExit(b *bus.Bus, m *bus.Movie, hashPath string, finish func()) {
+	want, err := readFrameHashes(hashPath)
+	if err != nil {
+		log.Fatalf("Error reading hashes: %v", err)
+	}
+	d, err := b.VerifyMovie(m, want)
+	finish()
+	if err != nil {
+		log.Fatalf("Playback failed: %v", err)
+	}
+	if d != nil {
+		fmt.Printf("frame %d: hash %016x, expected %016x\n", d.Frame, d.Got, d.Want)
+		os.Exit(1)
+	}
+	fmt.Printf("%d frame hashes match\n", len(want))
+	os.Exit(0)
+}
+
+// playCmd plays a script back in the window, or headlessly with -exit or -verify.
+func playCmd(args []string) {
+	fs := newFlagSet("play")
+	settings := addSettingsFlags(fs, true)
+	exit := fs.Bool("exit", false, "run headlessly as fast as possible, print the final frame hash and exit")
+	verify := fs.String("verify", "", "run headlessly and compare each frame's hash with this file, as written by headless -dump-hashes, exiting 1 at the first that differs")
+	wavOut := addWAVFlags(fs)
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cfg, cfgPath := settings.load()
+	romPath, moviePath := fs.Arg(0), fs.Arg(1)
+
+	b := newBus(romPath)
+	configureBus(b, cfg)
+	playback, err := loadPlayback(b, moviePath)
+	if err != nil {
+		log.Fatalf("Error loading playback: %v", err)
+	}
+	stopWAV := wavOut.record(b)
+	if *verify != "" {
+		verifyAndExit(b, playback, *verify, stopWAV)
+	}
+	if *exit {
+		playAndExit(b, playback, stopWAV)
+	}
+	defer stopWAV()
+
+	if err := b.StartPlayback(playback); err != nil {
+		log.Fatalf("Error starting playback: %v", err)
+	}
+	b.SetPaused(false)
+	log.Printf("Playing %s (%d frames)\n", moviePath, len(playback.Frames))
+	runWindow(b, nil, romPath, cfg, cfgPath, nil)
+}
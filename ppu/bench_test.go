@@ -0,0 +1,91 @@
+package ppu
+
+import "testing"
+
+// newBenchPPU renders the background from createTestCartridge with sprites on.
+func newBenchPPU() *PPU {
+	p := New(nil)
+	p.ConnectCartridge(createTestCartridge())
+	p.palette[0x01] = 0x16
+	p.Ctrl = 0x20
+	p.Mask = 0x1E
+	// A row of sprites on every scanline exercises sprite evaluation
+	for i := 0; i < 64; i++ {
+		p.oam[i*4] = byte(i * 4)
+		p.oam[i*4+3] = byte(i * 4)
+	}
+	return p
+}
+
+// BenchmarkScanline times one scanline (341 dots) of rendering.
+func BenchmarkScanline(b *testing.B) {
+	p := newBenchPPU()
+	b.ReportAllocs()
+	for b.Loop() {
+		for dot := 0; dot < 341; dot++ {
+			p.Clock()
+		}
+	}
+}
+
+func TestScanlineAllocs(t *testing.T) {
+	p := newBenchPPU()
+	scanline := func() {
+		for dot := 0; dot < 341; dot++ {
+			p.Clock()
+		}
+	}
+	if allocs := testing.AllocsPerRun(262, scanline); allocs != 0 {
+		t.Errorf("Expected rendering not to allocate, got %v allocations per scanline", allocs)
+	}
+}
+
+// newCrowdedPPU puts all 64 sprites on scanlines 100-107, so evaluation fills the
+// secondary OAM and takes the overflow path.
+func newCrowdedPPU() *PPU {
+	p := newBenchPPU()
+	p.Ctrl = 0x00 // 8x8 sprites
+	for i := 0; i < 64; i++ {
+		p.oam[i*4] = 100
+		p.oam[i*4+3] = byte(i * 4)
+	}
+	return p
+}
+
+// BenchmarkSpriteEvaluation times one frame with more than eight sprites on a line.
+func BenchmarkSpriteEvaluation(b *testing.B) {
+	p := newCrowdedPPU()
+	b.ReportAllocs()
+	for b.Loop() {
+		for dot := 0; dot < 341*262; dot++ {
+			p.Clock()
+		}
+	}
+}
+
+func TestSpriteEvaluation(t *testing.T) {
+	p := newCrowdedPPU()
+	for p.Scanline != 100 || p.Cycle != 321 {
+		p.Clock()
+	}
+	if p.spriteScanlineLen != 8 {
+		t.Errorf("Expected 8 sprites in secondary OAM, got %d", p.spriteScanlineLen)
+	}
+	for i, s := range p.spriteScanline {
+		if want := byte(i * 4); s.x != want {
+			t.Errorf("Expected secondary OAM slot %d to hold the sprite at x=%d, got x=%d", i, want, s.x)
+		}
+	}
+	if p.Status&0x20 == 0 {
+		t.Error("Expected sprite overflow with 64 sprites on one line")
+	}
+
+	frame := func() {
+		for dot := 0; dot < 341*262; dot++ {
+			p.Clock()
+		}
+	}
+	if allocs := testing.AllocsPerRun(5, frame); allocs != 0 {
+		t.Errorf("Expected sprite evaluation not to allocate, got %v allocations per frame", allocs)
+	}
+}
@@ -44,6 +44,23 @@ func (p *PPU) PPUDebugRead(addr uint16) byte {
 	return data
 }
 
+// CPUDebugRead returns what a CPU read of a PPU register would, without clearing the
+// vblank flag or the write toggle, or refilling the $2007 buffer and advancing v.
+func (p *PPU) CPUDebugRead(addr uint16) byte {
+	switch addr {
+	case 0x0002:
+		return p.status()
+	case 0x0004:
+		return p.oam[p.oamAddr]
+	case 0x0007:
+		if p.vramAddr&0x3FFF >= 0x3F00 {
+			return p.PPUDebugRead(p.vramAddr)
+		}
+		return p.ppuData
+	}
+	return 0
+}
+
 // GetPatternTable extracts the requested pattern table (0 or 1) into a 128x128 RGBA byte slice using the specified palette index (0-7).
 func (p *PPU) GetPatternTable(i int, palette byte, dest []byte) {
 	for tileY := 0; tileY < 16; tileY++ {
@@ -81,3 +98,97 @@ func (p *PPU) GetPatternTable(i int, palette byte, dest []byte) {
 		}
 	}
 }
+
+// GetNametableMemory returns the four logical nametables ($2000-$2FFF) as currently mirrored.
+func (p *PPU) GetNametableMemory() []byte {
+	data := make([]byte, 0x1000)
+	for i := range data {
+		data[i] = p.PPUDebugRead(0x2000 + uint16(i))
+	}
+	return data
+}
+
+// GetOAM returns a copy of the 256 bytes of sprite memory.
+func (p *PPU) GetOAM() []byte {
+	data := make([]byte, len(p.oam))
+	copy(data, p.oam[:])
+	return data
+}
+
+// GetPaletteRAM returns palette memory ($3F00-$3F1F) with the backdrop mirrors applied.
+func (p *PPU) GetPaletteRAM() []byte {
+	data := make([]byte, 32)
+	for i := range data {
+		data[i] = p.PPUDebugRead(0x3F00 + uint16(i))
+	}
+	return data
+}
+
+// GetNametableImage renders all four nametables into a 512x480 RGBA byte slice using the current background pattern table.
+func (p *PPU) GetNametableImage(dest []byte) {
+	patternBase := (uint16(p.Ctrl>>4) & 1) * 0x1000
+	for nt := 0; nt < 4; nt++ {
+		base := 0x2000 + uint16(nt)*0x400
+		for tileY := 0; tileY < 30; tileY++ {
+			for tileX := 0; tileX < 32; tileX++ {
+				tileID := p.PPUDebugRead(base + uint16(tileY*32+tileX))
+				attrib := p.PPUDebugRead(base + 0x3C0 + uint16((tileY/4)*8+tileX/4))
+				shift := ((tileY%4)/2)*4 + ((tileX%4)/2)*2
+				palette := (attrib >> shift) & 0x03
+
+				for row := uint16(0); row < 8; row++ {
+					tileLSB := p.PPUDebugRead(patternBase + uint16(tileID)*16 + row)
+					tileMSB := p.PPUDebugRead(patternBase + uint16(tileID)*16 + row + 8)
+
+					for col := 0; col < 8; col++ {
+						pixel := (tileLSB & 0x01) | ((tileMSB & 0x01) << 1)
+						tileLSB >>= 1
+						tileMSB >>= 1
+
+						// Pixel 0 shows the universal backdrop color, as on screen
+						addr := uint16(0x3F00)
+						if pixel != 0 {
+							addr += uint16(palette)*4 + uint16(pixel)
+						}
+						c := p.SystemPalette[p.PPUDebugRead(addr)&0x3F]
+
+						x := (nt%2)*256 + tileX*8 + (7 - col)
+						y := (nt/2)*240 + tileY*8 + int(row)
+						idx := (y*512 + x) * 4
+						dest[idx] = c.R
+						dest[idx+1] = c.G
+						dest[idx+2] = c.B
+						dest[idx+3] = 255
+					}
+				}
+			}
+		}
+	}
+}
+
+// Registers summarizes the PPU's internal registers for debuggers.
+type Registers struct {
+	Ctrl, Mask, Status, OAMAddr byte
+	V, T                        uint16 // Current and temporary VRAM addresses ("loopy" v and t)
+	FineX                       byte
+	W                           bool // Write toggle shared by $2005 and $2006
+	Scanline, Dot, Frame        int
+	NMIPending                  bool // NMI raised but not yet taken by the CPU; set by the bus
+}
+
+// GetRegisters returns the PPU's registers without side effects.
+func (p *PPU) GetRegisters() Registers {
+	return Registers{
+		Ctrl:     p.Ctrl,
+		Mask:     p.Mask,
+		Status:   p.Status,
+		OAMAddr:  p.oamAddr,
+		V:        p.vramAddr,
+		T:        p.vramTmpAddr,
+		FineX:    p.fineX,
+		W:        p.addrLatch != 0,
+		Scanline: p.Scanline,
+		Dot:      p.Cycle,
+		Frame:    p.FrameCounter,
+	}
+}
@@ -0,0 +1,71 @@
+package ppu
+
+import "testing"
+
+func TestGetNametableImage(t *testing.T) {
+	ppu := New(nil)
+	ppu.ConnectCartridge(createTestCartridge()) // Vertical mirroring
+
+	// Nametable 0 uses tile 0 (solid color 1), nametable 1 uses the blank tile 1
+	for i := 0; i < 0x3C0; i++ {
+		ppu.vram[i] = 0x00
+		ppu.vram[0x400+i] = 0x01
+	}
+	ppu.palette[0x00] = 0x0F
+	ppu.palette[0x01] = 0x16
+
+	dest := make([]byte, 512*480*4)
+	ppu.GetNametableImage(dest)
+
+	red := ppu.SystemPalette[0x16]
+	backdrop := ppu.SystemPalette[0x0F]
+	tests := []struct {
+		name    string
+		x, y    int
+		r, g, b byte
+	}{
+		{"nametable 0", 10, 10, red.R, red.G, red.B},
+		{"nametable 1", 300, 10, backdrop.R, backdrop.G, backdrop.B},
+		{"nametable 2 mirrors 0", 10, 250, red.R, red.G, red.B},
+		{"nametable 3 mirrors 1", 300, 250, backdrop.R, backdrop.G, backdrop.B},
+	}
+	for _, tt := range tests {
+		idx := (tt.y*512 + tt.x) * 4
+		if dest[idx] != tt.r || dest[idx+1] != tt.g || dest[idx+2] != tt.b {
+			t.Errorf("%s: expected (%d,%d,%d), got (%d,%d,%d)", tt.name, tt.r, tt.g, tt.b, dest[idx], dest[idx+1], dest[idx+2])
+		}
+	}
+}
+
+func TestDebugMemoryDumps(t *testing.T) {
+	ppu := New(nil)
+	ppu.ConnectCartridge(createTestCartridge())
+
+	ppu.vram[0x005] = 0xAB
+	ppu.oam[4] = 0x42
+	ppu.palette[0x00] = 0x21
+
+	nt := ppu.GetNametableMemory()
+	if len(nt) != 0x1000 || nt[0x005] != 0xAB || nt[0x805] != 0xAB {
+		t.Errorf("Expected nametable byte mirrored at $2005 and $2805")
+	}
+	if oam := ppu.GetOAM(); oam[4] != 0x42 {
+		t.Errorf("Expected OAM byte 4 to be 42, got %02X", oam[4])
+	}
+	if pal := ppu.GetPaletteRAM(); pal[0x10] != 0x21 {
+		t.Errorf("Expected $3F10 to mirror the backdrop, got %02X", pal[0x10])
+	}
+}
+
+func TestGetRegisters(t *testing.T) {
+	ppu := New(nil)
+	ppu.CPUWrite(0x0000, 0x80) // Enable NMI
+	ppu.CPUWrite(0x0006, 0x21) // v = $2108
+	ppu.CPUWrite(0x0006, 0x08)
+	ppu.CPUWrite(0x0005, 0x0D) // Coarse X 1, fine X 5, leaving the write toggle set
+
+	r := ppu.GetRegisters()
+	if r.Ctrl != 0x80 || r.V != 0x2108 || r.T != 0x2101 || r.FineX != 5 || !r.W {
+		t.Errorf("Unexpected registers %+v", r)
+	}
+}
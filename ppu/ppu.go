@@ -1,17 +1,19 @@
 package ppu
 
 import (
+	"context"
 	"image"
 	"image/color"
+	"log/slog"
+	"math/bits"
 
 	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/logging"
 )
 
-// Declare logDebug function from main package
-var LogDebug func(format string, a ...interface{})
-
 // PPU represents the Picture Processing Unit.
 type PPU struct {
+	log          *slog.Logger
 	cart         *cartridge.Cartridge
 	nt_map       [4]uint16
 	vram         [2048]byte
@@ -29,7 +31,6 @@ type PPU struct {
 	ppuData      byte
 	oamAddr      byte
 	FrameCounter int
-	NMI          bool
 
 	// Frame buffer
 	frame *image.RGBA
@@ -48,12 +49,27 @@ type PPU struct {
 	bgNextTileMSB      byte
 
 	// Sprite rendering
-	spriteScanline    []spriteInfo
+	spriteScanline    [8]spriteInfo // Secondary OAM: the sprites found for the next scanline
+	spriteScanlineLen int
 	spriteZeroHit     bool
 	spriteZero        bool
 	spriteEvalCycle   int
 	sprite0InScanline bool
 	spriteCount       byte
+
+	// Sprite shifters: the pattern rows of the sprites on the scanline being drawn,
+	// fetched from secondary OAM at the end of the previous scanline
+	spritePatternLo [8]byte // Flipped when needed so bit 7 is the leftmost pixel
+	spritePatternHi [8]byte
+	spriteAttr      [8]byte
+	spriteX         [8]byte
+	spriteLineLen   int
+	spriteZeroLine  bool // Slot 0 holds sprite 0
+
+	// After power-on the PPU ignores writes to $2000, $2001, $2005 and $2006 until the
+	// end of its first vertical blank, about 29658 CPU cycles. Emulating it is optional.
+	warmup    bool
+	warmingUp bool
 }
 
 type spriteInfo struct {
@@ -74,13 +90,15 @@ func (p *PPU) Reset() {
 	p.ppuData = 0x00
 	p.oamAddr = 0x00
 	p.FrameCounter = 0
-	p.NMI = false
+	p.warmingUp = true
 
 	p.spriteEvalCycle = 0
 	p.sprite0InScanline = false
-	p.spriteScanline = p.spriteScanline[:0] // Clear the secondary OAM
+	p.spriteScanlineLen = 0 // Clear the secondary OAM
 
 	p.spriteCount = 0
+	p.spriteLineLen = 0
+	p.spriteZeroLine = false
 
 	p.bgPatternShifterLo = 0x0000
 	p.bgPatternShifterHi = 0x0000
@@ -96,16 +114,28 @@ func (p *PPU) Reset() {
 	for i := range p.palette {
 		p.palette[i] = 0x0F
 	}
+
+	// Clear nametables, OAM and the frame buffer so every power-on starts from identical memory
+	p.vram = [2048]byte{}
+	p.oam = [256]byte{}
+	if p.frame != nil {
+		for i := range p.frame.Pix {
+			p.frame.Pix[i] = 0
+		}
+	}
 }
 
-// New creates a new PPU instance.
-func New() *PPU {
+// New creates a new PPU instance that logs to log. A nil log discards everything.
+func New(log *slog.Logger) *PPU {
+	if log == nil {
+		log = slog.New(slog.DiscardHandler)
+	}
 	p := &PPU{
+		log:   log,
 		frame: image.NewRGBA(image.Rect(0, 0, 256, 240)),
 	}
 	p.SystemPalette = getSystemPalette()
 
-	p.spriteScanline = make([]spriteInfo, 8)
 	p.Reset() // Call Reset here to initialize state
 	return p
 }
@@ -122,6 +152,7 @@ func (p *PPU) ConnectCartridge(cart *cartridge.Cartridge) {
 		return
 	}
 	mirror := p.cart.Mapper.GetMirroring()
+	p.log.Debug("cartridge connected", "mirroring", mirror)
 	if mirror == cartridge.MirrorVertical {
 		p.nt_map = [4]uint16{0x0000, 0x0400, 0x0000, 0x0400}
 	} else if mirror == cartridge.MirrorHorizontal {
@@ -152,6 +183,7 @@ func (p *PPU) Clock() {
 		if p.Scanline == -1 && p.Cycle == 1 {
 			p.Status &= 0x1F
 			p.spriteZeroHit = false
+			p.warmingUp = false
 		}
 
 		if p.Scanline < 240 && p.Cycle >= 1 && p.Cycle <= 256 {
@@ -199,7 +231,7 @@ func (p *PPU) Clock() {
 			// Sprite evaluation initialization (occurs at cycle 257 for all renderable scanlines)
 			if p.Cycle == 257 && p.Scanline >= -1 && p.Scanline < 240 {
 				// Clear secondary OAM (p.spriteScanline)
-				p.spriteScanline = p.spriteScanline[:0]
+				p.spriteScanlineLen = 0
 				p.spriteCount = 0
 				p.sprite0InScanline = false
 				p.oamAddr = 0    // OAMADDR is set to 0 at dot 257 of each scanline if rendering is enabled.
@@ -225,12 +257,13 @@ func (p *PPU) Clock() {
 					// The +1 is because sprite Y coordinate is top-most scanline of sprite - 1
 					if (p.Scanline+1) >= int(y) && (p.Scanline+1) < int(y)+int(spriteHeight) {
 						if p.spriteCount < 8 {
-							p.spriteScanline = append(p.spriteScanline, spriteInfo{
+							p.spriteScanline[p.spriteScanlineLen] = spriteInfo{
 								y:    y,
 								id:   id,
 								attr: attr,
 								x:    x,
-							})
+							}
+							p.spriteScanlineLen++
 							if oamIndex == 0 { // Check if sprite 0 is found (first entry in primary OAM)
 								p.sprite0InScanline = true
 							}
@@ -244,6 +277,10 @@ func (p *PPU) Clock() {
 				}
 			}
 
+			if p.Cycle == 320 {
+				p.loadSpriteShifters()
+			}
+
 			if p.Scanline == -1 && p.Cycle >= 280 && p.Cycle <= 304 {
 				p.transferAddressY()
 			}
@@ -252,8 +289,8 @@ func (p *PPU) Clock() {
 
 	if p.Scanline == 241 && p.Cycle == 1 {
 		p.Status |= 0x80
-		if (p.Ctrl & 0x80) != 0 {
-			p.NMI = true
+		if p.log.Enabled(context.Background(), logging.LevelTrace) {
+			p.log.Log(context.Background(), logging.LevelTrace, "vblank", "frame", p.FrameCounter, "nmi", p.NMIOutput())
 		}
 	}
 
@@ -327,7 +364,7 @@ func (p *PPU) PPUWrite(addr uint16, data byte) {
 		if addr == 0x001C {
 			addr = 0x000C
 		}
-		p.palette[addr] = data
+		p.palette[addr] = data & 0x3F // Palette RAM is 6 bits wide
 	}
 }
 
@@ -344,10 +381,7 @@ func (p *PPU) CPURead(addr uint16) byte {
 	case 0x0000: // Control
 	case 0x0001: // Mask
 	case 0x0002: // Status
-		data = (p.Status & 0xE0) | (p.ppuData & 0x1F)
-		if p.spriteZeroHit {
-			data |= 0x40
-		}
+		data = p.status()
 		p.Status &= 0x7F // Clear VBlank flag
 		p.addrLatch = 0
 	case 0x0003: // OAM Address
@@ -358,34 +392,48 @@ func (p *PPU) CPURead(addr uint16) byte {
 	case 0x0007: // PPU Data
 		data = p.ppuData // Always return the buffered data
 
-		// Update the buffer with the content at current vramAddr
-		// If vramAddr is in the palette range, fetch from the mirrored nametable for the buffer
-		if p.vramAddr >= 0x3F00 {
-			p.ppuData = p.PPURead(p.vramAddr - 0x1000) // Read from mirrored nametable space for buffer
-			data = p.PPURead(p.vramAddr)               // Return actual palette value immediately
+		// Palette reads are not buffered, but they still refill the buffer, with the
+		// nametable byte that $3F00-$3FFF mirrors over ($2F00-$2FFF)
+		addr := p.vramAddr & 0x3FFF
+		if addr >= 0x3F00 {
+			p.ppuData = p.PPURead(addr - 0x1000)
+			data = p.PPURead(addr)
 		} else {
-			p.ppuData = p.PPURead(p.vramAddr)
+			p.ppuData = p.PPURead(addr)
 		}
+		p.incrementVRAMAddr()
+	}
+	return data
+}
 
-		if (p.Ctrl & 0x04) != 0 {
-			p.vramAddr += 32
-		} else {
-			p.vramAddr++
-		}
+// status returns the value a $2002 read sees, with the stale read buffer in the low bits.
+func (p *PPU) status() byte {
+	data := (p.Status & 0xE0) | (p.ppuData & 0x1F)
+	if p.spriteZeroHit {
+		data |= 0x40
 	}
 	return data
 }
 
+// SetWarmup turns emulation of the power-on warm-up on or off. Homebrew that writes the
+// PPU straight after reset only works with it off, as in many emulators.
+func (p *PPU) SetWarmup(enabled bool) {
+	p.warmup = enabled
+}
+
 // CPUWrite writes to PPU registers.
 func (p *PPU) CPUWrite(addr uint16, data byte) {
+	if p.warmup && p.warmingUp {
+		switch addr {
+		case 0x0000, 0x0001, 0x0005, 0x0006:
+			return
+		}
+	}
 	switch addr {
 	case 0x0000: // Control
-		oldCtrl := p.Ctrl
+		// Enabling NMI during vertical blank raises the NMI line straight away
 		p.Ctrl = data
 		p.vramTmpAddr = (p.vramTmpAddr & 0xF3FF) | ((uint16(data) & 0x03) << 10)
-		if (oldCtrl&0x80) == 0 && (p.Ctrl&0x80) != 0 && (p.Status&0x80) != 0 {
-			p.NMI = true
-		}
 	case 0x0001: // Mask
 		p.Mask = data
 	case 0x0002: // Status
@@ -414,19 +462,30 @@ func (p *PPU) CPUWrite(addr uint16, data byte) {
 		}
 	case 0x0007: // PPU Data
 		p.PPUWrite(p.vramAddr, data)
-		if (p.Ctrl & 0x04) != 0 {
-			p.vramAddr += 32
-		} else {
-			p.vramAddr++
-		}
+		p.incrementVRAMAddr()
 	}
 }
 
-// DoOAMDMA performs OAM DMA transfer.
-func (p *PPU) DoOAMDMA(data [256]byte) {
-	for i := 0; i < 256; i++ {
-		p.oam[byte((uint16(p.oamAddr)+uint16(i))%256)] = data[i]
+// incrementVRAMAddr advances v after a $2007 access. While the PPU is rendering, v
+// is the scroll position, and the access increments coarse X and Y at once instead.
+func (p *PPU) incrementVRAMAddr() {
+	if p.Scanline < 240 && (p.Mask&0x08 != 0 || p.Mask&0x10 != 0) {
+		p.incrementScrollX()
+		p.incrementScrollY()
+		return
 	}
+	if (p.Ctrl & 0x04) != 0 {
+		p.vramAddr += 32
+	} else {
+		p.vramAddr++
+	}
+	p.vramAddr &= 0x7FFF
+}
+
+// NMIOutput reports whether the PPU holds the CPU's NMI line up: in vertical blank with
+// NMI enabled in PPUCTRL. The CPU takes an NMI when the line rises.
+func (p *PPU) NMIOutput() bool {
+	return p.Status&p.Ctrl&0x80 != 0
 }
 
 func (p *PPU) loadBGShifters() {
@@ -498,6 +557,50 @@ func (p *PPU) transferAddressY() {
 	}
 }
 
+// loadSpriteShifters fetches the pattern rows of the sprites in secondary OAM for the
+// next scanline. Like the hardware it fetches all eight slots, using tile $FF for the
+// empty ones, so mappers that watch A12 see the same fetches on every scanline.
+func (p *PPU) loadSpriteShifters() {
+	height := 8
+	if p.Ctrl&0x20 != 0 {
+		height = 16
+	}
+	for i := range p.spriteScanline {
+		s := spriteInfo{y: 0xFF, id: 0xFF, attr: 0xFF, x: 0xFF}
+		if i < p.spriteScanlineLen {
+			s = p.spriteScanline[i]
+		}
+
+		row := uint16(p.Scanline+1-int(s.y)) & 0x0F
+		if s.attr&0x80 != 0 { // vertical flip
+			row = uint16(height-1) - row
+		}
+		var addr uint16
+		if height == 8 {
+			addr = uint16((p.Ctrl>>3)&1)*0x1000 + uint16(s.id)*16 + row&0x07
+		} else {
+			tileID := uint16(s.id) & 0xFE
+			if row > 7 {
+				tileID++
+				row -= 8
+			}
+			addr = (uint16(s.id)&1)*0x1000 + tileID*16 + row
+		}
+		lo, hi := p.PPURead(addr), p.PPURead(addr+8)
+
+		if i >= p.spriteScanlineLen {
+			continue
+		}
+		if s.attr&0x40 != 0 { // horizontal flip
+			lo, hi = bits.Reverse8(lo), bits.Reverse8(hi)
+		}
+		p.spritePatternLo[i], p.spritePatternHi[i] = lo, hi
+		p.spriteAttr[i], p.spriteX[i] = s.attr, s.x
+	}
+	p.spriteLineLen = p.spriteScanlineLen
+	p.spriteZeroLine = p.sprite0InScanline
+}
+
 func (p *PPU) renderPixel() {
 
 	var bgPixel byte
@@ -505,7 +608,9 @@ func (p *PPU) renderPixel() {
 	var mux uint16          // Declared outside the if block
 	var p1, p2, a1, a2 bool // Declared outside the if block
 
-	if (p.Mask & 0x08) != 0 {
+	// PPUMASK bits 1 and 2 clip the background and sprites in the leftmost 8 pixels
+	x := p.Cycle - 1
+	if (p.Mask&0x08) != 0 && (x >= 8 || p.Mask&0x02 != 0) {
 		mux = 0x8000 >> p.fineX
 		p1 = (p.bgPatternShifterLo & uint16(mux)) > 0
 		p2 = (p.bgPatternShifterHi & uint16(mux)) > 0
@@ -521,56 +626,25 @@ func (p *PPU) renderPixel() {
 	var spPriority bool
 	var isSpriteZeroPixel bool
 
-	if (p.Mask & 0x10) != 0 {
-		for i := 0; i < len(p.spriteScanline); i++ {
-			if p.Cycle-1 >= int(p.spriteScanline[i].x) && p.Cycle-1 < int(p.spriteScanline[i].x)+8 {
-				spriteHeight := 8
-				if p.Ctrl&0x20 != 0 {
-					spriteHeight = 16
-				}
-
-				spriteYOffset := uint16(p.Scanline) - uint16(p.spriteScanline[i].y)
-				if p.spriteScanline[i].attr&0x80 != 0 { // vertical flip
-					spriteYOffset = uint16(spriteHeight-1) - spriteYOffset
-				}
-
-				var spritePatternAddrLo uint16
-				if spriteHeight == 8 {
-					spritePatternAddrLo = uint16((p.Ctrl>>3)&1)*0x1000 + uint16(p.spriteScanline[i].id)*16 + spriteYOffset
-				} else { // 8x16 sprites
-					tableOffset := (uint16(p.spriteScanline[i].id) & 1) * 0x1000
-					tileID := uint16(p.spriteScanline[i].id) & 0xFE
-					if spriteYOffset > 7 {
-						tileID++
-						spriteYOffset -= 8
-					}
-					spritePatternAddrLo = tableOffset + tileID*16 + spriteYOffset
-				}
-				spritePatternAddrHi := spritePatternAddrLo + 8
-
-				var spritePatternBitLo byte
-				var spritePatternBitHi byte
-				if p.spriteScanline[i].attr&0x40 != 0 { // horizontal flip
-					shift := byte(p.Cycle - 1 - int(p.spriteScanline[i].x))
-					spritePatternBitLo = (p.PPURead(spritePatternAddrLo) >> shift) & 0x01
-					spritePatternBitHi = (p.PPURead(spritePatternAddrHi) >> shift) & 0x01
-				} else {
-					shift := byte(7 - (p.Cycle - 1 - int(p.spriteScanline[i].x)))
-					spritePatternBitLo = (p.PPURead(spritePatternAddrLo) >> shift) & 0x01
-					spritePatternBitHi = (p.PPURead(spritePatternAddrHi) >> shift) & 0x01
-				}
-
-				spPixel = (spritePatternBitHi << 1) | spritePatternBitLo
-				spPalette = (p.spriteScanline[i].attr & 0x03) + 0x04
-				spPriority = (p.spriteScanline[i].attr & 0x20) == 0
-
-				if spPixel != 0 {
-					if i == 0 && p.sprite0InScanline {
-						isSpriteZeroPixel = true
-					}
-					break
-				}
+	if (p.Mask&0x10) != 0 && (x >= 8 || p.Mask&0x04 != 0) {
+		// The lowest slot with an opaque pixel wins, and only its priority bit decides
+		// whether it is drawn over the background: a behind-background sprite still hides
+		// the sprites in higher slots
+		for i := 0; i < p.spriteLineLen; i++ {
+			offset := x - int(p.spriteX[i])
+			if offset < 0 || offset > 7 {
+				continue
+			}
+			shift := 7 - byte(offset)
+			pixel := (p.spritePatternHi[i]>>shift&0x01)<<1 | (p.spritePatternLo[i] >> shift & 0x01)
+			if pixel == 0 {
+				continue
 			}
+			spPixel = pixel
+			spPalette = (p.spriteAttr[i] & 0x03) + 0x04
+			spPriority = (p.spriteAttr[i] & 0x20) == 0
+			isSpriteZeroPixel = i == 0 && p.spriteZeroLine
+			break
 		}
 	}
 
@@ -585,13 +659,10 @@ func (p *PPU) renderPixel() {
 			finalPixel = bgPixel
 			finalPalette = bgPalette
 		}
-		// Sprite 0 hit detection: occurs when an opaque pixel of sprite 0 overlaps an opaque background pixel
-		if isSpriteZeroPixel && !p.spriteZeroHit && p.Cycle < 256 {
-			// Sprite 0 hit happens on x=0 to 255. Note p.Cycle-1 is x.
-			// It requires both bg and sprite rendering to be enabled.
-			if (p.Mask&0x08) != 0 && (p.Mask&0x10) != 0 {
-				p.spriteZeroHit = true
-			}
+		// Sprite 0 hit: sprite 0's own opaque pixel over an opaque background pixel,
+		// whatever its priority, anywhere but x=255
+		if isSpriteZeroPixel && x != 255 {
+			p.spriteZeroHit = true
 		}
 	} else if bgPixel == 0 && spPixel != 0 {
 		finalPixel = spPixel
@@ -604,13 +675,18 @@ func (p *PPU) renderPixel() {
 		finalPalette = 0
 	}
 
+	// Transparent pixels show the universal background colour at $3F00, except that with
+	// rendering off and v pointing into palette RAM the PPU outputs the entry v selects
+	// (the "background palette hack" some intros fade the screen with)
 	var colorIndex byte
-	if finalPixel == 0 {
+	if p.Mask&0x18 == 0 && p.vramAddr&0x3F00 == 0x3F00 {
+		colorIndex = p.PPURead(p.vramAddr)
+	} else if finalPixel == 0 {
 		colorIndex = p.PPURead(0x3F00)
 	} else {
 		colorIndex = p.PPURead(0x3F00 + uint16(finalPalette)*4 + uint16(finalPixel))
 	}
-	p.frame.Set(p.Cycle-1, p.Scanline, p.SystemPalette[colorIndex])
+	p.frame.SetRGBA(p.Cycle-1, p.Scanline, p.SystemPalette[colorIndex])
 }
 
 func boolToByte(b bool) byte {
@@ -7,7 +7,7 @@ import (
 	"github.com/meadori/vibemulator/cartridge"
 )
 
-// mockBus for CPU to interact with, similar to nestest/main.go
+// mockBus for CPU to interact with
 type mockBus struct {
 	PPU *PPU
 	Ram [65536]byte // 64KB of RAM for CPU address space
@@ -141,15 +141,12 @@ func createTestCartridge() *cartridge.Cartridge {
 // TestPPURenderBackground checks if the PPU correctly renders a solid background tile.
 func TestPPURenderBackground(t *testing.T) {
 	// Step 1: Initialize PPU and Cartridge
-	ppu := New()
+	ppu := New(nil)
 	cart := createTestCartridge()
 	ppu.ConnectCartridge(cart)
 
-	// Assign a dummy LogDebug function to prevent nil pointer dereference during PPU.Clock()
-	LogDebug = func(format string, a ...interface{}) {}
-
 	// Ensure spriteScanline is empty for background-only test
-	ppu.spriteScanline = []spriteInfo{}
+	ppu.spriteScanlineLen = 0
 
 	// Push all sprites off-screen by initializing OAM Y-coordinates to 0xFF
 	for i := 0; i < len(ppu.oam); i++ {
@@ -211,3 +208,178 @@ func TestPPURenderBackground(t *testing.T) {
 		}
 	}
 }
+
+// renderSprites draws frames over the solid background of createTestCartridge with the
+// given PPUMASK and OAM entries, and stops at the end of the second frame's picture.
+func renderSprites(mask byte, sprites ...[4]byte) *PPU {
+	p := New(nil)
+	cart := createTestCartridge()
+	chr := cart.Mapper.(*mockMapper).chrROM
+	for row := 0; row < 8; row++ {
+		chr[0x10+row], chr[0x18+row] = 0xF0, 0xF0 // Tile 1: left half color 3
+		chr[0x20+row], chr[0x28+row] = 0xFF, 0xFF // Tile 2: solid color 3
+	}
+	p.ConnectCartridge(cart)
+
+	for i := range p.oam {
+		p.oam[i] = 0xFF
+	}
+	for i, s := range sprites {
+		copy(p.oam[i*4:], s[:])
+	}
+	p.palette[0x01] = 0x16
+	p.palette[0x13] = 0x01
+	p.palette[0x17] = 0x2A
+	p.palette[0x1B] = 0x28
+	p.Mask = mask
+	for frame := 0; frame < 2; frame++ {
+		for p.Scanline != 240 {
+			p.Clock()
+		}
+		for p.Scanline == 240 && frame == 0 {
+			p.Clock()
+		}
+	}
+	return p
+}
+
+func TestSpritePriority(t *testing.T) {
+	const y, x = 50, 100
+	tests := []struct {
+		name    string
+		mask    byte
+		sprites [][4]byte
+		px      int
+		want    byte // Palette entry expected at (px, y+2)
+	}{
+		// Games that cycle OAM order to flicker show whichever overlapping sprite is first
+		{"first of two", 0x16, [][4]byte{{y, 2, 0, x}, {y, 2, 1, x}}, x, 0x13},
+		{"first after rotation", 0x16, [][4]byte{{y, 2, 1, x}, {y, 2, 0, x}}, x, 0x17},
+		{"later sprite through a transparent pixel", 0x16, [][4]byte{{y, 1, 0, x}, {y, 2, 1, x}}, x + 4, 0x17},
+		{"horizontal flip", 0x16, [][4]byte{{y, 1, 0x40, x}, {y, 2, 1, x}}, x, 0x17},
+		{"behind the background", 0x1E, [][4]byte{{y, 2, 0x20, x}}, x, 0x01},
+		{"behind an empty background", 0x16, [][4]byte{{y, 2, 0x20, x}}, x, 0x13},
+		// The first opaque sprite decides priority, even when it loses to the background
+		{"behind sprite hides later sprites", 0x1E, [][4]byte{{y, 2, 0x20, x}, {y, 2, 2, x}}, x, 0x01},
+		{"front sprite over behind sprite", 0x1E, [][4]byte{{y, 2, 2, x}, {y, 2, 0x20, x}}, x, 0x1B},
+	}
+	for _, tt := range tests {
+		p := renderSprites(tt.mask, tt.sprites...)
+		want := p.SystemPalette[p.palette[tt.want]]
+		if got := p.GetFrame().At(tt.px, y+2).(color.RGBA); got != want {
+			t.Errorf("%s: expected %v at (%d, %d), got %v", tt.name, want, tt.px, y+2, got)
+		}
+	}
+}
+
+// a12Mapper counts the rising edges of PPU A12, which MMC3 clocks its IRQ counter on.
+type a12Mapper struct {
+	mockMapper
+	high  bool
+	rises int
+}
+
+func (m *a12Mapper) PPUMapRead(addr uint16) (byte, bool) {
+	high := addr&0x1000 != 0
+	if high && !m.high {
+		m.rises++
+	}
+	m.high = high
+	return m.mockMapper.PPUMapRead(addr)
+}
+
+func TestSpriteFetchesEveryScanline(t *testing.T) {
+	p := New(nil)
+	cart := createTestCartridge()
+	m := &a12Mapper{mockMapper: *cart.Mapper.(*mockMapper)}
+	cart.Mapper = m
+	p.ConnectCartridge(cart)
+	for i := range p.oam {
+		p.oam[i] = 0xFF // No sprites on screen
+	}
+	p.Ctrl = 0x08 // Sprites at $1000, background at $0000
+	p.Mask = 0x1E
+
+	for p.Scanline != 0 || p.Cycle != 0 {
+		p.Clock()
+	}
+	m.rises = 0
+	for p.Scanline != 240 {
+		p.Clock()
+	}
+	if m.rises != 240 {
+		t.Errorf("Expected A12 to rise once per visible scanline, got %d rises in 240 lines", m.rises)
+	}
+}
+
+func TestSpriteZeroHit(t *testing.T) {
+	const y = 50
+	tests := []struct {
+		name    string
+		mask    byte
+		sprites [][4]byte
+		want    bool
+	}{
+		{"opaque over background", 0x1E, [][4]byte{{y, 2, 0, 100}}, true},
+		{"behind the background", 0x1E, [][4]byte{{y, 2, 0x20, 100}}, true},
+		{"no background", 0x16, [][4]byte{{y, 2, 0, 100}}, false},
+		// Another sprite's pixels must not count for sprite 0
+		{"transparent sprite 0", 0x1E, [][4]byte{{y, 3, 0, 100}, {y, 2, 0, 100}}, false},
+		{"sprite 0 off the line", 0x1E, [][4]byte{{y + 20, 2, 0, 100}, {y, 2, 0, 100}}, true},
+		{"only at x=255", 0x1E, [][4]byte{{y, 2, 0, 255}}, false},
+		{"left edge shown", 0x1E, [][4]byte{{y, 2, 0, 0}}, true},
+		{"left edge, background clipped", 0x1C, [][4]byte{{y, 2, 0, 0}}, false},
+		{"left edge, sprites clipped", 0x1A, [][4]byte{{y, 2, 0, 0}}, false},
+		{"partly clipped", 0x18, [][4]byte{{y, 2, 0, 4}}, true},
+	}
+	for _, tt := range tests {
+		p := renderSprites(tt.mask, tt.sprites...)
+		if hit := p.CPURead(0x0002)&0x40 != 0; hit != tt.want {
+			t.Errorf("%s: expected sprite 0 hit %v, got %v", tt.name, tt.want, hit)
+		}
+	}
+}
+
+func TestLeftClip(t *testing.T) {
+	p := renderSprites(0x18, [4]byte{50, 2, 0, 0})
+	frame := p.GetFrame()
+	backdrop, bg := p.SystemPalette[p.palette[0x00]], p.SystemPalette[p.palette[0x01]]
+	for _, tt := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{0, 10, backdrop}, {7, 10, backdrop}, {8, 10, bg},
+		{0, 52, backdrop}, {8, 52, bg},
+	} {
+		if got := frame.At(tt.x, tt.y).(color.RGBA); got != tt.want {
+			t.Errorf("At (%d, %d): expected %v, got %v", tt.x, tt.y, tt.want, got)
+		}
+	}
+}
+
+func TestBackdropFromPalettePointer(t *testing.T) {
+	ppu := New(nil)
+	ppu.ConnectCartridge(createTestCartridge())
+	ppu.PPUWrite(0x3F00, 0x0F)
+	ppu.PPUWrite(0x3F14, 0xE1) // Mirrors $3F04; only the low 6 bits are stored
+	if v := ppu.PPURead(0x3F04); v != 0x21 {
+		t.Fatalf("Expected $3F14 to write $3F04 as 21, got %02X", v)
+	}
+
+	// Rendering is off, so v pointing into palette RAM picks the colour of every pixel
+	ppu.vramAddr = 0x3F14
+	for ppu.Scanline != 1 {
+		ppu.Clock()
+	}
+	if got, want := ppu.GetFrame().RGBAAt(100, 0), ppu.SystemPalette[0x21]; got != want {
+		t.Errorf("Expected the backdrop from $3F14 %v, got %v", want, got)
+	}
+
+	ppu.vramAddr = 0x2000
+	for ppu.Scanline != 2 {
+		ppu.Clock()
+	}
+	if got, want := ppu.GetFrame().RGBAAt(100, 1), ppu.SystemPalette[0x0F]; got != want {
+		t.Errorf("Expected the universal background %v, got %v", want, got)
+	}
+}
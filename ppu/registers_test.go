@@ -0,0 +1,87 @@
+package ppu
+
+import "testing"
+
+func TestPPUDATAReadBuffer(t *testing.T) {
+	ppu := New(nil)
+	ppu.ConnectCartridge(createTestCartridge()) // Vertical mirroring
+	setAddr := func(addr uint16) {
+		ppu.CPUWrite(0x0006, byte(addr>>8))
+		ppu.CPUWrite(0x0006, byte(addr))
+	}
+
+	ppu.vram[0x105] = 0x11 // $2105
+	ppu.vram[0x705] = 0x55 // $2F05, which $3F05 mirrors over
+	ppu.palette[0x05] = 0x21
+
+	setAddr(0x2105)
+	ppu.CPURead(0x0007) // Primes the buffer
+	if v := ppu.CPURead(0x0007); v != 0x11 {
+		t.Errorf("Expected the second read of $2105 to return 11, got %02X", v)
+	}
+
+	setAddr(0x3F05)
+	if v := ppu.CPURead(0x0007); v != 0x21 {
+		t.Errorf("Expected a palette read to return the palette entry at once, got %02X", v)
+	}
+	setAddr(0x2000)
+	if v := ppu.CPURead(0x0007); v != 0x55 {
+		t.Errorf("Expected the palette read to fill the buffer from $2F05 (55), got %02X", v)
+	}
+}
+
+func TestPPUDATAIncrement(t *testing.T) {
+	tests := []struct {
+		name     string
+		scanline int
+		mask     byte
+		ctrl     byte
+		want     uint16
+	}{
+		{"vblank", 241, 0x18, 0x00, 0x7006},
+		{"vblank, increment 32", 241, 0x18, 0x04, 0x7025},
+		{"rendering off", 100, 0x00, 0x00, 0x7006},
+		// Coarse X and Y both step, and fine Y 7 wraps into coarse Y
+		{"rendering", 100, 0x18, 0x00, 0x0026},
+		{"pre-render line", -1, 0x08, 0x04, 0x0026},
+	}
+	for _, tt := range tests {
+		for _, access := range []string{"read", "write"} {
+			ppu := New(nil)
+			ppu.ConnectCartridge(createTestCartridge())
+			ppu.Scanline, ppu.Mask, ppu.Ctrl = tt.scanline, tt.mask, tt.ctrl
+			ppu.vramAddr = 0x7005 // Fine Y 7, coarse X 5
+			if access == "read" {
+				ppu.CPURead(0x0007)
+			} else {
+				ppu.CPUWrite(0x0007, 0x00)
+			}
+			if ppu.vramAddr != tt.want {
+				t.Errorf("%s, %s: expected v = $%04X, got $%04X", tt.name, access, tt.want, ppu.vramAddr)
+			}
+		}
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	ppu := New(nil)
+	ppu.ConnectCartridge(createTestCartridge())
+	ppu.SetWarmup(true)
+
+	ppu.CPUWrite(0x0000, 0x80)
+	ppu.CPUWrite(0x0003, 0x10)
+	if ppu.Ctrl != 0 {
+		t.Errorf("Expected PPUCTRL writes to be ignored while warming up, got %02X", ppu.Ctrl)
+	}
+	if ppu.oamAddr != 0x10 {
+		t.Errorf("Expected OAMADDR writes to work while warming up, got %02X", ppu.oamAddr)
+	}
+
+	for ppu.Scanline != -1 || ppu.Cycle != 2 {
+		ppu.Clock()
+	}
+	ppu.CPUWrite(0x0000, 0x80)
+	if ppu.Ctrl != 0x80 {
+		t.Errorf("Expected PPUCTRL writes to work after the first vertical blank, got %02X", ppu.Ctrl)
+	}
+}
@@ -1,5 +1,7 @@
 package ppu
 
+import "github.com/meadori/vibemulator/snap"
+
 type State struct {
 	Nt_map                                                                                                                            [4]uint16
 	Vram                                                                                                                              [2048]byte
@@ -8,19 +10,23 @@ type State struct {
 	Scanline, Cycle, FrameCounter, SpriteEvalCycle                                                                                    int
 	Status, Mask, Ctrl, FineX, AddrLatch, PpuData, OamAddr, BgNextTileID, BgNextTileAttrib, BgNextTileLSB, BgNextTileMSB, SpriteCount byte
 	VramAddr, VramTmpAddr, BgPatternShifterLo, BgPatternShifterHi, BgAttribShifterLo, BgAttribShifterHi                               uint16
-	NMI, SpriteZeroHit, SpriteZero, Sprite0InScanline                                                                                 bool
+	SpriteZeroHit, SpriteZero, Sprite0InScanline, WarmingUp                                                                           bool
 	FrameBuffer                                                                                                                       []byte
 }
 
 func (p *PPU) SaveState() State {
 	fb := make([]byte, len(p.frame.Pix))
 	copy(fb, p.frame.Pix)
+	return p.state(fb)
+}
 
+// state returns the PPU state with fb as its frame buffer.
+func (p *PPU) state(fb []byte) State {
 	return State{
 		p.nt_map, p.vram, p.oam, p.palette, p.Scanline, p.Cycle, p.FrameCounter, p.spriteEvalCycle,
 		p.Status, p.Mask, p.Ctrl, p.fineX, p.addrLatch, p.ppuData, p.oamAddr, p.bgNextTileID, p.bgNextTileAttrib, p.bgNextTileLSB, p.bgNextTileMSB, p.spriteCount,
 		p.vramAddr, p.vramTmpAddr, p.bgPatternShifterLo, p.bgPatternShifterHi, p.bgAttribShifterLo, p.bgAttribShifterHi,
-		p.NMI, p.spriteZeroHit, p.spriteZero, p.sprite0InScanline,
+		p.spriteZeroHit, p.spriteZero, p.sprite0InScanline, p.warmingUp,
 		fb,
 	}
 }
@@ -29,9 +35,52 @@ func (p *PPU) LoadState(s State) {
 	p.nt_map, p.vram, p.oam, p.palette, p.Scanline, p.Cycle, p.FrameCounter, p.spriteEvalCycle = s.Nt_map, s.Vram, s.Oam, s.Palette, s.Scanline, s.Cycle, s.FrameCounter, s.SpriteEvalCycle
 	p.Status, p.Mask, p.Ctrl, p.fineX, p.addrLatch, p.ppuData, p.oamAddr, p.bgNextTileID, p.bgNextTileAttrib, p.bgNextTileLSB, p.bgNextTileMSB, p.spriteCount = s.Status, s.Mask, s.Ctrl, s.FineX, s.AddrLatch, s.PpuData, s.OamAddr, s.BgNextTileID, s.BgNextTileAttrib, s.BgNextTileLSB, s.BgNextTileMSB, s.SpriteCount
 	p.vramAddr, p.vramTmpAddr, p.bgPatternShifterLo, p.bgPatternShifterHi, p.bgAttribShifterLo, p.bgAttribShifterHi = s.VramAddr, s.VramTmpAddr, s.BgPatternShifterLo, s.BgPatternShifterHi, s.BgAttribShifterLo, s.BgAttribShifterHi
-	p.NMI, p.spriteZeroHit, p.spriteZero, p.sprite0InScanline = s.NMI, s.SpriteZeroHit, s.SpriteZero, s.Sprite0InScanline
+	p.spriteZeroHit, p.spriteZero, p.sprite0InScanline, p.warmingUp = s.SpriteZeroHit, s.SpriteZero, s.Sprite0InScanline, s.WarmingUp
 
 	if len(s.FrameBuffer) == len(p.frame.Pix) {
 		copy(p.frame.Pix, s.FrameBuffer)
 	}
 }
+
+// Snapshot writes the state saved by SaveState in snap's flat encoding, without copying the frame buffer first.
+func (p *PPU) Snapshot(w *snap.Writer) {
+	s := p.state(p.frame.Pix)
+	for _, v := range s.Nt_map {
+		w.U16(v)
+	}
+	w.Raw(s.Vram[:])
+	w.Raw(s.Oam[:])
+	w.Raw(s.Palette[:])
+	for _, v := range [...]int{s.Scanline, s.Cycle, s.FrameCounter, s.SpriteEvalCycle} {
+		w.Int(v)
+	}
+	for _, v := range [...]byte{s.Status, s.Mask, s.Ctrl, s.FineX, s.AddrLatch, s.PpuData, s.OamAddr, s.BgNextTileID, s.BgNextTileAttrib, s.BgNextTileLSB, s.BgNextTileMSB, s.SpriteCount} {
+		w.U8(v)
+	}
+	for _, v := range [...]uint16{s.VramAddr, s.VramTmpAddr, s.BgPatternShifterLo, s.BgPatternShifterHi, s.BgAttribShifterLo, s.BgAttribShifterHi} {
+		w.U16(v)
+	}
+	for _, v := range [...]bool{s.SpriteZeroHit, s.SpriteZero, s.Sprite0InScanline, s.WarmingUp} {
+		w.Bool(v)
+	}
+	w.Bytes(s.FrameBuffer)
+}
+
+// Restore loads a state written by Snapshot.
+func (p *PPU) Restore(r *snap.Reader) {
+	var s State
+	for i := range s.Nt_map {
+		s.Nt_map[i] = r.U16()
+	}
+	r.Raw(s.Vram[:])
+	r.Raw(s.Oam[:])
+	r.Raw(s.Palette[:])
+	s.Scanline, s.Cycle, s.FrameCounter, s.SpriteEvalCycle = r.Int(), r.Int(), r.Int(), r.Int()
+	s.Status, s.Mask, s.Ctrl, s.FineX, s.AddrLatch, s.PpuData = r.U8(), r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+	s.OamAddr, s.BgNextTileID, s.BgNextTileAttrib, s.BgNextTileLSB, s.BgNextTileMSB, s.SpriteCount = r.U8(), r.U8(), r.U8(), r.U8(), r.U8(), r.U8()
+	s.VramAddr, s.VramTmpAddr = r.U16(), r.U16()
+	s.BgPatternShifterLo, s.BgPatternShifterHi, s.BgAttribShifterLo, s.BgAttribShifterHi = r.U16(), r.U16(), r.U16(), r.U16()
+	s.SpriteZeroHit, s.SpriteZero, s.Sprite0InScanline, s.WarmingUp = r.Bool(), r.Bool(), r.Bool(), r.Bool()
+	s.FrameBuffer = r.Bytes()
+	p.LoadState(s)
+}
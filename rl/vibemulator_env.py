@@ -123,15 +123,12 @@ class VibemulatorEnv(gym.Env):
 
     def _get_obs(self):
         try:
-            response = self.stub.GetFrame(controller_pb2.Empty())
-            # Convert raw RGBA bytes (256x240 * 4) to RGB Numpy array
+            # Ask the server for packed RGB so the alpha channel never crosses the wire
+            response = self.stub.GetFrame(controller_pb2.FrameRequest(
+                encoding=controller_pb2.FRAME_ENCODING_RGB))
             raw_bytes = np.frombuffer(response.pixels, dtype=np.uint8)
-            # Ensure shape is correct (240 height, 256 width, 4 channels)
-            if len(raw_bytes) == 256 * 240 * 4:
-                rgba = raw_bytes.reshape((240, 256, 4))
-                # Drop Alpha channel for standard RGB observation
-                rgb = rgba[:, :, :3]
-                return rgb
+            if len(raw_bytes) == 256 * 240 * 3:
+                return raw_bytes.reshape((240, 256, 3))
             else:
                 return np.zeros((240, 256, 3), dtype=np.uint8)
         except grpc.RpcError as e:
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/cartridge"
+	"github.com/meadori/vibemulator/logging"
+	"github.com/meadori/vibemulator/script"
+)
+
+// rominfoCmd prints the header of each ROM given, with its hashes and any warnings about
+// the header. With -verify it also checks that each ROM boots. It exits with status 1
+// if a ROM can't be read or fails verification.
+func rominfoCmd(args []string) {
+	fs := newFlagSet("rominfo")
+	verify := fs.Bool("verify", false, "run each ROM headlessly and check that it boots")
+	frames := fs.Int("frames", 600, "frames to run for -verify")
+	fs.Parse(args)
+	if fs.NArg() == 0 || *frames <= 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	// Keep the cartridge messages of -verify out of the report
+	logging.SetLevel("all", slog.LevelWarn)
+
+	failed := false
+	for i, path := range fs.Args() {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := printROMInfo(path, *verify, *frames); err != nil {
+			log.Printf("%s: %v", path, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func printROMInfo(path string, verify bool, frames int) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	h, err := cartridge.ParseHeader(data)
+	if err != nil {
+		return err
+	}
+
+	mapper := cartridge.MapperName(h.Mapper)
+	if mapper == "" {
+		mapper = "unsupported"
+	}
+	chr := fmt.Sprintf("%d KB", h.CHRSize/1024)
+	if h.CHRSize == 0 {
+		chr = "8 KB RAM"
+	}
+	mirror := "horizontal"
+	switch h.Mirror {
+	case cartridge.MirrorVertical:
+		mirror = "vertical"
+	case cartridge.MirrorFourScreen:
+		mirror = "four-screen"
+	}
+	format := "iNES"
+	if h.NES2 {
+		format = "NES 2.0"
+	}
+	// ROM databases such as No-Intro hash the data without the header
+	rom := data[16:]
+	if h.Trainer && len(rom) >= 512 {
+		rom = rom[512:]
+	}
+	romSHA1 := sha1.Sum(rom)
+
+	fmt.Printf("file:      %s\n", filepath.Base(path))
+	fmt.Printf("sha1:      %s\n", script.ROMHash(data))
+	fmt.Printf("rom sha1:  %s\n", hex.EncodeToString(romSHA1[:]))
+	fmt.Printf("rom crc32: %08x\n", crc32.ChecksumIEEE(rom))
+	fmt.Printf("format:    %s\n", format)
+	fmt.Printf("mapper:    %d (%s)\n", h.Mapper, mapper)
+	fmt.Printf("prg rom:   %d KB\n", h.PRGSize/1024)
+	fmt.Printf("chr:       %s\n", chr)
+	fmt.Printf("mirroring: %s\n", mirror)
+	fmt.Printf("battery:   %s\n", yesNo(h.Battery))
+	fmt.Printf("trainer:   %s\n", yesNo(h.Trainer))
+	for _, w := range cartridge.CheckHeader(data) {
+		fmt.Printf("warning:   %s\n", w)
+	}
+
+	if !verify {
+		return nil
+	}
+	result, err := verifyROM(data, frames)
+	if err != nil {
+		fmt.Printf("verify:    FAIL, %v\n", err)
+		return fmt.Errorf("failed verification")
+	}
+	fmt.Printf("verify:    ok, %s\n", result)
+	return nil
+}
+
+// verifyROM runs a ROM headlessly for the given number of frames. It boots if it turns
+// rendering on and draws more than one distinct picture without the emulator panicking.
+func verifyROM(data []byte, frames int) (result string, err error) {
+	b := bus.New()
+	if err := b.LoadROM(data); err != nil {
+		return "", err
+	}
+
+	frame, firstRendered := 0, -1
+	pictures := map[uint64]bool{}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("emulator panicked at frame %d: %v", frame, r)
+		}
+	}()
+	for ; frame < frames; frame++ {
+		b.RunFrame()
+		if firstRendered < 0 && b.PPU.Mask&0x18 != 0 {
+			firstRendered = frame
+		}
+		pictures[b.FrameHash()] = true
+	}
+
+	switch {
+	case firstRendered < 0:
+		return "", fmt.Errorf("rendering never turned on in %d frames", frames)
+	case len(pictures) < 2:
+		return "", fmt.Errorf("the screen never changed in %d frames", frames)
+	}
+	return fmt.Sprintf("rendering on at frame %d, %d distinct frames in %d", firstRendered, len(pictures), frames), nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/logging"
+)
+
+// Exit statuses of the test command, besides the value written under -until-write ADDR.
+const (
+	testPassed  = 0
+	testError   = 2   // Bad arguments, or a ROM that can't be run
+	testTimeout = 124 // As timeout(1) exits
+)
+
+// writeCondition stops a test run when the CPU writes value to addr, or anything if
+// anyValue is set.
+type writeCondition struct {
+	addr     uint16
+	value    byte
+	anyValue bool
+}
+
+// writeConditions collects repeated -until-write flags.
+type writeConditions []writeCondition
+
+func (w *writeConditions) String() string {
+	var s []string
+	for _, c := range *w {
+		if c.anyValue {
+			s = append(s, fmt.Sprintf("$%04X", c.addr))
+		} else {
+			s = append(s, fmt.Sprintf("$%04X=$%02X", c.addr, c.value))
+		}
+	}
+	return strings.Join(s, ",")
+}
+
+func (w *writeConditions) Set(s string) error {
+	addr, value, hasValue := strings.Cut(s, "=")
+	a, err := parseNumber(addr, 16)
+	if err != nil {
+		return fmt.Errorf("bad address %q: %v", addr, err)
+	}
+	c := writeCondition{addr: uint16(a), anyValue: !hasValue}
+	if hasValue {
+		v, err := parseNumber(value, 8)
+		if err != nil {
+			return fmt.Errorf("bad value %q: %v", value, err)
+		}
+		c.value = byte(v)
+	}
+	*w = append(*w, c)
+	return nil
+}
+
+// parseNumber parses a number written as $hex, 0xhex or decimal, as assemblers do.
+func parseNumber(s string, bits int) (uint64, error) {
+	if hex, ok := strings.CutPrefix(s, "$"); ok {
+		return strconv.ParseUint(hex, 16, bits)
+	}
+	return strconv.ParseUint(s, 0, bits)
+}
+
+// testCmd runs a ROM headlessly until it writes one of the -until-write values, for
+// homebrew projects that build test ROMs and run them from a Makefile. It exits with
+// status 0 when a condition is met, the value written for a condition without one,
+// 124 on timeout and 2 if the ROM can't be run.
+func testCmd(args []string) {
+	fs := newFlagSet("test")
+	var until writeConditions
+	fs.Var(&until, "until-write", "stop when the CPU writes `addr=value`, e.g. $6000=$00; repeat for several. Given only an address, any write stops the run and the value becomes the exit status")
+	timeout := fs.Duration("timeout", 10*time.Second, "give up after this much emulated time")
+	trace := fs.Int("trace", 0, "on failure, timeout or crash, print the last `n` instructions executed to stderr in nestest's log format")
+	fs.Parse(args)
+	if fs.NArg() != 1 || len(until) == 0 || *timeout <= 0 || *trace < 0 {
+		fs.Usage()
+		os.Exit(testError)
+	}
+	logging.SetLevel("all", slog.LevelWarn)
+
+	path := fs.Arg(0)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "test: %v\n", err)
+		os.Exit(testError)
+	}
+	status, msg, err := runTest(data, until, int(math.Ceil(timeout.Seconds()*nesFPS)), *trace, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "test: %s: %v\n", path, err)
+		os.Exit(testError)
+	}
+	fmt.Printf("%s: %s\n", path, msg)
+	os.Exit(status)
+}
+
+// runTest runs a ROM for up to frames frames, until it writes one of until, and returns
+// the exit status and what happened. Unless it passes, the last trace instructions are
+// written to traceOut.
+func runTest(data []byte, until writeConditions, frames, trace int, traceOut io.Writer) (status int, msg string, err error) {
+	b := bus.New()
+	if err := b.LoadROM(data); err != nil {
+		return 0, "", err
+	}
+	b.EnableTrace(trace)
+	defer func() {
+		if status != testPassed || err != nil {
+			b.WriteTrace(traceOut)
+		}
+	}()
+
+	var hit *writeCondition
+	for _, c := range until {
+		b.OnMemoryWrite(c.addr, c.addr, func(addr uint16, data byte) {
+			if hit == nil && (c.anyValue || data == c.value) {
+				hit = &writeCondition{addr: addr, value: data}
+			}
+		})
+	}
+
+	frame := 0
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("emulator panicked at frame %d: %v", frame, r)
+		}
+	}()
+	for ; frame < frames && hit == nil; frame++ {
+		b.RunFrame()
+	}
+	if hit == nil {
+		return testTimeout, fmt.Sprintf("TIMEOUT after %d frames", frames), nil
+	}
+	status = testPassed
+	for _, c := range until {
+		if c.anyValue && c.addr == hit.addr {
+			status = int(hit.value)
+		}
+	}
+	result := "PASS"
+	if status != testPassed {
+		result = "FAIL"
+	}
+	return status, fmt.Sprintf("%s, wrote $%02X to $%04X in frame %d", result, hit.value, hit.addr, frame), nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/config"
+	"github.com/meadori/vibemulator/display"
+)
+
+// runCmd opens the emulator window, with the ROM given as the only argument inserted.
+func runCmd(args []string) {
+	fs := newFlagSet("run")
+	settings := addSettingsFlags(fs, true)
+	recordFile := fs.String("record", "", "record gameplay to a script file")
+	shmPath := addSharedMemoryFlag(fs)
+	wavOut := addWAVFlags(fs)
+	watch := fs.Bool("watch", false, "reload the ROM whenever the file changes, e.g. when it is reassembled")
+	watchKeepRAM := fs.Bool("watch-keep-ram", false, "keep CPU and PRG RAM when -watch reloads the ROM")
+	watchState := fs.String("watch-state", "", "load this savestate each time -watch reloads the ROM")
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if (*watchKeepRAM || *watchState != "") && !*watch {
+		log.Fatalf("-watch-keep-ram and -watch-state need -watch")
+	}
+	cfg, cfgPath := settings.load()
+
+	romPath := fs.Arg(0)
+	if *watch && romPath == "" {
+		log.Fatalf("-watch needs a ROM")
+	}
+	logDebug("Starting emulator...")
+	b := newBus(romPath)
+	configureBus(b, cfg)
+	if *shmPath != "" {
+		defer publishFrames(b, *shmPath)()
+	}
+	defer wavOut.record(b)()
+
+	// Setup recording file if requested
+	var recFile *os.File
+	if *recordFile != "" {
+		var err error
+		recFile, err = os.Create(*recordFile)
+		if err != nil {
+			log.Fatalf("Failed to create record file: %v", err)
+		}
+		defer recFile.Close()
+		log.Printf("Recording gameplay to %s\n", *recordFile)
+	}
+
+	var reload *hotReloadFlags
+	if *watch {
+		reload = &hotReloadFlags{statePath: *watchState, keepRAM: *watchKeepRAM}
+	}
+	runWindow(b, recFile, romPath, cfg, cfgPath, reload)
+}
+
+// hotReloadFlags are the -watch options of run.
+type hotReloadFlags struct {
+	statePath string
+	keepRAM   bool
+}
+
+// runWindow starts the servers for b and runs the emulator window until it is closed.
+// reload, if not nil, has the window reload the ROM whenever the file changes.
+func runWindow(b *bus.Bus, recFile *os.File, romPath string, cfg config.Config, cfgPath string, reload *hotReloadFlags) {
+	grpcServer := startServers(b, cfg)
+	defer grpcServer.Stop()
+
+	d := display.New(b, grpcServer, recFile, romPath, cfg, cfgPath)
+	logDebug("Display created.")
+	if reload != nil {
+		d.WatchROM(romPath, reload.statePath, reload.keepRAM)
+	}
+	ebiten.SetWindowSize(display.WindowSize(cfg.Video.Scale))
+	ebiten.SetFullscreen(cfg.Video.Fullscreen)
+	ebiten.SetWindowTitle("Vibemulator")
+	ebiten.SetWindowResizable(true)
+
+	// Stop the game loop cleanly on Ctrl-C so the battery save is flushed
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		d.Quit()
+	}()
+
+	logDebug("Starting Ebiten game loop...")
+	err := ebiten.RunGame(d)
+	signal.Stop(sigs)
+	if err := d.WriteAutosave(); err != nil {
+		log.Printf("Failed to write autosave: %v", err)
+	}
+	if err := d.FlushBattery(); err != nil {
+		log.Printf("Failed to write battery save: %v", err)
+	}
+	if err := d.FinishRecording(); err != nil {
+		log.Printf("Failed to finish recording: %v", err)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package script
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Recorder writes a version 2 script as input arrives, so a recording survives a crash up
+// to its last entry.
+type Recorder struct {
+	w         *bufio.Writer
+	last      *Entry
+	end       int
+	rerecords int
+}
+
+// NewRecorder writes the header and returns a recorder for the entries.
+func NewRecorder(w io.Writer, h Header) (*Recorder, error) {
+	r := &Recorder{w: bufio.NewWriter(w), rerecords: h.Rerecords}
+	writeHeader(r.w, h)
+	return r, r.w.Flush()
+}
+
+// Record notes the buttons held at a frame. Only changes are written; going back to an
+// earlier frame counts as a rerecord.
+func (r *Recorder) Record(frame int, p1, p2 [8]bool) error {
+	e := Entry{Frame: frame, P1: p1, P2: p2}
+	switch {
+	case r.last != nil && frame < r.last.Frame:
+		r.rerecords++
+	case r.last != nil && e.P1 == r.last.P1 && e.P2 == r.last.P2:
+		r.end = max(r.end, frame+1)
+		return nil
+	}
+	r.last = &e
+	r.end = frame + 1
+	writeEntry(r.w, e)
+	return r.w.Flush()
+}
+
+// Close writes the rerecord count and the length, which runs to the last recorded frame.
+func (r *Recorder) Close() error {
+	fmt.Fprintf(r.w, "@rerecords %d\n@end %d\n", r.rerecords, r.end)
+	return r.w.Flush()
+}
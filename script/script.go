@@ -0,0 +1,290 @@
+// Package script reads and writes the text input scripts recorded by vibemulator -record
+// and cmd/client, and replayed by cmd/client.
+//
+// Version 1 scripts are bare lines of "<frames> P1:<buttons> P2:<buttons>", each holding
+// the buttons for that many frames. Version 2 scripts begin with a header of "@" lines
+// naming the ROM, the emulator core and how playback starts, followed by frame-indexed
+// entries, and end with the rerecord count and the length:
+//
+//	@version 2
+//	@emulator vibemulator/1
+//	@rom 9f2dc4a1...        (SHA-1 of the iNES image)
+//	@start reset            (or "@start state <base64 savestate>")
+//	0 P1:NONE P2:NONE
+//	120 P1:START P2:NONE
+//	@rerecords 3
+//	@end 600
+//
+// An entry holds its buttons from its frame until the next entry. Frames count from the
+// frame in progress at the reset or savestate, which is frame 0. An entry at or before an earlier
+// entry's frame replaces it and everything after it, so recorders can rewind by appending.
+// Buttons are "NONE" or names joined with "+": A B SELECT START UP DOWN LEFT RIGHT.
+package script
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Version is the newest script format version.
+const Version = 2
+
+// Start says how playback of a script begins.
+type Start int
+
+const (
+	// StartFromReset powers the console on with the ROM freshly loaded
+	StartFromReset Start = iota
+	// StartFromState loads the savestate embedded in the header
+	StartFromState
+)
+
+// Header describes where a script was recorded. Version 1 scripts have an empty header.
+type Header struct {
+	Emulator  string // Emulator core that recorded the script, e.g. "vibemulator/1"
+	ROMHash   string // Hex SHA-1 of the iNES image
+	Start     Start
+	State     []byte // Savestate for StartFromState
+	Rerecords int    // Times the recording was rewound and recorded over
+}
+
+// Entry sets both controllers from Frame until the next entry.
+type Entry struct {
+	Frame  int
+	P1, P2 [8]bool
+}
+
+// Script is a parsed script.
+type Script struct {
+	Version int
+	Header
+	Entries []Entry
+
+	// End is the number of frames the script lasts; the last entry holds until then
+	End int
+}
+
+// ROMHash returns the hex SHA-1 used in headers for an iNES image.
+func ROMHash(rom []byte) string {
+	sum := sha1.Sum(rom)
+	return hex.EncodeToString(sum[:])
+}
+
+// Parse reads a version 1 or 2 script. Blank lines and lines starting with # are ignored.
+func Parse(r io.Reader) (*Script, error) {
+	s := &Script{Version: 1}
+	scanner := bufio.NewScanner(r)
+	// Embedded savestates make header lines long
+	scanner.Buffer(nil, 16<<20)
+	ended := false
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if ended {
+			return nil, fmt.Errorf("line %d: entry after @end", n)
+		}
+
+		var err error
+		if strings.HasPrefix(line, "@") {
+			ended, err = s.parseDirective(line)
+		} else {
+			err = s.parseEntry(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if s.Version >= 2 && !ended && len(s.Entries) > 0 {
+		// A recording cut short still replays up to its last entry
+		s.End = s.Entries[len(s.Entries)-1].Frame + 1
+	}
+	return s, nil
+}
+
+// parseDirective handles an @ line and reports whether it was @end
+func (s *Script) parseDirective(line string) (bool, error) {
+	key, value, _ := strings.Cut(line[1:], " ")
+	value = strings.TrimSpace(value)
+	if key != "version" && s.Version < 2 {
+		return false, fmt.Errorf("@%s before @version", key)
+	}
+
+	switch key {
+	case "version":
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 2 || v > Version {
+			return false, fmt.Errorf("unsupported script version %q", value)
+		}
+		if len(s.Entries) > 0 {
+			return false, fmt.Errorf("@version after entries")
+		}
+		s.Version = v
+	case "emulator":
+		s.Emulator = value
+	case "rom":
+		s.ROMHash = strings.ToLower(value)
+	case "start":
+		mode, blob, _ := strings.Cut(value, " ")
+		switch mode {
+		case "reset":
+			s.Start = StartFromReset
+		case "state":
+			state, err := base64.StdEncoding.DecodeString(strings.TrimSpace(blob))
+			if err != nil || len(state) == 0 {
+				return false, fmt.Errorf("invalid savestate in @start")
+			}
+			s.Start, s.State = StartFromState, state
+		default:
+			return false, fmt.Errorf("unknown start %q", mode)
+		}
+	case "rerecords":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return false, fmt.Errorf("invalid rerecord count %q", value)
+		}
+		s.Rerecords = n
+	case "end":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return false, fmt.Errorf("invalid end frame %q", value)
+		}
+		if len(s.Entries) > 0 && n <= s.Entries[len(s.Entries)-1].Frame {
+			return false, fmt.Errorf("@end %d is not after the last entry", n)
+		}
+		s.End = n
+		return true, nil
+	default:
+		// Unknown directives are skipped so newer minor additions stay readable
+	}
+	return false, nil
+}
+
+// parseEntry handles "<n> P1:<buttons> [P2:<buttons>]", where n is a frame count in
+// version 1 and a frame index in version 2
+func (s *Script) parseEntry(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return fmt.Errorf("invalid entry %q", line)
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid frame %q", fields[0])
+	}
+
+	e := Entry{Frame: n}
+	for _, f := range fields[1:] {
+		player, buttons, ok := strings.Cut(f, ":")
+		if !ok {
+			return fmt.Errorf("invalid input %q", f)
+		}
+		state, err := ParseButtons(buttons)
+		if err != nil {
+			return err
+		}
+		switch player {
+		case "P1":
+			e.P1 = state
+		case "P2":
+			e.P2 = state
+		default:
+			return fmt.Errorf("invalid player %q", player)
+		}
+	}
+
+	if s.Version < 2 {
+		// Version 1 lines hold their buttons for n frames
+		e.Frame = s.End
+		s.End += n
+		s.Entries = append(s.Entries, e)
+		return nil
+	}
+	s.add(e)
+	return nil
+}
+
+// add appends an entry, first dropping the entries it rewinds over
+func (s *Script) add(e Entry) {
+	i := len(s.Entries)
+	for i > 0 && s.Entries[i-1].Frame >= e.Frame {
+		i--
+	}
+	s.Entries = append(s.Entries[:i], e)
+}
+
+// Write writes the script in the version 2 format.
+func (s *Script) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	writeHeader(bw, s.Header)
+	for _, e := range s.Entries {
+		writeEntry(bw, e)
+	}
+	fmt.Fprintf(bw, "@rerecords %d\n@end %d\n", s.Rerecords, s.End)
+	return bw.Flush()
+}
+
+func writeHeader(w io.Writer, h Header) {
+	fmt.Fprintf(w, "@version %d\n", Version)
+	if h.Emulator != "" {
+		fmt.Fprintf(w, "@emulator %s\n", h.Emulator)
+	}
+	if h.ROMHash != "" {
+		fmt.Fprintf(w, "@rom %s\n", h.ROMHash)
+	}
+	if h.Start == StartFromState {
+		fmt.Fprintf(w, "@start state %s\n", base64.StdEncoding.EncodeToString(h.State))
+	} else {
+		fmt.Fprintln(w, "@start reset")
+	}
+}
+
+func writeEntry(w io.Writer, e Entry) {
+	fmt.Fprintf(w, "%d P1:%s P2:%s\n", e.Frame, FormatButtons(e.P1), FormatButtons(e.P2))
+}
+
+// buttonNames are in controller bit order
+var buttonNames = [8]string{"A", "B", "SELECT", "START", "UP", "DOWN", "LEFT", "RIGHT"}
+
+// ParseButtons parses "NONE" or button names joined with "+", e.g. "A+RIGHT".
+func ParseButtons(s string) ([8]bool, error) {
+	var state [8]bool
+	if s == "NONE" {
+		return state, nil
+	}
+	for _, name := range strings.Split(s, "+") {
+		found := false
+		for i, b := range buttonNames {
+			if strings.EqualFold(name, b) {
+				state[i], found = true, true
+			}
+		}
+		if !found {
+			return state, fmt.Errorf("unknown button %q", name)
+		}
+	}
+	return state, nil
+}
+
+// FormatButtons is the inverse of ParseButtons.
+func FormatButtons(state [8]bool) string {
+	var names []string
+	for i, pressed := range state {
+		if pressed {
+			names = append(names, buttonNames[i])
+		}
+	}
+	if len(names) == 0 {
+		return "NONE"
+	}
+	return strings.Join(names, "+")
+}
@@ -0,0 +1,99 @@
+package script
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	none  [8]bool
+	start = [8]bool{3: true}
+	jump  = [8]bool{0: true, 7: true}
+)
+
+func TestParseVersion1(t *testing.T) {
+	s, err := Parse(strings.NewReader("# old script\n120 P1:NONE P2:NONE\n30 P1:START P2:NONE\n\n10 P1:A+RIGHT P2:NONE\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{0, none, none}, {120, start, none}, {150, jump, none}}
+	if s.Version != 1 || s.End != 160 || !reflect.DeepEqual(s.Entries, want) {
+		t.Errorf("Expected version 1 entries %v ending at 160, got version %d %v ending at %d", want, s.Version, s.Entries, s.End)
+	}
+}
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	s := &Script{
+		Version: Version,
+		Header: Header{
+			Emulator:  "vibemulator/1",
+			ROMHash:   ROMHash([]byte("NES\x1a")),
+			Start:     StartFromState,
+			State:     []byte{1, 2, 3},
+			Rerecords: 4,
+		},
+		Entries: []Entry{{0, none, none}, {120, start, jump}},
+		End:     600,
+	}
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("Expected %+v after a round trip, got %+v", s, got)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := NewRecorder(&buf, Header{Emulator: "vibemulator/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for frame := 0; frame < 100; frame++ {
+		p1 := none
+		if frame >= 50 {
+			p1 = start
+		}
+		r.Record(frame, p1, none)
+	}
+	// Rewind to frame 40 and play on with different input
+	for frame := 40; frame < 60; frame++ {
+		r.Record(frame, jump, none)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{0, none, none}, {40, jump, none}}
+	if !reflect.DeepEqual(s.Entries, want) || s.End != 60 || s.Rerecords != 1 {
+		t.Errorf("Expected entries %v ending at 60 with 1 rerecord, got %v ending at %d with %d\n%s", want, s.Entries, s.End, s.Rerecords, buf.String())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		"10 P1:JUMP P2:NONE",
+		"x P1:NONE",
+		"10 P3:NONE",
+		"@rom abc",
+		"@version 9",
+		"@version 2\n@start state !!",
+		"@version 2\n10 P1:NONE P2:NONE\n@end 5",
+		"@version 2\n@end 5\n6 P1:NONE P2:NONE",
+	} {
+		if _, err := Parse(strings.NewReader(src)); err == nil {
+			t.Errorf("Expected an error parsing %q", src)
+		}
+	}
+}
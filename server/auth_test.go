@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCheckToken(t *testing.T) {
+	md, err := tokenCredentials("secret").GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	if err := checkToken(ctx, "secret"); err != nil {
+		t.Errorf("Expected the client token to be accepted, got %v", err)
+	}
+	if err := checkToken(ctx, "other"); err == nil {
+		t.Error("Expected a mismatched token to be rejected")
+	}
+	if err := checkToken(context.Background(), "secret"); err == nil {
+		t.Error("Expected a missing token to be rejected")
+	}
+}
+
+func TestServerTLSRequiresKeyPair(t *testing.T) {
+	if _, err := (TLSConfig{CertFile: "server.pem"}).serverCredentials(); err == nil {
+		t.Error("Expected an error for a certificate without a key")
+	}
+}
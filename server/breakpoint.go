@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// AddBreakpoint installs a breakpoint, parsing its condition in the emulator
+func (s *GRPCServer) AddBreakpoint(ctx context.Context, in *apiv1.Breakpoint) (*apiv1.Breakpoint, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Frame != 0 {
+		bp, err := bus.AddPausepoint(int(in.Frame))
+		if err != nil {
+			return nil, err
+		}
+		return breakpointToProto(bp), nil
+	}
+
+	if in.Address > 0xFFFF {
+		return nil, fmt.Errorf("invalid address $%X", in.Address)
+	}
+	bp, err := bus.AddBreakpoint(uint16(in.Address), in.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return breakpointToProto(bp), nil
+}
+
+// RemoveBreakpoint deletes a breakpoint by ID
+func (s *GRPCServer) RemoveBreakpoint(ctx context.Context, in *apiv1.Breakpoint) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bus.RemoveBreakpoint(hookID(in.Id)) {
+		return nil, fmt.Errorf("no breakpoint %d", in.Id)
+	}
+	return &apiv1.Empty{}, nil
+}
+
+// ListBreakpoints returns the installed breakpoints
+func (s *GRPCServer) ListBreakpoints(ctx context.Context, in *apiv1.Empty) (*apiv1.BreakpointList, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &apiv1.BreakpointList{}
+	for _, bp := range bus.Breakpoints() {
+		list.Breakpoints = append(list.Breakpoints, breakpointToProto(bp))
+	}
+	return list, nil
+}
+
+// GetBreakpointHit returns and clears the last breakpoint hit
+func (s *GRPCServer) GetBreakpointHit(ctx context.Context, in *apiv1.Empty) (*apiv1.BreakpointHit, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hit, ok := bus.TakeBreakHit()
+	if !ok {
+		return &apiv1.BreakpointHit{}, nil
+	}
+	return &apiv1.BreakpointHit{
+		Hit:        true,
+		Breakpoint: breakpointToProto(hit.Breakpoint),
+		Frame:      uint64(hit.Frame),
+	}, nil
+}
+
+// Evaluate evaluates expressions, reporting parse errors per expression
+func (s *GRPCServer) Evaluate(ctx context.Context, in *apiv1.EvaluateRequest) (*apiv1.EvaluateResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apiv1.EvaluateResponse{}
+	for _, src := range in.Expressions {
+		v, err := bus.Evaluate(src)
+		result := &apiv1.EvaluateResult{Value: v}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+func breakpointToProto(bp bus.Breakpoint) *apiv1.Breakpoint {
+	return &apiv1.Breakpoint{
+		Id:        uint32(bp.ID),
+		Address:   uint32(bp.Addr),
+		Condition: bp.Condition,
+		Frame:     uint64(bp.Frame),
+	}
+}
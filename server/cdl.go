@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// StartCDL starts a code/data log, continuing from the log in the request if there is one
+func (s *GRPCServer) StartCDL(ctx context.Context, in *apiv1.CDLRequest) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prev := in.GetData()
+	if len(prev) == 0 {
+		prev = nil
+	}
+	if err := bus.StartCDL(prev); err != nil {
+		return nil, err
+	}
+	return &apiv1.Empty{}, nil
+}
+
+// StopCDL stops logging and returns the log
+func (s *GRPCServer) StopCDL(ctx context.Context, in *apiv1.Empty) (*apiv1.CDLReport, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := bus.StopCDL()
+	if !ok {
+		return nil, fmt.Errorf("no code/data log has been started")
+	}
+	return cdlToProto(l), nil
+}
+
+// GetCDL returns the running or most recent code/data log
+func (s *GRPCServer) GetCDL(ctx context.Context, in *apiv1.Empty) (*apiv1.CDLReport, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := bus.CDL()
+	if !ok {
+		return nil, fmt.Errorf("no code/data log has been started")
+	}
+	return cdlToProto(l), nil
+}
+
+func cdlToProto(l bus.CodeDataLog) *apiv1.CDLReport {
+	code, data := l.Coverage()
+	return &apiv1.CDLReport{
+		Running:   l.Running,
+		Data:      l.Bytes(),
+		PrgSize:   uint32(len(l.PRG)),
+		CodeBytes: uint32(code),
+		DataBytes: uint32(data),
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// AddCheat decodes and enables a cheat code
+func (s *GRPCServer) AddCheat(ctx context.Context, in *apiv1.Cheat) (*apiv1.Cheat, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := bus.AddCheat(in.Code)
+	if err != nil {
+		return nil, err
+	}
+	return cheatToProto(c), nil
+}
+
+// ListCheats returns the installed cheats
+func (s *GRPCServer) ListCheats(ctx context.Context, in *apiv1.Empty) (*apiv1.CheatList, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &apiv1.CheatList{}
+	for _, c := range bus.Cheats() {
+		list.Cheats = append(list.Cheats, cheatToProto(c))
+	}
+	return list, nil
+}
+
+// SetCheatEnabled turns a cheat on or off
+func (s *GRPCServer) SetCheatEnabled(ctx context.Context, in *apiv1.Cheat) (*apiv1.Cheat, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := bus.SetCheatEnabled(int(in.Id), in.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	return cheatToProto(c), nil
+}
+
+func cheatToProto(c bus.Cheat) *apiv1.Cheat {
+	return &apiv1.Cheat{
+		Id:         uint32(c.ID),
+		Code:       c.Code,
+		Address:    uint32(c.Decoded.Addr),
+		Value:      uint32(c.Decoded.Value),
+		HasCompare: c.Decoded.HasCompare,
+		Compare:    uint32(c.Decoded.Compare),
+		Enabled:    c.Enabled,
+	}
+}
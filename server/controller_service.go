@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/meadori/vibemulator/api"
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc"
+)
+
+// v1Server is what the server implements: every vibemulator.v1 service
+type v1Server interface {
+	apiv1.InputServiceServer
+	apiv1.VideoServiceServer
+	apiv1.StateServiceServer
+	apiv1.DebugServiceServer
+}
+
+// controllerServiceDesc serves the unversioned api.ControllerService with the v1
+// services' handlers. Its messages have the same fields as their v1 copies, so a request
+// decodes the same into either, and the server only implements the v1 services.
+var controllerServiceDesc = aliasService(&api.ControllerService_ServiceDesc,
+	&apiv1.InputService_ServiceDesc,
+	&apiv1.VideoService_ServiceDesc,
+	&apiv1.StateService_ServiceDesc,
+	&apiv1.DebugService_ServiceDesc,
+)
+
+// aliasService describes the service desc names whose methods are handled by the method
+// of the same name in one of impls. Interceptors see desc's method names.
+func aliasService(desc *grpc.ServiceDesc, impls ...*grpc.ServiceDesc) *grpc.ServiceDesc {
+	unary := make(map[string]grpc.MethodDesc)
+	streams := make(map[string]grpc.StreamDesc)
+	for _, impl := range impls {
+		for _, m := range impl.Methods {
+			unary[m.MethodName] = m
+		}
+		for _, st := range impl.Streams {
+			streams[st.StreamName] = st
+		}
+	}
+
+	alias := &grpc.ServiceDesc{
+		ServiceName: desc.ServiceName,
+		HandlerType: (*v1Server)(nil),
+		Metadata:    desc.Metadata,
+	}
+	for _, m := range desc.Methods {
+		impl, ok := unary[m.MethodName]
+		if !ok {
+			panic(fmt.Sprintf("no v1 method for %s/%s", desc.ServiceName, m.MethodName))
+		}
+		alias.Methods = append(alias.Methods, grpc.MethodDesc{
+			MethodName: m.MethodName,
+			Handler:    renameMethod(impl.Handler, "/"+desc.ServiceName+"/"+m.MethodName),
+		})
+	}
+	for _, st := range desc.Streams {
+		impl, ok := streams[st.StreamName]
+		if !ok || impl.ServerStreams != st.ServerStreams || impl.ClientStreams != st.ClientStreams {
+			panic(fmt.Sprintf("no v1 stream for %s/%s", desc.ServiceName, st.StreamName))
+		}
+		st.Handler = impl.Handler
+		alias.Streams = append(alias.Streams, st)
+	}
+	return alias
+}
+
+// renameMethod wraps a unary handler so interceptors see fullMethod rather than the
+// name the handler was generated for. gRPC names streams from the desc itself.
+func renameMethod(h grpc.MethodHandler, fullMethod string) grpc.MethodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		if interceptor == nil {
+			return h(srv, ctx, dec, nil)
+		}
+		return h(srv, ctx, dec, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: info.Server, FullMethod: fullMethod}, handler)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/meadori/vibemulator/api"
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestControllerServiceUsesV1Handlers(t *testing.T) {
+	s := NewGRPCServer()
+	fake := &fakeBus{}
+	s.SetBus(fake)
+
+	var handler grpc.MethodHandler
+	for _, m := range controllerServiceDesc.Methods {
+		if m.MethodName == "StepFrame" {
+			handler = m.Handler
+		}
+	}
+	if handler == nil {
+		t.Fatal("Expected ControllerService to serve StepFrame")
+	}
+
+	// A request encoded as the legacy message decodes into the v1 one the handler takes
+	raw, err := proto.Marshal(&api.StepRequest{P1: &api.InputState{A: true}, Frames: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := func(m interface{}) error { return proto.Unmarshal(raw, m.(proto.Message)) }
+	var method string
+	intercept := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method = info.FullMethod
+		return handler(ctx, req)
+	}
+
+	resp, err := handler(s, context.Background(), dec, intercept)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obs := resp.(*apiv1.Observation); obs.Frame != 3 || !fake.p1[0] {
+		t.Errorf("Expected 3 frames with A held, got frame %d and %v", obs.Frame, fake.p1)
+	}
+	if method != "/api.ControllerService/StepFrame" {
+		t.Errorf("Expected interceptors to see the ControllerService method, got %q", method)
+	}
+}
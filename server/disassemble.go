@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/cpu"
+)
+
+// defaultDisassembleCount is used when a request leaves count unset
+const defaultDisassembleCount = 10
+
+// maxDisassembleCount bounds a single Disassemble request
+const maxDisassembleCount = 1024
+
+// Disassemble decodes instructions around an address using the CPU's opcode table
+func (s *GRPCServer) Disassemble(ctx context.Context, in *apiv1.DisassembleRequest) (*apiv1.DisassembleResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count := in.GetCount()
+	if count == 0 {
+		count = defaultDisassembleCount
+	}
+	if count > maxDisassembleCount || in.GetBefore() > maxDisassembleCount {
+		return nil, fmt.Errorf("at most %d instructions can be disassembled at once", maxDisassembleCount)
+	}
+
+	_, _, _, _, _, pc, _ := bus.GetCPUState()
+	addr := pc
+	if in.Address != nil {
+		if in.GetAddress() > 0xFFFF {
+			return nil, fmt.Errorf("invalid address $%X", in.GetAddress())
+		}
+		addr = uint16(in.GetAddress())
+	}
+
+	resp := &apiv1.DisassembleResponse{Pc: uint32(pc)}
+	for a := resync(bus, addr, int(in.GetBefore())); a != addr; {
+		instr := decode(bus, a)
+		resp.Instructions = append(resp.Instructions, instr)
+		a += uint16(len(instr.Bytes))
+	}
+	for i := uint32(0); i < count; i++ {
+		instr := decode(bus, addr)
+		resp.Instructions = append(resp.Instructions, instr)
+		addr += uint16(len(instr.Bytes))
+	}
+	return resp, nil
+}
+
+// decode reads one instruction without side effects
+func decode(bus EmuInterface, addr uint16) *apiv1.Instruction {
+	d := cpu.Decode(peek(bus), addr)
+	return &apiv1.Instruction{
+		Address:  uint32(addr),
+		Bytes:    d.Bytes(),
+		Mnemonic: d.Mnemonic,
+		Mode:     d.Mode,
+	}
+}
+
+// peek reads CPU memory without side effects, for decoding
+func peek(bus EmuInterface) func(uint16) byte {
+	return func(addr uint16) byte {
+		return bus.GetMemoryBlock(addr, 1)[0]
+	}
+}
+
+// resync finds a start address at most before instructions ahead of addr whose instruction
+// stream lands exactly on addr. 6502 code can't be decoded backwards reliably, so this
+// keeps the candidate reaching addr with the most instructions, and falls back to addr
+// when nothing lines up.
+func resync(bus EmuInterface, addr uint16, before int) uint16 {
+	best, bestCount := addr, 0
+	for back := 1; back <= 3*before && back <= int(addr) && bestCount < before; back++ {
+		a, n := int(addr)-back, 0
+		for a < int(addr) {
+			a += cpu.Decode(peek(bus), uint16(a)).Size()
+			n++
+		}
+		if a == int(addr) && n <= before && n > bestCount {
+			best, bestCount = addr-uint16(back), n
+		}
+	}
+	return best
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+)
+
+// programBus serves a program from $8000
+type programBus struct {
+	fakeBus
+	mem [0x10000]byte
+	pc  uint16
+}
+
+func newProgramBus(pc uint16, prg ...byte) *programBus {
+	b := &programBus{pc: pc}
+	copy(b.mem[0x8000:], prg)
+	return b
+}
+
+func (b *programBus) GetMemoryBlock(addr uint16, size int) []byte {
+	block := make([]byte, size)
+	for i := range block {
+		block[i] = b.mem[addr+uint16(i)]
+	}
+	return block
+}
+
+func (b *programBus) GetCPUState() (a, x, y, sp, p byte, pc uint16, cycles int) {
+	return 0, 0, 0, 0xFD, 0x24, b.pc, 0
+}
+
+func TestDisassemble(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(newProgramBus(0x8005,
+		0xA9, 0x1E, // $8000 LDA #$1E
+		0x8D, 0x01, 0x20, // $8002 STA $2001
+		0xE6, 0x00, // $8005 INC $00
+		0x4C, 0x05, 0x80, // $8007 JMP $8005
+	))
+
+	resp, err := s.Disassemble(context.Background(), &apiv1.DisassembleRequest{Count: 2, Before: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pc != 0x8005 {
+		t.Errorf("Expected PC $8005, got $%04X", resp.Pc)
+	}
+
+	want := []struct {
+		addr     uint32
+		mnemonic string
+		mode     string
+		size     int
+	}{
+		{0x8000, "LDA", "imm", 2},
+		{0x8002, "STA", "abs", 3},
+		{0x8005, "INC", "zp0", 2},
+		{0x8007, "JMP", "abs", 3},
+	}
+	if len(resp.Instructions) != len(want) {
+		t.Fatalf("Expected %d instructions, got %d", len(want), len(resp.Instructions))
+	}
+	for i, w := range want {
+		got := resp.Instructions[i]
+		if got.Address != w.addr || got.Mnemonic != w.mnemonic || got.Mode != w.mode || len(got.Bytes) != w.size {
+			t.Errorf("Instruction %d: expected %04X %s %s (%d bytes), got %04X %s %s (%d bytes)",
+				i, w.addr, w.mnemonic, w.mode, w.size, got.Address, got.Mnemonic, got.Mode, len(got.Bytes))
+		}
+	}
+}
+
+func TestDisassembleAtAddress(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(newProgramBus(0x8000, 0xA9, 0x1E, 0x8D, 0x01, 0x20))
+
+	addr := uint32(0x8002)
+	resp, err := s.Disassemble(context.Background(), &apiv1.DisassembleRequest{Address: &addr, Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Instructions) != 1 || resp.Instructions[0].Mnemonic != "STA" {
+		t.Errorf("Expected a single STA at $8002, got %v", resp.Instructions)
+	}
+
+	addr = 0x10000
+	if _, err := s.Disassemble(context.Background(), &apiv1.DisassembleRequest{Address: &addr}); err == nil {
+		t.Error("Expected an error for an out of range address")
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// frameEncodeQueue bounds how many frames may wait for an encoder across all streams
+const frameEncodeQueue = 16
+
+// encodePool runs PNG/JPEG encoding for every frame stream on a fixed set of workers
+type encodePool struct {
+	jobs chan func()
+}
+
+// frameEncoders is shared by all streams. It leaves a core for the emulation loop.
+var frameEncoders = sync.OnceValue(func() *encodePool {
+	return newEncodePool(max(1, runtime.GOMAXPROCS(0)-1), frameEncodeQueue)
+})
+
+func newEncodePool(workers, queue int) *encodePool {
+	p := &encodePool{jobs: make(chan func(), queue)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range p.jobs {
+				job()
+			}
+		}()
+	}
+	return p
+}
+
+// trySubmit queues job unless the queue is full
+func (p *encodePool) trySubmit(job func()) bool {
+	select {
+	case p.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// frameEncoder encodes one stream's frames on a pool. The stream has at most one frame
+// being encoded and only its newest encoded frame waits to be sent, so a slow client
+// gets a lower frame rate instead of holding up the goroutine capturing frames.
+type frameEncoder[T any] struct {
+	pool    *encodePool
+	busy    atomic.Bool
+	out     chan T
+	dropped atomic.Uint64
+}
+
+func newFrameEncoder[T any](pool *encodePool) *frameEncoder[T] {
+	return &frameEncoder[T]{pool: pool, out: make(chan T, 1)}
+}
+
+// submit hands encode to the pool and never blocks. The frame is dropped if the previous
+// one is still being encoded or the pool is saturated.
+func (e *frameEncoder[T]) submit(encode func() T) bool {
+	if !e.busy.CompareAndSwap(false, true) {
+		e.dropped.Add(1)
+		return false
+	}
+	ok := e.pool.trySubmit(func() {
+		v := encode()
+		// Replace a frame the sender hasn't picked up. Only one job runs per stream,
+		// so out is empty once drained and the send below can't block.
+		select {
+		case <-e.out:
+			e.dropped.Add(1)
+		default:
+		}
+		e.out <- v
+		e.busy.Store(false)
+	})
+	if !ok {
+		e.busy.Store(false)
+		e.dropped.Add(1)
+	}
+	return ok
+}
+
+// frames delivers encoded frames in the order they finish
+func (e *frameEncoder[T]) frames() <-chan T {
+	return e.out
+}
+
+// pollFrames calls fn with each new frame number, checking every interval until ctx is done
+func pollFrames(ctx context.Context, bus EmuInterface, interval time.Duration, fn func(frame int)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastFrame := -1
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// Skip frames the client has already seen, e.g. while paused
+		if frame := bus.GetFrameNumber(); frame != lastFrame {
+			lastFrame = frame
+			fn(frame)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+// waitIdle waits for the encoder to finish its frame in flight
+func waitIdle[T any](t *testing.T, e *frameEncoder[T]) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for e.busy.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("Encoder never finished its frame")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFrameEncoderDropsWhileBusy(t *testing.T) {
+	e := newFrameEncoder[int](newEncodePool(1, 1))
+	release := make(chan struct{})
+	if !e.submit(func() int { <-release; return 1 }) {
+		t.Fatal("Expected the first frame to be accepted")
+	}
+	if e.submit(func() int { return 2 }) {
+		t.Error("Expected a frame to be dropped while the previous one is encoding")
+	}
+	close(release)
+
+	if v := <-e.frames(); v != 1 {
+		t.Errorf("Expected frame 1, got %d", v)
+	}
+	if n := e.dropped.Load(); n != 1 {
+		t.Errorf("Expected 1 dropped frame, got %d", n)
+	}
+}
+
+func TestFrameEncoderKeepsNewest(t *testing.T) {
+	e := newFrameEncoder[int](newEncodePool(1, 1))
+	// Nobody receives, as with a client stuck on a slow send
+	for i := 1; i <= 3; i++ {
+		if !e.submit(func() int { return i }) {
+			t.Fatalf("Expected frame %d to be accepted", i)
+		}
+		waitIdle(t, e)
+	}
+
+	if v := <-e.frames(); v != 3 {
+		t.Errorf("Expected only the newest frame 3 to be waiting, got %d", v)
+	}
+	if n := e.dropped.Load(); n != 2 {
+		t.Errorf("Expected 2 dropped frames, got %d", n)
+	}
+}
+
+func TestFrameEncoderPoolFull(t *testing.T) {
+	// No workers, so the single queue slot fills up
+	pool := newEncodePool(0, 1)
+	a, b := newFrameEncoder[int](pool), newFrameEncoder[int](pool)
+	if !a.submit(func() int { return 1 }) {
+		t.Fatal("Expected the first frame to be queued")
+	}
+	if b.submit(func() int { return 2 }) {
+		t.Error("Expected a frame to be dropped when the pool queue is full")
+	}
+	if b.busy.Load() {
+		t.Error("Expected a dropped frame not to leave the stream busy")
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+)
+
+// NES output dimensions and the scanlines hidden by overscan on most TVs
+const (
+	frameWidth    = 256
+	frameHeight   = 240
+	overscanLines = 8
+)
+
+// encodeFrame converts a raw RGBA PPU frame into the shape and encoding a client asked for
+func encodeFrame(pix []byte, req *apiv1.FrameRequest) (*apiv1.FrameResponse, error) {
+	if len(pix) < frameWidth*frameHeight*4 {
+		return nil, fmt.Errorf("frame buffer is %d bytes, expected %d", len(pix), frameWidth*frameHeight*4)
+	}
+
+	src := &image.RGBA{Pix: pix, Stride: frameWidth * 4, Rect: image.Rect(0, 0, frameWidth, frameHeight)}
+	if req.GetCropOverscan() {
+		src = src.SubImage(image.Rect(0, overscanLines, frameWidth, frameHeight-overscanLines)).(*image.RGBA)
+	}
+	return encodeImage(src, req)
+}
+
+// encodeImage resizes and encodes any RGBA image, e.g. a frame or a debug view
+func encodeImage(src *image.RGBA, req *apiv1.FrameRequest) (*apiv1.FrameResponse, error) {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	if req.GetWidth() > 0 && req.GetHeight() > 0 {
+		w, h = int(req.GetWidth()), int(req.GetHeight())
+	} else if f := int(req.GetDownscale()); f > 1 {
+		w, h = w/f, h/f
+	}
+	if w <= 0 || h <= 0 || w > src.Rect.Dx() || h > src.Rect.Dy() {
+		return nil, fmt.Errorf("invalid output size %dx%d", w, h)
+	}
+
+	img := src
+	if w != src.Rect.Dx() || h != src.Rect.Dy() {
+		img = resize(src, w, h)
+	}
+
+	resp := &apiv1.FrameResponse{Width: uint32(w), Height: uint32(h), Encoding: req.GetEncoding()}
+	switch req.GetEncoding() {
+	case apiv1.FrameEncoding_FRAME_ENCODING_RGBA:
+		resp.Pixels = packed(img, 4)
+	case apiv1.FrameEncoding_FRAME_ENCODING_RGB:
+		resp.Pixels = packed(img, 3)
+	case apiv1.FrameEncoding_FRAME_ENCODING_GRAYSCALE:
+		resp.Pixels = packed(img, 1)
+	case apiv1.FrameEncoding_FRAME_ENCODING_PNG:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("failed to encode frame: %v", err)
+		}
+		resp.Pixels = buf.Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported frame encoding %v", req.GetEncoding())
+	}
+	return resp, nil
+}
+
+// resize box-averages src into a w x h image. Each output pixel covers the source pixels
+// whose centres fall inside it, which also handles non-integer ratios like 256->84.
+func resize(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, (y+1)*sh/h
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, (x+1)*sw/w
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.PixOffset(src.Rect.Min.X+x0, src.Rect.Min.Y+sy)
+				for i := row; i < row+(x1-x0)*4; i += 4 {
+					sum[0] += int(src.Pix[i])
+					sum[1] += int(src.Pix[i+1])
+					sum[2] += int(src.Pix[i+2])
+					sum[3] += int(src.Pix[i+3])
+				}
+			}
+			n := (x1 - x0) * (y1 - y0)
+			o := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[o+c] = byte(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
+
+// packed copies img into a tightly packed buffer with the given number of channels.
+// One channel yields ITU-R BT.601 luma.
+func packed(img *image.RGBA, channels int) []byte {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	out := make([]byte, 0, w*h*channels)
+	for y := 0; y < h; y++ {
+		row := img.PixOffset(img.Rect.Min.X, img.Rect.Min.Y+y)
+		for i := row; i < row+w*4; i += 4 {
+			r, g, b := img.Pix[i], img.Pix[i+1], img.Pix[i+2]
+			switch channels {
+			case 4:
+				out = append(out, r, g, b, img.Pix[i+3])
+			case 3:
+				out = append(out, r, g, b)
+			default:
+				out = append(out, byte((299*int(r)+587*int(g)+114*int(b))/1000))
+			}
+		}
+	}
+	return out
+}
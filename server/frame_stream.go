@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+	"google.golang.org/grpc"
+)
+
+// framePollInterval is how often StreamFrames checks for a new frame, a little faster than 60Hz
+const framePollInterval = time.Second / 120
+
+// GetFrameHash returns the hash of the last completed frame
+func (s *GRPCServer) GetFrameHash(ctx context.Context, in *apiv1.Empty) (*apiv1.FrameHashResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.FrameHashResponse{Hash: bus.FrameHash(), Frame: uint64(bus.GetFrameNumber())}, nil
+}
+
+// StreamFrames sends each new frame to the client until it disconnects. Frames are
+// encoded on the shared pool and dropped when the client can't keep up.
+func (s *GRPCServer) StreamFrames(in *apiv1.StreamFramesRequest, stream grpc.ServerStreamingServer[apiv1.FrameResponse]) error {
+	bus, err := s.busFor(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	type encoded struct {
+		resp *apiv1.FrameResponse
+		err  error
+	}
+	enc := newFrameEncoder[encoded](frameEncoders())
+	go pollFrames(ctx, bus, framePollInterval, func(frame int) {
+		// Hash the same snapshot we encode so the two always agree
+		pix := append([]byte(nil), bus.GetFramePixels()...)
+		enc.submit(func() encoded {
+			resp := &apiv1.FrameResponse{}
+			if !in.HashesOnly {
+				var err error
+				if resp, err = encodeFrame(pix, in.Format); err != nil {
+					return encoded{err: err}
+				}
+			}
+			resp.Frame = uint64(frame)
+			resp.Hash = hashPixels(pix)
+			return encoded{resp: resp}
+		})
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case f := <-enc.frames():
+			if f.err != nil {
+				return f.err
+			}
+			if err := stream.Send(f.resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// hashPixels matches the bus FrameHash for a snapshot of the frame buffer
+func hashPixels(pix []byte) uint64 {
+	return bus.HashPixels(pix)
+}
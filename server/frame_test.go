@@ -0,0 +1,118 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc"
+)
+
+// testFrame returns an RGBA frame whose rows alternate between white and black
+func testFrame() []byte {
+	pix := make([]byte, frameWidth*frameHeight*4)
+	for y := 0; y < frameHeight; y++ {
+		for x := 0; x < frameWidth; x++ {
+			i := (y*frameWidth + x) * 4
+			if y%2 == 0 {
+				pix[i], pix[i+1], pix[i+2] = 0xFF, 0xFF, 0xFF
+			}
+			pix[i+3] = 0xFF
+		}
+	}
+	return pix
+}
+
+func TestEncodeFrameFormats(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *apiv1.FrameRequest
+		width, height int
+		size          int
+	}{
+		{"raw", &apiv1.FrameRequest{}, 256, 240, 256 * 240 * 4},
+		{"rgb", &apiv1.FrameRequest{Encoding: apiv1.FrameEncoding_FRAME_ENCODING_RGB}, 256, 240, 256 * 240 * 3},
+		{"crop", &apiv1.FrameRequest{CropOverscan: true}, 256, 224, 256 * 224 * 4},
+		{"downscale", &apiv1.FrameRequest{Downscale: 2}, 128, 120, 128 * 120 * 4},
+		{"atari", &apiv1.FrameRequest{Encoding: apiv1.FrameEncoding_FRAME_ENCODING_GRAYSCALE, CropOverscan: true, Width: 84, Height: 84}, 84, 84, 84 * 84},
+	}
+
+	for _, tt := range tests {
+		resp, err := encodeFrame(testFrame(), tt.req)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if int(resp.Width) != tt.width || int(resp.Height) != tt.height {
+			t.Errorf("%s: expected %dx%d, got %dx%d", tt.name, tt.width, tt.height, resp.Width, resp.Height)
+		}
+		if len(resp.Pixels) != tt.size {
+			t.Errorf("%s: expected %d bytes, got %d", tt.name, tt.size, len(resp.Pixels))
+		}
+	}
+}
+
+func TestEncodeFrameAveragesAndPNG(t *testing.T) {
+	// Averaging two rows of white and black gives mid grey
+	resp, err := encodeFrame(testFrame(), &apiv1.FrameRequest{Encoding: apiv1.FrameEncoding_FRAME_ENCODING_GRAYSCALE, Downscale: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pixels[0] != 0x7F {
+		t.Errorf("Expected grey 0x7F after averaging, got %02X", resp.Pixels[0])
+	}
+
+	resp, err = encodeFrame(testFrame(), &apiv1.FrameRequest{Encoding: apiv1.FrameEncoding_FRAME_ENCODING_PNG})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(resp.Pixels))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 256 || b.Dy() != 240 {
+		t.Errorf("Expected a 256x240 PNG, got %v", b)
+	}
+
+	if _, err := encodeFrame(testFrame(), &apiv1.FrameRequest{Width: 512, Height: 480}); err == nil {
+		t.Error("Expected an error when upscaling")
+	}
+}
+
+// streamRecorder is a minimal server stream that collects sent frames
+type streamRecorder struct {
+	grpc.ServerStreamingServer[apiv1.FrameResponse]
+	ctx    context.Context
+	cancel context.CancelFunc
+	sent   []*apiv1.FrameResponse
+}
+
+func (r *streamRecorder) Context() context.Context { return r.ctx }
+func (r *streamRecorder) Send(f *apiv1.FrameResponse) error {
+	r.sent = append(r.sent, f)
+	r.cancel()
+	return nil
+}
+
+func TestStreamFramesIncludesHash(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := &streamRecorder{ctx: ctx, cancel: cancel}
+	if err := s.StreamFrames(&apiv1.StreamFramesRequest{HashesOnly: true}, rec); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.sent) != 1 {
+		t.Fatalf("Expected one frame before cancelling, got %d", len(rec.sent))
+	}
+
+	want, err := s.GetFrameHash(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.sent[0].Hash != want.Hash || len(rec.sent[0].Pixels) != 0 {
+		t.Errorf("Expected hash-only frame with hash %016X, got %016X and %d pixel bytes", want.Hash, rec.sent[0].Hash, len(rec.sent[0].Pixels))
+	}
+}
@@ -3,41 +3,149 @@ package server
 import (
 	"context"
 	"fmt"
+	"image"
 	"io"
 	"log"
 	"net"
+	"net/http"
 	"sync"
+	"time"
 
-	"github.com/meadori/vibemulator/api"
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/apu"
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/pacing"
+	"github.com/meadori/vibemulator/ppu"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// MaxROMSize caps the size of ROM images accepted over gRPC. The largest licensed NES
+// boards hold 1 MiB, and this stays well under gRPC's default 4 MiB message limit.
+const MaxROMSize = 2 << 20
+
+// MaxStepFrames caps the frames a single StepRequest may ask for, a minute of play, so
+// one StepFrame cannot hold the emulator for an unbounded time and one InsertMovieInput
+// cannot grow a movie without bound.
+const MaxStepFrames = 60 * 60
+
+// emulatorVersion names the core in SaveState responses (handlers shadow the bus package)
+const emulatorVersion = bus.Version
+
 // EmuInterface defines the methods required from the emulator bus for RL
 type EmuInterface interface {
 	Read(addr uint16) byte
 	GetFramePixels() []byte
 	LoadState(filename string) error
+	LoadStateFromBytes(data []byte) error
+	SaveStateToBytes() ([]byte, error)
+	ROMHash() string
 	Reset()
 	SetPaused(bool)
-	RequestStep()
+	RequestStep() <-chan struct{}
+	RequestFrameStep() <-chan struct{}
+	RunUntil(cond bus.StopCondition) <-chan bool
+	RasterPosition() (scanline, dot int)
 	GetCPUState() (a, x, y, sp, p byte, pc uint16, cycles int)
-	GetMemoryBlock(addr uint16, size uint16) []byte
+	Clocks() (ppu, cpu uint64)
+	Jammed() bool
+	GetMemoryBlock(addr uint16, size int) []byte
+	MemoryMap() []bus.Region
+	ReadMemoryBlock(addr uint16, size int) []byte
+	WriteMemoryBlock(addr uint16, data []byte)
+	ResetEpisode(state []byte) error
+	StepFrame(p1, p2 [8]bool, frames int)
+	GetFrameNumber() int
+	LoadROM(data []byte) error
+	SetController1State(buttons [8]bool)
+	SetController2State(buttons [8]bool)
+	QueueInput(player int, frame int, buttons [8]bool) error
+	GetPPURegisters() ppu.Registers
+	GetAPURegisters() apu.Registers
+	GetNametableMemory() []byte
+	GetOAM() []byte
+	GetPaletteRAM() []byte
+	GetPatternTableImage(table int, palette byte) *image.RGBA
+	GetNametableImage() *image.RGBA
+	FrameHash() uint64
+	Input() (p1, p2 [8]bool)
+	OnFrame(fn func(frame int)) bus.HookID
+	RemoveHook(id bus.HookID)
+	AddWatchpoint(start, end uint16, write bool) bus.Watchpoint
+	RemoveWatchpoint(id bus.HookID) bool
+	Watchpoints() []bus.Watchpoint
+	TakeWatchHit() (bus.WatchHit, bool)
+	AddBreakpoint(addr uint16, condition string) (bus.Breakpoint, error)
+	AddPausepoint(frame int) (bus.Breakpoint, error)
+	RemoveBreakpoint(id bus.HookID) bool
+	Breakpoints() []bus.Breakpoint
+	TakeBreakHit() (bus.BreakHit, bool)
+	Evaluate(src string) (int64, error)
+	AddCheat(code string) (bus.Cheat, error)
+	SetCheatEnabled(id int, enabled bool) (bus.Cheat, error)
+	Cheats() []bus.Cheat
+	StartProfile()
+	StopProfile() (bus.Profile, bool)
+	Profile() (bus.Profile, bool)
+	StartCDL(prev []byte) error
+	StopCDL() (bus.CodeDataLog, bool)
+	CDL() (bus.CodeDataLog, bool)
+	StartRecording() error
+	StopRecording() (*bus.Movie, error)
+	PlayMovie(m *bus.Movie) (uint64, error)
+	EditMovie(m *bus.Movie) error
+	SeekMovie(frame int) error
+	TruncateMovie() error
+	InsertMovieInput(input bus.MovieFrame, n int) error
+	EditedMovie() (*bus.Movie, int, error)
+	UpdateMovieHash() error
 }
 
-// GRPCServer manages the network controller connections
+// The bus is the only real EmuInterface; main needs cgo, so check it here
+var _ EmuInterface = (*bus.Bus)(nil)
+
+// GRPCServer manages the network controller connections. It serves the same methods
+// as the focused v1 services and as the all-in-one ControllerService.
 type GRPCServer struct {
-	api.UnimplementedControllerServiceServer
-	mu       sync.Mutex
-	P1State  [8]bool
-	P2State  [8]bool
-	listener net.Listener
-	server   *grpc.Server
-	emuBus   EmuInterface
+	apiv1.UnimplementedInputServiceServer
+	apiv1.UnimplementedVideoServiceServer
+	apiv1.UnimplementedStateServiceServer
+	apiv1.UnimplementedDebugServiceServer
+	mu         sync.Mutex
+	P1State    [8]bool
+	P2State    [8]bool
+	listener   net.Listener
+	server     *grpc.Server
+	httpServer *http.Server
+	health     *health.Server
+	emuBus     EmuInterface
+
+	// Headless instances created with CreateSession, keyed by session ID, and their limits
+	sessions    map[string]*session
+	newSession  func() EmuInterface
+	maxSessions int
+	sessionIdle time.Duration
+
+	// Observation specs registered with SetObservationSpec, keyed by session ID ("" is the default instance)
+	specs map[string][]*apiv1.ObservationFeature
+
+	// Frame and audio timing of the windowed instance; nil when headless
+	pacing *pacing.Meter
+
+	// Folder MovieRequest filenames are confined to; empty refuses filenames
+	movieDir string
+
+	// Origins besides the gateway's own whose pages may open the WebSocket
+	allowedOrigins []string
 }
 
 // NewGRPCServer initializes the gRPC controller server
 func NewGRPCServer() *GRPCServer {
-	return &GRPCServer{}
+	return &GRPCServer{maxSessions: DefaultMaxSessions, sessionIdle: DefaultSessionIdle}
 }
 
 // SetBus assigns the system bus to the gRPC server for RL memory/frame reads
@@ -45,149 +153,328 @@ func (s *GRPCServer) SetBus(b EmuInterface) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.emuBus = b
+	s.updateHealth()
 }
 
-// GetFrame returns the raw pixel data from the emulator
-func (s *GRPCServer) GetFrame(ctx context.Context, in *api.Empty) (*api.FrameResponse, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// The server implements every service it registers
+var (
+	_ apiv1.InputServiceServer = (*GRPCServer)(nil)
+	_ apiv1.VideoServiceServer = (*GRPCServer)(nil)
+	_ apiv1.StateServiceServer = (*GRPCServer)(nil)
+	_ apiv1.DebugServiceServer = (*GRPCServer)(nil)
+)
+
+// services are the descriptions of every service the server registers besides health
+// and reflection
+var services = []*grpc.ServiceDesc{
+	controllerServiceDesc,
+	&apiv1.InputService_ServiceDesc,
+	&apiv1.VideoService_ServiceDesc,
+	&apiv1.StateService_ServiceDesc,
+	&apiv1.DebugService_ServiceDesc,
+}
 
-	if bus == nil {
-		return nil, fmt.Errorf("emulator bus not connected")
+// updateHealth reports the services as serving once a bus is attached. Callers hold s.mu.
+func (s *GRPCServer) updateHealth() {
+	if s.health == nil {
+		return
+	}
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if s.emuBus != nil {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	for _, desc := range services {
+		s.health.SetServingStatus(desc.ServiceName, status)
 	}
-	pixels := bus.GetFramePixels()
-	return &api.FrameResponse{Pixels: pixels}, nil
 }
 
-// ReadMemory returns the data at a specific memory address in the NES RAM
-func (s *GRPCServer) ReadMemory(ctx context.Context, in *api.MemoryRequest) (*api.MemoryResponse, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// GetFrame returns the current frame, optionally cropped, downscaled and re-encoded
+func (s *GRPCServer) GetFrame(ctx context.Context, in *apiv1.FrameRequest) (*apiv1.FrameResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return encodeFrame(bus.GetFramePixels(), in)
+}
 
-	if bus == nil {
-		return nil, fmt.Errorf("emulator bus not connected")
+// ReadMemory returns the data at a specific memory address in the NES RAM
+func (s *GRPCServer) ReadMemory(ctx context.Context, in *apiv1.MemoryRequest) (*apiv1.MemoryResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	data := bus.Read(uint16(in.Address))
-	return &api.MemoryResponse{Data: uint32(data)}, nil
+	if in.Address > 0xFFFF {
+		return nil, fmt.Errorf("address $%X is outside the CPU address space", in.Address)
+	}
+	// Neither path trips read watchpoints
+	data := readBlock(bus, uint16(in.Address), 1, in.SideEffects)[0]
+	return &apiv1.MemoryResponse{Data: uint32(data)}, nil
 }
 
 // LoadState commands the emulator to load a specific save state file
-func (s *GRPCServer) LoadState(ctx context.Context, in *api.StateRequest) (*api.Empty, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
-
-	if bus == nil {
-		return nil, fmt.Errorf("emulator bus not connected")
+func (s *GRPCServer) LoadState(ctx context.Context, in *apiv1.StateRequest) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := bus.LoadState(in.Filename); err != nil {
+	if len(in.State) > 0 {
+		err = bus.LoadStateFromBytes(in.State)
+	} else {
+		err = bus.LoadState(in.Filename)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to load state: %v", err)
 	}
-	return &api.Empty{}, nil
+	return &apiv1.Empty{}, nil
 }
 
-// ResetSystem triggers a hardware reset of the NES, returning to the title screen
-func (s *GRPCServer) ResetSystem(ctx context.Context, in *api.Empty) (*api.Empty, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// SaveState snapshots the emulator
+func (s *GRPCServer) SaveState(ctx context.Context, in *apiv1.Empty) (*apiv1.StateResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	if bus == nil {
-		return nil, fmt.Errorf("emulator bus not connected")
+	state, err := bus.SaveStateToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save state: %v", err)
+	}
+	return &apiv1.StateResponse{
+		State:    state,
+		Frame:    uint64(bus.GetFrameNumber()),
+		RomSha1:  bus.ROMHash(),
+		Emulator: emulatorVersion,
+	}, nil
+}
+
+// ResetSystem triggers a hardware reset of the NES, returning to the title screen
+func (s *GRPCServer) ResetSystem(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	bus.Reset()
-	return &api.Empty{}, nil
+	return &apiv1.Empty{}, nil
 }
 
-// Pause suspends the emulator loop
-func (s *GRPCServer) Pause(ctx context.Context, in *api.Empty) (*api.Empty, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// ResetEpisode power-cycles the emulator into a deterministic state and returns the initial observation
+func (s *GRPCServer) ResetEpisode(ctx context.Context, in *apiv1.EpisodeRequest) (*apiv1.Observation, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	if bus != nil {
-		bus.SetPaused(true)
+	if sessionID(ctx) == "" {
+		// Drop any held network input so it cannot leak into the new episode
+		s.mu.Lock()
+		s.P1State = [8]bool{}
+		s.P2State = [8]bool{}
+		s.mu.Unlock()
+	}
+
+	if err := bus.ResetEpisode(in.State); err != nil {
+		return nil, fmt.Errorf("failed to reset episode: %v", err)
 	}
-	return &api.Empty{}, nil
+	return s.observe(ctx, bus), nil
 }
 
-// Resume restarts the emulator loop
-func (s *GRPCServer) Resume(ctx context.Context, in *api.Empty) (*api.Empty, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// StepFrame advances the emulator by whole frames with the requested inputs held
+func (s *GRPCServer) StepFrame(ctx context.Context, in *apiv1.StepRequest) (*apiv1.Observation, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	if bus != nil {
-		bus.SetPaused(false)
+	if in.Frames > MaxStepFrames {
+		return nil, status.Errorf(codes.InvalidArgument, "too many frames: %d (max %d)", in.Frames, MaxStepFrames)
+	}
+	frames := int(in.Frames)
+	if frames <= 0 {
+		frames = 1
+	}
+	bus.StepFrame(buttonsFromInput(in.P1), buttonsFromInput(in.P2), frames)
+	return s.observe(ctx, bus), nil
+}
+
+// LoadROM inserts a ROM pushed by the client and power-cycles the emulator, leaving it paused
+func (s *GRPCServer) LoadROM(ctx context.Context, in *apiv1.ROMRequest) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Data) > MaxROMSize {
+		return nil, fmt.Errorf("ROM is too large: %d bytes (max %d)", len(in.Data), MaxROMSize)
+	}
+	if err := bus.LoadROM(in.Data); err != nil {
+		return nil, fmt.Errorf("failed to load ROM: %v", err)
+	}
+	return &apiv1.Empty{}, nil
+}
+
+// buttonsFromInput converts an InputState message into the bus button order
+func buttonsFromInput(in *apiv1.InputState) [8]bool {
+	if in == nil {
+		return [8]bool{}
+	}
+	return [8]bool{in.A, in.B, in.Select, in.Start, in.Up, in.Down, in.Left, in.Right}
+}
+
+// Pause suspends the emulator loop and waits for it to stop
+func (s *GRPCServer) Pause(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return &api.Empty{}, nil
+
+	bus.SetPaused(true)
+	return &apiv1.Empty{}, nil
+}
+
+// Resume restarts the emulator loop
+func (s *GRPCServer) Resume(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bus.SetPaused(false)
+	return &apiv1.Empty{}, nil
 }
 
 // Step advances the CPU by one instruction
-func (s *GRPCServer) Step(ctx context.Context, in *api.Empty) (*api.Empty, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+func (s *GRPCServer) Step(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return waitForStep(ctx, bus, bus.RequestStep())
+}
 
-	if bus != nil {
-		bus.RequestStep()
+// AdvanceFrame runs the emulator to the end of the current frame
+func (s *GRPCServer) AdvanceFrame(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return &api.Empty{}, nil
+	return waitForStep(ctx, bus, bus.RequestFrameStep())
 }
 
-// GetCPUState returns the CPU register values
-func (s *GRPCServer) GetCPUState(ctx context.Context, in *api.Empty) (*api.CPUStateResponse, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// waitForStep waits for the emulator loop to finish a step. A caller that gives up
+// leaves the emulator paused rather than stepping later.
+func waitForStep(ctx context.Context, bus EmuInterface, done <-chan struct{}) (*apiv1.Empty, error) {
+	select {
+	case <-done:
+		return &apiv1.Empty{}, nil
+	case <-ctx.Done():
+		bus.SetPaused(true)
+		return nil, ctx.Err()
+	}
+}
 
-	if bus == nil {
-		return nil, fmt.Errorf("emulator bus not connected")
+// GetCPUState returns the CPU register values
+func (s *GRPCServer) GetCPUState(ctx context.Context, in *apiv1.Empty) (*apiv1.CPUStateResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	a, x, y, sp, p, pc, cycles := bus.GetCPUState()
-	return &api.CPUStateResponse{
-		A:      uint32(a),
-		X:      uint32(x),
-		Y:      uint32(y),
-		Sp:     uint32(sp),
-		Status: uint32(p),
-		Pc:     uint32(pc),
-		Cycles: uint32(cycles),
+	scanline, dot := bus.RasterPosition()
+	ppuCycles, cpuCycles := bus.Clocks()
+	return &apiv1.CPUStateResponse{
+		A:         uint32(a),
+		X:         uint32(x),
+		Y:         uint32(y),
+		Sp:        uint32(sp),
+		Status:    uint32(p),
+		Pc:        uint32(pc),
+		Cycles:    uint32(cycles),
+		Frame:     uint64(bus.GetFrameNumber()),
+		Scanline:  int32(scanline),
+		Dot:       uint32(dot),
+		PpuCycles: ppuCycles,
+		CpuCycles: cpuCycles,
+		Jammed:    bus.Jammed(),
 	}, nil
 }
 
-// ReadMemoryBlock returns a block of raw NES RAM
-func (s *GRPCServer) ReadMemoryBlock(ctx context.Context, in *api.MemoryBlockRequest) (*api.MemoryBlockResponse, error) {
-	s.mu.Lock()
-	bus := s.emuBus
-	s.mu.Unlock()
+// ReadMemoryBlock returns a block of the CPU address space
+func (s *GRPCServer) ReadMemoryBlock(ctx context.Context, in *apiv1.MemoryBlockRequest) (*apiv1.MemoryBlockResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	if bus == nil {
-		return nil, fmt.Errorf("emulator bus not connected")
+	if uint64(in.Address)+uint64(in.Size) > 0x10000 {
+		return nil, fmt.Errorf("read of %d bytes at $%04X runs past $FFFF", in.Size, in.Address)
 	}
+	block := readBlock(bus, uint16(in.Address), int(in.Size), in.SideEffects)
+	return &apiv1.MemoryBlockResponse{Data: block}, nil
+}
 
-	block := bus.GetMemoryBlock(uint16(in.Address), uint16(in.Size))
-	return &api.MemoryBlockResponse{Data: block}, nil
+// readBlock reads memory for a debugger, peeking registers unless sideEffects is set.
+func readBlock(bus EmuInterface, addr uint16, size int, sideEffects bool) []byte {
+	if sideEffects {
+		return bus.ReadMemoryBlock(addr, size)
+	}
+	return bus.GetMemoryBlock(addr, size)
 }
 
+// WriteMemoryBlock writes a block of bytes starting at an address
+func (s *GRPCServer) WriteMemoryBlock(ctx context.Context, in *apiv1.MemoryWriteRequest) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-// Start begins listening for gRPC connections on the given port
-func (s *GRPCServer) Start(port int) error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if int(in.Address)+len(in.Data) > 0x10000 {
+		return nil, fmt.Errorf("write of %d bytes at $%04X runs past $FFFF", len(in.Data), in.Address)
+	}
+	bus.WriteMemoryBlock(uint16(in.Address), in.Data)
+	return &apiv1.Empty{}, nil
+}
+
+// Config controls where the gRPC server listens and how clients authenticate
+type Config struct {
+	Addr  string    // Address to bind, e.g. "localhost:50051" or ":50051" for all interfaces
+	TLS   TLSConfig // Optional server certificate, plus a client CA for mTLS
+	Token string    // Optional bearer token every RPC must carry
+}
+
+// Start begins listening for gRPC connections with the given configuration
+func (s *GRPCServer) Start(cfg Config) error {
+	creds, err := cfg.TLS.serverCredentials()
+	if err != nil {
+		return err
+	}
+	opts := []grpc.ServerOption{grpc.Creds(creds)}
+	if cfg.Token != "" {
+		opts = append(opts, tokenInterceptors(cfg.Token)...)
+	}
+
+	lis, err := net.Listen("tcp", cfg.Addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 	s.listener = lis
-	s.server = grpc.NewServer()
-	api.RegisterControllerServiceServer(s.server, s)
+	s.server = grpc.NewServer(opts...)
+	for _, desc := range services {
+		s.server.RegisterService(desc, s)
+	}
 
-	log.Printf("gRPC server listening on :%d", port)
+	// Standard health checking and reflection so grpcurl and readiness probes work out of the box
+	s.mu.Lock()
+	s.health = health.NewServer()
+	s.updateHealth()
+	s.mu.Unlock()
+	healthpb.RegisterHealthServer(s.server, s.health)
+	reflection.Register(s.server)
+
+	log.Printf("gRPC server listening on %s (tls=%v, auth=%v)", lis.Addr(), cfg.TLS.Enabled(), cfg.Token != "")
 
 	// Run the server in a background goroutine
 	go func() {
@@ -201,13 +488,28 @@ func (s *GRPCServer) Start(port int) error {
 
 // Stop gracefully shuts down the gRPC server
 func (s *GRPCServer) Stop() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+	if s.health != nil {
+		s.health.Shutdown()
+	}
 	if s.server != nil {
 		s.server.GracefulStop()
 	}
 }
 
-// StreamInput handles incoming controller streams from clients
-func (s *GRPCServer) StreamInput(stream grpc.BidiStreamingServer[api.InputState, api.Empty]) error {
+// StreamInput handles incoming controller streams from clients. Streams for the default
+// instance are polled by the display; streams for a session drive its bus directly.
+func (s *GRPCServer) StreamInput(stream grpc.BidiStreamingServer[apiv1.InputState, apiv1.Empty]) error {
+	var bus EmuInterface
+	if sessionID(stream.Context()) != "" {
+		var err error
+		if bus, err = s.busFor(stream.Context()); err != nil {
+			return err
+		}
+	}
+
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
@@ -217,25 +519,49 @@ func (s *GRPCServer) StreamInput(stream grpc.BidiStreamingServer[api.InputState,
 			return err
 		}
 
-		s.mu.Lock()
-		state := [8]bool{
-			req.A,
-			req.B,
-			req.Select,
-			req.Start,
-			req.Up,
-			req.Down,
-			req.Left,
-			req.Right,
+		if err := s.applyInput(bus, req); err != nil {
+			return err
+		}
+	}
+}
+
+// applyInput routes a controller update either to a session bus or, when bus is nil, to the
+// network state polled by the display. Frame-targeted updates are queued on the bus itself.
+func (s *GRPCServer) applyInput(bus EmuInterface, req *apiv1.InputState) error {
+	state := buttonsFromInput(req)
+	if req.TargetFrame != nil {
+		if bus == nil {
+			s.mu.Lock()
+			bus = s.emuBus
+			s.mu.Unlock()
+		}
+		if bus == nil {
+			return fmt.Errorf("emulator bus not connected")
+		}
+		player := int(req.PlayerIndex)
+		if player == 0 {
+			player = 1
 		}
+		return bus.QueueInput(player, int(*req.TargetFrame), state)
+	}
 
-		if req.PlayerIndex == 1 || req.PlayerIndex == 0 { // Default to P1 if not specified
-			s.P1State = state
+	if bus != nil {
+		if req.PlayerIndex == 1 || req.PlayerIndex == 0 {
+			bus.SetController1State(state)
 		} else if req.PlayerIndex == 2 {
-			s.P2State = state
+			bus.SetController2State(state)
 		}
-		s.mu.Unlock()
+		return nil
+	}
+
+	s.mu.Lock()
+	if req.PlayerIndex == 1 || req.PlayerIndex == 0 { // Default to P1 if not specified
+		s.P1State = state
+	} else if req.PlayerIndex == 2 {
+		s.P2State = state
 	}
+	s.mu.Unlock()
+	return nil
 }
 
 // GetP1State returns the current network state for Player 1
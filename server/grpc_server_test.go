@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/meadori/vibemulator/api"
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthCheck(t *testing.T) {
+	s := NewGRPCServer()
+	if err := s.Start(Config{Addr: "127.0.0.1:0", Token: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	opts, err := DialOptions(TLSConfig{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.NewClient(s.listener.Addr().String(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		// Probes carry no token, so the health service must not require one
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "api.ControllerService"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Status
+	}
+
+	if got := check(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING before a bus is attached, got %v", got)
+	}
+	s.SetBus(&fakeBus{})
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING with a bus attached, got %v", got)
+	}
+}
+
+func TestV1Services(t *testing.T) {
+	s := NewGRPCServer()
+	if err := s.Start(Config{Addr: "127.0.0.1:0"}); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+	s.SetBus(&fakeBus{frame: 7})
+
+	opts, err := DialOptions(TLSConfig{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.NewClient(s.listener.Addr().String(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// A focused service answers the same as the all-in-one one
+	got, err := apiv1.NewVideoServiceClient(conn).GetFrameHash(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := api.NewControllerServiceClient(conn).GetFrameHash(context.Background(), &api.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Frame != 7 || got.Hash != want.Hash {
+		t.Errorf("Expected frame 7 hash %016x, got frame %d hash %016x", want.Hash, got.Frame, got.Hash)
+	}
+
+	health := healthpb.NewHealthClient(conn)
+	for _, desc := range services {
+		resp, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: desc.ServiceName})
+		if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected %s to be SERVING, got %v (err=%v)", desc.ServiceName, resp.GetStatus(), err)
+		}
+	}
+}
+
+func TestWriteMemoryBlockRange(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+
+	_, err := s.WriteMemoryBlock(context.Background(), &apiv1.MemoryWriteRequest{Address: 0xFFFF, Data: []byte{1, 2}})
+	if err == nil {
+		t.Error("Expected an error for a write past $FFFF")
+	}
+}
+
+func TestStepFrameLimit(t *testing.T) {
+	s := NewGRPCServer()
+	fake := &fakeBus{}
+	s.SetBus(fake)
+
+	_, err := s.StepFrame(context.Background(), &apiv1.StepRequest{Frames: MaxStepFrames + 1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for %d frames, got %v", MaxStepFrames+1, err)
+	}
+	if fake.frame != 0 {
+		t.Errorf("Expected a refused step not to run, ran %d frames", fake.frame)
+	}
+	if _, err := s.StepFrame(context.Background(), &apiv1.StepRequest{Frames: MaxStepFrames}); err != nil {
+		t.Errorf("Expected %d frames to be allowed, got %v", MaxStepFrames, err)
+	}
+}
+
+func TestReadMemoryBlockRange(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+
+	if _, err := s.ReadMemoryBlock(context.Background(), &apiv1.MemoryBlockRequest{Address: 0xFFFF, Size: 2}); err == nil {
+		t.Error("Expected an error for a read past $FFFF")
+	}
+	res, err := s.ReadMemoryBlock(context.Background(), &apiv1.MemoryBlockRequest{Size: 0x10000})
+	if err != nil || len(res.GetData()) != 0x10000 {
+		t.Errorf("Expected the whole address space, got %d bytes (err=%v)", len(res.GetData()), err)
+	}
+	if _, err := s.ReadMemory(context.Background(), &apiv1.MemoryRequest{Address: 0x10000}); err == nil {
+		t.Error("Expected an error for an address past $FFFF")
+	}
+}
+
+// timingBus is paused mid-frame, on a JAM
+type timingBus struct {
+	fakeBus
+}
+
+func (b *timingBus) GetFrameNumber() int                 { return 42 }
+func (b *timingBus) RasterPosition() (scanline, dot int) { return -1, 340 }
+func (b *timingBus) Clocks() (ppu, cpu uint64)           { return 1 << 33, 1<<33/3 + 1 }
+func (b *timingBus) Jammed() bool                        { return true }
+func (b *timingBus) GetCPUState() (a, x, y, sp, p byte, pc uint16, cycles int) {
+	return 1, 2, 3, 0xFD, 0x24, 0xC000, 2
+}
+
+func TestGetCPUStateTiming(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&timingBus{})
+
+	st, err := s.GetCPUState(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Pc != 0xC000 || st.Cycles != 2 || st.Frame != 42 || st.Scanline != -1 || st.Dot != 340 {
+		t.Errorf("Unexpected CPU state %v", st)
+	}
+	if st.PpuCycles != 1<<33 || st.CpuCycles != 1<<33/3+1 {
+		t.Errorf("Expected 64-bit cycle counters, got %d PPU and %d CPU", st.PpuCycles, st.CpuCycles)
+	}
+	if !st.Jammed {
+		t.Error("Expected the CPU to be reported jammed")
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/apu"
+)
+
+// GetPPUState returns the PPU's internal registers and raster position
+func (s *GRPCServer) GetPPUState(ctx context.Context, in *apiv1.Empty) (*apiv1.PPUStateResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bus.GetPPURegisters()
+	return &apiv1.PPUStateResponse{
+		Ctrl:       uint32(r.Ctrl),
+		Mask:       uint32(r.Mask),
+		Status:     uint32(r.Status),
+		OamAddr:    uint32(r.OAMAddr),
+		V:          uint32(r.V),
+		T:          uint32(r.T),
+		FineX:      uint32(r.FineX),
+		W:          r.W,
+		Scanline:   int32(r.Scanline),
+		Dot:        uint32(r.Dot),
+		Frame:      uint64(r.Frame),
+		NmiPending: r.NMIPending,
+	}, nil
+}
+
+// GetAPUState returns the state of each sound channel and the frame counter
+func (s *GRPCServer) GetAPUState(ctx context.Context, in *apiv1.Empty) (*apiv1.APUStateResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bus.GetAPURegisters()
+	return &apiv1.APUStateResponse{
+		Pulse1:            apuChannelToProto(r.Pulse1),
+		Pulse2:            apuChannelToProto(r.Pulse2),
+		Triangle:          apuChannelToProto(r.Triangle),
+		Noise:             apuChannelToProto(r.Noise),
+		Dmc:               apuChannelToProto(r.DMC),
+		DmcAddress:        uint32(r.DMCAddress),
+		DmcBytesRemaining: uint32(r.DMCBytesRemaining),
+		FiveStep:          r.FiveStep,
+		IrqInhibit:        r.IRQInhibit,
+		FrameIrq:          r.FrameIRQ,
+		DmcIrq:            r.DMCIRQ,
+	}, nil
+}
+
+func apuChannelToProto(c apu.Channel) *apiv1.APUChannel {
+	return &apiv1.APUChannel{
+		Enabled:       c.Enabled,
+		Period:        uint32(c.Period),
+		LengthCounter: uint32(c.LengthCounter),
+		Halt:          c.Halt,
+		Volume:        uint32(c.Volume),
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/apu"
+	"github.com/meadori/vibemulator/ppu"
+)
+
+// registerBus reports fixed PPU and APU registers
+type registerBus struct {
+	fakeBus
+}
+
+func (*registerBus) GetPPURegisters() ppu.Registers {
+	return ppu.Registers{Ctrl: 0x80, V: 0x2108, FineX: 5, W: true, Scanline: -1, Dot: 340, NMIPending: true}
+}
+
+func (*registerBus) GetAPURegisters() apu.Registers {
+	return apu.Registers{
+		Pulse1:   apu.Channel{Enabled: true, Period: 0x1AB, LengthCounter: 10, Volume: 15},
+		DMC:      apu.Channel{Period: 428},
+		FiveStep: true,
+		FrameIRQ: true,
+	}
+}
+
+func TestGetPPUState(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&registerBus{})
+
+	resp, err := s.GetPPUState(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Ctrl != 0x80 || resp.V != 0x2108 || resp.FineX != 5 || !resp.W || resp.Scanline != -1 || resp.Dot != 340 || !resp.NmiPending {
+		t.Errorf("Unexpected PPU state %v", resp)
+	}
+}
+
+func TestGetAPUState(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&registerBus{})
+
+	resp, err := s.GetAPUState(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := resp.Pulse1; !p.Enabled || p.Period != 0x1AB || p.LengthCounter != 10 || p.Volume != 15 {
+		t.Errorf("Unexpected pulse 1 state %v", p)
+	}
+	if resp.Dmc.Period != 428 || resp.Triangle.Enabled || !resp.FiveStep || !resp.FrameIrq {
+		t.Errorf("Unexpected APU state %v", resp)
+	}
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// HTTPHandler exposes a subset of the controller API as plain HTTP/JSON so scripts can drive
+// the emulator without generating gRPC stubs. Sessions are selected with the X-Session-Id
+// header, and token auth uses the usual Authorization: Bearer header.
+//
+//	POST /api/pause, /api/resume, /api/step, /api/advance-frame, /api/reset
+//	GET  /api/cpu
+//	GET  /api/memory?addr=0x0300&size=16
+//	GET  /api/frame.png
+//	GET  /api/pacing
+//	GET  /ws    (WebSocket frame stream and controller input)
+//	GET  /play  (browser remote-play page)
+//
+// Browsers cannot set headers on WebSocket requests, so the session and token may also be
+// passed as ?session= and ?token= query parameters. Requests other than GET from other
+// sites' pages are refused, as /ws handshakes are, so they can't drive the emulator.
+func (s *GRPCServer) HTTPHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+
+	empty := func(call func(context.Context, *apiv1.Empty) (*apiv1.Empty, error)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if _, err := call(r.Context(), &apiv1.Empty{}); err != nil {
+				httpError(w, err)
+				return
+			}
+			writeJSON(w, map[string]bool{"ok": true})
+		}
+	}
+	mux.HandleFunc("POST /api/pause", empty(s.Pause))
+	mux.HandleFunc("POST /api/resume", empty(s.Resume))
+	mux.HandleFunc("POST /api/step", empty(s.Step))
+	mux.HandleFunc("POST /api/advance-frame", empty(s.AdvanceFrame))
+	mux.HandleFunc("POST /api/reset", empty(s.ResetSystem))
+
+	mux.HandleFunc("GET /api/cpu", func(w http.ResponseWriter, r *http.Request) {
+		st, err := s.GetCPUState(r.Context(), &apiv1.Empty{})
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"a": st.A, "x": st.X, "y": st.Y, "sp": st.Sp,
+			"status": st.Status, "pc": st.Pc, "cycles": st.Cycles,
+			"frame": st.Frame, "scanline": st.Scanline, "dot": st.Dot,
+			"ppu_cycles": st.PpuCycles, "cpu_cycles": st.CpuCycles, "jammed": st.Jammed,
+		})
+	})
+
+	mux.HandleFunc("GET /api/pacing", func(w http.ResponseWriter, r *http.Request) {
+		st, err := s.GetPacingStats(r.Context(), &apiv1.Empty{})
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, st)
+	})
+
+	mux.HandleFunc("GET /api/memory", func(w http.ResponseWriter, r *http.Request) {
+		addr, err := strconv.ParseUint(r.URL.Query().Get("addr"), 0, 16)
+		if err != nil {
+			http.Error(w, "addr must be a 16-bit address", http.StatusBadRequest)
+			return
+		}
+		size := uint64(1)
+		if v := r.URL.Query().Get("size"); v != "" {
+			if size, err = strconv.ParseUint(v, 0, 16); err != nil {
+				http.Error(w, "size must be at most 65535", http.StatusBadRequest)
+				return
+			}
+		}
+
+		block, err := s.ReadMemoryBlock(r.Context(), &apiv1.MemoryBlockRequest{Address: uint32(addr), Size: uint32(size)})
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		// Encode as a list of numbers rather than base64 so scripts can index it directly
+		data := make([]int, len(block.Data))
+		for i, b := range block.Data {
+			data[i] = int(b)
+		}
+		writeJSON(w, map[string]interface{}{"address": addr, "data": data})
+	})
+
+	mux.HandleFunc("GET /api/frame.png", func(w http.ResponseWriter, r *http.Request) {
+		frame, err := s.GetFrame(r.Context(), &apiv1.FrameRequest{Encoding: apiv1.FrameEncoding_FRAME_ENCODING_PNG})
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(frame.Pixels)
+	})
+
+	mux.HandleFunc("GET /play", handlePlay)
+	mux.HandleFunc("GET /ws", func(w http.ResponseWriter, r *http.Request) {
+		s.handleWebSocket(r.Context()).ServeHTTP(w, r)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Carry the HTTP headers over as gRPC metadata so sessions and auth behave the same
+		md := metadata.MD{}
+		if id := r.Header.Get("X-Session-Id"); id != "" {
+			md.Set(SessionMetadataKey, id)
+		} else if id := r.URL.Query().Get("session"); id != "" {
+			md.Set(SessionMetadataKey, id)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			md.Set("authorization", auth)
+		} else if t := r.URL.Query().Get("token"); t != "" {
+			md.Set("authorization", "Bearer "+t)
+		}
+		ctx := metadata.NewIncomingContext(r.Context(), md)
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if err := s.checkRequestOrigin(r); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+		}
+		if token != "" {
+			if err := checkToken(ctx, token); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// checkRequestOrigin applies checkOrigin's policy to a plain HTTP request. Browsers send
+// Origin with every POST; requests without one don't come from a page.
+func (s *GRPCServer) checkRequestOrigin(r *http.Request) error {
+	v := r.Header.Get("Origin")
+	if v == "" {
+		return nil
+	}
+	origin, err := url.ParseRequestURI(v)
+	if err != nil {
+		return fmt.Errorf("invalid origin %q", v)
+	}
+	if !s.originAllowed(origin, r.Host) {
+		return fmt.Errorf("origin %s is not allowed", origin)
+	}
+	return nil
+}
+
+// originAllowed reports whether a page on origin may drive the gateway on host: either the
+// gateway served it, or its origin is allowed.
+func (s *GRPCServer) originAllowed(origin *url.URL, host string) bool {
+	if origin.Host == host {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.allowedOrigins, origin.Scheme+"://"+origin.Host)
+}
+
+// StartHTTP serves the HTTP/JSON gateway on addr in the background, with the same TLS
+// settings and token as the gRPC server
+func (s *GRPCServer) StartHTTP(addr string, cfg Config) error {
+	tlsCfg, err := cfg.TLS.serverTLS()
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	if tlsCfg != nil {
+		lis = tls.NewListener(lis, tlsCfg)
+	}
+	s.httpServer = &http.Server{Handler: s.HTTPHandler(cfg.Token)}
+
+	log.Printf("HTTP gateway listening on %s (tls=%v, auth=%v)", lis.Addr(), tlsCfg != nil, cfg.Token != "")
+	go func() {
+		if err := s.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.Printf("HTTP gateway error: %v", err)
+		}
+	}()
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v", err)
+	}
+}
+
+func httpError(w http.ResponseWriter, err error) {
+	http.Error(w, fmt.Sprintf("%v", err), http.StatusInternalServerError)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPGateway(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+	ts := httptest.NewServer(s.HTTPHandler(""))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/memory?addr=0x10&size=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mem struct {
+		Address int   `json:"address"`
+		Data    []int `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&mem)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mem.Address != 0x10 || len(mem.Data) != 3 || mem.Data[2] != 0x12 {
+		t.Errorf("Unexpected memory response: %+v", mem)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/frame.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 256 || b.Dy() != 240 {
+		t.Errorf("Expected a 256x240 frame, got %v", b)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/memory?addr=nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a bad address, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPGatewayToken(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+	ts := httptest.NewServer(s.HTTPHandler("secret"))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/frame.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest("GET", ts.URL+"/api/frame.png", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 with a token, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPGatewayOrigin(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&untilBus{})
+	ts := httptest.NewServer(s.HTTPHandler(""))
+	defer ts.Close()
+
+	pause := func(origin string) int {
+		req, _ := http.NewRequest("POST", ts.URL+"/api/pause", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := pause("https://evil.example"); got != http.StatusForbidden {
+		t.Errorf("Expected 403 for another site's page, got %d", got)
+	}
+	if got := pause("null"); got != http.StatusForbidden {
+		t.Errorf("Expected 403 for an opaque origin, got %d", got)
+	}
+	if got := pause(""); got != http.StatusOK {
+		t.Errorf("Expected 200 without an Origin, got %d", got)
+	}
+	if got := pause(ts.URL); got != http.StatusOK {
+		t.Errorf("Expected 200 for the gateway's own page, got %d", got)
+	}
+	s.SetAllowedOrigins([]string{"https://evil.example"})
+	if got := pause("https://evil.example"); got != http.StatusOK {
+		t.Errorf("Expected 200 for an allowed origin, got %d", got)
+	}
+}
+
+func TestStartHTTPRequiresKeyPair(t *testing.T) {
+	s := NewGRPCServer()
+	if err := s.StartHTTP("localhost:0", Config{TLS: TLSConfig{CertFile: "server.pem"}}); err == nil {
+		t.Error("Expected an error for a certificate without a key")
+	}
+}
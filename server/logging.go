@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/logging"
+)
+
+// GetLogLevels returns the level of every logging subsystem
+func (s *GRPCServer) GetLogLevels(ctx context.Context, in *apiv1.Empty) (*apiv1.LogLevels, error) {
+	return logLevels(), nil
+}
+
+// SetLogLevels changes the levels given and returns them all. Levels are process-wide,
+// so they apply to every session.
+func (s *GRPCServer) SetLogLevels(ctx context.Context, in *apiv1.LogLevels) (*apiv1.LogLevels, error) {
+	levels := make(map[string]slog.Level, len(in.Levels))
+	for name, value := range in.Levels {
+		level, err := logging.ParseLevel(value)
+		if err != nil {
+			return nil, err
+		}
+		levels[name] = level
+	}
+	// Apply "all" first so it doesn't undo a subsystem set in the same call
+	if level, ok := levels["all"]; ok {
+		logging.SetLevel("all", level)
+	}
+	for name, level := range levels {
+		if name == "all" {
+			continue
+		}
+		if err := logging.SetLevel(name, level); err != nil {
+			return nil, err
+		}
+	}
+	return logLevels(), nil
+}
+
+func logLevels() *apiv1.LogLevels {
+	out := &apiv1.LogLevels{Levels: map[string]string{}}
+	for name, level := range logging.Levels() {
+		out.Levels[name] = logging.LevelName(level)
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/logging"
+)
+
+func TestSetLogLevels(t *testing.T) {
+	saved := logging.Levels()
+	defer func() {
+		for name, level := range saved {
+			logging.SetLevel(name, level)
+		}
+	}()
+	s := NewGRPCServer()
+
+	resp, err := s.SetLogLevels(context.Background(), &apiv1.LogLevels{Levels: map[string]string{"cpu": "trace", "all": "warn"}})
+	if err != nil {
+		t.Fatalf("SetLogLevels failed: %v", err)
+	}
+	if resp.Levels["cpu"] != "TRACE" || resp.Levels["bus"] != "WARN" {
+		t.Errorf("Expected cpu at TRACE and bus at WARN, got %v", resp.Levels)
+	}
+
+	if _, err := s.SetLogLevels(context.Background(), &apiv1.LogLevels{Levels: map[string]string{"ppu": "debug", "cpu": "loud"}}); err == nil {
+		t.Errorf("Expected an error for an invalid level")
+	}
+	resp, _ = s.GetLogLevels(context.Background(), &apiv1.Empty{})
+	if resp.Levels["ppu"] != "WARN" {
+		t.Errorf("Expected an invalid request to change nothing, got ppu at %s", resp.Levels["ppu"])
+	}
+}
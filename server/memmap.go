@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+)
+
+// GetMemoryMap describes the CPU address space as the mapper currently banks it
+func (s *GRPCServer) GetMemoryMap(ctx context.Context, in *apiv1.Empty) (*apiv1.MemoryMap, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apiv1.MemoryMap{}
+	for _, r := range bus.MemoryMap() {
+		resp.Regions = append(resp.Regions, &apiv1.MemoryRegion{
+			Start:  uint32(r.Start),
+			End:    uint32(r.End),
+			Kind:   string(r.Kind),
+			Mirror: uint32(r.Mirror),
+			Offset: int32(r.Offset),
+			Bank:   int32(r.Bank),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// mapBus reports a fixed memory map
+type mapBus struct {
+	fakeBus
+}
+
+func (*mapBus) MemoryMap() []bus.Region {
+	return []bus.Region{
+		{Start: 0x0000, End: 0x1FFF, Kind: bus.RegionRAM, Mirror: 2048, Offset: -1, Bank: -1},
+		{Start: 0x8000, End: 0xBFFF, Kind: bus.RegionPRGROM, Offset: 0x8000, Bank: 2},
+	}
+}
+
+func TestGetMemoryMap(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&mapBus{})
+
+	resp, err := s.GetMemoryMap(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	regions := resp.GetRegions()
+	if len(regions) != 2 {
+		t.Fatalf("Expected 2 regions, got %d", len(regions))
+	}
+	if r := regions[0]; r.Kind != "ram" || r.End != 0x1FFF || r.Mirror != 2048 || r.Offset != -1 {
+		t.Errorf("Unexpected RAM region %v", r)
+	}
+	if r := regions[1]; r.Kind != "prg-rom" || r.Start != 0x8000 || r.Offset != 0x8000 || r.Bank != 2 {
+		t.Errorf("Unexpected PRG ROM region %v", r)
+	}
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetMovieDir sets the folder that MovieRequest filenames name files in. Clients can't
+// reach outside it, and without one they must send and receive movie bytes instead.
+func (s *GRPCServer) SetMovieDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.movieDir = dir
+}
+
+// movieFile resolves a MovieRequest filename inside the movie folder
+func (s *GRPCServer) movieFile(name string) (string, error) {
+	s.mu.Lock()
+	dir := s.movieDir
+	s.mu.Unlock()
+	if dir == "" {
+		return "", fmt.Errorf("this server keeps no movie files; send the movie bytes instead")
+	}
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("movie filename %q must be a relative path inside the movie folder", name)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// StartRecording begins logging per-frame input on the target instance
+func (s *GRPCServer) StartRecording(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bus.StartRecording(); err != nil {
+		return nil, fmt.Errorf("failed to start recording: %v", err)
+	}
+	return &apiv1.Empty{}, nil
+}
+
+// StopRecording ends the recording, optionally saving it to the movie folder, and returns
+// the movie
+func (s *GRPCServer) StopRecording(ctx context.Context, in *apiv1.MovieRequest) (*apiv1.MovieResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := ""
+	if in.Filename != "" {
+		if path, err = s.movieFile(in.Filename); err != nil {
+			return nil, err
+		}
+	}
+
+	movie, err := bus.StopRecording()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop recording: %v", err)
+	}
+	data, err := movie.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode movie: %v", err)
+	}
+	if path != "" {
+		if err := storage.WriteFile(path, data); err != nil {
+			return nil, fmt.Errorf("failed to save movie: %v", err)
+		}
+	}
+
+	return &apiv1.MovieResponse{
+		Movie:        data,
+		Frames:       uint32(len(movie.Frames) - 1),
+		FrameHash:    movie.FinalHash,
+		RecordedHash: movie.FinalHash,
+	}, nil
+}
+
+// PlayMovie replays a movie from its starting state and reports the final frame hash
+func (s *GRPCServer) PlayMovie(ctx context.Context, in *apiv1.MovieRequest) (*apiv1.MovieResponse, error) {
+	movie, err := s.readMovie(in)
+	if err != nil {
+		return nil, err
+	}
+
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := bus.PlayMovie(movie)
+	if err != nil {
+		return nil, fmt.Errorf("failed to play movie: %v", err)
+	}
+	return &apiv1.MovieResponse{
+		Frames:       uint32(len(movie.Frames) - 1),
+		FrameHash:    hash,
+		RecordedHash: movie.FinalHash,
+	}, nil
+}
+
+// readMovie loads the movie named by a request, from the movie folder or inline
+func (s *GRPCServer) readMovie(in *apiv1.MovieRequest) (*bus.Movie, error) {
+	data := in.Movie
+	if in.Filename != "" {
+		path, err := s.movieFile(in.Filename)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read movie: %v", err)
+		}
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no movie given")
+	}
+
+	movie, err := bus.DecodeMovie(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode movie: %v", err)
+	}
+	return movie, nil
+}
+
+// EditMovie loads a movie for editing and seeks to its first frame
+func (s *GRPCServer) EditMovie(ctx context.Context, in *apiv1.MovieRequest) (*apiv1.MovieResponse, error) {
+	movie, err := s.readMovie(in)
+	if err != nil {
+		return nil, err
+	}
+
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bus.EditMovie(movie); err != nil {
+		return nil, fmt.Errorf("failed to edit movie: %v", err)
+	}
+	return movieEditResponse(bus, false)
+}
+
+// SeekMovie replays the movie being edited to the start of a frame
+func (s *GRPCServer) SeekMovie(ctx context.Context, in *apiv1.MovieSeekRequest) (*apiv1.MovieResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bus.SeekMovie(int(in.Frame)); err != nil {
+		return nil, fmt.Errorf("failed to seek: %v", err)
+	}
+	return movieEditResponse(bus, false)
+}
+
+// TruncateMovie drops the edited movie's input after the current frame
+func (s *GRPCServer) TruncateMovie(ctx context.Context, in *apiv1.Empty) (*apiv1.MovieResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bus.TruncateMovie(); err != nil {
+		return nil, fmt.Errorf("failed to truncate movie: %v", err)
+	}
+	return movieEditResponse(bus, false)
+}
+
+// InsertMovieInput inserts frames of input into the edited movie at the current frame
+func (s *GRPCServer) InsertMovieInput(ctx context.Context, in *apiv1.StepRequest) (*apiv1.MovieResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Frames > MaxStepFrames {
+		return nil, status.Errorf(codes.InvalidArgument, "too many frames: %d (max %d)", in.Frames, MaxStepFrames)
+	}
+	frames := int(in.Frames)
+	if frames == 0 {
+		frames = 1
+	}
+	if err := bus.InsertMovieInput(movieFrame(in), frames); err != nil {
+		return nil, fmt.Errorf("failed to insert input: %v", err)
+	}
+	return movieEditResponse(bus, false)
+}
+
+// MovieStatus describes the movie being edited without replaying or returning it
+func (s *GRPCServer) MovieStatus(ctx context.Context, in *apiv1.Empty) (*apiv1.MovieResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return movieEditResponse(bus, false)
+}
+
+// SaveMovie returns the edited movie, optionally saving it to the movie folder
+func (s *GRPCServer) SaveMovie(ctx context.Context, in *apiv1.MovieRequest) (*apiv1.MovieResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := ""
+	if in.Filename != "" {
+		if path, err = s.movieFile(in.Filename); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := bus.UpdateMovieHash(); err != nil {
+		return nil, fmt.Errorf("failed to hash movie: %v", err)
+	}
+	res, err := movieEditResponse(bus, true)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		if err := storage.WriteFile(path, res.Movie); err != nil {
+			return nil, fmt.Errorf("failed to save movie: %v", err)
+		}
+	}
+	return res, nil
+}
+
+// movieFrame converts a StepRequest's inputs into a movie frame
+func movieFrame(in *apiv1.StepRequest) bus.MovieFrame {
+	return bus.MovieFrame{P1: buttonsFromInput(in.P1), P2: buttonsFromInput(in.P2)}
+}
+
+// movieEditResponse describes the movie being edited and where the emulator is in it,
+// with the encoded movie when withMovie is set
+func movieEditResponse(bus EmuInterface, withMovie bool) (*apiv1.MovieResponse, error) {
+	movie, frame, err := bus.EditedMovie()
+	if err != nil {
+		return nil, err
+	}
+	res := &apiv1.MovieResponse{
+		Frames:       uint32(len(movie.Frames) - 1),
+		FrameHash:    bus.FrameHash(),
+		RecordedHash: movie.FinalHash,
+		Position:     uint32(frame),
+	}
+	if withMovie {
+		if res.Movie, err = movie.Encode(); err != nil {
+			return nil, fmt.Errorf("failed to encode movie: %v", err)
+		}
+	}
+	return res, nil
+}
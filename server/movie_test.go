@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// editBus edits a movie the way the bus does, without emulating it
+type editBus struct {
+	fakeBus
+	movie *bus.Movie
+	frame int
+}
+
+func (b *editBus) FrameHash() uint64 { return 0xF00D }
+
+func (b *editBus) EditedMovie() (*bus.Movie, int, error) {
+	return b.movie, b.frame, nil
+}
+
+func (b *editBus) UpdateMovieHash() error { return nil }
+
+func (b *editBus) InsertMovieInput(input bus.MovieFrame, n int) error {
+	b.movie.Frames = slices.Insert(b.movie.Frames, b.frame, slices.Repeat([]bus.MovieFrame{input}, n)...)
+	return nil
+}
+
+func TestMovieEditing(t *testing.T) {
+	s := NewGRPCServer()
+	b := &editBus{movie: &bus.Movie{Frames: make([]bus.MovieFrame, 3), FinalHash: 0xBEEF}, frame: 1}
+	s.SetBus(b)
+	ctx := context.Background()
+
+	res, err := s.InsertMovieInput(ctx, &apiv1.StepRequest{P1: &apiv1.InputState{Start: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Frames != 3 || res.Position != 1 || res.FrameHash != 0xF00D || res.Movie != nil {
+		t.Errorf("Expected one frame inserted at frame 1, got %v", res)
+	}
+	if want := (bus.MovieFrame{P1: [8]bool{false, false, false, true}}); b.movie.Frames[1] != want {
+		t.Errorf("Expected Start inserted, got %v", b.movie.Frames[1])
+	}
+
+	if _, err := s.InsertMovieInput(ctx, &apiv1.StepRequest{Frames: MaxStepFrames + 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument inserting %d frames, got %v", MaxStepFrames+1, err)
+	}
+	if len(b.movie.Frames) != 4 {
+		t.Errorf("Expected a refused insert to leave 4 frames, got %d", len(b.movie.Frames))
+	}
+
+	res, err = s.MovieStatus(ctx, &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Frames != 3 || res.Position != 1 || res.Movie != nil {
+		t.Errorf("Expected the status of a 4-frame movie without the movie, got %v", res)
+	}
+
+	dir := t.TempDir()
+	s.SetMovieDir(dir)
+	if _, err := s.SaveMovie(ctx, &apiv1.MovieRequest{Filename: "../edited.movie"}); err == nil {
+		t.Error("Expected a filename outside the movie folder to be refused")
+	}
+	path := filepath.Join(dir, "edited.movie")
+	res, err = s.SaveMovie(ctx, &apiv1.MovieRequest{Filename: "edited.movie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := bus.DecodeMovie(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Frames) != 4 || saved.FinalHash != 0xBEEF || res.RecordedHash != 0xBEEF || len(res.Movie) != len(data) {
+		t.Errorf("Expected the edited movie saved and returned, got %d frames and hash %X", len(saved.Frames), saved.FinalHash)
+	}
+}
+
+// recordBus hands back a two-frame recording
+type recordBus struct {
+	fakeBus
+}
+
+func (b *recordBus) StopRecording() (*bus.Movie, error) {
+	return &bus.Movie{Frames: make([]bus.MovieFrame, 2)}, nil
+}
+
+func TestMovieFilenames(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&recordBus{})
+	ctx := context.Background()
+
+	if _, err := s.StopRecording(ctx, &apiv1.MovieRequest{Filename: "run.movie"}); err == nil {
+		t.Error("Expected a filename to be refused without a movie folder")
+	}
+
+	dir := t.TempDir()
+	s.SetMovieDir(dir)
+	for _, name := range []string{"../run.movie", "runs/../../run.movie", filepath.Join(dir, "run.movie")} {
+		if _, err := s.StopRecording(ctx, &apiv1.MovieRequest{Filename: name}); err == nil {
+			t.Errorf("Expected %q to be refused", name)
+		}
+		if _, err := s.PlayMovie(ctx, &apiv1.MovieRequest{Filename: name}); err == nil {
+			t.Errorf("Expected reading %q to be refused", name)
+		}
+	}
+
+	if _, err := s.StopRecording(ctx, &apiv1.MovieRequest{Filename: "runs/run.movie"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "runs", "run.movie"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movie, err := bus.DecodeMovie(data); err != nil || len(movie.Frames) != 2 {
+		t.Errorf("Expected the recording saved in the movie folder, got %v", err)
+	}
+}
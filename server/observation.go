@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+)
+
+// maxFeatureLength is the widest feature that still decodes into a uint64
+const maxFeatureLength = 8
+
+// SetObservationSpec registers the memory features returned with every observation
+func (s *GRPCServer) SetObservationSpec(ctx context.Context, in *apiv1.ObservationSpec) (*apiv1.Empty, error) {
+	if _, err := s.busFor(ctx); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	features := make([]*apiv1.ObservationFeature, 0, len(in.Features))
+	for _, f := range in.Features {
+		if f.Name == "" {
+			return nil, fmt.Errorf("feature at $%04X has no name", f.Address)
+		}
+		if seen[f.Name] {
+			return nil, fmt.Errorf("duplicate feature %q", f.Name)
+		}
+		seen[f.Name] = true
+
+		length := f.Length
+		if length == 0 {
+			length = 1
+		}
+		if length > maxFeatureLength {
+			return nil, fmt.Errorf("feature %q is %d bytes (max %d)", f.Name, length, maxFeatureLength)
+		}
+		if f.Address > 0x10000-length {
+			return nil, fmt.Errorf("feature %q runs past the end of the address space", f.Name)
+		}
+		features = append(features, &apiv1.ObservationFeature{Name: f.Name, Address: f.Address, Length: length})
+	}
+
+	id := sessionID(ctx)
+	s.mu.Lock()
+	if s.specs == nil {
+		s.specs = make(map[string][]*apiv1.ObservationFeature)
+	}
+	s.specs[id] = features
+	s.mu.Unlock()
+
+	return &apiv1.Empty{}, nil
+}
+
+// observe builds an RL observation from the current emulator frame and registered features
+func (s *GRPCServer) observe(ctx context.Context, bus EmuInterface) *apiv1.Observation {
+	obs := &apiv1.Observation{
+		Pixels: bus.GetFramePixels(),
+		Frame:  uint64(bus.GetFrameNumber()),
+	}
+
+	s.mu.Lock()
+	features := s.specs[sessionID(ctx)]
+	s.mu.Unlock()
+
+	if len(features) > 0 {
+		obs.Features = make(map[string]uint64, len(features))
+		for _, f := range features {
+			var v uint64
+			for i, b := range bus.GetMemoryBlock(uint16(f.Address), int(f.Length)) {
+				v |= uint64(b) << (8 * i)
+			}
+			obs.Features[f.Name] = v
+		}
+	}
+	return obs
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+)
+
+func TestObservationSpec(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+	ctx := context.Background()
+
+	spec := &apiv1.ObservationSpec{Features: []*apiv1.ObservationFeature{
+		{Name: "lives", Address: 0x75A},
+		{Name: "score", Address: 0x10, Length: 2},
+	}}
+	if _, err := s.SetObservationSpec(ctx, spec); err != nil {
+		t.Fatal(err)
+	}
+
+	obs, err := s.StepFrame(ctx, &apiv1.StepRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obs.Features["lives"] != 0x5A {
+		t.Errorf("Expected lives 0x5A, got %X", obs.Features["lives"])
+	}
+	if obs.Features["score"] != 0x1110 {
+		t.Errorf("Expected little-endian score 0x1110, got %X", obs.Features["score"])
+	}
+}
+
+func TestObservationSpecValidation(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+
+	bad := []*apiv1.ObservationSpec{
+		{Features: []*apiv1.ObservationFeature{{Address: 0x10}}},
+		{Features: []*apiv1.ObservationFeature{{Name: "x", Address: 0x10}, {Name: "x", Address: 0x11}}},
+		{Features: []*apiv1.ObservationFeature{{Name: "x", Length: 9}}},
+		{Features: []*apiv1.ObservationFeature{{Name: "x", Address: 0xFFFF, Length: 2}}},
+		{Features: []*apiv1.ObservationFeature{{Name: "x", Address: 0xFFFFFFFF, Length: 2}}},
+	}
+	for i, spec := range bad {
+		if _, err := s.SetObservationSpec(context.Background(), spec); err == nil {
+			t.Errorf("Spec %d: expected a validation error", i)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/pacing"
+)
+
+// SetPacing assigns the meter the display loop reports frame and audio timing to
+func (s *GRPCServer) SetPacing(m *pacing.Meter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pacing = m
+}
+
+// GetPacingStats reports frame delivery and audio sync for the windowed instance.
+// Headless sessions run as fast as they are driven, so they have no pacing to measure.
+func (s *GRPCServer) GetPacingStats(ctx context.Context, in *apiv1.Empty) (*apiv1.PacingStats, error) {
+	s.mu.Lock()
+	m := s.pacing
+	s.mu.Unlock()
+	if m == nil || sessionID(ctx) != "" {
+		return nil, fmt.Errorf("pacing is only measured for the windowed emulator")
+	}
+	st := m.Stats()
+	return &apiv1.PacingStats{
+		Frames:         uint32(st.Frames),
+		MeanIntervalUs: st.MeanInterval.Microseconds(),
+		JitterUs:       st.Jitter.Microseconds(),
+		MaxIntervalUs:  st.MaxInterval.Microseconds(),
+		Dropped:        uint64(st.Dropped),
+		Duplicated:     uint64(st.Duplicated),
+		AudioQueuedUs:  st.AudioQueued.Microseconds(),
+		AudioDriftUs:   st.AudioDrift.Microseconds(),
+		AudioUnderruns: uint64(st.AudioUnderruns),
+	}, nil
+}
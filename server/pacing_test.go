@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/pacing"
+)
+
+func TestGetPacingStats(t *testing.T) {
+	s := NewGRPCServer()
+	if _, err := s.GetPacingStats(context.Background(), &apiv1.Empty{}); err == nil {
+		t.Error("Expected an error without a pacing meter")
+	}
+
+	m := pacing.NewMeter()
+	s.SetPacing(m)
+	start := time.Now()
+	m.Frame(start, 0)
+	m.Frame(start.Add(pacing.FrameInterval), 0)
+	m.Frame(start.Add(4*pacing.FrameInterval), 0) // Two frames late
+	st, err := s.GetPacingStats(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Frames != 2 || st.Dropped != 2 || st.MaxIntervalUs != (3*pacing.FrameInterval).Microseconds() {
+		t.Errorf("Unexpected pacing stats: %v", st)
+	}
+}
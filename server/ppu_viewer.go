@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+)
+
+// ReadNametables returns the logical nametables as currently mirrored
+func (s *GRPCServer) ReadNametables(ctx context.Context, in *apiv1.Empty) (*apiv1.MemoryBlockResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.MemoryBlockResponse{Data: bus.GetNametableMemory()}, nil
+}
+
+// ReadOAM returns sprite memory
+func (s *GRPCServer) ReadOAM(ctx context.Context, in *apiv1.Empty) (*apiv1.MemoryBlockResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.MemoryBlockResponse{Data: bus.GetOAM()}, nil
+}
+
+// ReadPalette returns palette RAM
+func (s *GRPCServer) ReadPalette(ctx context.Context, in *apiv1.Empty) (*apiv1.MemoryBlockResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.MemoryBlockResponse{Data: bus.GetPaletteRAM()}, nil
+}
+
+// GetPatternTableImage renders a pattern table with the requested palette
+func (s *GRPCServer) GetPatternTableImage(ctx context.Context, in *apiv1.PatternTableRequest) (*apiv1.FrameResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Table > 1 {
+		return nil, fmt.Errorf("pattern table must be 0 or 1, got %d", in.Table)
+	}
+	if in.Palette > 7 {
+		return nil, fmt.Errorf("palette must be 0-7, got %d", in.Palette)
+	}
+	return encodeImage(bus.GetPatternTableImage(int(in.Table), byte(in.Palette)), in.Format)
+}
+
+// GetNametableImage renders all four nametables
+func (s *GRPCServer) GetNametableImage(ctx context.Context, in *apiv1.FrameRequest) (*apiv1.FrameResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return encodeImage(bus.GetNametableImage(), in)
+}
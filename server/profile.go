@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// StartProfile starts a new instruction profile, discarding the previous one
+func (s *GRPCServer) StartProfile(ctx context.Context, in *apiv1.Empty) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bus.StartProfile()
+	return &apiv1.Empty{}, nil
+}
+
+// StopProfile stops profiling and returns the report
+func (s *GRPCServer) StopProfile(ctx context.Context, in *apiv1.Empty) (*apiv1.ProfileReport, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prof, ok := bus.StopProfile()
+	if !ok {
+		return nil, fmt.Errorf("no profile has been started")
+	}
+	return profileToProto(prof), nil
+}
+
+// GetProfile returns the running or most recent profile
+func (s *GRPCServer) GetProfile(ctx context.Context, in *apiv1.Empty) (*apiv1.ProfileReport, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prof, ok := bus.Profile()
+	if !ok {
+		return nil, fmt.Errorf("no profile has been started")
+	}
+	return profileToProto(prof), nil
+}
+
+func profileToProto(prof bus.Profile) *apiv1.ProfileReport {
+	report := &apiv1.ProfileReport{
+		Running:      prof.Running,
+		Instructions: prof.Instructions,
+		Frames:       uint64(prof.Frames),
+	}
+	for _, e := range prof.PCs {
+		report.Pcs = append(report.Pcs, &apiv1.ProfileEntry{Address: uint32(e.Addr), Count: e.Count})
+	}
+	for _, e := range prof.Subroutines {
+		report.Subroutines = append(report.Subroutines, &apiv1.ProfileEntry{Address: uint32(e.Addr), Count: e.Count})
+	}
+	return report
+}
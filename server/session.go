@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SessionMetadataKey is the gRPC metadata key selecting which emulator instance an RPC targets.
+// RPCs without it go to the bus attached with SetBus.
+const SessionMetadataKey = "session-id"
+
+// Each headless instance holds a full emulator, so a server hosts at most
+// DefaultMaxSessions of them and drops one no RPC has used for DefaultSessionIdle.
+const (
+	DefaultMaxSessions = 16
+	DefaultSessionIdle = 10 * time.Minute
+)
+
+// session is a headless instance created with CreateSession
+type session struct {
+	bus      EmuInterface
+	lastUsed time.Time
+}
+
+// SetSessionLimits caps the sessions CreateSession keeps and how long one may go unused
+// before it is dropped. Zero disables either limit.
+func (s *GRPCServer) SetSessionLimits(max int, idle time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxSessions = max
+	s.sessionIdle = idle
+}
+
+// SetSessionFactory registers the constructor used by CreateSession for new headless instances
+func (s *GRPCServer) SetSessionFactory(factory func() EmuInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.newSession = factory
+}
+
+// CreateSession starts a new independent emulator instance and returns its ID. It fails
+// with ResourceExhausted when the server already hosts its maximum number of sessions.
+func (s *GRPCServer) CreateSession(ctx context.Context, in *apiv1.Empty) (*apiv1.SessionResponse, error) {
+	s.mu.Lock()
+	factory := s.newSession
+	s.mu.Unlock()
+
+	if factory == nil {
+		return nil, fmt.Errorf("sessions are not supported by this server")
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expireSessions(time.Now())
+	if s.maxSessions > 0 && len(s.sessions) >= s.maxSessions {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many sessions: %d (max %d); destroy one first", len(s.sessions), s.maxSessions)
+	}
+	if s.sessions == nil {
+		s.sessions = make(map[string]*session)
+	}
+	s.sessions[id] = &session{bus: factory(), lastUsed: time.Now()}
+
+	return &apiv1.SessionResponse{SessionId: id}, nil
+}
+
+// DestroySession drops an emulator instance created with CreateSession
+func (s *GRPCServer) DestroySession(ctx context.Context, in *apiv1.SessionRequest) (*apiv1.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[in.SessionId]; !ok {
+		return nil, fmt.Errorf("unknown session %q", in.SessionId)
+	}
+	s.dropSession(in.SessionId)
+	return &apiv1.Empty{}, nil
+}
+
+// dropSession forgets a session and its observation spec. The caller holds s.mu.
+func (s *GRPCServer) dropSession(id string) {
+	delete(s.sessions, id)
+	delete(s.specs, id)
+}
+
+// expireSessions drops the sessions no RPC has used within the idle timeout. The caller
+// holds s.mu.
+func (s *GRPCServer) expireSessions(now time.Time) {
+	if s.sessionIdle <= 0 {
+		return
+	}
+	for id, sess := range s.sessions {
+		if now.Sub(sess.lastUsed) > s.sessionIdle {
+			s.dropSession(id)
+		}
+	}
+}
+
+// busFor resolves the emulator instance an RPC targets from its session metadata
+func (s *GRPCServer) busFor(ctx context.Context) (EmuInterface, error) {
+	id := sessionID(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == "" {
+		if s.emuBus == nil {
+			return nil, fmt.Errorf("emulator bus not connected")
+		}
+		return s.emuBus, nil
+	}
+
+	sess, ok := s.sessions[id]
+	now := time.Now()
+	if ok && s.sessionIdle > 0 && now.Sub(sess.lastUsed) > s.sessionIdle {
+		s.dropSession(id)
+		ok = false
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown session %q", id)
+	}
+	sess.lastUsed = now
+	return sess.bus, nil
+}
+
+// sessionID extracts the session ID from the incoming request metadata, if any
+func sessionID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ids := md.Get(SessionMetadataKey); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+func newSessionID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
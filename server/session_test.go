@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeBus is a minimal EmuInterface that tracks the frame count, runs frame hooks and
+// serves fake memory
+type fakeBus struct {
+	EmuInterface
+	mu     sync.Mutex
+	frame  int
+	p1, p2 [8]bool
+	hooks  map[bus.HookID]func(int)
+	nextID bus.HookID
+}
+
+func (f *fakeBus) StepFrame(p1, p2 [8]bool, frames int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.p1, f.p2 = p1, p2
+	for i := 0; i < frames; i++ {
+		f.frame++
+		for _, fn := range f.hooks {
+			fn(f.frame)
+		}
+	}
+}
+
+func (f *fakeBus) OnFrame(fn func(frame int)) bus.HookID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hooks == nil {
+		f.hooks = make(map[bus.HookID]func(int))
+	}
+	f.nextID++
+	f.hooks[f.nextID] = fn
+	return f.nextID
+}
+
+func (f *fakeBus) RemoveHook(id bus.HookID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.hooks, id)
+}
+
+func (f *fakeBus) hookCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.hooks)
+}
+
+// Input and GetFrameNumber are called from hooks, which already hold f.mu
+func (f *fakeBus) Input() (p1, p2 [8]bool) { return f.p1, f.p2 }
+func (f *fakeBus) GetFrameNumber() int     { return f.frame }
+func (f *fakeBus) GetFramePixels() []byte  { return make([]byte, 256*240*4) }
+func (f *fakeBus) FrameHash() uint64       { return bus.HashPixels(f.GetFramePixels()) }
+func (f *fakeBus) GetMemoryBlock(addr uint16, size int) []byte {
+	block := make([]byte, size)
+	for i := range block {
+		block[i] = byte(addr) + byte(i)
+	}
+	return block
+}
+
+func withSession(id string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(SessionMetadataKey, id))
+}
+
+func TestSessionsAreIndependent(t *testing.T) {
+	s := NewGRPCServer()
+	def := &fakeBus{}
+	s.SetBus(def)
+	s.SetSessionFactory(func() EmuInterface { return &fakeBus{} })
+
+	resp, err := s.CreateSession(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := withSession(resp.SessionId)
+
+	obs, err := s.StepFrame(ctx, &apiv1.StepRequest{Frames: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obs.Frame != 3 {
+		t.Errorf("Expected session at frame 3, got %d", obs.Frame)
+	}
+	if def.frame != 0 {
+		t.Errorf("Stepping a session advanced the default bus to frame %d", def.frame)
+	}
+
+	if _, err := s.StepFrame(context.Background(), &apiv1.StepRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if def.frame != 1 {
+		t.Errorf("Expected default bus at frame 1, got %d", def.frame)
+	}
+
+	if _, err := s.DestroySession(context.Background(), &apiv1.SessionRequest{SessionId: resp.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StepFrame(ctx, &apiv1.StepRequest{}); err == nil {
+		t.Error("Expected an error for a destroyed session")
+	}
+}
+
+func TestCreateSessionWithoutFactory(t *testing.T) {
+	s := NewGRPCServer()
+	if _, err := s.CreateSession(context.Background(), &apiv1.Empty{}); err == nil {
+		t.Error("Expected an error when no session factory is set")
+	}
+}
+
+func TestCreateSessionLimit(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetSessionFactory(func() EmuInterface { return &fakeBus{} })
+	s.SetSessionLimits(2, 0)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		resp, err := s.CreateSession(context.Background(), &apiv1.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, resp.SessionId)
+	}
+	if _, err := s.CreateSession(context.Background(), &apiv1.Empty{}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted past the limit, got %v", err)
+	}
+
+	if _, err := s.DestroySession(context.Background(), &apiv1.SessionRequest{SessionId: ids[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateSession(context.Background(), &apiv1.Empty{}); err != nil {
+		t.Errorf("Expected a session once one was destroyed, got %v", err)
+	}
+}
+
+func TestIdleSessionsExpire(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetSessionFactory(func() EmuInterface { return &fakeBus{} })
+	s.SetSessionLimits(1, 50*time.Millisecond)
+
+	idle, err := s.CreateSession(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// The idle session no longer counts against the limit
+	used, err := s.CreateSession(context.Background(), &apiv1.Empty{})
+	if err != nil {
+		t.Fatalf("Expected the idle session to make room, got %v", err)
+	}
+	if _, err := s.StepFrame(withSession(idle.SessionId), &apiv1.StepRequest{}); err == nil {
+		t.Error("Expected an error for an expired session")
+	}
+
+	// RPCs keep a session alive
+	for i := 0; i < 4; i++ {
+		time.Sleep(20 * time.Millisecond)
+		if _, err := s.StepFrame(withSession(used.SessionId), &apiv1.StepRequest{}); err != nil {
+			t.Fatalf("Expected a session in use to stay alive, got %v", err)
+		}
+	}
+}
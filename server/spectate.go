@@ -0,0 +1,98 @@
+package server
+
+import (
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// spectatorBuffer is how many frames a spectator may fall behind before it is dropped
+const spectatorBuffer = 600
+
+// spectatorFrame is what the emulation goroutine captures for a spectator at each frame start
+type spectatorFrame struct {
+	frame  int
+	p1, p2 [8]bool
+	hash   uint64
+	pixels []byte // Only captured when the spectator wants video
+}
+
+// Spectate streams the input (and optionally video) of a running game to a read-only client
+func (s *GRPCServer) Spectate(in *apiv1.SpectateRequest, stream grpc.ServerStreamingServer[apiv1.SpectatorUpdate]) error {
+	bus, err := s.busFor(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	frames := make(chan spectatorFrame, spectatorBuffer)
+	lagged := make(chan struct{})
+	id := bus.OnFrame(func(frame int) {
+		// Runs on the emulation goroutine, so never block it
+		f := spectatorFrame{frame: frame, hash: bus.FrameHash()}
+		f.p1, f.p2 = bus.Input()
+		if in.Video {
+			f.pixels = append([]byte(nil), bus.GetFramePixels()...)
+		}
+		select {
+		case frames <- f:
+		default:
+			select {
+			case <-lagged:
+			default:
+				close(lagged)
+			}
+		}
+	})
+	defer bus.RemoveHook(id)
+
+	delay := int(in.DelayFrames)
+	var pending []spectatorFrame
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-lagged:
+			return status.Error(codes.ResourceExhausted, "spectator fell too far behind")
+		case f := <-frames:
+			pending = append(pending, f)
+		}
+
+		// Release everything that is now at least delay frames old
+		latest := pending[len(pending)-1].frame
+		n := 0
+		for n < len(pending) && pending[n].frame <= latest-delay {
+			if err := s.sendSpectatorUpdate(stream, in, pending[n]); err != nil {
+				return err
+			}
+			n++
+		}
+		pending = pending[n:]
+	}
+}
+
+func (s *GRPCServer) sendSpectatorUpdate(stream grpc.ServerStreamingServer[apiv1.SpectatorUpdate], in *apiv1.SpectateRequest, f spectatorFrame) error {
+	update := &apiv1.SpectatorUpdate{
+		Frame: uint64(f.frame),
+		P1:    inputFromButtons(1, f.p1),
+		P2:    inputFromButtons(2, f.p2),
+		Hash:  f.hash,
+	}
+	if in.Video {
+		video, err := encodeFrame(f.pixels, in.Format)
+		if err != nil {
+			return err
+		}
+		update.Video = video
+	}
+	return stream.Send(update)
+}
+
+// inputFromButtons is the inverse of buttonsFromInput
+func inputFromButtons(player int32, b [8]bool) *apiv1.InputState {
+	return &apiv1.InputState{
+		PlayerIndex: player,
+		A:           b[0], B: b[1], Select: b[2], Start: b[3],
+		Up: b[4], Down: b[5], Left: b[6], Right: b[7],
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"google.golang.org/grpc"
+)
+
+// spectatorStream hands every update to the test over a channel
+type spectatorStream struct {
+	grpc.ServerStreamingServer[apiv1.SpectatorUpdate]
+	ctx     context.Context
+	updates chan *apiv1.SpectatorUpdate
+}
+
+func (s *spectatorStream) Context() context.Context { return s.ctx }
+func (s *spectatorStream) Send(u *apiv1.SpectatorUpdate) error {
+	s.updates <- u
+	return nil
+}
+
+func TestSpectateDelaysInput(t *testing.T) {
+	s := NewGRPCServer()
+	fake := &fakeBus{}
+	s.SetBus(fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &spectatorStream{ctx: ctx, updates: make(chan *apiv1.SpectatorUpdate, 16)}
+	done := make(chan error)
+	go func() { done <- s.Spectate(&apiv1.SpectateRequest{DelayFrames: 2}, stream) }()
+
+	for fake.hookCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	fake.StepFrame([8]bool{true}, [8]bool{}, 1)
+	fake.StepFrame([8]bool{}, [8]bool{false, false, false, true}, 2)
+
+	// Three frames played with a delay of two releases only the first
+	select {
+	case u := <-stream.updates:
+		if u.Frame != 1 || !u.P1.A || u.P2.Start {
+			t.Errorf("Unexpected first update: %v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the first update")
+	}
+	select {
+	case u := <-stream.updates:
+		t.Errorf("Update for frame %d released before its delay", u.Frame)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	fake.StepFrame([8]bool{}, [8]bool{}, 1)
+	select {
+	case u := <-stream.updates:
+		if u.Frame != 2 || u.P1.A || !u.P2.Start {
+			t.Errorf("Unexpected second update: %v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the second update")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if fake.hookCount() != 0 {
+		t.Error("Spectate left its frame hook registered")
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// RunUntil resumes the emulator and waits for a stop condition
+func (s *GRPCServer) RunUntil(ctx context.Context, in *apiv1.RunUntilRequest) (*apiv1.RunUntilResponse, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cond, err := stopCondition(in)
+	if err != nil {
+		return nil, err
+	}
+
+	done := bus.RunUntil(cond)
+	var reached bool
+	select {
+	case reached = <-done:
+	case <-ctx.Done():
+		// The caller gave up (e.g. Ctrl-C in vdb), so leave the emulator where it is
+		bus.SetPaused(true)
+		return nil, ctx.Err()
+	}
+
+	_, _, _, _, _, pc, _ := bus.GetCPUState()
+	scanline, dot := bus.RasterPosition()
+	return &apiv1.RunUntilResponse{
+		Reached:  reached,
+		Pc:       uint32(pc),
+		Scanline: int32(scanline),
+		Dot:      uint32(dot),
+		Frame:    uint64(bus.GetFrameNumber()),
+	}, nil
+}
+
+// stopCondition converts and validates a RunUntil request
+func stopCondition(in *apiv1.RunUntilRequest) (bus.StopCondition, error) {
+	switch in.Condition {
+	case apiv1.StopCondition_STOP_AT_ADDRESS:
+		if in.Address > 0xFFFF {
+			return bus.StopCondition{}, fmt.Errorf("invalid address $%X", in.Address)
+		}
+		return bus.StopCondition{Kind: bus.StopAtAddress, Addr: uint16(in.Address)}, nil
+	case apiv1.StopCondition_STOP_AT_SCANLINE:
+		if in.Scanline < -1 || in.Scanline > 260 {
+			return bus.StopCondition{}, fmt.Errorf("invalid scanline %d (want -1 to 260)", in.Scanline)
+		}
+		return bus.StopCondition{Kind: bus.StopAtScanline, Scanline: int(in.Scanline)}, nil
+	case apiv1.StopCondition_STOP_AT_VBLANK:
+		return bus.StopCondition{Kind: bus.StopAtVBlank}, nil
+	case apiv1.StopCondition_STOP_AT_FRAME:
+		return bus.StopCondition{Kind: bus.StopAtFrame, Frame: int(in.Frame)}, nil
+	}
+	return bus.StopCondition{}, fmt.Errorf("unknown stop condition %v", in.Condition)
+}
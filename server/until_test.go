@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// untilBus stops immediately at the requested address, or never when hang is set
+type untilBus struct {
+	fakeBus
+	hang   bool
+	paused bool
+	pc     uint16
+}
+
+func (b *untilBus) RunUntil(cond bus.StopCondition) <-chan bool {
+	done := make(chan bool, 1)
+	if !b.hang {
+		b.pc = cond.Addr
+		done <- true
+	}
+	return done
+}
+
+func (b *untilBus) SetPaused(paused bool)               { b.paused = paused }
+func (b *untilBus) RasterPosition() (scanline, dot int) { return 241, 3 }
+func (b *untilBus) GetCPUState() (a, x, y, sp, p byte, pc uint16, cycles int) {
+	return 0, 0, 0, 0xFD, 0x24, b.pc, 0
+}
+
+func TestRunUntil(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&untilBus{})
+
+	resp, err := s.RunUntil(context.Background(), &apiv1.RunUntilRequest{Condition: apiv1.StopCondition_STOP_AT_ADDRESS, Address: 0xC123})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Reached || resp.Pc != 0xC123 || resp.Scanline != 241 || resp.Dot != 3 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	if _, err := s.RunUntil(context.Background(), &apiv1.RunUntilRequest{Condition: apiv1.StopCondition_STOP_AT_SCANLINE, Scanline: 261}); err == nil {
+		t.Error("Expected an error for scanline 261")
+	}
+}
+
+func TestRunUntilCancelPauses(t *testing.T) {
+	b := &untilBus{hang: true}
+	s := NewGRPCServer()
+	s.SetBus(b)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.RunUntil(ctx, &apiv1.RunUntilRequest{Condition: apiv1.StopCondition_STOP_AT_VBLANK}); err == nil {
+		t.Error("Expected the cancelled call to fail")
+	}
+	if !b.paused {
+		t.Error("Expected cancelling RunUntil to pause the emulator")
+	}
+}
+
+func (b *untilBus) RequestStep() <-chan struct{} {
+	done := make(chan struct{})
+	if !b.hang {
+		b.pc++
+		close(done)
+	}
+	return done
+}
+
+func TestStepWaitsForTheEmulator(t *testing.T) {
+	b := &untilBus{}
+	s := NewGRPCServer()
+	s.SetBus(b)
+	if _, err := s.Step(context.Background(), &apiv1.Empty{}); err != nil || b.pc != 1 {
+		t.Errorf("Expected Step to return after the step, got pc %d (err=%v)", b.pc, err)
+	}
+
+	// With nothing clocking the emulator, a cancelled Step pauses instead of stepping later
+	b.hang = true
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Step(ctx, &apiv1.Empty{}); err == nil || !b.paused {
+		t.Errorf("Expected the cancelled Step to fail and pause, got err=%v paused=%v", err, b.paused)
+	}
+}
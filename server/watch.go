@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"github.com/meadori/vibemulator/bus"
+)
+
+// AddWatchpoint installs a read or write watchpoint
+func (s *GRPCServer) AddWatchpoint(ctx context.Context, in *apiv1.Watchpoint) (*apiv1.Watchpoint, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	end := in.End
+	if end == 0 {
+		end = in.Start
+	}
+	if in.Start > 0xFFFF || end > 0xFFFF || end < in.Start {
+		return nil, fmt.Errorf("invalid watch range $%04X-$%04X", in.Start, end)
+	}
+	return watchpointToProto(bus.AddWatchpoint(uint16(in.Start), uint16(end), in.Write)), nil
+}
+
+// RemoveWatchpoint deletes a watchpoint by ID
+func (s *GRPCServer) RemoveWatchpoint(ctx context.Context, in *apiv1.Watchpoint) (*apiv1.Empty, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bus.RemoveWatchpoint(hookID(in.Id)) {
+		return nil, fmt.Errorf("no watchpoint %d", in.Id)
+	}
+	return &apiv1.Empty{}, nil
+}
+
+// ListWatchpoints returns the installed watchpoints
+func (s *GRPCServer) ListWatchpoints(ctx context.Context, in *apiv1.Empty) (*apiv1.WatchpointList, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &apiv1.WatchpointList{}
+	for _, w := range bus.Watchpoints() {
+		list.Watchpoints = append(list.Watchpoints, watchpointToProto(w))
+	}
+	return list, nil
+}
+
+// GetWatchHit returns and clears the last watchpoint hit
+func (s *GRPCServer) GetWatchHit(ctx context.Context, in *apiv1.Empty) (*apiv1.WatchHit, error) {
+	bus, err := s.busFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hit, ok := bus.TakeWatchHit()
+	if !ok {
+		return &apiv1.WatchHit{}, nil
+	}
+	return &apiv1.WatchHit{
+		Hit:        true,
+		Watchpoint: watchpointToProto(hit.Watchpoint),
+		Address:    uint32(hit.Addr),
+		Write:      hit.Write,
+		OldValue:   uint32(hit.Old),
+		HasOld:     hit.HasOld,
+		NewValue:   uint32(hit.New),
+		Pc:         uint32(hit.PC),
+		Frame:      uint64(hit.Frame),
+	}, nil
+}
+
+func hookID(id uint32) bus.HookID {
+	return bus.HookID(id)
+}
+
+func watchpointToProto(w bus.Watchpoint) *apiv1.Watchpoint {
+	return &apiv1.Watchpoint{Id: uint32(w.ID), Start: uint32(w.Start), End: uint32(w.End), Write: w.Write}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"golang.org/x/net/websocket"
+)
+
+//go:embed web/play.html
+var playPage []byte
+
+// handlePlay serves the remote-play page that talks to /ws
+func handlePlay(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(playPage)
+}
+
+// SetAllowedOrigins lists the origins, such as "https://example.com", whose pages may
+// open the WebSocket besides the gateway's own
+func (s *GRPCServer) SetAllowedOrigins(origins []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowedOrigins = origins
+}
+
+// checkOrigin refuses WebSocket handshakes from pages on other origins unless they are
+// allowed, so a site the user happens to visit can't drive the emulator. Browsers always
+// send Origin; clients that don't are not pages and are let through.
+func (s *GRPCServer) checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	config.Origin = origin
+	if origin == nil || s.originAllowed(origin, r.Host) {
+		return nil
+	}
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
+
+// handleWebSocket streams encoded frames to the client as binary messages and accepts
+// controller updates as JSON text messages shaped like InputState, e.g.
+// {"player_index":1,"a":true,"right":true}. Query parameters select the encoding
+// (format=jpeg|png, default jpeg), the frame rate (fps, default 30) and the JPEG quality.
+// Handshakes from other sites' pages are refused by checkOrigin.
+func (s *GRPCServer) handleWebSocket(ctx context.Context) websocket.Server {
+	return websocket.Server{Handshake: s.checkOrigin, Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+		query := ws.Request().URL.Query()
+
+		bus, err := s.busFor(ctx)
+		if err != nil {
+			websocket.Message.Send(ws, err.Error())
+			return
+		}
+		// Input for the default instance goes through the display, like StreamInput
+		var inputBus EmuInterface
+		if sessionID(ctx) != "" {
+			inputBus = bus
+		}
+
+		// The reader stops the frame loop when the client goes away
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer cancel()
+			for {
+				var req apiv1.InputState
+				if err := websocket.JSON.Receive(ws, &req); err != nil {
+					// Closing the socket also unblocks a send in progress
+					ws.Close()
+					return
+				}
+				if err := s.applyInput(inputBus, &req); err != nil {
+					websocket.Message.Send(ws, err.Error())
+				}
+			}
+		}()
+
+		fps := 30
+		if v, err := strconv.Atoi(query.Get("fps")); err == nil && v > 0 && v <= 60 {
+			fps = v
+		}
+		quality := 80
+		if v, err := strconv.Atoi(query.Get("quality")); err == nil && v > 0 && v <= 100 {
+			quality = v
+		}
+		usePNG := query.Get("format") == "png"
+
+		// Encoding happens on the shared pool so a slow connection only costs this client frames
+		type encoded struct {
+			data []byte
+			err  error
+		}
+		enc := newFrameEncoder[encoded](frameEncoders())
+		go pollFrames(ctx, bus, time.Second/time.Duration(fps), func(frame int) {
+			img := image.NewRGBA(image.Rect(0, 0, 256, 240))
+			copy(img.Pix, bus.GetFramePixels())
+			enc.submit(func() encoded {
+				var buf bytes.Buffer
+				var err error
+				if usePNG {
+					err = png.Encode(&buf, img)
+				} else {
+					err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+				}
+				return encoded{buf.Bytes(), err}
+			})
+		})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case f := <-enc.frames():
+				if f.err != nil {
+					return
+				}
+				if err := websocket.Message.Send(ws, f.data); err != nil {
+					return
+				}
+			}
+		}
+	}}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"bytes"
+	"image/png"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/meadori/vibemulator/api/v1"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebSocketStream(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+	ts := httptest.NewServer(s.HTTPHandler(""))
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws?format=png&fps=60"
+	ws, err := websocket.Dial(url, "", ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	var frame []byte
+	if err := websocket.Message.Receive(ws, &frame); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := png.Decode(bytes.NewReader(frame)); err != nil {
+		t.Errorf("Expected a PNG frame: %v", err)
+	}
+
+	if err := websocket.JSON.Send(ws, &apiv1.InputState{PlayerIndex: 2, Start: true}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for !s.GetP2State()[3] {
+		if time.Now().After(deadline) {
+			t.Fatal("Input sent over the WebSocket never reached player 2")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	s := NewGRPCServer()
+	s.SetBus(&fakeBus{})
+	ts := httptest.NewServer(s.HTTPHandler(""))
+	defer ts.Close()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+
+	if ws, err := websocket.Dial(url, "", "https://evil.example"); err == nil {
+		ws.Close()
+		t.Error("Expected a handshake from another origin to be refused")
+	}
+
+	s.SetAllowedOrigins([]string{"https://evil.example"})
+	ws, err := websocket.Dial(url, "", "https://evil.example")
+	if err != nil {
+		t.Fatalf("Expected an allowed origin to connect: %v", err)
+	}
+	ws.Close()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/shm"
+)
+
+func addSharedMemoryFlag(fs *flag.FlagSet) *string {
+	return fs.String("shm", "", "publish every frame and the CPU RAM to this file for local readers, e.g. /dev/shm/vibemulator (see package shm)")
+}
+
+// publishFrames writes each frame b completes, with the CPU RAM, to the shared-memory
+// file at path. The returned function stops publishing.
+func publishFrames(b *bus.Bus, path string) func() {
+	w, err := shm.Create(path, shm.DefaultSlots)
+	if err != nil {
+		log.Fatalf("Error creating shared memory: %v", err)
+	}
+	var id bus.HookID
+	id = b.OnFrame(func(frame int) {
+		if err := w.Publish(frame, b.PPU.GetFrame().Pix, b.GetMemoryBlock(0, shm.RAMSize)); err != nil {
+			log.Printf("Stopped publishing frames: %v", err)
+			b.RemoveHook(id)
+		}
+	})
+	log.Printf("Publishing frames to %s", path)
+	return func() {
+		b.RemoveHook(id)
+		w.Close()
+	}
+}
@@ -0,0 +1,197 @@
+// Package shm publishes completed frames and the 2KB of CPU RAM into a file that local
+// processes, such as a Python RL trainer, map into memory, so they can read
+// observations without gRPC serialization. Put the file on a RAM-backed filesystem
+// such as /dev/shm so it never touches a disk.
+//
+// The file is a 64-byte header followed by a ring of slots. Integers are little-endian.
+//
+//	Header
+//	  0  [8]byte  magic "VIBESHM1"
+//	  8  uint32   layout version (1)
+//	 12  uint32   number of slots
+//	 16  uint32   frame width in pixels (256)
+//	 20  uint32   frame height in pixels (240)
+//	 24  uint32   RAM size in bytes (2048)
+//	 28  uint32   slot size in bytes
+//	 32  uint64   sequence number of the latest complete slot; 0 before the first
+//
+//	Slot, at 64 + ((seq-1) mod slots) * slot size
+//	  0  uint64   sequence number of the data in the slot; 0 while it is being written
+//	  8  uint64   PPU frame number
+//	 16  RGBA pixels, width*height*4 bytes, row by row
+//	  …  RAM ($0000-$07FF)
+//
+// A writer fills a slot, then stores its sequence number, then the header's. To read,
+// load the header's sequence number n, and check the slot's is n before and after
+// copying it out; if it has changed the writer lapped the reader, so start again.
+package shm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	Magic   = "VIBESHM1"
+	Version = 1
+
+	Width   = 256
+	Height  = 240
+	RAMSize = 2048
+
+	// DefaultSlots gives readers three frames to copy one out before it is overwritten.
+	DefaultSlots = 4
+
+	headerSize    = 64
+	latestOffset  = 32
+	slotHeader    = 16
+	frameSize     = Width * Height * 4
+	slotSize      = slotHeader + frameSize + RAMSize
+	maxSlots      = 256
+	seqOffset     = 0
+	frameOffset   = 8
+	pixelsOffset  = slotHeader
+	ramOffset     = slotHeader + frameSize
+	versionOffset = 8
+)
+
+var le = binary.LittleEndian
+
+// Slot is one published frame.
+type Slot struct {
+	Seq    uint64
+	Frame  uint64
+	Pixels []byte // RGBA, Width*Height*4 bytes
+	RAM    []byte
+}
+
+// Writer publishes frames to a file.
+type Writer struct {
+	f     *os.File
+	slots int
+	seq   uint64
+	buf   []byte
+}
+
+// Create creates or truncates the file at path with room for slots frames.
+func Create(path string, slots int) (*Writer, error) {
+	if slots < 2 || slots > maxSlots {
+		return nil, fmt.Errorf("invalid slot count %d: must be 2-%d", slots, maxSlots)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	header := make([]byte, headerSize)
+	copy(header, Magic)
+	for i, v := range []uint32{Version, uint32(slots), Width, Height, RAMSize, slotSize} {
+		le.PutUint32(header[versionOffset+4*i:], v)
+	}
+	if _, err := f.WriteAt(header, 0); err == nil {
+		err = f.Truncate(headerSize + int64(slots)*slotSize)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return &Writer{f: f, slots: slots, buf: make([]byte, slotSize)}, nil
+}
+
+// Publish writes a frame's pixels and RAM to the next slot.
+func (w *Writer) Publish(frame int, pixels, ram []byte) error {
+	if len(pixels) != frameSize || len(ram) != RAMSize {
+		return fmt.Errorf("expected %d bytes of pixels and %d of RAM, got %d and %d", frameSize, RAMSize, len(pixels), len(ram))
+	}
+	w.seq++
+	off := headerSize + int64((w.seq-1)%uint64(w.slots))*slotSize
+
+	// Mark the slot as being written before overwriting it
+	var zero [8]byte
+	if _, err := w.f.WriteAt(zero[:], off+seqOffset); err != nil {
+		return err
+	}
+	le.PutUint64(w.buf[frameOffset:], uint64(frame))
+	copy(w.buf[pixelsOffset:], pixels)
+	copy(w.buf[ramOffset:], ram)
+	if _, err := w.f.WriteAt(w.buf[frameOffset:], off+frameOffset); err != nil {
+		return err
+	}
+	var seq [8]byte
+	le.PutUint64(seq[:], w.seq)
+	if _, err := w.f.WriteAt(seq[:], off+seqOffset); err != nil {
+		return err
+	}
+	_, err := w.f.WriteAt(seq[:], latestOffset)
+	return err
+}
+
+// Close closes the file, leaving it in place for readers.
+func (w *Writer) Close() error {
+	return w.f.Close()
+}
+
+// ErrNoFrame is returned by Reader.Latest before the first frame is published.
+var ErrNoFrame = errors.New("no frame published yet")
+
+// Reader reads published frames; it is the reference for readers in other languages.
+type Reader struct {
+	f        *os.File
+	slots    int
+	slotSize int
+}
+
+// Open opens a file created by Create.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	header := make([]byte, headerSize)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if !bytes.Equal(header[:len(Magic)], []byte(Magic)) || le.Uint32(header[versionOffset:]) != Version {
+		f.Close()
+		return nil, fmt.Errorf("%s is not a version %d frame file", path, Version)
+	}
+	return &Reader{f: f, slots: int(le.Uint32(header[12:])), slotSize: int(le.Uint32(header[28:]))}, nil
+}
+
+// Latest copies out the most recently published frame.
+func (r *Reader) Latest() (Slot, error) {
+	var word [8]byte
+	for {
+		if _, err := r.f.ReadAt(word[:], latestOffset); err != nil {
+			return Slot{}, err
+		}
+		n := le.Uint64(word[:])
+		if n == 0 {
+			return Slot{}, ErrNoFrame
+		}
+		off := headerSize + int64((n-1)%uint64(r.slots))*int64(r.slotSize)
+		buf := make([]byte, r.slotSize)
+		if _, err := r.f.ReadAt(buf, off); err != nil {
+			return Slot{}, err
+		}
+		if le.Uint64(buf[seqOffset:]) != n {
+			continue
+		}
+		// The writer may have lapped us while we copied
+		if _, err := r.f.ReadAt(word[:], off+seqOffset); err != nil {
+			return Slot{}, err
+		}
+		if le.Uint64(word[:]) != n {
+			continue
+		}
+		return Slot{Seq: n, Frame: le.Uint64(buf[frameOffset:]), Pixels: buf[pixelsOffset:ramOffset], RAM: buf[ramOffset:]}, nil
+	}
+}
+
+// Close closes the file.
+func (r *Reader) Close() error {
+	return r.f.Close()
+}
@@ -0,0 +1,54 @@
+package shm
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestPublishAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frames")
+	w, err := Create(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	r, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, err := r.Latest(); !errors.Is(err, ErrNoFrame) {
+		t.Errorf("Expected ErrNoFrame before publishing, got %v", err)
+	}
+	pixels, ram := make([]byte, Width*Height*4), make([]byte, RAMSize)
+	// Go round the ring more than once
+	for frame := 1; frame <= 5; frame++ {
+		pixels[0], ram[RAMSize-1] = byte(frame), byte(frame*2)
+		if err := w.Publish(frame*10, pixels, ram); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s, err := r.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Seq != 5 || s.Frame != 50 || s.Pixels[0] != 5 || s.RAM[RAMSize-1] != 10 {
+		t.Errorf("Expected the fifth frame, got seq %d frame %d", s.Seq, s.Frame)
+	}
+	if !bytes.Equal(s.Pixels, pixels) || !bytes.Equal(s.RAM, ram) {
+		t.Error("Expected the published pixels and RAM")
+	}
+
+	if err := w.Publish(1, pixels[:10], ram); err == nil {
+		t.Error("Expected an error for a short frame")
+	}
+	if _, err := Create(path, 1); err == nil {
+		t.Error("Expected an error for a single slot")
+	}
+	if _, err := Open(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error opening a missing file")
+	}
+}
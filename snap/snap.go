@@ -0,0 +1,107 @@
+// Package snap is a flat little-endian binary encoding for in-memory emulator
+// snapshots. Unlike gob it carries no type information and appends into a caller's
+// buffer, so taking a snapshot into a reused buffer does not allocate.
+package snap
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ErrShort is returned when a snapshot ends before all of its fields were read.
+var ErrShort = errors.New("snapshot is truncated")
+
+// Writer appends values to Buf.
+type Writer struct {
+	Buf []byte
+}
+
+func (w *Writer) U8(v byte) { w.Buf = append(w.Buf, v) }
+
+func (w *Writer) Bool(v bool) {
+	if v {
+		w.U8(1)
+	} else {
+		w.U8(0)
+	}
+}
+
+func (w *Writer) U16(v uint16) { w.Buf = binary.LittleEndian.AppendUint16(w.Buf, v) }
+func (w *Writer) U64(v uint64) { w.Buf = binary.LittleEndian.AppendUint64(w.Buf, v) }
+
+// Int writes v as 64 bits so snapshots don't depend on the platform's int size.
+func (w *Writer) Int(v int)         { w.U64(uint64(int64(v))) }
+func (w *Writer) Float64(v float64) { w.U64(math.Float64bits(v)) }
+
+// Raw writes b as is; the reader must know its length.
+func (w *Writer) Raw(b []byte) { w.Buf = append(w.Buf, b...) }
+
+// Bytes writes b with a length prefix.
+func (w *Writer) Bytes(b []byte) {
+	w.Buf = binary.LittleEndian.AppendUint32(w.Buf, uint32(len(b)))
+	w.Raw(b)
+}
+
+// Reader reads values back in the order they were written. After the input runs
+// out every read returns zero and Err reports ErrShort.
+type Reader struct {
+	buf []byte
+	err error
+}
+
+func NewReader(b []byte) Reader { return Reader{buf: b} }
+
+// Err returns ErrShort if any read ran past the end of the input.
+func (r *Reader) Err() error { return r.err }
+
+// Len returns the number of unread bytes.
+func (r *Reader) Len() int { return len(r.buf) }
+
+func (r *Reader) next(n int) []byte {
+	if r.err != nil || len(r.buf) < n {
+		r.err = ErrShort
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *Reader) U8() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *Reader) Bool() bool { return r.U8() != 0 }
+
+func (r *Reader) U16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *Reader) U64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *Reader) Int() int         { return int(int64(r.U64())) }
+func (r *Reader) Float64() float64 { return math.Float64frombits(r.U64()) }
+
+// Raw fills dst from the input.
+func (r *Reader) Raw(dst []byte) { copy(dst, r.next(len(dst))) }
+
+// Bytes returns a length-prefixed byte slice. It aliases the input rather than copying.
+func (r *Reader) Bytes() []byte {
+	b := r.next(4)
+	if b == nil {
+		return nil
+	}
+	return r.next(int(binary.LittleEndian.Uint32(b)))
+}
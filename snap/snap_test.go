@@ -0,0 +1,66 @@
+package snap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var w Writer
+	w.U8(0xAB)
+	w.Bool(true)
+	w.U16(0xBEEF)
+	w.U64(1 << 40)
+	w.Int(-5)
+	w.Float64(0.25)
+	w.Raw([]byte{1, 2})
+	w.Bytes([]byte("abc"))
+
+	r := NewReader(w.Buf)
+	if v := r.U8(); v != 0xAB {
+		t.Errorf("Expected U8 0xAB, got 0x%02X", v)
+	}
+	if !r.Bool() {
+		t.Error("Expected Bool true")
+	}
+	if v := r.U16(); v != 0xBEEF {
+		t.Errorf("Expected U16 0xBEEF, got 0x%04X", v)
+	}
+	if v := r.U64(); v != 1<<40 {
+		t.Errorf("Expected U64 1<<40, got %d", v)
+	}
+	if v := r.Int(); v != -5 {
+		t.Errorf("Expected Int -5, got %d", v)
+	}
+	if v := r.Float64(); v != 0.25 {
+		t.Errorf("Expected Float64 0.25, got %v", v)
+	}
+	raw := make([]byte, 2)
+	r.Raw(raw)
+	if !bytes.Equal(raw, []byte{1, 2}) {
+		t.Errorf("Expected Raw [1 2], got %v", raw)
+	}
+	if v := r.Bytes(); string(v) != "abc" {
+		t.Errorf("Expected Bytes \"abc\", got %q", v)
+	}
+	if r.Err() != nil || r.Len() != 0 {
+		t.Errorf("Expected a clean end of input, got err=%v len=%d", r.Err(), r.Len())
+	}
+}
+
+func TestShortInput(t *testing.T) {
+	var w Writer
+	w.Bytes([]byte("abcdef"))
+
+	r := NewReader(w.Buf[:5])
+	if v := r.Bytes(); v != nil {
+		t.Errorf("Expected nil from a truncated Bytes, got %q", v)
+	}
+	if v := r.U64(); v != 0 {
+		t.Errorf("Expected 0 after the input ran out, got %d", v)
+	}
+	if !errors.Is(r.Err(), ErrShort) {
+		t.Errorf("Expected ErrShort, got %v", r.Err())
+	}
+}
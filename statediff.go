@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+
+	"github.com/meadori/vibemulator/bus"
+)
+
+// summarizedRegions are the memories statediff lists as runs of changed bytes unless
+// -all is given, since a redrawn screen or a tile upload changes hundreds of them.
+var summarizedRegions = map[string]bool{"Nametable RAM": true, "CHR RAM": true}
+
+// stateDiffCmd compares two savestates of the same game and lists the RAM, VRAM and
+// pixels that differ, for finding which variables an action touches.
+func stateDiffCmd(args []string) {
+	fs := newFlagSet("statediff")
+	pngPath := fs.String("png", "", "write the second state's frame to this PNG, dimmed, with the pixels that changed in red")
+	all := fs.Bool("all", false, "list every changed byte of nametable and CHR RAM instead of runs of them")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	before, beforeSum := readStateFile(fs.Arg(0))
+	after, afterSum := readStateFile(fs.Arg(1))
+	if beforeSum != afterSum {
+		log.Printf("Warning: the savestates were taken on different ROMs (SHA-1 %x and %x)", beforeSum, afterSum)
+	}
+
+	d := bus.DiffStates(before, after)
+	if len(d.Regions) == 0 {
+		fmt.Println("Memory: no changes")
+	}
+	for _, r := range d.Regions {
+		fmt.Printf("%s: %d bytes changed\n", r.Name, len(r.Changes))
+		if !summarizedRegions[r.Name] || *all {
+			for _, c := range r.Changes {
+				fmt.Printf("  %s\n", c)
+			}
+			continue
+		}
+		for _, run := range r.Runs() {
+			if len(run) == 1 {
+				fmt.Printf("  %s\n", run[0])
+			} else {
+				fmt.Printf("  $%04X-$%04X: %d bytes\n", run[0].Addr, run[len(run)-1].Addr, len(run))
+			}
+		}
+	}
+	fmt.Printf("Frame: %d pixels changed\n", d.PixelsChanged)
+
+	if *pngPath != "" {
+		if err := writeDiffPNG(*pngPath, before.PPU.FrameBuffer, after.PPU.FrameBuffer); err != nil {
+			log.Fatalf("Failed to write %s: %v", *pngPath, err)
+		}
+	}
+}
+
+// readStateFile reads and decodes the savestate at path.
+func readStateFile(path string) (bus.State, [sha1.Size]byte) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Error reading savestate: %v", err)
+	}
+	s, sum, err := bus.ReadState(data)
+	if err != nil {
+		log.Fatalf("Error reading %s: %v", path, err)
+	}
+	return s, sum
+}
+
+// writeDiffPNG writes the after frame at a third of its brightness, so the changed
+// pixels stand out in red.
+func writeDiffPNG(path string, before, after []byte) error {
+	img := image.NewRGBA(image.Rect(0, 0, 256, 240))
+	if len(after) == len(img.Pix) {
+		for i := 0; i < len(after); i += 4 {
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = after[i]/3, after[i+1]/3, after[i+2]/3, 0xFF
+		}
+	}
+	for _, p := range bus.ChangedPixels(before, after) {
+		if p < 256*240 {
+			img.Set(p%256, p/256, color.RGBA{0xFF, 0x00, 0x00, 0xFF})
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,135 @@
+// Package storage lays out the files the emulator keeps between runs. They live in a
+// per-platform data directory, with a folder per ROM keyed by the ROM's SHA-1 so that
+// renaming or moving a ROM keeps its saves:
+//
+//	<data>/roms/<sha1>/battery.sav   battery-backed cartridge RAM
+//	<data>/roms/<sha1>/state.sav     the F5/F7 savestate
+//	<data>/roms/<sha1>/autosave.sav  the rolling crash-recovery savestate
+//	<data>/roms/<sha1>/screenshots/  F12 screenshots
+//	<data>/roms/<sha1>/bookmarks/    F6 bookmarks (see package bookmark)
+//	<data>/compat.json               the player's compatibility reports (see package compat)
+//	<data>/movies/                   movies gRPC clients save by name
+//
+// The data directory is $XDG_DATA_HOME/vibemulator (~/.local/share/vibemulator) on
+// Linux and the BSDs, %AppData%\vibemulator on Windows and
+// ~/Library/Application Support/vibemulator on macOS.
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// DataDir returns the platform's data directory for the emulator.
+func DataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		// These keep application data alongside configuration
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "vibemulator"), nil
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "vibemulator"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "vibemulator"), nil
+}
+
+// ROM is the folder holding one ROM's files.
+type ROM string
+
+// ForROM returns the folder under root for the ROM with the given SHA-1.
+func ForROM(root, romHash string) ROM {
+	return ROM(filepath.Join(root, "roms", romHash))
+}
+
+func (r ROM) Battery() string     { return filepath.Join(string(r), "battery.sav") }
+func (r ROM) State() string       { return filepath.Join(string(r), "state.sav") }
+func (r ROM) Autosave() string    { return filepath.Join(string(r), "autosave.sav") }
+func (r ROM) Screenshots() string { return filepath.Join(string(r), "screenshots") }
+func (r ROM) Bookmarks() string   { return filepath.Join(string(r), "bookmarks") }
+
+// Compat returns the file under root holding the player's compatibility reports.
+func Compat(root string) string {
+	return filepath.Join(root, "compat.json")
+}
+
+// Movies returns the folder under root that gRPC clients save and load movies in by name.
+func Movies(root string) string {
+	return filepath.Join(root, "movies")
+}
+
+// WriteFile writes data to path, creating its directory. The file is replaced
+// atomically so a crash never leaves a save half written.
+func WriteFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".save-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+// Battery keeps a cartridge's battery-backed RAM in a file. The RAM is the cartridge's
+// own, so it must only be used from the goroutine running the emulator.
+type Battery struct {
+	path  string
+	ram   []byte
+	saved []byte // The RAM as last written, so unchanged RAM isn't rewritten
+}
+
+// OpenBattery loads the file at path, if there is one, into ram and returns a Battery
+// that flushes ram back to it.
+func OpenBattery(path string, ram []byte) (*Battery, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	// A file of the wrong size is from another dump or emulator; keep what fits
+	copy(ram, data)
+	b := &Battery{path: path, ram: ram, saved: append([]byte(nil), ram...)}
+	if err == nil && len(data) != len(ram) {
+		return b, fmt.Errorf("%s holds %d bytes, expected %d", path, len(data), len(ram))
+	}
+	return b, nil
+}
+
+// Holds reports whether ram is the memory b saves. Cartridges get new RAM when they are
+// reinserted, at which point the battery has to be opened again.
+func (b *Battery) Holds(ram []byte) bool {
+	return b != nil && len(ram) > 0 && len(b.ram) == len(ram) && &b.ram[0] == &ram[0]
+}
+
+// Flush writes the RAM to the file if it changed since the last write.
+func (b *Battery) Flush() error {
+	if bytes.Equal(b.ram, b.saved) {
+		return nil
+	}
+	if err := WriteFile(b.path, b.ram); err != nil {
+		return err
+	}
+	copy(b.saved, b.ram)
+	return nil
+}
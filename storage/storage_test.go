@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDataDirFollowsXDG(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("XDG_DATA_HOME only applies on Unix")
+	}
+	t.Setenv("XDG_DATA_HOME", "/xdg/data")
+	dir, err := DataDir()
+	if err != nil || dir != filepath.Join("/xdg/data", "vibemulator") {
+		t.Errorf("Expected /xdg/data/vibemulator, got %q, %v", dir, err)
+	}
+
+	// Relative paths are invalid per the spec and ignored
+	t.Setenv("XDG_DATA_HOME", "data")
+	t.Setenv("HOME", "/home/me")
+	dir, err = DataDir()
+	if err != nil || dir != filepath.Join("/home/me", ".local", "share", "vibemulator") {
+		t.Errorf("Expected ~/.local/share/vibemulator, got %q, %v", dir, err)
+	}
+}
+
+func TestBatteryRoundTrip(t *testing.T) {
+	path := ForROM(t.TempDir(), "0123abcd").Battery()
+	ram := make([]byte, 8192)
+	b, err := OpenBattery(path, ram)
+	if err != nil {
+		t.Fatalf("Expected a missing file to open empty, got %v", err)
+	}
+	if err := b.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected unchanged RAM not to be written")
+	}
+
+	ram[0x123] = 0x42
+	if err := b.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	// Reinserting the cartridge gives it new RAM, which the file restores
+	fresh := make([]byte, 8192)
+	if b.Holds(fresh) || !b.Holds(ram) {
+		t.Errorf("Expected Holds to match only the RAM the battery was opened with")
+	}
+	if _, err := OpenBattery(path, fresh); err != nil {
+		t.Fatal(err)
+	}
+	if fresh[0x123] != 0x42 {
+		t.Errorf("Expected the saved byte to be restored, got %02X", fresh[0x123])
+	}
+}
+
+func TestBatteryWrongSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "battery.sav")
+	if err := WriteFile(path, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	ram := make([]byte, 8)
+	b, err := OpenBattery(path, ram)
+	if err == nil {
+		t.Errorf("Expected an error for a file of the wrong size")
+	}
+	if b == nil || ram[2] != 3 {
+		t.Errorf("Expected the bytes that fit to be loaded, got %v", ram)
+	}
+}
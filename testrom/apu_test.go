@@ -0,0 +1,21 @@
+package testrom
+
+import "testing"
+
+// The APU suites guard length counters, frame counter IRQ timing and DMC rates and DMA.
+// apu_test's 7-dmc_basics and 8-dmc_rates cover the DMC's timer and its rate table.
+
+func TestAPUTest(t *testing.T) {
+	runSuite(t, "apu_test", RunBlargg, 60*60)
+}
+
+// TestAPU2005 runs blargg's 2005 frame counter tests, which time length counter clocks
+// and the frame IRQ against $4017 writes and reset.
+func TestAPU2005(t *testing.T) {
+	runSuite(t, "blargg_apu_2005.07.30", RunLegacy, 60*20)
+}
+
+// TestSprDMAAndDMCDMA runs blargg's tests of a DMC fetch landing during an OAM DMA.
+func TestSprDMAAndDMCDMA(t *testing.T) {
+	runSuite(t, "sprdma_and_dmc_dma", RunBlargg, 60*60)
+}
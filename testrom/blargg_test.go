@@ -0,0 +1,91 @@
+package testrom
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestInstrTestV5 runs blargg's instr_test-v5 ROMs from testdata/instr_test-v5.
+// The ROMs aren't checked in; the test is skipped without them.
+func TestInstrTestV5(t *testing.T) {
+	runSuite(t, "instr_test-v5", RunBlargg, 60*60)
+}
+
+// TestCPUInterrupts runs blargg's cpu_interrupts_v2 ROMs, which time IRQs and NMIs
+// against CLI, SEI and PLP, BRK and each other.
+func TestCPUInterrupts(t *testing.T) {
+	runSuite(t, "cpu_interrupts_v2", RunBlargg, 60*60)
+}
+
+// blarggROM builds an MMC1 ROM that speaks the $6000 protocol: it reports code and
+// msg straight away, or only after a reset when needsReset is set.
+func blarggROM(code byte, msg string, needsReset bool) []byte {
+	sta := func(addr uint16) []byte { return []byte{0x8D, byte(addr), byte(addr >> 8)} }
+	lda := func(v byte) []byte { return []byte{0xA9, v} }
+
+	var prog []byte
+	emit := func(parts ...[]byte) {
+		for _, p := range parts {
+			prog = append(prog, p...)
+		}
+	}
+	emit(lda(0x80), sta(0x6000), lda(0xDE), sta(0x6001), lda(0xB0), sta(0x6002), lda(0x61), sta(0x6003))
+	if needsReset {
+		// $6100 survives the reset and tells the two runs apart
+		emit([]byte{0xAD, 0x00, 0x61}, []byte{0xD0, 11})
+		emit([]byte{0xEE, 0x00, 0x61}, lda(0x81), sta(0x6000))
+		wait := 0xC000 + len(prog)
+		emit([]byte{0x4C, byte(wait), byte(wait >> 8)})
+	}
+	for i, c := range []byte(msg + "\x00") {
+		emit(lda(c), sta(0x6004+uint16(i)))
+	}
+	emit(lda(code), sta(0x6000))
+	done := 0xC000 + len(prog)
+	emit([]byte{0x4C, byte(done), byte(done >> 8)})
+
+	header := []byte{'N', 'E', 'S', 0x1A, 0x02, 0x01, 0x10, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+	prg := make([]byte, 0x8000)
+	copy(prg[0x4000:], prog)
+	for _, v := range []int{0x7FFA, 0x7FFC, 0x7FFE} {
+		prg[v], prg[v+1] = 0x00, 0xC0
+	}
+	return append(append(header, prg...), make([]byte, 0x2000)...)
+}
+
+func TestRunBlargg(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       byte
+		msg        string
+		needsReset bool
+	}{
+		{"pass", 0, "\nPassed\n", false},
+		{"fail", 3, "Failed #3", false},
+		{"reset", 0, "Passed", true},
+	}
+	for _, tt := range tests {
+		res, err := RunBlargg(blarggROM(tt.code, tt.msg, tt.needsReset), 60)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if res.Code != tt.code || res.Message != strings.TrimSpace(tt.msg) {
+			t.Errorf("%s: Expected code %d %q, got %d %q", tt.name, tt.code, strings.TrimSpace(tt.msg), res.Code, res.Message)
+		}
+		if res.Passed() != (tt.code == 0) {
+			t.Errorf("%s: Expected Passed() to be %v", tt.name, tt.code == 0)
+		}
+		if tt.needsReset && res.Frames <= resetDelayFrames {
+			t.Errorf("%s: Expected the result only after the reset, got it at frame %d", tt.name, res.Frames)
+		}
+	}
+}
+
+func TestRunBlarggTimeout(t *testing.T) {
+	rom := blarggROM(0, "", false)
+	// Spin at $C000 before anything is written
+	copy(rom[16+0x4000:], []byte{0x4C, 0x00, 0xC0})
+	if _, err := RunBlargg(rom, 10); err == nil {
+		t.Error("Expected an error from a ROM that never reports")
+	}
+}
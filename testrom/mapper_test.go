@@ -0,0 +1,10 @@
+package testrom
+
+import "testing"
+
+// mmc3_test_2 covers MMC3 IRQ clocking, A12 filtering and counter reload edge cases.
+// The mapper follows MMC3B/C, so 6-MMC3_alt, which checks the MMC3A variant, is a
+// known failure in the compatibility matrix.
+func TestMMC3(t *testing.T) {
+	runSuite(t, "mmc3_test_2", RunBlargg, 60*60)
+}
@@ -0,0 +1,48 @@
+package testrom
+
+import "testing"
+
+// The PPU suites guard VBlank/NMI timing and sprite evaluation. Copy each suite's ROMs
+// into the matching testdata directory to run it.
+
+func TestPPUVblNMI(t *testing.T) {
+	runSuite(t, "ppu_vbl_nmi", RunBlargg, 60*60)
+}
+
+func TestSpriteHit(t *testing.T) {
+	runSuite(t, "sprite_hit_tests_2005.10.05", RunLegacy, 60*20)
+}
+
+func TestSpriteOverflow(t *testing.T) {
+	runSuite(t, "sprite_overflow_tests", RunLegacy, 60*20)
+}
+
+func TestRunLegacy(t *testing.T) {
+	for _, tt := range []struct{ stored, code byte }{{1, 0}, {3, 3}} {
+		header := []byte{'N', 'E', 'S', 0x1A, 0x01, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+		prg := make([]byte, 0x4000)
+		// LDA #stored; STA $F8; JMP $C004
+		copy(prg, []byte{0xA9, tt.stored, 0x85, 0xF8, 0x4C, 0x04, 0xC0})
+		prg[0x3FFC], prg[0x3FFD] = 0x00, 0xC0
+		rom := append(append(header, prg...), make([]byte, 0x2000)...)
+
+		res, err := RunLegacy(rom, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Code != tt.code {
+			t.Errorf("Expected code %d for $F8=%d, got %d", tt.code, tt.stored, res.Code)
+		}
+	}
+
+	// The check in progress isn't a result until the ROM stops: LDA #2; STA $F8; INC $00;
+	// JMP $C004
+	header := []byte{'N', 'E', 'S', 0x1A, 0x01, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+	prg := make([]byte, 0x4000)
+	copy(prg, []byte{0xA9, 0x02, 0x85, 0xF8, 0xE6, 0x00, 0x4C, 0x04, 0xC0})
+	prg[0x3FFC], prg[0x3FFD] = 0x00, 0xC0
+	rom := append(append(header, prg...), make([]byte, 0x2000)...)
+	if res, err := RunLegacy(rom, 10); err == nil {
+		t.Errorf("Expected no result from a ROM still running, got code %d", res.Code)
+	}
+}
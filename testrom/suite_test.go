@@ -0,0 +1,188 @@
+package testrom
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// compatPath is the checked-in compatibility matrix: one "<suite>/<rom> pass|fail" line
+// per ROM. ROMs missing from it are expected to pass.
+const compatPath = "testdata/compat.txt"
+
+var update = flag.Bool("update", false, "rewrite "+compatPath+" with the results of this run")
+
+// requireROMsEnv makes a suite without ROMs fail rather than skip. CI sets it after
+// fetching the ROMs with make test-roms, so a missing suite can't pass unnoticed.
+const requireROMsEnv = "VIBEMULATOR_REQUIRE_TEST_ROMS"
+
+var compat = struct {
+	sync.Mutex
+	expected map[string]string
+	results  map[string]string
+}{results: make(map[string]string)}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	expected, err := readCompat(compatPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	compat.expected = expected
+
+	code := m.Run()
+	if *update {
+		if err := writeCompat(compatPath, expected, compat.results); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(code)
+}
+
+func readCompat(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open compatibility matrix: %v", err)
+	}
+	defer f.Close()
+
+	m := make(map[string]string)
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || (fields[1] != "pass" && fields[1] != "fail") {
+			return nil, fmt.Errorf("%s:%d: expected \"<suite>/<rom> pass|fail\"", path, n)
+		}
+		m[fields[0]] = fields[1]
+	}
+	return m, s.Err()
+}
+
+// writeCompat merges this run's results into the matrix, keeping entries for suites that didn't run.
+func writeCompat(path string, old, results map[string]string) error {
+	merged := make(map[string]string, len(old)+len(results))
+	for k, v := range old {
+		merged[k] = v
+	}
+	for k, v := range results {
+		merged[k] = v
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("# Test ROM compatibility matrix, checked by go test ./testrom.\n")
+	sb.WriteString("# Regenerate with: go test ./testrom -update\n")
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s %s\n", k, merged[k])
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// runner runs one ROM under a test protocol.
+type runner func(rom []byte, maxFrames int) (Result, error)
+
+// runSuite runs every .nes file under testdata/dir as a subtest and logs a pass/fail
+// line per ROM once they are all done. A ROM only fails the test when it does worse
+// than the compatibility matrix says. The ROMs aren't checked in, so the suite is
+// skipped when there are none, unless $VIBEMULATOR_REQUIRE_TEST_ROMS is set.
+func runSuite(t *testing.T, dir string, run runner, maxFrames int) {
+	root := filepath.Join("testdata", dir)
+	var roms []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".nes") {
+			roms = append(roms, path)
+		}
+		return nil
+	})
+	if len(roms) == 0 {
+		if os.Getenv(requireROMsEnv) != "" {
+			t.Fatalf("No ROMs in %s; run make test-roms to fetch them", root)
+		}
+		t.Skipf("No ROMs in %s; run make test-roms or copy the suite's .nes files there to run it", root)
+	}
+
+	var mu sync.Mutex
+	verdicts := make(map[string]string)
+	t.Cleanup(func() {
+		names := make([]string, 0, len(verdicts))
+		for name := range verdicts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var sb strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&sb, "\n  %-40s %s", name, verdicts[name])
+		}
+		t.Logf("%s:%s", dir, sb.String())
+	})
+
+	for _, path := range roms {
+		rel, _ := filepath.Rel(root, path)
+		name := filepath.ToSlash(rel)
+		key := dir + "/" + name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			verdict := "error"
+			defer func() {
+				mu.Lock()
+				verdicts[name] = verdict
+				mu.Unlock()
+			}()
+
+			rom, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// A ROM that never reports a result fails like one that reports a failure,
+			// so a hang can be recorded as a known failure too
+			res, runErr := run(rom, maxFrames)
+			if runErr != nil {
+				res = Result{Message: runErr.Error()}
+			}
+
+			got := "fail"
+			if runErr == nil && res.Passed() {
+				got = "pass"
+			}
+			compat.Lock()
+			want := compat.expected[key]
+			compat.results[key] = got
+			compat.Unlock()
+
+			code := fmt.Sprint(res.Code)
+			if runErr != nil {
+				code = "no result"
+			}
+			switch {
+			case got == "pass" && want == "fail":
+				verdict = "pass (new)"
+				t.Logf("Now passes; run with -update to record it in %s", compatPath)
+			case got == "pass":
+				verdict = "pass"
+			case want == "fail":
+				verdict = fmt.Sprintf("known failure (%s)", code)
+				t.Logf("Known failure (%s):\n%s", code, res.Message)
+			default:
+				verdict = fmt.Sprintf("FAIL (%s)", code)
+				t.Errorf("Failed (%s):\n%s", code, res.Message)
+			}
+		})
+	}
+}
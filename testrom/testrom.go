@@ -0,0 +1,126 @@
+// Package testrom runs hardware test ROMs headlessly and reads back their verdicts.
+package testrom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/meadori/vibemulator/bus"
+)
+
+// blargg's test shell reports through cartridge RAM: a status byte at $6000, the
+// signature DE B0 61 at $6001-$6003 and a NUL-terminated message from $6004.
+const (
+	statusAddr  = 0x6000
+	messageAddr = 0x6004
+	maxMessage  = 0x1000
+
+	statusRunning    = 0x80
+	statusNeedsReset = 0x81
+
+	// The shell wants the reset button pressed at least 100ms after it asks
+	resetDelayFrames = 6
+)
+
+var signature = []byte{0xDE, 0xB0, 0x61}
+
+// blargg's 2005 PPU tests predate the shell and only leave a result code in zero page:
+// 1 when every test passed, otherwise a code naming the first check that failed. While
+// they run it holds the number of the check in progress, so it is only the result once
+// the ROM has stopped in its JMP-to-itself exit loop.
+const (
+	legacyResultAddr = 0x00F8
+	legacyPassed     = 1
+)
+
+// Result is what a test ROM reported when it finished.
+type Result struct {
+	Code    byte   // 0 when every test passed, otherwise the number of the failing test
+	Message string // Text the ROM printed
+	Frames  int    // Frames run before the ROM finished
+}
+
+// Passed reports whether the ROM reported success.
+func (r Result) Passed() bool {
+	return r.Code == 0
+}
+
+// RunBlargg runs a ROM built on blargg's test shell until it reports a result, pressing
+// reset whenever it asks for one. It gives up after maxFrames.
+func RunBlargg(rom []byte, maxFrames int) (Result, error) {
+	b := bus.New()
+	if err := b.LoadROM(rom); err != nil {
+		return Result{}, fmt.Errorf("failed to load ROM: %v", err)
+	}
+
+	resetAt := -1
+	for frame := 1; frame <= maxFrames; frame++ {
+		b.RunFrame()
+		status, ok := blarggStatus(b)
+		switch {
+		case !ok || status == statusRunning:
+		case status == statusNeedsReset:
+			if resetAt < 0 {
+				resetAt = frame + resetDelayFrames
+			} else if frame >= resetAt {
+				b.Reset()
+				resetAt = -1
+			}
+		default:
+			return Result{Code: status, Message: blarggMessage(b), Frames: frame}, nil
+		}
+	}
+	return Result{}, fmt.Errorf("no result after %d frames: %q", maxFrames, blarggMessage(b))
+}
+
+// blarggStatus returns the status byte once the shell has written its signature.
+func blarggStatus(b *bus.Bus) (byte, bool) {
+	mem := b.GetMemoryBlock(statusAddr, 4)
+	for i, s := range signature {
+		if mem[i+1] != s {
+			return 0, false
+		}
+	}
+	return mem[0], true
+}
+
+func blarggMessage(b *bus.Bus) string {
+	text := b.GetMemoryBlock(messageAddr, maxMessage)
+	if i := strings.IndexByte(string(text), 0); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(string(text))
+}
+
+// RunLegacy runs one of blargg's 2005 PPU tests until it stops with a result code, giving
+// up after maxFrames. Those ROMs only print their message on screen, so Message is empty.
+func RunLegacy(rom []byte, maxFrames int) (Result, error) {
+	b := bus.New()
+	if err := b.LoadROM(rom); err != nil {
+		return Result{}, fmt.Errorf("failed to load ROM: %v", err)
+	}
+
+	for frame := 1; frame <= maxFrames; frame++ {
+		b.RunFrame()
+		if !exited(b) {
+			continue
+		}
+		code := b.GetMemoryBlock(legacyResultAddr, 1)[0]
+		if code == legacyPassed {
+			code = 0
+		}
+		return Result{Code: code, Frames: frame}, nil
+	}
+	return Result{}, fmt.Errorf("no result after %d frames", maxFrames)
+}
+
+// exited reports whether the CPU is spinning on a JMP to itself. It finishes the current
+// instruction first so that PC is on an opcode.
+func exited(b *bus.Bus) bool {
+	for !b.IsInstructionComplete() {
+		b.Clock()
+	}
+	_, _, _, _, _, pc, _ := b.GetCPUState()
+	op := b.GetMemoryBlock(pc, 3)
+	return op[0] == 0x4C && uint16(op[1])|uint16(op[2])<<8 == pc
+}
@@ -0,0 +1,83 @@
+// Package wav writes mono 16-bit PCM WAV files, for recording the APU's output. The
+// sizes in the header are only known once recording stops, so the file is written
+// through an io.WriteSeeker and Close goes back to fill them in.
+package wav
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// headerSize is the length of the RIFF, fmt and data chunk headers before the samples.
+const headerSize = 44
+
+// Writer writes samples to a WAV file.
+type Writer struct {
+	ws      io.WriteSeeker
+	w       *bufio.Writer
+	samples uint32
+}
+
+// NewWriter writes a WAV header for sampleRate samples a second to ws and returns a
+// Writer for the samples that follow.
+func NewWriter(ws io.WriteSeeker, sampleRate int) (*Writer, error) {
+	w := &Writer{ws: ws, w: bufio.NewWriter(ws)}
+	h := make([]byte, 0, headerSize)
+	h = append(h, "RIFF"...)
+	h = binary.LittleEndian.AppendUint32(h, 0) // Filled in by Close
+	h = append(h, "WAVEfmt "...)
+	h = binary.LittleEndian.AppendUint32(h, 16)
+	h = binary.LittleEndian.AppendUint16(h, 1) // PCM
+	h = binary.LittleEndian.AppendUint16(h, 1) // Mono
+	h = binary.LittleEndian.AppendUint32(h, uint32(sampleRate))
+	h = binary.LittleEndian.AppendUint32(h, uint32(sampleRate)*2) // Bytes a second
+	h = binary.LittleEndian.AppendUint16(h, 2)                    // Bytes a sample
+	h = binary.LittleEndian.AppendUint16(h, 16)                   // Bits a sample
+	h = append(h, "data"...)
+	h = binary.LittleEndian.AppendUint32(h, 0) // Filled in by Close
+	if _, err := w.w.Write(h); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends samples, clipping them to -1 to 1.
+func (w *Writer) Write(samples ...float32) error {
+	for _, s := range samples {
+		s = min(max(s, -1), 1)
+		v := int16(s * 32767)
+		if err := w.w.WriteByte(byte(v)); err != nil {
+			return err
+		}
+		if err := w.w.WriteByte(byte(v >> 8)); err != nil {
+			return err
+		}
+	}
+	w.samples += uint32(len(samples))
+	return nil
+}
+
+// Close writes the chunk sizes into the header. It doesn't close the underlying file.
+func (w *Writer) Close() error {
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	dataSize := w.samples * 2
+	for _, f := range []struct {
+		offset int64
+		size   uint32
+	}{
+		{4, headerSize - 8 + dataSize},
+		{headerSize - 4, dataSize},
+	} {
+		if _, err := w.ws.Seek(f.offset, io.SeekStart); err != nil {
+			return err
+		}
+		if err := binary.Write(w.ws, binary.LittleEndian, f.size); err != nil {
+			return err
+		}
+	}
+	_, err := w.ws.Seek(0, io.SeekEnd)
+	return err
+}
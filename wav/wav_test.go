@@ -0,0 +1,57 @@
+package wav
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWriter(f, 44100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(0, 0.5, -2); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != headerSize+8 {
+		t.Fatalf("Expected %d bytes, got %d", headerSize+8, len(data))
+	}
+	if string(data[0:4]) != "RIFF" || string(data[8:16]) != "WAVEfmt " || string(data[36:40]) != "data" {
+		t.Errorf("Expected RIFF, WAVE, fmt and data chunks, got %q", data[:40])
+	}
+	if size := binary.LittleEndian.Uint32(data[4:]); size != uint32(len(data)-8) {
+		t.Errorf("Expected a RIFF size of %d, got %d", len(data)-8, size)
+	}
+	if size := binary.LittleEndian.Uint32(data[40:]); size != 8 {
+		t.Errorf("Expected a data size of 8, got %d", size)
+	}
+	if rate := binary.LittleEndian.Uint32(data[24:]); rate != 44100 {
+		t.Errorf("Expected a sample rate of 44100, got %d", rate)
+	}
+
+	want := []int16{0, 16383, -32767, 32767}
+	for i, w := range want {
+		if got := int16(binary.LittleEndian.Uint16(data[headerSize+2*i:])); got != w {
+			t.Errorf("Sample %d: expected %d, got %d", i, w, got)
+		}
+	}
+}
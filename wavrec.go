@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/meadori/vibemulator/apu"
+	"github.com/meadori/vibemulator/bus"
+	"github.com/meadori/vibemulator/wav"
+)
+
+// wavFlags are the audio recording options shared by run and play.
+type wavFlags struct {
+	path  *string
+	stems *bool
+}
+
+func addWAVFlags(fs *flag.FlagSet) wavFlags {
+	return wavFlags{
+		path:  fs.String("wav", "", "record the audio to this WAV file"),
+		stems: fs.Bool("wav-stems", false, "with -wav, also record each APU channel to its own file beside it, e.g. out.pulse1.wav"),
+	}
+}
+
+// record starts recording b's audio if -wav was given. The returned function finishes
+// the files.
+func (f wavFlags) record(b *bus.Bus) func() {
+	if *f.path == "" {
+		if *f.stems {
+			log.Fatalf("-wav-stems needs -wav")
+		}
+		return func() {}
+	}
+	paths := []string{*f.path}
+	if *f.stems {
+		for _, name := range apu.ChannelNames {
+			paths = append(paths, stemPath(*f.path, name))
+		}
+	}
+
+	rate := int(b.APU.SampleRate())
+	var files []*os.File
+	var writers []*wav.Writer
+	for _, path := range paths {
+		file, err := os.Create(path)
+		if err != nil {
+			log.Fatalf("Failed to create WAV file: %v", err)
+		}
+		w, err := wav.NewWriter(file, rate)
+		if err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		files, writers = append(files, file), append(writers, w)
+	}
+
+	var err error
+	b.APU.SetSampleTap(func(mix float32, channels [apu.NumChannels]float32) {
+		if err != nil {
+			return
+		}
+		err = writers[0].Write(mix)
+		for i := 1; i < len(writers) && err == nil; i++ {
+			err = writers[i].Write(channels[i-1])
+		}
+		if err != nil {
+			log.Printf("Stopped recording audio: %v", err)
+		}
+	})
+	log.Printf("Recording audio to %s", strings.Join(paths, ", "))
+	return func() {
+		b.APU.SetSampleTap(nil)
+		for i, w := range writers {
+			if err := w.Close(); err != nil {
+				log.Printf("Failed to finish %s: %v", paths[i], err)
+			}
+			files[i].Close()
+		}
+	}
+}
+
+// stemPath names the file a channel's stem is recorded to, beside the mix at path.
+func stemPath(path, channel string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + channel + ext
+}